@@ -32,6 +32,25 @@ func EmptyInterval() Interval { return Interval{1, 0} }
 // IntervalFromPoint returns an interval representing a single point.
 func IntervalFromPoint(p float64) Interval { return Interval{p, p} }
 
+// IntervalFromPoints returns the smallest interval that contains all of the
+// given points, or an empty interval if there are none. An error is returned
+// if any point is NaN, since a NaN would otherwise silently produce a bogus
+// interval.
+func IntervalFromPoints(pts []float64) (Interval, error) {
+	if len(pts) == 0 {
+		return EmptyInterval(), nil
+	}
+	i := Interval{math.Inf(1), math.Inf(-1)}
+	for n, p := range pts {
+		if math.IsNaN(p) {
+			return EmptyInterval(), fmt.Errorf("r1: point %d is NaN", n)
+		}
+		i.Lo = math.Min(i.Lo, p)
+		i.Hi = math.Max(i.Hi, p)
+	}
+	return i, nil
+}
+
 // IsEmpty reports whether the interval is empty.
 func (i Interval) IsEmpty() bool { return i.Lo > i.Hi }
 
@@ -153,14 +172,20 @@ const (
 // real line, so any interval with a small enough length will match
 // the empty interval.
 func (i Interval) ApproxEqual(other Interval) bool {
+	return i.ApproxEqualWithin(other, epsilon)
+}
+
+// ApproxEqualWithin is like ApproxEqual, but each endpoint may move by at
+// most maxError.
+func (i Interval) ApproxEqualWithin(other Interval, maxError float64) bool {
 	if i.IsEmpty() {
-		return other.Length() <= 2*epsilon
+		return other.Length() <= 2*maxError
 	}
 	if other.IsEmpty() {
-		return i.Length() <= 2*epsilon
+		return i.Length() <= 2*maxError
 	}
-	return math.Abs(other.Lo-i.Lo) <= epsilon &&
-		math.Abs(other.Hi-i.Hi) <= epsilon
+	return math.Abs(other.Lo-i.Lo) <= maxError &&
+		math.Abs(other.Hi-i.Hi) <= maxError
 }
 
 // DirectedHausdorffDistance returns the Hausdorff distance to the given interval. For two
@@ -15,6 +15,7 @@
 package r1
 
 import (
+	"math"
 	"testing"
 )
 
@@ -263,6 +264,34 @@ func TestAddPoint(t *testing.T) {
 	}
 }
 
+func TestIntervalFromPoints(t *testing.T) {
+	tests := []struct {
+		pts  []float64
+		want Interval
+	}{
+		{nil, empty},
+		{[]float64{3}, Interval{3, 3}},
+		{[]float64{3, -1, 2}, Interval{-1, 3}},
+		{[]float64{math.Inf(-1), 0}, Interval{math.Inf(-1), 0}},
+	}
+	for _, test := range tests {
+		got, err := IntervalFromPoints(test.pts)
+		if err != nil {
+			t.Errorf("IntervalFromPoints(%v) returned error: %v", test.pts, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("IntervalFromPoints(%v) = %v, want equal to %v", test.pts, got, test.want)
+		}
+	}
+
+	for _, pts := range [][]float64{{math.NaN()}, {1, math.NaN(), 2}, {1, 2, math.NaN()}} {
+		if got, err := IntervalFromPoints(pts); err == nil {
+			t.Errorf("IntervalFromPoints(%v) = %v, want error", pts, got)
+		}
+	}
+}
+
 func TestClampPoint(t *testing.T) {
 	tests := []struct {
 		interval Interval
@@ -352,3 +381,16 @@ func TestApproxEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestApproxEqualWithin(t *testing.T) {
+	i := Interval{1, 2}
+	if !i.ApproxEqualWithin(Interval{1.05, 1.95}, 0.1) {
+		t.Errorf("%v.ApproxEqualWithin(%v, 0.1) = false, want true", i, Interval{1.05, 1.95})
+	}
+	if i.ApproxEqualWithin(Interval{1.2, 2}, 0.1) {
+		t.Errorf("%v.ApproxEqualWithin(%v, 0.1) = true, want false", i, Interval{1.2, 2})
+	}
+	if !EmptyInterval().ApproxEqualWithin(Interval{1, 1.1}, 0.1) {
+		t.Errorf("empty interval should be within 0.1 of %v", Interval{1, 1.1})
+	}
+}
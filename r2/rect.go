@@ -39,10 +39,10 @@ func (p Point) Mul(m float64) Point { return Point{m * p.X, m * p.Y} }
 func (p Point) Ortho() Point { return Point{-p.Y, p.X} }
 
 // Dot returns the dot product between p and op.
-func (p Point) Dot(op Point) float64 { return p.X*op.X + p.Y*op.Y }
+func (p Point) Dot(op Point) float64 { return float64(p.X*op.X) + float64(p.Y*op.Y) }
 
 // Cross returns the cross product of p and op.
-func (p Point) Cross(op Point) float64 { return p.X*op.Y - p.Y*op.X }
+func (p Point) Cross(op Point) float64 { return float64(p.X*op.Y) - float64(p.Y*op.X) }
 
 // Norm returns the vector's norm.
 func (p Point) Norm() float64 { return math.Hypot(p.X, p.Y) }
@@ -84,6 +84,30 @@ func RectFromPoints(pts ...Point) Rect {
 	return r
 }
 
+// RectFromPointsOrError constructs the smallest rect that contains all of
+// the given points, computing the bounds in a single pass. Unlike
+// RectFromPoints, it returns EmptyRect if there are no points, and an error
+// if any point has a NaN coordinate.
+func RectFromPointsOrError(pts []Point) (Rect, error) {
+	if len(pts) == 0 {
+		return EmptyRect(), nil
+	}
+	r := Rect{
+		X: r1.Interval{Lo: math.Inf(1), Hi: math.Inf(-1)},
+		Y: r1.Interval{Lo: math.Inf(1), Hi: math.Inf(-1)},
+	}
+	for i, p := range pts {
+		if math.IsNaN(p.X) || math.IsNaN(p.Y) {
+			return EmptyRect(), fmt.Errorf("r2: point %d has a NaN coordinate", i)
+		}
+		r.X.Lo = math.Min(r.X.Lo, p.X)
+		r.X.Hi = math.Max(r.X.Hi, p.X)
+		r.Y.Lo = math.Min(r.Y.Lo, p.Y)
+		r.Y.Hi = math.Max(r.Y.Hi, p.Y)
+	}
+	return r, nil
+}
+
 // RectFromCenterSize constructs a rectangle with the given center and size.
 // Both dimensions of size must be non-negative.
 func RectFromCenterSize(center, size Point) Rect {
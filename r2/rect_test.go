@@ -100,6 +100,17 @@ func TestCross(t *testing.T) {
 	}
 }
 
+func TestCrossSelfIsExactlyZero(t *testing.T) {
+	// The products below are not exactly representable, so if the compiler
+	// fused one of them into the subtraction the result would be the
+	// rounding error of the other rather than zero.
+	for _, p := range []Point{{0.1, 0.7}, {1.0 / 3, 2.0 / 3}, {-1e-7, 123456.789}} {
+		if got := p.Cross(p); got != 0 {
+			t.Errorf("%v.Cross(%v) = %v, want 0", p, p, got)
+		}
+	}
+}
+
 func TestNorm(t *testing.T) {
 	tests := []struct {
 		p    Point
@@ -193,6 +204,35 @@ func TestFromVariousTypes(t *testing.T) {
 	}
 }
 
+func TestRectFromPointsOrError(t *testing.T) {
+	tests := []struct {
+		pts  []Point
+		want Rect
+	}{
+		{nil, empty},
+		{[]Point{sw}, rectSW},
+		{[]Point{sw, ne}, rect},
+		{[]Point{ne, se, sw, nw}, rect},
+		{[]Point{{0.25, 0.5}}, rectMid},
+	}
+	for _, test := range tests {
+		got, err := RectFromPointsOrError(test.pts)
+		if err != nil {
+			t.Errorf("RectFromPointsOrError(%v) returned error: %v", test.pts, err)
+			continue
+		}
+		if got != test.want && !(got.IsEmpty() && test.want.IsEmpty()) {
+			t.Errorf("RectFromPointsOrError(%v) = %v, want %v", test.pts, got, test.want)
+		}
+	}
+
+	for _, pts := range [][]Point{{{math.NaN(), 0}}, {sw, {0, math.NaN()}}, {ne, sw, {math.NaN(), math.NaN()}}} {
+		if got, err := RectFromPointsOrError(pts); err == nil {
+			t.Errorf("RectFromPointsOrError(%v) = %v, want error", pts, got)
+		}
+	}
+}
+
 func TestCenter(t *testing.T) {
 	tests := []struct {
 		rect Rect
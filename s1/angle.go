@@ -107,6 +107,26 @@ func (a Angle) Normalized() Angle {
 	return Angle(rad)
 }
 
+// NormalizedDifference returns a-b reduced to the range (-π, π], which is
+// the signed angle to turn from b to a the short way around the circle. It
+// is the correct way to subtract longitudes.
+func (a Angle) NormalizedDifference(b Angle) Angle {
+	return (a - b).Normalized()
+}
+
+// AbsDistance returns the distance between a and b on the circle, which is
+// in the range [0, π]. For example, the distance between 179 and -179
+// degrees is 2 degrees.
+func (a Angle) AbsDistance(b Angle) Angle {
+	return a.NormalizedDifference(b).Abs()
+}
+
+// Clamp returns the angle clamped to the range [lo, hi]. Unlike Interval,
+// the range does not wrap around the circle.
+func (a Angle) Clamp(lo, hi Angle) Angle {
+	return Angle(math.Max(float64(lo), math.Min(float64(hi), float64(a))))
+}
+
 func (a Angle) String() string {
 	return strconv.FormatFloat(a.Degrees(), 'f', 7, 64) // like "%.7f"
 }
@@ -169,3 +169,43 @@ func TestDegreesVsRadians(t *testing.T) {
 
 // TODO(roberts): Differences from C++
 //   Benchmarking code.
+
+func TestAngleNormalizedDifference(t *testing.T) {
+	tests := []struct {
+		a, b     Angle
+		diff     Angle
+		distance Angle
+	}{
+		{10 * Degree, 5 * Degree, 5 * Degree, 5 * Degree},
+		{5 * Degree, 10 * Degree, -5 * Degree, 5 * Degree},
+		{179 * Degree, -179 * Degree, -2 * Degree, 2 * Degree},
+		{-179 * Degree, 179 * Degree, 2 * Degree, 2 * Degree},
+		{540 * Degree, 0, 180 * Degree, 180 * Degree},
+		{0, 180 * Degree, 180 * Degree, 180 * Degree},
+		{725 * Degree, -5 * Degree, 10 * Degree, 10 * Degree},
+	}
+	for _, test := range tests {
+		if got := test.a.NormalizedDifference(test.b); !got.ApproxEqual(test.diff) {
+			t.Errorf("%v.NormalizedDifference(%v) = %v, want %v", test.a, test.b, got, test.diff)
+		}
+		if got := test.a.AbsDistance(test.b); !got.ApproxEqual(test.distance) {
+			t.Errorf("%v.AbsDistance(%v) = %v, want %v", test.a, test.b, got, test.distance)
+		}
+	}
+}
+
+func TestAngleClamp(t *testing.T) {
+	tests := []struct {
+		a, lo, hi, want Angle
+	}{
+		{5, 0, 10, 5},
+		{-5, 0, 10, 0},
+		{15, 0, 10, 10},
+		{InfAngle(), 0, 10, 10},
+	}
+	for _, test := range tests {
+		if got := test.a.Clamp(test.lo, test.hi); got != test.want {
+			t.Errorf("%v.Clamp(%v, %v) = %v, want %v", test.a, test.lo, test.hi, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s1
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// earthRadiusMeters is the mean radius of the Earth used to convert
+// distances on the Earth's surface to angles.
+const earthRadiusMeters = 6371010.0
+
+// angleUnits are the unit suffixes accepted by ParseAngle, with the size of
+// each unit. Suffixes that end with another suffix must come first.
+var angleUnits = []struct {
+	suffix string
+	unit   Angle
+}{
+	{"km@earth", Angle(1000 / earthRadiusMeters)},
+	{"m@earth", Angle(1 / earthRadiusMeters)},
+	{"deg", Degree},
+	{"rad", Radian},
+}
+
+// ParseAngle parses an angle given as a number immediately followed by a
+// unit, such as "12.5deg" or "3.1rad". Distances on the surface of the Earth
+// are also accepted with the units "m@earth" and "km@earth", for example
+// "450m@earth", and are converted using a mean Earth radius of 6371.01km.
+//
+// Parsing is strict: the unit is required, and whitespace, NaN and trailing
+// characters are rejected.
+func ParseAngle(s string) (Angle, error) {
+	for _, u := range angleUnits {
+		if !strings.HasSuffix(s, u.suffix) {
+			continue
+		}
+		num := strings.TrimSuffix(s, u.suffix)
+		v, err := strconv.ParseFloat(num, 64)
+		if err != nil || math.IsNaN(v) {
+			return 0, fmt.Errorf("s1: invalid angle %q", s)
+		}
+		return Angle(v) * u.unit, nil
+	}
+	return 0, fmt.Errorf("s1: angle %q has no unit, want one of deg, rad, m@earth or km@earth", s)
+}
+
+// FormatAngle formats an angle in the form accepted by ParseAngle. The angle
+// is written in degrees, such as "12.5deg", unless that would not round-trip
+// exactly, in which case it is written in radians.
+func FormatAngle(a Angle) string {
+	if deg := a.Degrees(); Angle(deg)*Degree == a {
+		return strconv.FormatFloat(deg, 'g', -1, 64) + "deg"
+	}
+	return strconv.FormatFloat(float64(a), 'g', -1, 64) + "rad"
+}
+
+// AngleText is an Angle that marshals to and from text using FormatAngle and
+// ParseAngle. Angle itself marshals as a plain number of radians; use
+// AngleText in structs that are read from config files or other
+// human-edited sources.
+type AngleText Angle
+
+// MarshalText implements encoding.TextMarshaler.
+func (a AngleText) MarshalText() ([]byte, error) {
+	if math.IsNaN(float64(a)) {
+		return nil, fmt.Errorf("s1: cannot marshal NaN angle")
+	}
+	return []byte(FormatAngle(Angle(a))), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseAngle.
+func (a *AngleText) UnmarshalText(text []byte) error {
+	v, err := ParseAngle(string(text))
+	if err != nil {
+		return err
+	}
+	*a = AngleText(v)
+	return nil
+}
+
+// ChordAngleText is a ChordAngle that marshals to and from text as the angle
+// it represents, using FormatAngle and ParseAngle. Converting back may differ
+// by a small error.
+type ChordAngleText ChordAngle
+
+// MarshalText implements encoding.TextMarshaler.
+func (c ChordAngleText) MarshalText() ([]byte, error) {
+	return AngleText(ChordAngle(c).Angle()).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseAngle. Any
+// negative angle gives NegativeChordAngle, and angles larger than 180
+// degrees give StraightChordAngle.
+func (c *ChordAngleText) UnmarshalText(text []byte) error {
+	a, err := ParseAngle(string(text))
+	if err != nil {
+		return err
+	}
+	*c = ChordAngleText(ChordAngleFromAngle(a))
+	return nil
+}
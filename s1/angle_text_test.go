@@ -0,0 +1,120 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s1
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseAngle(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Angle
+	}{
+		{"12.5deg", 12.5 * Degree},
+		{"-90deg", -90 * Degree},
+		{"3.1rad", 3.1 * Radian},
+		{"0rad", 0},
+		{"1e-3rad", 1e-3 * Radian},
+		{"450m@earth", Angle(450 / earthRadiusMeters)},
+		{"6371.01km@earth", Radian},
+	}
+	for _, test := range tests {
+		got, err := ParseAngle(test.s)
+		if err != nil {
+			t.Errorf("ParseAngle(%q) returned error %v", test.s, err)
+			continue
+		}
+		if !float64Near(float64(got), float64(test.want), 1e-15) {
+			t.Errorf("ParseAngle(%q) = %v, want %v", test.s, got, test.want)
+		}
+	}
+
+	for _, s := range []string{"", "12", "deg", "12 deg", " 12deg", "12deg ", "12degs", "12Deg", "NaNrad", "1,5deg", "450m", "12.5deg@earth"} {
+		if got, err := ParseAngle(s); err == nil {
+			t.Errorf("ParseAngle(%q) = %v, want error", s, got)
+		}
+	}
+}
+
+func TestAngleTextRoundTrip(t *testing.T) {
+	for _, a := range []Angle{0, 45 * Degree, -90 * Degree, 60 * Degree, 1 * Radian, 1e-20 * Radian, math.Pi * Radian, InfAngle()} {
+		text, err := AngleText(a).MarshalText()
+		if err != nil {
+			t.Errorf("AngleText(%v).MarshalText() returned error %v", a, err)
+			continue
+		}
+		var got AngleText
+		if err := got.UnmarshalText(text); err != nil || Angle(got) != a {
+			t.Errorf("UnmarshalText(%q) = %v, %v, want %v", text, got, err, a)
+		}
+	}
+	if got := FormatAngle(45 * Degree); got != "45deg" {
+		t.Errorf("FormatAngle(45 * Degree) = %q, want \"45deg\"", got)
+	}
+	if _, err := AngleText(math.NaN()).MarshalText(); err == nil {
+		t.Errorf("MarshalText of NaN should fail")
+	}
+}
+
+func TestAngleJSON(t *testing.T) {
+	type config struct {
+		Radius    AngleText
+		Tolerance ChordAngleText
+	}
+	var c config
+	if err := json.Unmarshal([]byte(`{"Radius": "450m@earth", "Tolerance": "0.5deg"}`), &c); err != nil {
+		t.Fatalf("json.Unmarshal returned error %v", err)
+	}
+	if want := Angle(450 / earthRadiusMeters); Angle(c.Radius) != want {
+		t.Errorf("Radius = %v, want %v", c.Radius, want)
+	}
+	if want := ChordAngleFromAngle(0.5 * Degree); !float64Near(float64(c.Tolerance), float64(want), 1e-15) {
+		t.Errorf("Tolerance = %v, want %v", c.Tolerance, want)
+	}
+
+	b, err := json.Marshal(config{Radius: AngleText(90 * Degree), Tolerance: ChordAngleText(NegativeChordAngle)})
+	if err != nil {
+		t.Fatalf("json.Marshal returned error %v", err)
+	}
+	var got config
+	if err := json.Unmarshal(b, &got); err != nil || Angle(got.Radius) != 90*Degree || ChordAngle(got.Tolerance) != NegativeChordAngle {
+		t.Errorf("JSON round trip of %s = %v, %v", b, got, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Radius": "12"}`), &c); err == nil {
+		t.Errorf("json.Unmarshal of an angle without units should fail")
+	}
+}
+
+func TestAngleJSONNumeric(t *testing.T) {
+	// Plain Angle values keep their numeric JSON encoding.
+	type point struct {
+		Lat, Lng Angle
+	}
+	b, err := json.Marshal(point{Lat: 1, Lng: 2})
+	if err != nil {
+		t.Fatalf("json.Marshal returned error %v", err)
+	}
+	if got, want := string(b), `{"Lat":1,"Lng":2}`; got != want {
+		t.Errorf("json.Marshal = %s, want %s", got, want)
+	}
+	var p point
+	if err := json.Unmarshal(b, &p); err != nil || p.Lat != 1 || p.Lng != 2 {
+		t.Errorf("json.Unmarshal(%s) = %v, %v", b, p, err)
+	}
+}
@@ -58,6 +58,15 @@ func IntervalFromEndpoints(lo, hi float64) Interval {
 	return i
 }
 
+// IntervalFromPoint returns an interval containing only the given point, which
+// must be in [-π,π].
+func IntervalFromPoint(p float64) Interval {
+	if p == -math.Pi {
+		p = math.Pi
+	}
+	return Interval{p, p}
+}
+
 // IntervalFromPointPair returns the minimal interval containing the two given points.
 // Both arguments must be in [-π,π].
 func IntervalFromPointPair(a, b float64) Interval {
@@ -357,26 +366,32 @@ func (i Interval) Expanded(margin float64) Interval {
 // so any interval with (length <= 2*ε) matches the empty interval, and
 // any interval with (length >= 2*π - 2*ε) matches the full interval.
 func (i Interval) ApproxEqual(other Interval) bool {
+	return i.ApproxEqualWithin(other, epsilon)
+}
+
+// ApproxEqualWithin is like ApproxEqual, but each endpoint may move by at
+// most maxError radians.
+func (i Interval) ApproxEqualWithin(other Interval, maxError float64) bool {
 	// Full and empty intervals require special cases because the endpoints
 	// are considered to be positioned arbitrarily.
 	if i.IsEmpty() {
-		return other.Length() <= 2*epsilon
+		return other.Length() <= 2*maxError
 	}
 	if other.IsEmpty() {
-		return i.Length() <= 2*epsilon
+		return i.Length() <= 2*maxError
 	}
 	if i.IsFull() {
-		return other.Length() >= 2*(math.Pi-epsilon)
+		return other.Length() >= 2*(math.Pi-maxError)
 	}
 	if other.IsFull() {
-		return i.Length() >= 2*(math.Pi-epsilon)
+		return i.Length() >= 2*(math.Pi-maxError)
 	}
 
 	// The purpose of the last test below is to verify that moving the endpoints
 	// does not invert the interval, e.g. [-1e20, 1e20] vs. [1e20, -1e20].
-	return (math.Abs(math.Remainder(other.Lo-i.Lo, 2*math.Pi)) <= epsilon &&
-		math.Abs(math.Remainder(other.Hi-i.Hi, 2*math.Pi)) <= epsilon &&
-		math.Abs(i.Length()-other.Length()) <= 2*epsilon)
+	return (math.Abs(math.Remainder(other.Lo-i.Lo, 2*math.Pi)) <= maxError &&
+		math.Abs(math.Remainder(other.Hi-i.Hi, 2*math.Pi)) <= maxError &&
+		math.Abs(i.Length()-other.Length()) <= 2*maxError)
 
 }
 
@@ -463,3 +478,23 @@ func (i Interval) Project(p float64) float64 {
 	}
 	return i.Hi
 }
+
+// ProjectAngle is like Project, but accepts any angle and normalizes it
+// to (-π, π] first. The interval must be non-empty.
+func (i Interval) ProjectAngle(a Angle) Angle {
+	return Angle(i.Project(float64(a.Normalized())))
+}
+
+// ClampPoint returns the closest point in the interval to the given point p,
+// which may be any value in radians. It is ProjectAngle under the name used
+// by r1.Interval. The interval must be non-empty.
+func (i Interval) ClampPoint(p float64) float64 {
+	return float64(i.ProjectAngle(Angle(p)))
+}
+
+// Distance returns the distance on the circle from the given angle to the
+// closest point of the interval, which is zero if the interval contains it.
+// The interval must be non-empty.
+func (i Interval) Distance(a Angle) Angle {
+	return a.AbsDistance(i.ProjectAngle(a))
+}
@@ -616,3 +616,86 @@ func TestIntervalProject(t *testing.T) {
 		}
 	}
 }
+
+func TestIntervalApproxEqualWithin(t *testing.T) {
+	i := Interval{3, -3}
+	if !i.ApproxEqualWithin(Interval{3.05, -3.05}, 0.1) {
+		t.Errorf("%v.ApproxEqualWithin(%v, 0.1) = false, want true", i, Interval{3.05, -3.05})
+	}
+	if i.ApproxEqualWithin(Interval{2.8, -3}, 0.1) {
+		t.Errorf("%v.ApproxEqualWithin(%v, 0.1) = true, want false", i, Interval{2.8, -3})
+	}
+	if !FullInterval().ApproxEqualWithin(Interval{-3.1, 3.1}, 0.1) {
+		t.Errorf("full interval should be within 0.1 of %v", Interval{-3.1, 3.1})
+	}
+}
+
+func TestIntervalProjectAngleAndDistance(t *testing.T) {
+	// The interval from 170 to -170 degrees, crossing the antimeridian.
+	i := IntervalFromEndpoints(float64(170*Degree), float64(-170*Degree))
+	tests := []struct {
+		a, project, distance Angle
+	}{
+		{180 * Degree, 180 * Degree, 0},
+		{-540 * Degree, 180 * Degree, 0},
+		{160 * Degree, 170 * Degree, 10 * Degree},
+		{-150 * Degree, -170 * Degree, 20 * Degree},
+		{520 * Degree, 170 * Degree, 10 * Degree},
+		{10 * Degree, 170 * Degree, 160 * Degree},
+	}
+	for _, test := range tests {
+		if got := i.ProjectAngle(test.a); float64(got.AbsDistance(test.project)) > epsilon {
+			t.Errorf("%v.ProjectAngle(%v) = %v, want %v", i, test.a, got, test.project)
+		}
+		if got := i.Distance(test.a); !got.ApproxEqual(test.distance) {
+			t.Errorf("%v.Distance(%v) = %v, want %v", i, test.a, got, test.distance)
+		}
+	}
+}
+
+func TestIntervalFromPoint(t *testing.T) {
+	tests := []struct {
+		p    float64
+		want Interval
+	}{
+		{0, Interval{0, 0}},
+		{1, Interval{1, 1}},
+		{math.Pi, Interval{math.Pi, math.Pi}},
+		{-math.Pi, Interval{math.Pi, math.Pi}},
+	}
+	for _, test := range tests {
+		got := IntervalFromPoint(test.p)
+		if got != test.want {
+			t.Errorf("IntervalFromPoint(%v) = %v, want %v", test.p, got, test.want)
+		}
+		if !got.IsValid() || got.IsEmpty() || !got.Contains(test.p) || got.Length() != 0 {
+			t.Errorf("IntervalFromPoint(%v) = %v, want a valid singleton interval containing it", test.p, got)
+		}
+	}
+}
+
+func TestIntervalProjectAngleWraps(t *testing.T) {
+	i := IntervalFromEndpoints(math.Pi-0.1, -math.Pi+0.1)
+	tests := []struct {
+		a, want Angle
+	}{
+		{math.Pi, math.Pi},
+		{-math.Pi, math.Pi},
+		{3 * math.Pi, math.Pi},
+		{1e-15, math.Pi - 0.1},
+		{-1e-15, -math.Pi + 0.1},
+		{2*math.Pi - 1e-15, -math.Pi + 0.1},
+	}
+	for _, test := range tests {
+		got := i.ProjectAngle(test.a)
+		if math.Abs(math.Remainder(float64(got-test.want), 2*math.Pi)) > 1e-14 {
+			t.Errorf("%v.ProjectAngle(%v) = %v, want %v", i, test.a, got, test.want)
+		}
+		if !i.Contains(float64(got)) {
+			t.Errorf("%v.ProjectAngle(%v) = %v, want a point in the interval", i, test.a, got)
+		}
+		if clamped := i.ClampPoint(float64(test.a)); clamped != float64(got) {
+			t.Errorf("%v.ClampPoint(%v) = %v, want %v", i, float64(test.a), clamped, float64(got))
+		}
+	}
+}
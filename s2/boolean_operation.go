@@ -0,0 +1,443 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// BooleanOperationType is the type of a BooleanOperation.
+type BooleanOperationType int
+
+const (
+	// BooleanOperationUnion computes the points contained by either input.
+	BooleanOperationUnion BooleanOperationType = iota
+	// BooleanOperationIntersection computes the points contained by both inputs.
+	BooleanOperationIntersection
+	// BooleanOperationDifference computes the points contained by the first
+	// input but not the second.
+	BooleanOperationDifference
+	// BooleanOperationSymmetricDifference computes the points contained by
+	// exactly one of the inputs.
+	BooleanOperationSymmetricDifference
+)
+
+func (t BooleanOperationType) String() string {
+	switch t {
+	case BooleanOperationUnion:
+		return "Union"
+	case BooleanOperationIntersection:
+		return "Intersection"
+	case BooleanOperationDifference:
+		return "Difference"
+	case BooleanOperationSymmetricDifference:
+		return "SymmetricDifference"
+	}
+	return fmt.Sprintf("BooleanOperationType(%d)", int(t))
+}
+
+// apply returns the result of the operation for a point that is or is not
+// contained by each of the inputs.
+func (t BooleanOperationType) apply(a, b bool) bool {
+	switch t {
+	case BooleanOperationUnion:
+		return a || b
+	case BooleanOperationIntersection:
+		return a && b
+	case BooleanOperationDifference:
+		return a && !b
+	default:
+		return a != b
+	}
+}
+
+// BooleanOperation computes the union, intersection, difference or symmetric
+// difference of the polygonal geometry in two ShapeIndexes, and returns the
+// result as a Polygon.
+//
+// The region of each input is the union of the polygon shapes (shapes of
+// dimension 2) in its index; the shapes of an index may overlap and may share
+// edges. Inputs with points or polylines are rejected.
+//
+// The algorithm splits the edges of both inputs at all of their crossings
+// (see NodePolylines), keeps every split edge that has the result of the
+// operation on exactly one side, and assembles the kept edges into loops.
+// Boundaries shared by the inputs are handled exactly, whichever direction
+// they run in. Vertices that are within twice the snap radius of the Snapper
+// of each other, and crossings within the snap radius of a vertex, are
+// merged, and the output vertices are then moved to the Snapper's snap sites.
+// Points on the boundary of an input follow the semi-open vertex model.
+//
+// This has two limitations. The edges are not split where snapping moves a
+// vertex near another edge, so snapping can make the output invalid unless
+// the snap radius is small compared to the distance between input features.
+// Points and polylines are not supported; inputs that contain them are
+// rejected.
+type BooleanOperation struct {
+	opType  BooleanOperationType
+	snapper Snapper
+}
+
+// NewBooleanOperation returns a BooleanOperation of the given type that snaps
+// its output with the given Snapper. If snapper is nil, vertices are only
+// merged when they are within the error of computing edge intersections.
+func NewBooleanOperation(opType BooleanOperationType, snapper Snapper) *BooleanOperation {
+	if snapper == nil {
+		snapper = NewIdentitySnapper(0)
+	}
+	return &BooleanOperation{opType: opType, snapper: snapper}
+}
+
+// BooleanOperationStats reports statistics about the work done by a
+// BooleanOperation, which can be used to log and tune the snapping.
+type BooleanOperationStats struct {
+	// InputEdges is the number of edges of the input shapes.
+	InputEdges int
+	// NodedEdges is the number of distinct edges after the input edges
+	// have been split at their crossings.
+	NodedEdges int
+	// EdgesClassified is the number of noded edges for which it has been
+	// decided whether they are part of the result.
+	EdgesClassified int
+	// OutputEdges is the number of edges of the result. It is only set in
+	// the final statistics.
+	OutputEdges int
+	// MaxSnapDisplacement is the largest distance that a vertex of the
+	// result was moved to its snap site. It is only set in the final
+	// statistics.
+	MaxSnapDisplacement s1.Angle
+}
+
+// booleanProgressInterval is the number of noded edges classified between
+// calls to the progress function of a BooleanOperation.
+const booleanProgressInterval = 256
+
+// booleanInputEdge records the input shape of a noded edge, and whether the
+// edge was reversed to give it the key direction of its group.
+type booleanInputEdge struct {
+	input    int
+	shapeID  int32
+	reversed bool
+}
+
+// Build returns the result of the operation on the polygons in the two
+// indexes.
+func (op *BooleanOperation) Build(a, b *ShapeIndex) (*Polygon, error) {
+	p, _, err := op.BuildWithStats(a, b, nil)
+	return p, err
+}
+
+// BuildWithStats is like Build, but also returns statistics about the work
+// done. If progress is non-nil, it is called periodically with the
+// statistics so far while the result is being computed.
+func (op *BooleanOperation) BuildWithStats(a, b *ShapeIndex, progress func(BooleanOperationStats)) (*Polygon, BooleanOperationStats, error) {
+	var stats BooleanOperationStats
+	cycles, full, err := op.build(a, b, &stats, progress)
+	switch {
+	case err != nil:
+		return nil, stats, err
+	case full:
+		return FullPolygon(), stats, nil
+	case len(cycles) == 0:
+		return PolygonFromLoops(nil), stats, nil
+	}
+	return polygonFromCycles(cycles), stats, nil
+}
+
+// BuildIndexed adds the result of the operation on the polygons in the two
+// indexes to the given index as a LaxPolygon, unless the result is empty.
+// This avoids the cost of constructing a Polygon, which must nest its loops
+// and build its own index, when the result is only used for queries against
+// the output index.
+//
+// The output index may be one of the inputs, since it is only modified after
+// the result has been computed.
+func (op *BooleanOperation) BuildIndexed(a, b, index *ShapeIndex) error {
+	cycles, full, err := op.build(a, b, &BooleanOperationStats{}, nil)
+	switch {
+	case err != nil:
+		return err
+	case full:
+		index.Add(LaxPolygonFromPoints([][]Point{{}}))
+	case len(cycles) > 0:
+		index.Add(LaxPolygonFromPoints(cycles))
+	}
+	return nil
+}
+
+// build returns the loops of the result of the operation, each with the
+// result on its left. If there are none, it reports whether the result is
+// full rather than empty. It records its work in stats, and calls progress
+// with them periodically if it is non-nil.
+func (op *BooleanOperation) build(a, b *ShapeIndex, stats *BooleanOperationStats, progress func(BooleanOperationStats)) (cycles [][]Point, full bool, err error) {
+	inputs := [2]*ShapeIndex{a, b}
+	var lines []*Polyline
+	var lineEdges []booleanInputEdge
+	for i, index := range inputs {
+		for id := int32(0); id < index.nextID; id++ {
+			shape := index.Shape(id)
+			if shape == nil {
+				continue
+			}
+			if shape.Dimension() != 2 {
+				return nil, false, fmt.Errorf("s2: BooleanOperation input %d shape %d has dimension %d, want 2", i, id, shape.Dimension())
+			}
+			for e := 0; e < shape.NumEdges(); e++ {
+				edge := shape.Edge(e)
+				lines = append(lines, &Polyline{edge.V0, edge.V1})
+				lineEdges = append(lineEdges, booleanInputEdge{input: i, shapeID: id})
+			}
+		}
+	}
+
+	stats.InputEdges = len(lines)
+
+	// Group the noded edges by their endpoints, in a canonical direction,
+	// and collect the noded boundary of each input shape.
+	snapRadius := s1.Angle(math.Max(float64(op.snapper.SnapRadius()), float64(IntersectionMergeRadius)))
+	noded, err := nodePolylinesPlanar(lines, snapRadius)
+	if err != nil {
+		return nil, false, err
+	}
+	groups := make(map[Edge][]booleanInputEdge)
+	var keys []Edge
+	shapeEdges := [2]map[int32][]Edge{{}, {}}
+	for i, segments := range noded {
+		for _, s := range segments {
+			r := lineEdges[i]
+			shapeEdges[r.input][r.shapeID] = append(shapeEdges[r.input][r.shapeID], s)
+			if s.V1.Cmp(s.V0.Vector) < 0 {
+				s, r.reversed = Edge{s.V1, s.V0}, true
+			}
+			if _, ok := groups[s]; !ok {
+				keys = append(keys, s)
+			}
+			groups[s] = append(groups[s], r)
+		}
+	}
+	stats.NodedEdges = len(keys)
+
+	// Points are classified against the noded boundaries rather than the
+	// input shapes, since noding moves the edges slightly, and a point near
+	// an edge could otherwise be on different sides of the two versions.
+	var queries [2]*ContainsPointQuery
+	for i, index := range inputs {
+		nodedIndex := NewShapeIndex()
+		for id := int32(0); id < index.nextID; id++ {
+			if shape := index.Shape(id); shape != nil && shape.NumEdges() == 0 {
+				// Empty and full shapes are not affected by noding.
+				nodedIndex.Add(shape)
+				continue
+			}
+			nodedIndex.Add(newBooleanShape(shapeEdges[i][id]))
+		}
+		queries[i] = NewContainsPointQuery(nodedIndex, VertexModelSemiOpen)
+	}
+	var result []Edge
+	for _, key := range keys {
+		// Work out whether each input contains the points just to the left
+		// and right of the edge. The shapes that have the edge on their
+		// boundary contain one side, and the others contain both or neither.
+		var left, right [2]bool
+		onBoundary := [2]map[int32]bool{{}, {}}
+		for _, r := range groups[key] {
+			onBoundary[r.input][r.shapeID] = true
+			if r.reversed {
+				right[r.input] = true
+			} else {
+				left[r.input] = true
+			}
+		}
+		mid := Point{key.V0.Add(key.V1.Vector).Normalize()}
+		for i, q := range queries {
+			for _, id := range q.containingShapeIDs(mid) {
+				if !onBoundary[i][id] {
+					left[i], right[i] = true, true
+				}
+			}
+		}
+
+		inLeft := op.opType.apply(left[0], left[1])
+		inRight := op.opType.apply(right[0], right[1])
+		switch {
+		case inLeft && !inRight:
+			result = append(result, key)
+		case inRight && !inLeft:
+			result = append(result, Edge{key.V1, key.V0})
+		}
+
+		stats.EdgesClassified++
+		if progress != nil && stats.EdgesClassified%booleanProgressInterval == 0 {
+			progress(*stats)
+		}
+	}
+
+	result, stats.MaxSnapDisplacement = snapBooleanEdges(result, op.snapper)
+	stats.OutputEdges = len(result)
+	if len(result) == 0 {
+		// The result is either empty or full.
+		p := OriginPoint()
+		full = op.opType.apply(len(queries[0].containingShapeIDs(p)) > 0, len(queries[1].containingShapeIDs(p)) > 0)
+		return nil, full, nil
+	}
+	cycles, err = assembleBooleanLoops(result)
+	return cycles, false, err
+}
+
+// snapBooleanEdges moves the endpoints of the edges to their snap sites and
+// removes the edges that become degenerate, as well as pairs of edges that
+// become the reverse of each other. It also returns the largest distance
+// that an endpoint moved.
+func snapBooleanEdges(edges []Edge, snapper Snapper) ([]Edge, s1.Angle) {
+	sites := make(map[Point]Point)
+	var maxDisplacement s1.Angle
+	snap := func(p Point) Point {
+		s, ok := sites[p]
+		if !ok {
+			s = snapper.SnapPoint(p)
+			sites[p] = s
+			if d := p.Distance(s); d > maxDisplacement {
+				maxDisplacement = d
+			}
+		}
+		return s
+	}
+	snapped := make([]Edge, len(edges))
+	for i, e := range edges {
+		snapped[i] = Edge{snap(e.V0), snap(e.V1)}
+	}
+	return discardSiblingPairs(snapped), maxDisplacement
+}
+
+// discardSiblingPairs removes degenerate edges, and cancels each edge
+// against its reverse, keeping as many copies of an edge as it occurs more
+// often than its reverse. This matches the C++ S2Builder options
+// DegenerateEdges::DISCARD and SiblingPairs::DISCARD.
+func discardSiblingPairs(edges []Edge) []Edge {
+	count := make(map[Edge]int)
+	var unique []Edge
+	for _, e := range edges {
+		if e.V0 == e.V1 {
+			continue
+		}
+		if count[e] == 0 {
+			unique = append(unique, e)
+		}
+		count[e]++
+	}
+	var result []Edge
+	for _, e := range unique {
+		for n := count[e] - count[Edge{e.V1, e.V0}]; n > 0; n-- {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// assembleBooleanLoops joins the directed edges into cycles, each with the
+// result on its left. Where several edges leave a vertex, each incoming edge
+// is continued with the first unused outgoing edge clockwise from it, so
+// that loops touching at a vertex are kept separate. Duplicate edges are
+// used once for each copy. It returns an error if the edges cannot be
+// assembled into closed loops, which happens if an edge ends at a vertex
+// that no unused edge leaves.
+func assembleBooleanLoops(edges []Edge) ([][]Point, error) {
+	out := make(map[Point][]Point)
+	remaining := make(map[Edge]int)
+	for _, e := range edges {
+		if remaining[e] == 0 {
+			out[e.V0] = append(out[e.V0], e.V1)
+		}
+		remaining[e]++
+	}
+	// next returns the first vertex clockwise from a around b that b has an
+	// unused edge to, or the end of the edge "closing" if it leaves b. The
+	// order is computed with exact predicates, so that it is consistent even
+	// for nearly parallel edges.
+	next := func(a, b Point, closing Edge) (Point, bool) {
+		var best Point
+		found := false
+		for _, c := range out[b] {
+			if remaining[Edge{b, c}] == 0 && (Edge{b, c}) != closing {
+				continue
+			}
+			// Clockwise from a, c comes before best if best, c and a are
+			// in counterclockwise order. Turning back to a comes last.
+			if !found || best == a || c != a && OrderedCCW(best, c, a, b) {
+				best, found = c, true
+			}
+		}
+		return best, found
+	}
+
+	var cycles [][]Point
+	for _, e := range edges {
+		for remaining[e] > 0 {
+			// Follow the edges from e until the walk continues with e
+			// again, which closes the ring.
+			remaining[e]--
+			ring := []Point{e.V0}
+			for a, b := e.V0, e.V1; ; {
+				c, ok := next(a, b, e)
+				if !ok {
+					return nil, fmt.Errorf("s2: edge chain from %v ending at %v is not closed", e.V0, b)
+				}
+				if (Edge{b, c}) == e {
+					break
+				}
+				remaining[Edge{b, c}]--
+				ring = append(ring, b)
+				a, b = b, c
+			}
+			for _, c := range splitRing(ring) {
+				if len(c) < 3 {
+					return nil, fmt.Errorf("s2: edges form a degenerate loop with %d vertices", len(c))
+				}
+				cycles = append(cycles, c)
+			}
+		}
+	}
+	return cycles, nil
+}
+
+// booleanShape is a polygonal shape whose boundary is given by directed
+// edges in no particular order. It represents an input shape of a
+// BooleanOperation after its edges have been noded.
+type booleanShape struct {
+	edges []Edge
+	ref   ReferencePoint
+}
+
+func newBooleanShape(edges []Edge) *booleanShape {
+	s := &booleanShape{edges: edges}
+	s.ref = referencePointForShape(s)
+	return s
+}
+
+func (s *booleanShape) NumEdges() int                          { return len(s.edges) }
+func (s *booleanShape) Edge(id int) Edge                       { return s.edges[id] }
+func (s *booleanShape) ReferencePoint() ReferencePoint         { return s.ref }
+func (s *booleanShape) NumChains() int                         { return len(s.edges) }
+func (s *booleanShape) Chain(chainID int) Chain                { return Chain{chainID, 1} }
+func (s *booleanShape) ChainEdge(chainID, offset int) Edge     { return s.edges[chainID] }
+func (s *booleanShape) ChainPosition(edgeID int) ChainPosition { return ChainPosition{edgeID, 0} }
+func (s *booleanShape) IsEmpty() bool                          { return defaultShapeIsEmpty(s) }
+func (s *booleanShape) IsFull() bool                           { return defaultShapeIsFull(s) }
+func (s *booleanShape) Dimension() int                         { return 2 }
+func (s *booleanShape) typeTag() typeTag                       { return typeTagNone }
+func (s *booleanShape) privateInterface()                      {}
@@ -0,0 +1,351 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+// indexFromPolygons returns a ShapeIndex containing the given polygons.
+func indexFromPolygons(polygons ...*Polygon) *ShapeIndex {
+	index := NewShapeIndex()
+	for _, p := range polygons {
+		index.Add(p)
+	}
+	return index
+}
+
+// regionContains reports whether any of the polygons contains p.
+func regionContains(polygons []*Polygon, p Point) bool {
+	for _, poly := range polygons {
+		if poly.ContainsPoint(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// nearBoundary reports whether p is within the given distance of an edge of
+// the polygons.
+func nearBoundary(polygons []*Polygon, p Point, dist s1.Angle) bool {
+	for _, poly := range polygons {
+		for i := 0; i < poly.NumEdges(); i++ {
+			e := poly.Edge(i)
+			if DistanceFromSegment(p, e.V0, e.V1) < dist {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestBooleanOperationPolygons(t *testing.T) {
+	tests := []struct {
+		desc string
+		a, b []string
+	}{
+		{"overlapping squares", []string{"0:0, 0:4, 4:4, 4:0"}, []string{"2:2, 2:6, 6:6, 6:2"}},
+		{"disjoint squares", []string{"0:0, 0:1, 1:1, 1:0"}, []string{"3:3, 3:4, 4:4, 4:3"}},
+		{"nested squares", []string{"0:0, 0:6, 6:6, 6:0"}, []string{"2:2, 2:3, 3:3, 3:2"}},
+		{"identical squares", []string{"0:0, 0:4, 4:4, 4:0"}, []string{"0:0, 0:4, 4:4, 4:0"}},
+		{"squares sharing an edge", []string{"0:0, 0:2, 2:2, 2:0"}, []string{"0:2, 0:4, 2:4, 2:2"}},
+		{"squares sharing part of an edge", []string{"0:0, 0:2, 2:2, 2:0"}, []string{"1:2, 1:4, 3:4, 3:2"}},
+		{"squares touching at a vertex", []string{"0:0, 0:2, 2:2, 2:0"}, []string{"2:2, 2:4, 4:4, 4:2"}},
+		{"square with a hole and an island", []string{"0:0, 0:8, 8:8, 8:0; 2:2, 2:6, 6:6, 6:2"}, []string{"3:3, 3:5, 5:5, 5:3"}},
+		{"hole crossed by a square", []string{"0:0, 0:8, 8:8, 8:0; 2:2, 2:6, 6:6, 6:2"}, []string{"1:3, 1:5, 7:5, 7:3"}},
+		{"overlapping shapes in one input", []string{"0:0, 0:4, 4:4, 4:0", "2:2, 2:6, 6:6, 6:2"}, []string{"3:-1, 3:7, 5:7, 5:-1"}},
+		{"empty input", []string{"0:0, 0:4, 4:4, 4:0"}, []string{"empty"}},
+		{"full input", []string{"0:0, 0:4, 4:4, 4:0"}, []string{"full"}},
+	}
+	ops := []BooleanOperationType{
+		BooleanOperationUnion,
+		BooleanOperationIntersection,
+		BooleanOperationDifference,
+		BooleanOperationSymmetricDifference,
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, test := range tests {
+		var a, b []*Polygon
+		for _, s := range test.a {
+			a = append(a, makePolygon(s, true))
+		}
+		for _, s := range test.b {
+			if s == "full" {
+				b = append(b, FullPolygon())
+				continue
+			}
+			b = append(b, makePolygon(s, true))
+		}
+		var points []Point
+		for i := 0; i < 500; i++ {
+			p := PointFromLatLng(LatLngFromDegrees(-2+10*rng.Float64(), -2+10*rng.Float64()))
+			if !nearBoundary(a, p, 1e-6) && !nearBoundary(b, p, 1e-6) {
+				points = append(points, p)
+			}
+		}
+
+		for _, opType := range ops {
+			result, err := NewBooleanOperation(opType, nil).Build(indexFromPolygons(a...), indexFromPolygons(b...))
+			if err != nil {
+				t.Errorf("%s: %v: Build returned error: %v", test.desc, opType, err)
+				continue
+			}
+			if err := result.Validate(); err != nil {
+				t.Errorf("%s: %v: result %v is not valid: %v", test.desc, opType, result, err)
+			}
+			for _, p := range points {
+				want := opType.apply(regionContains(a, p), regionContains(b, p))
+				if got := result.ContainsPoint(p); got != want {
+					t.Errorf("%s: %v: result.ContainsPoint(%v) = %v, want %v", test.desc, opType, LatLngFromPoint(p), got, want)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestBooleanOperationFullAndEmpty(t *testing.T) {
+	square := makePolygon("0:0, 0:4, 4:4, 4:0", true)
+	complement := makePolygon("0:0, 0:4, 4:4, 4:0", true)
+	complement.Invert()
+	tests := []struct {
+		opType BooleanOperationType
+		a, b   *Polygon
+		full   bool
+	}{
+		{BooleanOperationUnion, square, complement, true},
+		{BooleanOperationIntersection, square, complement, false},
+		{BooleanOperationDifference, square, square, false},
+		{BooleanOperationSymmetricDifference, square, complement, true},
+	}
+	for _, test := range tests {
+		result, err := NewBooleanOperation(test.opType, nil).Build(indexFromPolygons(test.a), indexFromPolygons(test.b))
+		if err != nil {
+			t.Errorf("%v: Build returned error: %v", test.opType, err)
+			continue
+		}
+		if test.full && !result.IsFull() || !test.full && !result.IsEmpty() {
+			t.Errorf("%v: Build(...) = %v, want full %v", test.opType, result, test.full)
+		}
+	}
+}
+
+func TestBooleanOperationSnapping(t *testing.T) {
+	const level = 10
+	snapper := CellIDSnapperForLevel(level)
+	a := indexFromPolygons(makePolygon("0:0, 0:4, 4:4, 4:0", true))
+	b := indexFromPolygons(makePolygon("2:2, 2:6, 6:6, 6:2", true))
+	result, err := NewBooleanOperation(BooleanOperationUnion, snapper).Build(a, b)
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if result.NumLoops() != 1 || result.Loop(0).NumVertices() != 8 {
+		t.Errorf("Build(...) = %v, want one loop with 8 vertices", result)
+	}
+	for _, l := range result.Loops() {
+		for _, v := range l.Vertices() {
+			if id := cellIDFromPoint(v).Parent(level); v != id.Point() {
+				t.Errorf("vertex %v is not snapped to the center of %v", v, id)
+			}
+		}
+	}
+}
+
+func TestBooleanOperationRejectsPolylines(t *testing.T) {
+	a := indexFromPolygons(makePolygon("0:0, 0:4, 4:4, 4:0", true))
+	b := NewShapeIndex()
+	b.Add(makePolyline("0:0, 1:1"))
+	if _, err := NewBooleanOperation(BooleanOperationUnion, nil).Build(a, b); err == nil {
+		t.Errorf("Build with a polyline input succeeded, want error")
+	}
+}
+
+func TestBooleanOperationBuildIndexed(t *testing.T) {
+	square := makePolygon("0:0, 0:4, 4:4, 4:0; 1:1, 1:3, 3:3, 3:1", true)
+	other := makePolygon("2:2, 2:6, 6:6, 6:2", true)
+	complement := makePolygon("0:0, 0:4, 4:4, 4:0; 1:1, 1:3, 3:3, 3:1", true)
+	complement.Invert()
+
+	rng := rand.New(rand.NewSource(1))
+	var points []Point
+	for i := 0; i < 500; i++ {
+		points = append(points, PointFromLatLng(LatLngFromDegrees(-2+10*rng.Float64(), -2+10*rng.Float64())))
+	}
+	tests := []struct {
+		opType    BooleanOperationType
+		a, b      *Polygon
+		numShapes int
+	}{
+		{BooleanOperationUnion, square, other, 1},
+		{BooleanOperationIntersection, square, other, 1},
+		{BooleanOperationDifference, square, other, 1},
+		{BooleanOperationSymmetricDifference, square, other, 1},
+		{BooleanOperationUnion, square, complement, 1},
+		{BooleanOperationIntersection, square, complement, 0},
+	}
+	for _, test := range tests {
+		op := NewBooleanOperation(test.opType, nil)
+		a, b := indexFromPolygons(test.a), indexFromPolygons(test.b)
+		want, err := op.Build(a, b)
+		if err != nil {
+			t.Fatalf("%v: Build returned error: %v", test.opType, err)
+		}
+		index := NewShapeIndex()
+		if err := op.BuildIndexed(a, b, index); err != nil {
+			t.Fatalf("%v: BuildIndexed returned error: %v", test.opType, err)
+		}
+		if got := index.Len(); got != test.numShapes {
+			t.Errorf("%v: BuildIndexed added %d shapes, want %d", test.opType, got, test.numShapes)
+			continue
+		}
+		if test.numShapes > 0 {
+			shape := index.Shape(0)
+			if _, ok := shape.(*LaxPolygon); !ok {
+				t.Errorf("%v: BuildIndexed added a %T, want *LaxPolygon", test.opType, shape)
+			}
+			if got, want := shape.IsFull(), want.IsFull(); got != want {
+				t.Errorf("%v: BuildIndexed shape IsFull() = %v, want %v", test.opType, got, want)
+			}
+		}
+		query := NewContainsPointQuery(index, VertexModelSemiOpen)
+		for _, p := range points {
+			if got, want := query.Contains(p), want.ContainsPoint(p); got != want {
+				t.Errorf("%v: BuildIndexed result contains %v = %v, want %v", test.opType, LatLngFromPoint(p), got, want)
+				break
+			}
+		}
+	}
+}
+
+// perturbLoop returns a copy of the loop in which each vertex is moved by a
+// random distance between minDist and maxDist with the given probability,
+// and is left where it is otherwise.
+func perturbLoop(rng *rand.Rand, l *Loop, prob float64, minDist, maxDist s1.Angle) *Loop {
+	vertices := make([]Point, l.NumVertices())
+	for i, v := range l.Vertices() {
+		vertices[i] = v
+		if rng.Float64() >= prob {
+			continue
+		}
+		d := minDist.Radians() * math.Pow(maxDist.Radians()/minDist.Radians(), rng.Float64())
+		dir := Point{v.Ortho()}
+		angle := 2 * math.Pi * rng.Float64()
+		offset := dir.Mul(math.Cos(angle)).Add(v.Cross(dir.Vector).Mul(math.Sin(angle)))
+		vertices[i] = Point{v.Add(offset.Mul(d)).Normalize()}
+	}
+	return LoopFromPoints(vertices)
+}
+
+func TestBooleanOperationNearlyCoincidentBoundaries(t *testing.T) {
+	ops := []BooleanOperationType{
+		BooleanOperationUnion,
+		BooleanOperationIntersection,
+		BooleanOperationDifference,
+		BooleanOperationSymmetricDifference,
+	}
+	tests := []struct {
+		desc string
+		// prob is the probability that each vertex of the second loop is
+		// moved; the edges between unmoved vertices are shared exactly.
+		prob float64
+	}{
+		{"nearly coincident boundaries", 1},
+		{"partly shared boundaries", 0.5},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, test := range tests {
+		for i := 0; i < 100; i++ {
+			center := PointFromLatLng(LatLngFromDegrees(160*rng.Float64()-80, 360*rng.Float64()-180))
+			radius := s1.Angle(0.01+0.1*rng.Float64()) * s1.Degree
+			a := RegularLoop(center, radius, 3+rng.Intn(50))
+			b := perturbLoop(rng, a, test.prob, 1e-15, 1e-10)
+			if b.Validate() != nil {
+				continue
+			}
+			pa, pb := []*Polygon{PolygonFromLoops([]*Loop{a})}, []*Polygon{PolygonFromLoops([]*Loop{b})}
+			var points []Point
+			for j := 0; j < 50; j++ {
+				p := samplePointFromCap(CapFromCenterAngle(center, 1.5*radius))
+				if !nearBoundary(pa, p, 1e-9) && !nearBoundary(pb, p, 1e-9) {
+					points = append(points, p)
+				}
+			}
+
+			for _, opType := range ops {
+				result, err := NewBooleanOperation(opType, nil).Build(indexFromPolygons(pa...), indexFromPolygons(pb...))
+				if err != nil {
+					t.Errorf("%s %d: %v: Build returned error: %v", test.desc, i, opType, err)
+					continue
+				}
+				if err := result.Validate(); err != nil {
+					t.Errorf("%s %d: %v: result is not valid: %v", test.desc, i, opType, err)
+				}
+				for _, p := range points {
+					want := opType.apply(regionContains(pa, p), regionContains(pb, p))
+					if got := result.ContainsPoint(p); got != want {
+						t.Errorf("%s %d: %v: result.ContainsPoint(%v) = %v, want %v", test.desc, i, opType, LatLngFromPoint(p), got, want)
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBooleanOperationBuildWithStats(t *testing.T) {
+	snapper := CellIDSnapperForLevel(20)
+	a := PolygonFromLoops([]*Loop{RegularLoop(PointFromLatLng(LatLngFromDegrees(0, 0)), s1.Degree, 600)})
+	b := PolygonFromLoops([]*Loop{RegularLoop(PointFromLatLng(LatLngFromDegrees(0, 1)), s1.Degree, 600)})
+	op := NewBooleanOperation(BooleanOperationUnion, snapper)
+
+	var calls int
+	var last BooleanOperationStats
+	got, stats, err := op.BuildWithStats(indexFromPolygons(a), indexFromPolygons(b), func(s BooleanOperationStats) {
+		calls++
+		if s.EdgesClassified < last.EdgesClassified {
+			t.Errorf("progress went backwards: %+v after %+v", s, last)
+		}
+		last = s
+	})
+	if err != nil {
+		t.Fatalf("BuildWithStats returned error: %v", err)
+	}
+	want, err := op.Build(indexFromPolygons(a), indexFromPolygons(b))
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	checkPolygonsEqual(t, "BuildWithStats()", got, want)
+	if stats.InputEdges != a.NumEdges()+b.NumEdges() {
+		t.Errorf("stats.InputEdges = %d, want %d", stats.InputEdges, a.NumEdges()+b.NumEdges())
+	}
+	if stats.NodedEdges <= stats.InputEdges || stats.EdgesClassified != stats.NodedEdges {
+		t.Errorf("stats = %+v, want EdgesClassified = NodedEdges > InputEdges", stats)
+	}
+	if stats.OutputEdges != got.NumEdges() {
+		t.Errorf("stats.OutputEdges = %d, want %d", stats.OutputEdges, got.NumEdges())
+	}
+	if stats.MaxSnapDisplacement <= 0 || stats.MaxSnapDisplacement > snapper.SnapRadius() {
+		t.Errorf("stats.MaxSnapDisplacement = %v, want in (0, %v]", stats.MaxSnapDisplacement, snapper.SnapRadius())
+	}
+	if want := stats.EdgesClassified / booleanProgressInterval; calls != want {
+		t.Errorf("progress called %d times, want %d (stats %+v)", calls, want, stats)
+	}
+}
@@ -14,6 +14,15 @@
 
 package s2
 
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/golang/geo/r2"
+	"github.com/golang/geo/s1"
+)
+
 const (
 	// maxEdgeDeviationRatio is set so that MaxEdgeDeviation will be large enough
 	// compared to snapRadius such that edge splitting is rare.
@@ -33,3 +42,375 @@ const (
 	// when MaxEdgeDeviation is exceeded.
 	maxEdgeDeviationRatio = 1.1
 )
+
+// BuilderOptions are the options of a Builder.
+type BuilderOptions struct {
+	// Snapper chooses the locations of the output vertices, and how far
+	// the input may move. If nil, an IdentitySnapper with a zero snap
+	// radius is used, so that only identical vertices are merged.
+	Snapper Snapper
+
+	// SplitCrossingEdges splits the input edges at their crossings before
+	// snapping, as by SplitCrossingEdges, so that the output can be a valid
+	// polygon or set of polylines even if the input edges cross.
+	SplitCrossingEdges bool
+
+	// IntersectionTolerance is the maximum distance that edges may move so
+	// that they pass through the points where they are split when
+	// SplitCrossingEdges is set.
+	IntersectionTolerance s1.Angle
+
+	// SimplifyEdgeChains simplifies the output chains within the snap
+	// radius while preserving their topology, as by SimplifyEdgeChains.
+	SimplifyEdgeChains bool
+}
+
+// BuilderStats reports statistics about the work done by a Builder, which
+// can be used to log and tune the snapping.
+type BuilderStats struct {
+	// InputEdges is the number of edges to be snapped, after they have
+	// been split at their crossings if SplitCrossingEdges is set.
+	InputEdges int
+	// Sites is the number of snap sites chosen for the input vertices.
+	Sites int
+	// EdgesSnapped is the number of input edges snapped so far.
+	EdgesSnapped int
+	// OutputEdges is the number of edges of the result. It is only set in
+	// the final statistics.
+	OutputEdges int
+	// MaxSnapDisplacement is the largest distance that an input vertex was
+	// moved to its snap site.
+	MaxSnapDisplacement s1.Angle
+}
+
+// builderProgressInterval is the number of edges snapped between calls to
+// the progress function of a Builder.
+const builderProgressInterval = 256
+
+// Builder assembles edges into polygons and polylines, snapping their
+// vertices with a Snapper. It follows the C++ S2Builder, but is simpler and
+// does not make all of the guarantees listed in the Snapper documentation.
+// Each vertex is moved to the closest snap site, and each edge is replaced
+// by the chain of sites whose Voronoi regions it passes through, among the
+// sites within MaxEdgeDeviation of it. Where every point of an edge is
+// that close to some site, the chain follows edges of the Delaunay
+// triangulation of the sites, so edges that did not cross in the input do
+// not cross in the output, although they may become duplicates or collapse
+// entirely. This is not guaranteed across larger gaps between sites. Unlike
+// the C++ version, edges are not kept MinEdgeVertexSeparation away from
+// other vertices, and the sites are chosen in a fixed order rather than to
+// minimize how far the input moves.
+//
+// Edges are added with the Add methods and assembled with one of the Build
+// methods, which can be called any number of times. BuildIndexed writes the
+// output directly into a ShapeIndex.
+//
+// Example usage, snapping a polygon to E7 coordinates:
+//
+//	b := NewBuilder(BuilderOptions{Snapper: NewIntLatLngSnapper(7)})
+//	b.AddPolygon(polygon)
+//	snapped, err := b.BuildPolygon()
+type Builder struct {
+	opts  BuilderOptions
+	edges []Edge
+}
+
+// NewBuilder returns a Builder with the given options.
+func NewBuilder(opts BuilderOptions) *Builder {
+	if opts.Snapper == nil {
+		opts.Snapper = NewIdentitySnapper(0)
+	}
+	return &Builder{opts: opts}
+}
+
+// AddEdge adds the directed edge from v0 to v1.
+func (b *Builder) AddEdge(v0, v1 Point) {
+	b.edges = append(b.edges, Edge{v0, v1})
+}
+
+// AddPolyline adds the edges of the polyline.
+func (b *Builder) AddPolyline(p *Polyline) {
+	b.AddShape(p)
+}
+
+// AddLoop adds the edges of the loop, with its interior on their left.
+// Empty and full loops have no edges, so they add nothing.
+func (b *Builder) AddLoop(l *Loop) {
+	b.AddShape(l)
+}
+
+// AddPolygon adds the edges of the polygon, with its interior on their left.
+func (b *Builder) AddPolygon(p *Polygon) {
+	b.AddShape(p)
+}
+
+// AddShape adds the edges of the shape. The degenerate edges of points are
+// discarded when building.
+func (b *Builder) AddShape(s Shape) {
+	for i := 0; i < s.NumEdges(); i++ {
+		b.edges = append(b.edges, s.Edge(i))
+	}
+}
+
+// BuildEdges returns the snapped edges, with degenerate edges removed.
+func (b *Builder) BuildEdges() []Edge {
+	return b.buildEdges(&BuilderStats{}, nil)
+}
+
+// buildEdges returns the snapped edges, recording the work done in stats and
+// calling progress with them periodically if it is non-nil.
+func (b *Builder) buildEdges(stats *BuilderStats, progress func(BuilderStats)) []Edge {
+	edges := b.edges
+	if b.opts.SplitCrossingEdges {
+		edges = SplitCrossingEdges(edges, b.opts.IntersectionTolerance)
+	}
+	edges = b.snapEdges(edges, stats, progress)
+	if b.opts.SimplifyEdgeChains {
+		edges = SimplifyEdgeChains(edges, b.opts.Snapper.SnapRadius())
+	}
+	return edges
+}
+
+// BuildPolygon assembles the snapped edges into a polygon whose interior is
+// on the left of every edge. Pairs of edges in opposite directions (such as
+// a boundary shared by two input polygons) are discarded, while other
+// duplicate edges are kept, so that overlapping input polygons give an error
+// rather than being merged. If no edges remain, the result is the empty
+// polygon. An error is returned if the edges do not form closed loops.
+func (b *Builder) BuildPolygon() (*Polygon, error) {
+	p, _, err := b.BuildPolygonWithStats(nil)
+	return p, err
+}
+
+// BuildPolygonWithStats is like BuildPolygon, but also returns statistics
+// about the work done. If progress is non-nil, it is called periodically
+// with the statistics so far while the edges are being snapped.
+func (b *Builder) BuildPolygonWithStats(progress func(BuilderStats)) (*Polygon, BuilderStats, error) {
+	var stats BuilderStats
+	edges := discardSiblingPairs(b.buildEdges(&stats, progress))
+	stats.OutputEdges = len(edges)
+	if len(edges) == 0 {
+		return PolygonFromLoops(nil), stats, nil
+	}
+	cycles, err := assembleBooleanLoops(edges)
+	if err != nil {
+		return nil, stats, err
+	}
+	p := polygonFromCycles(cycles)
+	if err := p.Validate(); err != nil {
+		return nil, stats, fmt.Errorf("s2: builder output is not a valid polygon: %v", err)
+	}
+	return p, stats, nil
+}
+
+// BuildIndexed assembles the snapped edges into loops as BuildPolygon does,
+// and adds them to the given index as a LaxPolygon, unless no edges remain.
+// This is the analog of the C++ IndexedS2PolygonLayer, and avoids the cost
+// of constructing a Polygon, which must nest its loops and build its own
+// index, when the result is only used for queries against the output index.
+// The output is checked with a ValidationQuery, and nothing is added to the
+// index if it is invalid.
+func (b *Builder) BuildIndexed(index *ShapeIndex) error {
+	edges := discardSiblingPairs(b.BuildEdges())
+	if len(edges) == 0 {
+		return nil
+	}
+	cycles, err := assembleBooleanLoops(edges)
+	if err != nil {
+		return err
+	}
+	p := LaxPolygonFromPoints(cycles)
+	if err := NewValidQuery().ValidateShape(p); err != nil {
+		return fmt.Errorf("s2: builder output is not a valid polygon: %v", err)
+	}
+	index.Add(p)
+	return nil
+}
+
+// BuildPolylines assembles the snapped edges into polylines. Each polyline
+// is a maximal chain of edges whose interior vertices have exactly one
+// incoming and one outgoing edge; closed chains start and end at the same
+// vertex.
+func (b *Builder) BuildPolylines() []*Polyline {
+	var lines []*Polyline
+	for _, chain := range assembleEdgeChains(b.BuildEdges()) {
+		line := Polyline(chain)
+		lines = append(lines, &line)
+	}
+	return lines
+}
+
+// snapEdges snaps the vertices of the edges to snap sites, and replaces each
+// edge with the chain of nearby sites that it passes through. It records
+// its work in stats, and calls progress with them periodically if it is
+// non-nil.
+func (b *Builder) snapEdges(edges []Edge, stats *BuilderStats, progress func(BuilderStats)) []Edge {
+	snapper := b.opts.Snapper
+	snapRadius := snapper.SnapRadius()
+
+	var vertices []Point
+	seen := make(map[Point]bool)
+	for _, e := range edges {
+		for _, v := range []Point{e.V0, e.V1} {
+			if !seen[v] {
+				seen[v] = true
+				vertices = append(vertices, v)
+			}
+		}
+	}
+	// Choose the sites in a fixed order so that the output does not depend
+	// on the order of the input edges.
+	sort.Slice(vertices, func(i, j int) bool { return vertices[i].Cmp(vertices[j].Vector) < 0 })
+
+	// Keep each candidate site unless it is too close to an earlier site.
+	selected := newNodeSites(snapper.MinVertexSeparation())
+	var sites PointVector
+	for _, v := range vertices {
+		c := snapper.SnapPoint(v)
+		if _, ok := selected.closest(c); !ok {
+			selected.add(c)
+			sites = append(sites, c)
+		}
+	}
+
+	// Snap each vertex to the closest site.
+	nearby := newNodeSites(snapRadius)
+	for _, s := range sites {
+		nearby.add(s)
+	}
+	siteOf := make(map[Point]Point)
+	for _, v := range vertices {
+		s, ok := nearby.closest(v)
+		if !ok {
+			// This only happens for snappers whose MinVertexSeparation is
+			// too large for their SnapRadius.
+			s = snapper.SnapPoint(v)
+			nearby.add(s)
+			sites = append(sites, s)
+		}
+		siteOf[v] = s
+		if d := v.Distance(s); d > stats.MaxSnapDisplacement {
+			stats.MaxSnapDisplacement = d
+		}
+	}
+	stats.InputEdges = len(edges)
+	stats.Sites = len(sites)
+
+	index := NewShapeIndex()
+	index.Add(&sites)
+	limit := s1.ChordAngleFromAngle(snapper.MaxEdgeDeviation()).Successor()
+	query := NewClosestEdgeQuery(index, NewClosestEdgeQueryOptions().DistanceLimit(limit))
+
+	var result []Edge
+	for _, e := range edges {
+		v0, v1 := siteOf[e.V0], siteOf[e.V1]
+		var candidates []Point
+		for _, r := range query.FindEdges(NewMinDistanceToEdgeTarget(e)) {
+			candidates = append(candidates, sites[r.EdgeID()])
+		}
+		prev := v0
+		for _, s := range append(voronoiSiteChain(e, candidates), v1) {
+			if s != prev {
+				result = append(result, Edge{prev, s})
+				prev = s
+			}
+		}
+
+		stats.EdgesSnapped++
+		if progress != nil && stats.EdgesSnapped%builderProgressInterval == 0 {
+			progress(*stats)
+		}
+	}
+	return result
+}
+
+// voronoiSiteChain returns the sites whose Voronoi regions the edge passes
+// through, in order, considering only the given candidate sites.
+//
+// A point x(t) = cos(t)*V0 + sin(t)*T at distance t along the edge is
+// closest to the site s that maximizes x(t)·s = (cos(t), sin(t))·p(s), where
+// p(s) = (V0·s, T·s) is the projection of s onto the plane of the edge. As t
+// increases from 0 to the length of the edge, the closest site therefore
+// moves counterclockwise around the convex hull of the projected sites, and
+// the next site is the one whose projection first overtakes the current one.
+func voronoiSiteChain(e Edge, candidates []Point) []Point {
+	if len(candidates) == 0 {
+		return nil
+	}
+	a := e.V0
+	t := Point{e.V0.PointCross(e.V1).Normalize()}.Cross(a.Vector)
+	length := e.V0.Distance(e.V1).Radians()
+	proj := make([]r2.Point, len(candidates))
+	cur := 0
+	for i, s := range candidates {
+		proj[i] = r2.Point{X: a.Dot(s.Vector), Y: t.Dot(s.Vector)}
+		if proj[i].X > proj[cur].X {
+			cur = i
+		}
+	}
+
+	chain := []Point{candidates[cur]}
+	for theta := 0.0; ; {
+		next, nextTheta := -1, length
+		for j := range proj {
+			d := proj[j].Sub(proj[cur])
+			if j == cur || d.Norm() == 0 {
+				continue
+			}
+			// x(t)·d increases through zero when t - atan2(d) is -Pi/2.
+			overtake := math.Remainder(math.Atan2(d.Y, d.X)-math.Pi/2-theta, 2*math.Pi)
+			if overtake < 0 {
+				overtake += 2 * math.Pi
+			}
+			if overtake += theta; overtake > theta && overtake < nextTheta {
+				next, nextTheta = j, overtake
+			}
+		}
+		if next < 0 {
+			return chain
+		}
+		cur, theta = next, nextTheta
+		chain = append(chain, candidates[cur])
+	}
+}
+
+// assembleEdgeChains returns the vertices of the maximal chains of the
+// directed edges. A chain continues through a vertex only if the vertex has
+// exactly one incoming and one outgoing edge.
+func assembleEdgeChains(edges []Edge) [][]Point {
+	out := make(map[Point][]int)
+	inDegree := make(map[Point]int)
+	for i, e := range edges {
+		out[e.V0] = append(out[e.V0], i)
+		inDegree[e.V1]++
+	}
+	interior := func(v Point) bool { return inDegree[v] == 1 && len(out[v]) == 1 }
+
+	used := make([]bool, len(edges))
+	follow := func(i int) []Point {
+		chain := []Point{edges[i].V0}
+		for {
+			used[i] = true
+			v := edges[i].V1
+			chain = append(chain, v)
+			if !interior(v) || used[out[v][0]] {
+				return chain
+			}
+			i = out[v][0]
+		}
+	}
+
+	var chains [][]Point
+	for i, e := range edges {
+		if !used[i] && !interior(e.V0) {
+			chains = append(chains, follow(i))
+		}
+	}
+	// The remaining edges form closed chains.
+	for i := range edges {
+		if !used[i] {
+			chains = append(chains, follow(i))
+		}
+	}
+	return chains
+}
@@ -232,10 +232,10 @@ func (sf CellIDSnapper) minSnapRadiusForLevel(level int) s1.Angle {
 	// point can move when snapped, taking into account numerical errors.
 	//
 	// The maximum error when converting from an Point to a CellID is
-	// MaxDiagMetric.Deriv * dblEpsilon. The maximum error when converting a
-	// CellID center back to a Point is 1.5 * dblEpsilon. These add up to
-	// just slightly less than 4 * dblEpsilon.
-	return s1.Angle(0.5*MaxDiagMetric.Value(level) + 4*dblEpsilon)
+	// MaxDiagMetric.Deriv * DblEpsilon. The maximum error when converting a
+	// CellID center back to a Point is 1.5 * DblEpsilon. These add up to
+	// just slightly less than 4 * DblEpsilon.
+	return s1.Angle(0.5*MaxDiagMetric.Value(level) + 4*DblEpsilon)
 }
 
 // levelForMaxSnapRadius reports the minimum Cell level (i.e., largest Cells) such
@@ -249,8 +249,8 @@ func (sf CellIDSnapper) minSnapRadiusForLevel(level int) s1.Angle {
 //	sf := CellIDSnapperForLevel(f.levelForMaxSnapRadius(distance));
 func (sf CellIDSnapper) levelForMaxSnapRadius(snapRadius s1.Angle) int {
 	// When choosing a level, we need to acount for the error bound of
-	// 4 * dblEpsilon that is added by MinSnapRadiusForLevel.
-	return MaxDiagMetric.MinLevel(2 * (snapRadius.Radians() - 4*dblEpsilon))
+	// 4 * DblEpsilon that is added by MinSnapRadiusForLevel.
+	return MaxDiagMetric.MinLevel(2 * (snapRadius.Radians() - 4*DblEpsilon))
 }
 
 // MaxEdgeDeviation returns the maximum edge deviation this type supports.
@@ -405,7 +405,7 @@ func (sf IntLatLngSnapper) minSnapRadiusForExponent(exponent int) s1.Angle {
 	// point can move when snapped, taking into account numerical errors.
 	//
 	// The maximum errors in latitude and longitude can be bounded as
-	// follows (as absolute errors in terms of dblEpsilon):
+	// follows (as absolute errors in terms of DblEpsilon):
 	//
 	//                                      Latitude      Longitude
 	// Convert to LatLng:                      1.000          1.000
@@ -419,14 +419,14 @@ func (sf IntLatLngSnapper) minSnapRadiusForExponent(exponent int) s1.Angle {
 	//
 	// The maximum error when converting the LatLng back to a Point is
 	//
-	//   sqrt(2) * (maximum error in latitude or longitude) + 1.5 * dblEpsilon
+	//   sqrt(2) * (maximum error in latitude or longitude) + 1.5 * DblEpsilon
 	//
-	// which works out to (9 * sqrt(2) + 1.5) * dblEpsilon radians. Finally
+	// which works out to (9 * sqrt(2) + 1.5) * DblEpsilon radians. Finally
 	// we need to consider the effect of rounding to integer coordinates
 	// (much larger than the errors above), which can change the position by
 	// up to (sqrt(2) * 0.5 * sf.to) radians.
 	power := math.Pow10(exponent)
-	return (s1.Degree*s1.Angle((1/math.Sqrt2)/power) + s1.Angle((9*math.Sqrt2+1.5)*dblEpsilon))
+	return (s1.Degree*s1.Angle((1/math.Sqrt2)/power) + s1.Angle((9*math.Sqrt2+1.5)*DblEpsilon))
 }
 
 // exponentForMaxSnapRadius returns the minimum exponent such that vertices will
@@ -435,8 +435,8 @@ func (sf IntLatLngSnapper) minSnapRadiusForExponent(exponent int) s1.Angle {
 // range values are silently clamped).
 func (sf IntLatLngSnapper) exponentForMaxSnapRadius(snapRadius s1.Angle) int {
 	// When choosing an exponent, we need to acount for the error bound of
-	// (9 * sqrt(2) + 1.5) * dblEpsilon added by minSnapRadiusForExponent.
-	snapRadius -= (9*math.Sqrt2 + 1.5) * dblEpsilon
+	// (9 * sqrt(2) + 1.5) * DblEpsilon added by minSnapRadiusForExponent.
+	snapRadius -= (9*math.Sqrt2 + 1.5) * DblEpsilon
 	snapRadius = s1.Angle(math.Max(float64(snapRadius), 1e-30))
 	exponent := math.Log10((1 / math.Sqrt2) / snapRadius.Degrees())
 
@@ -444,7 +444,7 @@ func (sf IntLatLngSnapper) exponentForMaxSnapRadius(snapRadius s1.Angle) int {
 	// this function is the inverse of minSnapRadiusForExponent we subtract a
 	// small error tolerance.
 	return maxInt(minIntSnappingExponent,
-		minInt(maxIntSnappingExponent, int(math.Ceil(exponent-2*dblEpsilon))))
+		minInt(maxIntSnappingExponent, int(math.Ceil(exponent-2*DblEpsilon))))
 }
 
 // MaxEdgeDeviation returns the maximum edge deviation this type supports.
@@ -511,7 +511,7 @@ func (sf IntLatLngSnapper) MinEdgeVertexSeparation() s1.Angle {
 // SnapPoint returns a candidate snap site for the given point.
 func (sf IntLatLngSnapper) SnapPoint(point Point) Point {
 	input := LatLngFromPoint(point)
-	lat := s1.Angle(roundAngle(input.Lat * sf.from))
-	lng := s1.Angle(roundAngle(input.Lng * sf.from))
-	return PointFromLatLng(LatLng{lat * sf.to, lng * sf.to})
+	lat := math.Round(input.Lat.Degrees() * float64(sf.from))
+	lng := math.Round(input.Lng.Degrees() * float64(sf.from))
+	return PointFromLatLng(LatLngFromDegrees(lat*float64(sf.to), lng*float64(sf.to)))
 }
@@ -101,37 +101,41 @@ func TestIntLatLngSnapperExponentToFromSnapRadius(t *testing.T) {
 	}
 }
 
-/*
-TODO(roberts): Uncomment when LatLng helpers are incorporated.
 func TestIntLatLngSnapperSnapPoint(t *testing.T) {
 	for iter := 0; iter < 1000; iter++ {
-		// Test that IntLatLngSnapper does not modify points that were
-		// generated using the LatLngFrom{E5,E6,E7} methods. This ensures
-		// that both functions are using bitwise-compatible conversion methods.
-		p := randomPoint()
-		ll := LatLngFromPoint(p)
-
-		p5 := LatLngFromE5(ll.Lat.E5(), ll.Lng.E5()).ToPoint()
-		if got := NewIntLatLngSnapper(5).SnapPoint(p5); !p5.ApproxEqual(got) {
-			t.Errorf("NewIntLatLngSnapper(5).SnapPoint(%v) = %v, want %v", p5, got, p5)
-		}
-		p6 := LatLngFromE6(ll.Lat.E6(), ll.Lng.E6()).ToPoint()
-		if got := NewIntLatLngSnapper(6).SnapPoint(p6); !p6.ApproxEqual(got) {
-			t.Errorf("NewIntLatLngSnapper(6).SnapPoint(%v) = %v, want %v", p6, got, p6)
-		}
-		p7 := LatLngFromE7(ll.Lat.E7(), ll.Lng.E7()).ToPoint()
-		if got := NewIntLatLngSnapper(7).SnapPoint(p7); !p7.ApproxEqual(got) {
-			t.Errorf("NewIntLatLngSnapper(7).SnapPoint(%v) = %v, want %v", p7, got, p7)
+		// Test that IntLatLngSnapper does not modify points whose
+		// coordinates are already integer multiples of 10^-exp degrees.
+		ll := LatLngFromPoint(randomPoint())
+		for _, test := range []struct {
+			exp      int
+			lat, lng int32
+			scale    float64
+		}{
+			{5, ll.Lat.E5(), ll.Lng.E5(), 1e5},
+			{6, ll.Lat.E6(), ll.Lng.E6(), 1e6},
+			{7, ll.Lat.E7(), ll.Lng.E7(), 1e7},
+		} {
+			p := PointFromLatLng(LatLngFromDegrees(float64(test.lat)/test.scale, float64(test.lng)/test.scale))
+			if got := NewIntLatLngSnapper(test.exp).SnapPoint(p); !p.ApproxEqual(got) {
+				t.Errorf("NewIntLatLngSnapper(%d).SnapPoint(%v) = %v, want %v", test.exp, p, got, p)
+			}
 		}
 
 		// Make sure that we're not snapping using some lower exponent.
-		p7not6 := LatLngFromE7(10*ll.Lat.E6()+1, 10*ll.Lng.E6()+1).ToPoint()
+		p7not6 := PointFromLatLng(LatLngFromDegrees(float64(10*ll.Lat.E6()+1)/1e7, float64(10*ll.Lng.E6()+1)/1e7))
 		if got := NewIntLatLngSnapper(6).SnapPoint(p7not6); p7not6 == got {
-			t.Errorf("NewIntLatLngSnapper(6).SnapPoint(%v) = %v, want %v", p7not6, got, p7not6)
+			t.Errorf("NewIntLatLngSnapper(6).SnapPoint(%v) = %v, want a different point", p7not6, got)
 		}
 	}
+
+	// Coordinates are rounded in degrees rather than in radians, which
+	// used to snap this point to about 0.1234724:0.7654143.
+	p := PointFromLatLng(LatLngFromDegrees(0.1234567, 0.7654321))
+	want := PointFromLatLng(LatLngFromDegrees(0.123457, 0.765432))
+	if got := NewIntLatLngSnapper(6).SnapPoint(p); !got.ApproxEqual(want) {
+		t.Errorf("NewIntLatLngSnapper(6).SnapPoint(%v) = %v, want %v", LatLngFromPoint(p), LatLngFromPoint(got), LatLngFromPoint(want))
+	}
 }
-*/
 
 // TODO(roberts): Differences from C++:
 // bunch of helper methods for these tests:
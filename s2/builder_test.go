@@ -0,0 +1,327 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestBuilderIdentity(t *testing.T) {
+	polygon := makePolygon("0:0, 0:4, 4:4, 4:0; 1:1, 1:3, 3:3, 3:1", true)
+	b := NewBuilder(BuilderOptions{})
+	b.AddPolygon(polygon)
+	got, err := b.BuildPolygon()
+	if err != nil {
+		t.Fatalf("BuildPolygon returned error: %v", err)
+	}
+	if got.NumLoops() != polygon.NumLoops() || !got.Contains(polygon) || !polygon.Contains(got) {
+		t.Errorf("BuildPolygon() = %v, want %v", got, polygon)
+	}
+}
+
+func TestBuilderEmpty(t *testing.T) {
+	got, err := NewBuilder(BuilderOptions{}).BuildPolygon()
+	if err != nil {
+		t.Fatalf("BuildPolygon returned error: %v", err)
+	}
+	if !got.IsEmpty() {
+		t.Errorf("BuildPolygon() = %v, want empty", got)
+	}
+	if lines := NewBuilder(BuilderOptions{}).BuildPolylines(); len(lines) != 0 {
+		t.Errorf("BuildPolylines() = %v, want none", lines)
+	}
+}
+
+func TestBuilderCellIDSnapper(t *testing.T) {
+	const level = 12
+	b := NewBuilder(BuilderOptions{Snapper: CellIDSnapperForLevel(level)})
+	b.AddPolygon(makePolygon("0.1:0.1, 0.1:4.3, 4.2:4.3, 4.2:0.1", true))
+	got, err := b.BuildPolygon()
+	if err != nil {
+		t.Fatalf("BuildPolygon returned error: %v", err)
+	}
+	if got.NumLoops() != 1 {
+		t.Fatalf("BuildPolygon() = %v, want one loop", got)
+	}
+	for _, v := range got.Loop(0).Vertices() {
+		if id := cellIDFromPoint(v).Parent(level); v != id.Point() {
+			t.Errorf("vertex %v is not snapped to the center of %v", v, id)
+		}
+	}
+}
+
+func TestBuilderIntLatLngSnapper(t *testing.T) {
+	b := NewBuilder(BuilderOptions{Snapper: NewIntLatLngSnapper(6)})
+	b.AddPolyline(makePolyline("0.1234567:0.7654321, 1.0000004:2.9999996, 3.3333333:4.4444444"))
+	lines := b.BuildPolylines()
+	if len(lines) != 1 {
+		t.Fatalf("BuildPolylines() returned %d polylines, want 1", len(lines))
+	}
+	for _, v := range *lines[0] {
+		ll := LatLngFromPoint(v)
+		for _, deg := range []float64{ll.Lat.Degrees(), ll.Lng.Degrees()} {
+			if e6 := deg * 1e6; math.Abs(e6-math.Round(e6)) > 1e-6 {
+				t.Errorf("vertex %v is not snapped to E6 coordinates", ll)
+			}
+		}
+	}
+}
+
+func TestBuilderMergesSharedEdges(t *testing.T) {
+	// The shared edge of the two squares differs by about 1cm, which is
+	// merged by a snap radius of about 1m.
+	snapRadius := 1e-5 * s1.Degree
+	b := NewBuilder(BuilderOptions{Snapper: NewIdentitySnapper(snapRadius)})
+	b.AddPolygon(makePolygon("0:0, 0:2, 2:2, 2:0", true))
+	b.AddPolygon(makePolygon("0.0000001:2, 0:4, 2:4, 2.0000001:2", true))
+	got, err := b.BuildPolygon()
+	if err != nil {
+		t.Fatalf("BuildPolygon returned error: %v", err)
+	}
+	if got.NumLoops() != 1 {
+		t.Fatalf("BuildPolygon() = %v, want one loop", got)
+	}
+	if !got.ContainsPoint(parsePoint("1:2")) {
+		t.Errorf("BuildPolygon() = %v, want it to contain the shared edge", got)
+	}
+	if n := got.Loop(0).NumVertices(); n != 6 {
+		t.Errorf("BuildPolygon() has %d vertices, want 6", n)
+	}
+}
+
+func TestBuilderPolygonUnclosedChain(t *testing.T) {
+	b := NewBuilder(BuilderOptions{})
+	b.AddPolyline(makePolyline("0:0, 0:1, 1:1"))
+	if got, err := b.BuildPolygon(); err == nil {
+		t.Errorf("BuildPolygon() of an unclosed chain = %v, want error", got)
+	}
+}
+
+func TestBuilderPolygonDuplicateEdges(t *testing.T) {
+	// The duplicate copies of the loop are kept, so the output is invalid
+	// rather than silently merged.
+	loop := makeLoop("0:0, 0:1, 1:1")
+	b := NewBuilder(BuilderOptions{})
+	b.AddLoop(loop)
+	b.AddLoop(loop)
+	if got, err := b.BuildPolygon(); err == nil {
+		t.Errorf("BuildPolygon() of a doubled loop = %v, want error", got)
+	}
+
+	// A third copy is cancelled by a reversed copy.
+	b.AddLoop(loop)
+	reversed := makeLoop("1:1, 0:1, 0:0")
+	b.AddLoop(reversed)
+	b.AddLoop(reversed)
+	got, err := b.BuildPolygon()
+	if err != nil {
+		t.Fatalf("BuildPolygon() returned error: %v", err)
+	}
+	if got.NumLoops() != 1 || got.Loop(0).NumVertices() != 3 {
+		t.Errorf("BuildPolygon() = %v, want one loop with 3 vertices", got)
+	}
+}
+
+func TestDiscardSiblingPairs(t *testing.T) {
+	a, b, c := parsePoint("0:0"), parsePoint("0:1"), parsePoint("1:1")
+	got := discardSiblingPairs([]Edge{{a, b}, {a, b}, {b, a}, {a, b}, {b, c}, {c, c}, {c, b}})
+	if want := []Edge{{a, b}, {a, b}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("discardSiblingPairs() = %v, want %v", got, want)
+	}
+}
+
+func TestBuilderBuildIndexed(t *testing.T) {
+	b := NewBuilder(BuilderOptions{Snapper: NewIntLatLngSnapper(7)})
+	b.AddPolygon(makePolygon("0:0, 0:2, 2:2, 2:0", true))
+	b.AddPolygon(makePolygon("0:2, 0:4, 2:4, 2:2", true))
+	index := NewShapeIndex()
+	if err := b.BuildIndexed(index); err != nil {
+		t.Fatalf("BuildIndexed returned error: %v", err)
+	}
+	if got := index.Len(); got != 1 {
+		t.Fatalf("BuildIndexed added %d shapes, want 1", got)
+	}
+	if _, ok := index.Shape(0).(*LaxPolygon); !ok {
+		t.Errorf("BuildIndexed added a %T, want *LaxPolygon", index.Shape(0))
+	}
+	want, err := b.BuildPolygon()
+	if err != nil {
+		t.Fatalf("BuildPolygon returned error: %v", err)
+	}
+	query := NewContainsPointQuery(index, VertexModelSemiOpen)
+	for _, p := range []string{"1:1", "1:3", "1:2", "3:3", "1:5"} {
+		pt := parsePoint(p)
+		if got, want := query.Contains(pt), want.ContainsPoint(pt); got != want {
+			t.Errorf("BuildIndexed result contains %s = %v, want %v", p, got, want)
+		}
+	}
+
+	// Empty output adds nothing, and errors are returned.
+	if err := NewBuilder(BuilderOptions{}).BuildIndexed(index); err != nil || index.Len() != 1 {
+		t.Errorf("BuildIndexed of no edges = %v and index has %d shapes, want nil and 1", err, index.Len())
+	}
+	open := NewBuilder(BuilderOptions{})
+	open.AddPolyline(makePolyline("0:0, 0:1, 1:1"))
+	if err := open.BuildIndexed(index); err == nil || index.Len() != 1 {
+		t.Errorf("BuildIndexed of an unclosed chain = %v and index has %d shapes, want error and 1", err, index.Len())
+	}
+}
+
+func TestBuilderPolylines(t *testing.T) {
+	b := NewBuilder(BuilderOptions{})
+	b.AddPolyline(makePolyline("0:0, 0:1, 0:2"))
+	b.AddPolyline(makePolyline("0:2, 0:3"))
+	b.AddLoop(makeLoop("5:5, 5:6, 6:6"))
+	lines := b.BuildPolylines()
+	if len(lines) != 2 {
+		t.Fatalf("BuildPolylines() returned %d polylines, want 2", len(lines))
+	}
+	if want := makePolyline("0:0, 0:1, 0:2, 0:3"); !lines[0].Equal(want) {
+		t.Errorf("BuildPolylines()[0] = %v, want %v", lines[0], want)
+	}
+	if n := len(*lines[1]); n != 4 || (*lines[1])[0] != (*lines[1])[3] {
+		t.Errorf("BuildPolylines()[1] = %v, want a closed polyline with 4 vertices", lines[1])
+	}
+}
+
+func TestBuilderSplitCrossingEdges(t *testing.T) {
+	for _, split := range []bool{false, true} {
+		b := NewBuilder(BuilderOptions{SplitCrossingEdges: split})
+		b.AddPolyline(makePolyline("-1:0, 1:0"))
+		b.AddPolyline(makePolyline("0:-1, 0:1"))
+		want := 2
+		if split {
+			want = 4
+		}
+		if got := len(b.BuildPolylines()); got != want {
+			t.Errorf("SplitCrossingEdges %v: BuildPolylines() returned %d polylines, want %d", split, got, want)
+		}
+	}
+}
+
+func TestBuilderSimplifyEdgeChains(t *testing.T) {
+	line := makePolyline("0:0, 0.0000001:1, 0:2, 0.0000001:3, 0:4")
+	for _, simplify := range []bool{false, true} {
+		snapRadius := 1e-5 * s1.Degree
+		b := NewBuilder(BuilderOptions{Snapper: NewIdentitySnapper(snapRadius), SimplifyEdgeChains: simplify})
+		b.AddPolyline(line)
+		edges := b.BuildEdges()
+		want := 4
+		if simplify {
+			want = 1
+		}
+		if len(edges) != want {
+			t.Errorf("SimplifyEdgeChains %v: BuildEdges() returned %d edges, want %d", simplify, len(edges), want)
+		}
+	}
+}
+
+// randomStarLoop returns a loop with n vertices around the center, at random
+// distances between 0.3 and 1 times the radius in increasing directions.
+func randomStarLoop(rng *rand.Rand, center Point, radius s1.Angle, n int) *Loop {
+	frame := getFrame(center)
+	vertices := make([]Point, n)
+	for i := range vertices {
+		angle := 2 * math.Pi * (float64(i) + 0.9*rng.Float64()) / float64(n)
+		r := radius.Radians() * (0.3 + 0.7*rng.Float64())
+		vertices[i] = fromFrame(frame, PointFromCoords(r*math.Cos(angle), r*math.Sin(angle), 1))
+	}
+	return LoopFromPoints(vertices)
+}
+
+func TestBuilderSnapRandomLoops(t *testing.T) {
+	snappers := []Snapper{
+		NewIntLatLngSnapper(3),
+		NewIntLatLngSnapper(4),
+		NewIntLatLngSnapper(5),
+		CellIDSnapperForLevel(8),
+		CellIDSnapperForLevel(10),
+		CellIDSnapperForLevel(12),
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		center := PointFromLatLng(LatLngFromDegrees(160*rng.Float64()-80, 360*rng.Float64()-180))
+		radius := s1.Angle(0.01+0.1*rng.Float64()) * s1.Degree
+		loop := randomStarLoop(rng, center, radius, 3+rng.Intn(198))
+		if loop.Validate() != nil {
+			continue
+		}
+		for _, snapper := range snappers {
+			b := NewBuilder(BuilderOptions{Snapper: snapper})
+			b.AddLoop(loop)
+			got, err := b.BuildPolygon()
+			if err != nil {
+				t.Errorf("loop %d snapped with radius %v: BuildPolygon returned error: %v", i, snapper.SnapRadius(), err)
+				continue
+			}
+			if err := got.Validate(); err != nil {
+				t.Errorf("loop %d snapped with radius %v: BuildPolygon() = %v is not valid: %v", i, snapper.SnapRadius(), got, err)
+			}
+			// Points farther than the edges can move keep their side.
+			for j := 0; j < 20; j++ {
+				p := samplePointFromCap(CapFromCenterAngle(center, 2*radius))
+				if loop.DistanceToBoundary(p) <= snapper.MaxEdgeDeviation() {
+					continue
+				}
+				if got, want := got.ContainsPoint(p), loop.ContainsPoint(p); got != want {
+					t.Errorf("loop %d snapped with radius %v: ContainsPoint(%v) = %v, want %v", i, snapper.SnapRadius(), p, got, want)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestBuilderBuildPolygonWithStats(t *testing.T) {
+	snapper := NewIntLatLngSnapper(4)
+	loop := RegularLoop(PointFromLatLng(LatLngFromDegrees(10, 20)), s1.Degree, 1000)
+	b := NewBuilder(BuilderOptions{Snapper: snapper})
+	b.AddLoop(loop)
+
+	var calls int
+	var last BuilderStats
+	got, stats, err := b.BuildPolygonWithStats(func(s BuilderStats) {
+		calls++
+		if s.EdgesSnapped < last.EdgesSnapped {
+			t.Errorf("progress went backwards: %+v after %+v", s, last)
+		}
+		last = s
+	})
+	if err != nil {
+		t.Fatalf("BuildPolygonWithStats returned error: %v", err)
+	}
+	want, err := b.BuildPolygon()
+	if err != nil {
+		t.Fatalf("BuildPolygon returned error: %v", err)
+	}
+	checkPolygonsEqual(t, "BuildPolygonWithStats()", got, want)
+	if stats.InputEdges != loop.NumEdges() || stats.EdgesSnapped != stats.InputEdges {
+		t.Errorf("stats = %+v, want InputEdges = EdgesSnapped = %d", stats, loop.NumEdges())
+	}
+	if stats.OutputEdges != got.NumEdges() {
+		t.Errorf("stats.OutputEdges = %d, want %d", stats.OutputEdges, got.NumEdges())
+	}
+	if stats.Sites == 0 || stats.MaxSnapDisplacement <= 0 || stats.MaxSnapDisplacement > snapper.SnapRadius() {
+		t.Errorf("stats = %+v, want Sites > 0 and 0 < MaxSnapDisplacement <= %v", stats, snapper.SnapRadius())
+	}
+	if want := stats.EdgesSnapped / builderProgressInterval; calls != want {
+		t.Errorf("progress called %d times, want %d (stats %+v)", calls, want, stats)
+	}
+}
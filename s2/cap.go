@@ -165,6 +165,32 @@ func (c Cap) Intersects(other Cap) bool {
 	return c.radius.Add(other.radius) >= ChordAngleBetweenPoints(c.center, other.center)
 }
 
+// IntersectionPoint returns a point contained by both this cap and the other
+// one, and reports whether there is such a point (i.e. whether the caps
+// intersect). This is much cheaper than computing the intersection itself.
+//
+// If either cap contains the center of the other, that center is returned.
+// Otherwise the point is the midpoint of the overlap along the great circle
+// through the two centers, which is contained by both caps up to rounding
+// error unless the caps only just touch.
+func (c Cap) IntersectionPoint(other Cap) (Point, bool) {
+	if !c.Intersects(other) {
+		return Point{}, false
+	}
+	if other.ContainsPoint(c.center) {
+		return c.center, true
+	}
+	if c.ContainsPoint(other.center) {
+		return other.center, true
+	}
+
+	// Neither center is contained, so the centers are distinct and the overlap
+	// along the line from c.center is the interval [d - other.radius, c.radius].
+	d := c.center.Distance(other.center)
+	t := 0.5 * (d - other.Radius() + c.Radius())
+	return PointOnLine(c.center, other.center, t), true
+}
+
 // InteriorIntersects reports whether this caps interior intersects the other cap.
 func (c Cap) InteriorIntersects(other Cap) bool {
 	// Make sure this cap has an interior and the other cap is non-empty.
@@ -306,7 +332,7 @@ func (c Cap) AddCap(other Cap) Cap {
 	// We round up the distance to ensure that the cap is actually contained.
 	// TODO(roberts): Do some error analysis in order to guarantee this.
 	dist := ChordAngleBetweenPoints(c.center, other.center).Add(other.radius)
-	if newRad := dist.Expanded(dblEpsilon * float64(dist)); newRad > c.radius {
+	if newRad := dist.Expanded(DblEpsilon * float64(dist)); newRad > c.radius {
 		c.radius = newRad
 	}
 	return c
@@ -50,7 +50,7 @@ var (
 	// here.
 	concaveCenter = PointFromLatLng(LatLngFromDegrees(80, 10))
 	concaveRadius = s1.ChordAngleFromAngle(150 * s1.Degree)
-	maxCapError   = concaveRadius.MaxPointError() + concaveRadius.MaxAngleError() + 3*dblEpsilon
+	maxCapError   = concaveRadius.MaxPointError() + concaveRadius.MaxAngleError() + 3*DblEpsilon
 	concave       = CapFromCenterChordAngle(concaveCenter, concaveRadius)
 	concaveMin    = CapFromCenterChordAngle(concaveCenter, concaveRadius.Expanded(-maxCapError))
 	concaveMax    = CapFromCenterChordAngle(concaveCenter, concaveRadius.Expanded(maxCapError))
@@ -725,3 +725,58 @@ func TestCapEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestCapIntersectionPoint(t *testing.T) {
+	tests := []struct {
+		a, b Cap
+		want bool
+	}{
+		{emptyCap, emptyCap, false},
+		{emptyCap, fullCap, false},
+		{fullCap, fullCap, true},
+		{fullCap, tiny, true},
+		{xAxis, xAxis, true},
+		{xAxis, yAxis, false},
+		{xAxis, xComp, true},
+		{hemi, xAxis, true},
+		{hemi, hemi.Complement(), true},
+		{concave, tiny, true},
+		{
+			CapFromCenterAngle(xAxisPt, 50*s1.Degree),
+			CapFromCenterAngle(yAxisPt, 50*s1.Degree),
+			true,
+		},
+		{
+			CapFromCenterAngle(xAxisPt, 40*s1.Degree),
+			CapFromCenterAngle(yAxisPt, 40*s1.Degree),
+			false,
+		},
+	}
+	for _, test := range tests {
+		for _, c := range [][2]Cap{{test.a, test.b}, {test.b, test.a}} {
+			p, ok := c[0].IntersectionPoint(c[1])
+			if ok != test.want {
+				t.Errorf("%v.IntersectionPoint(%v) = _, %v, want %v", c[0], c[1], ok, test.want)
+				continue
+			}
+			if ok && (!c[0].Expanded(1e-15).ContainsPoint(p) || !c[1].Expanded(1e-15).ContainsPoint(p)) {
+				t.Errorf("%v.IntersectionPoint(%v) = %v, not contained by both caps", c[0], c[1], p)
+			}
+		}
+	}
+}
+
+func TestCapIntersectionPointRandom(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		a := CapFromCenterAngle(randomPoint(), s1.Angle(math.Pi*randomFloat64()))
+		b := CapFromCenterAngle(randomPoint(), s1.Angle(math.Pi*randomFloat64()))
+		p, ok := a.IntersectionPoint(b)
+		if ok != a.Intersects(b) {
+			t.Errorf("%v.IntersectionPoint(%v) = _, %v, want %v", a, b, ok, !ok)
+			continue
+		}
+		if ok && (!a.Expanded(1e-14).ContainsPoint(p) || !b.Expanded(1e-14).ContainsPoint(p)) {
+			t.Errorf("%v.IntersectionPoint(%v) = %v, not contained by both caps", a, b, p)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LevelIndex returns the position of this cell among all cells at its
+// level, in Hilbert curve order. The cells at level k are numbered
+// consecutively from 0 to NumCellsAtLevel(k)-1, which makes the index
+// suitable for use as a key in a bitset or bitmap.
+func (ci CellID) LevelIndex() uint64 {
+	return uint64(ci) >> uint64(PosBits-2*ci.Level())
+}
+
+// CellIDFromLevelIndex returns the cell at the given level with the given
+// index. It is the inverse of LevelIndex.
+func CellIDFromLevelIndex(index uint64, level int) CellID {
+	return CellID(index<<uint64(PosBits-2*level) | lsbForLevel(level))
+}
+
+// NumCellsAtLevel returns the number of cells at the given level.
+func NumCellsAtLevel(level int) uint64 {
+	return uint64(NumFaces) << uint64(2*level)
+}
+
+// CellIndexRange is a half-open range [Lo, Hi) of cell indices at some level.
+type CellIndexRange struct {
+	Lo, Hi uint64
+}
+
+// Len returns the number of indices in the range.
+func (r CellIndexRange) Len() uint64 { return r.Hi - r.Lo }
+
+// CellBitset is a set of cells at a single fixed level, stored as a
+// run-length compressed bitset over the cell indices at that level (see
+// CellID.LevelIndex). Since cells that are close together along the
+// Hilbert curve have consecutive indices, a normalized CellUnion converts
+// into a small number of runs, and membership tests and set operations are
+// fast regardless of how many cells the set contains.
+//
+// The runs can be passed directly to compressed bitmap libraries that
+// support adding ranges (such as roaring bitmaps), and a bitset read back
+// from such a library can be rebuilt with CellBitsetFromRanges.
+//
+// The zero value is an empty set at level 0.
+type CellBitset struct {
+	level int
+	// ranges is sorted, and contains no empty or adjacent ranges.
+	ranges []CellIndexRange
+}
+
+// CellBitsetFromCellUnion returns the cells at the given level that
+// intersect the given cell union. Cells of the union that are larger than
+// the level are expanded into all of their descendants at that level, and
+// cells that are smaller are replaced by their ancestor at that level, so
+// the result covers the union. The union must be valid, but need not be
+// normalized.
+func CellBitsetFromCellUnion(cu CellUnion, level int) *CellBitset {
+	ranges := make([]CellIndexRange, 0, len(cu))
+	for _, id := range cu {
+		ranges = append(ranges, cellIndexRangeAtLevel(id, level))
+	}
+	return CellBitsetFromRanges(level, ranges)
+}
+
+// CellBitsetFromRanges returns the set of cells at the given level whose
+// indices are contained by any of the given ranges. The ranges may be in
+// any order and may overlap. Ranges that extend past NumCellsAtLevel(level)
+// are truncated.
+func CellBitsetFromRanges(level int, ranges []CellIndexRange) *CellBitset {
+	limit := NumCellsAtLevel(level)
+	var rs []CellIndexRange
+	for _, r := range ranges {
+		if r.Hi > limit {
+			r.Hi = limit
+		}
+		if r.Lo < r.Hi {
+			rs = append(rs, r)
+		}
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i].Lo < rs[j].Lo })
+
+	// Merge overlapping and adjacent ranges in place.
+	out := rs[:0]
+	for _, r := range rs {
+		if n := len(out); n > 0 && r.Lo <= out[n-1].Hi {
+			if r.Hi > out[n-1].Hi {
+				out[n-1].Hi = r.Hi
+			}
+			continue
+		}
+		out = append(out, r)
+	}
+	return &CellBitset{level: level, ranges: out}
+}
+
+// cellIndexRangeAtLevel returns the range of indices of the cells at the
+// given level that intersect the given cell.
+func cellIndexRangeAtLevel(id CellID, level int) CellIndexRange {
+	if id.Level() >= level {
+		i := id.Parent(level).LevelIndex()
+		return CellIndexRange{i, i + 1}
+	}
+	lo := id.ChildBeginAtLevel(level).LevelIndex()
+	return CellIndexRange{lo, lo + uint64(1)<<uint64(2*(level-id.Level()))}
+}
+
+// Level returns the level of the cells in this set.
+func (s *CellBitset) Level() int { return s.level }
+
+// Ranges returns the runs of consecutive cell indices in this set, in
+// increasing order. The returned slice must not be modified.
+func (s *CellBitset) Ranges() []CellIndexRange { return s.ranges }
+
+// IsEmpty reports whether this set contains no cells.
+func (s *CellBitset) IsEmpty() bool { return len(s.ranges) == 0 }
+
+// Cardinality returns the number of cells in this set.
+func (s *CellBitset) Cardinality() uint64 {
+	var n uint64
+	for _, r := range s.ranges {
+		n += r.Len()
+	}
+	return n
+}
+
+// containsRange reports whether every index in the given range is in this set.
+func (s *CellBitset) containsRange(r CellIndexRange) bool {
+	// Find the first run that ends after r.Lo.
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi > r.Lo })
+	return i < len(s.ranges) && s.ranges[i].Lo <= r.Lo && r.Hi <= s.ranges[i].Hi
+}
+
+// intersectsRange reports whether any index in the given range is in this set.
+func (s *CellBitset) intersectsRange(r CellIndexRange) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].Hi > r.Lo })
+	return i < len(s.ranges) && s.ranges[i].Lo < r.Hi
+}
+
+// ContainsIndex reports whether the cell with the given index is in this set.
+func (s *CellBitset) ContainsIndex(index uint64) bool {
+	return s.containsRange(CellIndexRange{index, index + 1})
+}
+
+// ContainsCellID reports whether this set contains the given cell. Cells
+// smaller than the level of this set are contained if their ancestor is;
+// larger cells are contained if all of their descendants at this level are.
+func (s *CellBitset) ContainsCellID(id CellID) bool {
+	return s.containsRange(cellIndexRangeAtLevel(id, s.level))
+}
+
+// IntersectsCellID reports whether this set intersects the given cell.
+func (s *CellBitset) IntersectsCellID(id CellID) bool {
+	return s.intersectsRange(cellIndexRangeAtLevel(id, s.level))
+}
+
+// ContainsPoint reports whether this set contains the cell containing the
+// given point.
+func (s *CellBitset) ContainsPoint(p Point) bool {
+	return s.ContainsIndex(cellIDFromPoint(p).Parent(s.level).LevelIndex())
+}
+
+// CellUnion returns this set as a normalized CellUnion. Runs of cells are
+// combined into the largest cells possible.
+func (s *CellBitset) CellUnion() CellUnion {
+	var cu CellUnion
+	for _, r := range s.ranges {
+		begin := CellIDFromLevelIndex(r.Lo, s.level).RangeMin()
+		end := CellIDFromLevelIndex(r.Hi-1, s.level).RangeMax().Next()
+		cu = append(cu, CellUnionFromRange(begin, end)...)
+	}
+	// Runs are separated by at least one missing cell, so the cells from
+	// different runs can never be siblings and the result is normalized.
+	return cu
+}
+
+// AtLevel returns this set converted to the given level. Converting to a
+// finer level is exact. Converting to a coarser level returns every cell
+// at that level with a descendant in this set.
+func (s *CellBitset) AtLevel(level int) *CellBitset {
+	if level == s.level {
+		return s
+	}
+	ranges := make([]CellIndexRange, len(s.ranges))
+	for i, r := range s.ranges {
+		if level > s.level {
+			shift := uint64(2 * (level - s.level))
+			ranges[i] = CellIndexRange{r.Lo << shift, r.Hi << shift}
+		} else {
+			shift := uint64(2 * (s.level - level))
+			ranges[i] = CellIndexRange{r.Lo >> shift, (r.Hi-1)>>shift + 1}
+		}
+	}
+	return CellBitsetFromRanges(level, ranges)
+}
+
+// commonLevel returns both sets converted to the finer of their two levels.
+func (s *CellBitset) commonLevel(o *CellBitset) (*CellBitset, *CellBitset) {
+	if s.level < o.level {
+		return s.AtLevel(o.level), o
+	}
+	return s, o.AtLevel(s.level)
+}
+
+// Union returns the union of this set and the given set. If the two sets
+// are at different levels, the result is at the finer level.
+func (s *CellBitset) Union(o *CellBitset) *CellBitset {
+	x, y := s.commonLevel(o)
+	ranges := make([]CellIndexRange, 0, len(x.ranges)+len(y.ranges))
+	ranges = append(ranges, x.ranges...)
+	ranges = append(ranges, y.ranges...)
+	return CellBitsetFromRanges(x.level, ranges)
+}
+
+// Intersection returns the intersection of this set and the given set. If
+// the two sets are at different levels, the result is at the finer level.
+func (s *CellBitset) Intersection(o *CellBitset) *CellBitset {
+	x, y := s.commonLevel(o)
+	result := &CellBitset{level: x.level}
+	for i, j := 0, 0; i < len(x.ranges) && j < len(y.ranges); {
+		a, b := x.ranges[i], y.ranges[j]
+		lo, hi := maxUint64(a.Lo, b.Lo), minUint64(a.Hi, b.Hi)
+		if lo < hi {
+			result.ranges = append(result.ranges, CellIndexRange{lo, hi})
+		}
+		if a.Hi < b.Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// Difference returns the cells of this set that are not in the given set.
+// If the two sets are at different levels, the result is at the finer level.
+func (s *CellBitset) Difference(o *CellBitset) *CellBitset {
+	x, y := s.commonLevel(o)
+	result := &CellBitset{level: x.level}
+	j := 0
+	for _, r := range x.ranges {
+		lo := r.Lo
+		// Skip the ranges of y that end before this range starts.
+		for j < len(y.ranges) && y.ranges[j].Hi <= lo {
+			j++
+		}
+		for k := j; k < len(y.ranges) && y.ranges[k].Lo < r.Hi; k++ {
+			if y.ranges[k].Lo > lo {
+				result.ranges = append(result.ranges, CellIndexRange{lo, y.ranges[k].Lo})
+			}
+			lo = maxUint64(lo, y.ranges[k].Hi)
+		}
+		if lo < r.Hi {
+			result.ranges = append(result.ranges, CellIndexRange{lo, r.Hi})
+		}
+	}
+	return result
+}
+
+// Equal reports whether the two sets contain the same cells at the same level.
+func (s *CellBitset) Equal(o *CellBitset) bool {
+	if s.level != o.level || len(s.ranges) != len(o.ranges) {
+		return false
+	}
+	for i, r := range s.ranges {
+		if r != o.ranges[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *CellBitset) String() string {
+	return fmt.Sprintf("CellBitset(level=%d, cells=%d, runs=%d)", s.level, s.Cardinality(), len(s.ranges))
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxUint64(a, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+)
+
+func TestCellIDLevelIndexRoundTrip(t *testing.T) {
+	for level := 0; level <= MaxLevel; level++ {
+		first := CellIDFromFace(0).ChildBeginAtLevel(level)
+		last := CellIDFromFace(5).ChildEndAtLevel(level).Prev()
+		if got := first.LevelIndex(); got != 0 {
+			t.Errorf("%v.LevelIndex() = %d, want 0", first, got)
+		}
+		if got, want := last.LevelIndex(), NumCellsAtLevel(level)-1; got != want {
+			t.Errorf("%v.LevelIndex() = %d, want %d", last, got, want)
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		id := randomCellID()
+		index := id.LevelIndex()
+		if got := CellIDFromLevelIndex(index, id.Level()); got != id {
+			t.Errorf("CellIDFromLevelIndex(%d, %d) = %v, want %v", index, id.Level(), got, id)
+		}
+		if id.Level() < MaxLevel {
+			if got, want := id.Next().LevelIndex(), index+1; got != want {
+				t.Errorf("%v.Next().LevelIndex() = %d, want %d", id, got, want)
+			}
+		}
+	}
+}
+
+func TestCellBitsetFromRanges(t *testing.T) {
+	s := CellBitsetFromRanges(1, []CellIndexRange{{10, 12}, {3, 5}, {4, 8}, {12, 13}, {20, 20}, {22, 100}})
+	want := []CellIndexRange{{3, 8}, {10, 13}, {22, 24}}
+	got := s.Ranges()
+	if len(got) != len(want) {
+		t.Fatalf("CellBitsetFromRanges(...).Ranges() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("CellBitsetFromRanges(...).Ranges() = %v, want %v", got, want)
+		}
+	}
+	if got, want := s.Cardinality(), uint64(10); got != want {
+		t.Errorf("%v.Cardinality() = %d, want %d", s, got, want)
+	}
+	for i := uint64(0); i < 24; i++ {
+		want := (i >= 3 && i < 8) || (i >= 10 && i < 13) || i >= 22
+		if got := s.ContainsIndex(i); got != want {
+			t.Errorf("%v.ContainsIndex(%d) = %v, want %v", s, i, got, want)
+		}
+	}
+}
+
+func TestCellBitsetCellUnionRoundTrip(t *testing.T) {
+	const level = 10
+	for i := 0; i < 20; i++ {
+		var cu CellUnion
+		for j := 0; j < 10; j++ {
+			cu = append(cu, randomCellIDForLevel(randomUniformInt(level+1)))
+		}
+		cu.Normalize()
+
+		s := CellBitsetFromCellUnion(cu, level)
+		got := s.CellUnion()
+		if !got.IsNormalized() {
+			t.Errorf("%v.CellUnion() = %v is not normalized", s, got)
+		}
+		if !got.Equal(cu) {
+			t.Errorf("CellBitsetFromCellUnion(%v, %d).CellUnion() = %v, want %v", cu, level, got, cu)
+		}
+
+		var want uint64
+		for _, id := range cu {
+			want += NumCellsAtLevel(level) / NumCellsAtLevel(id.Level())
+		}
+		if got := s.Cardinality(); got != want {
+			t.Errorf("%v.Cardinality() = %d, want %d", s, got, want)
+		}
+
+		for _, id := range cu {
+			if !s.ContainsCellID(id) {
+				t.Errorf("%v.ContainsCellID(%v) = false, want true", s, id)
+			}
+			p := samplePointFromCap(CapFromCenterArea(id.Point(), 1e-12))
+			if want := cu.ContainsPoint(p); s.ContainsPoint(p) != want {
+				t.Errorf("%v.ContainsPoint(%v) = %v, want %v", s, p, !want, want)
+			}
+		}
+	}
+}
+
+func TestCellBitsetCoversFinerCells(t *testing.T) {
+	leaf := cellIDFromPoint(PointFromLatLng(LatLngFromDegrees(10, 20)))
+	s := CellBitsetFromCellUnion(CellUnion{leaf}, 5)
+	if got, want := s.Cardinality(), uint64(1); got != want {
+		t.Fatalf("Cardinality() = %d, want %d", got, want)
+	}
+	if !s.ContainsCellID(leaf.Parent(5)) || !s.ContainsCellID(leaf) {
+		t.Errorf("%v should contain %v and its ancestor at level 5", s, leaf)
+	}
+	if s.ContainsCellID(leaf.Parent(4)) {
+		t.Errorf("%v should not contain %v", s, leaf.Parent(4))
+	}
+	if !s.IntersectsCellID(leaf.Parent(4)) {
+		t.Errorf("%v should intersect %v", s, leaf.Parent(4))
+	}
+}
+
+func TestCellBitsetFull(t *testing.T) {
+	var faces CellUnion
+	for face := 0; face < NumFaces; face++ {
+		faces = append(faces, CellIDFromFace(face))
+	}
+	full := CellBitsetFromCellUnion(faces, 3)
+	if got, want := full.Cardinality(), NumCellsAtLevel(3); got != want {
+		t.Errorf("full.Cardinality() = %d, want %d", got, want)
+	}
+	if got := full.CellUnion(); !got.Equal(faces) {
+		t.Errorf("full.CellUnion() = %v, want %v", got, faces)
+	}
+}
+
+func TestCellBitsetSetOperations(t *testing.T) {
+	const level = 8
+	for i := 0; i < 20; i++ {
+		var x, y CellUnion
+		for j := 0; j < 8; j++ {
+			x = append(x, randomCellIDForLevel(randomUniformInt(level+1)))
+			y = append(y, randomCellIDForLevel(randomUniformInt(level+1)))
+		}
+		x.Normalize()
+		y.Normalize()
+		// Make the sets overlap.
+		y = CellUnionFromUnion(y, CellUnion{x[0].ChildBeginAtLevel(level)})
+
+		sx := CellBitsetFromCellUnion(x, level)
+		sy := CellBitsetFromCellUnion(y, level)
+
+		tests := []struct {
+			name string
+			got  *CellBitset
+			want CellUnion
+		}{
+			{"Union", sx.Union(sy), CellUnionFromUnion(x, y)},
+			{"Intersection", sx.Intersection(sy), CellUnionFromIntersection(x, y)},
+			{"Difference", sx.Difference(sy), CellUnionFromDifference(x, y)},
+			{"Difference", sy.Difference(sx), CellUnionFromDifference(y, x)},
+		}
+		for _, test := range tests {
+			if want := CellBitsetFromCellUnion(test.want, level); !test.got.Equal(want) {
+				t.Errorf("%s(%v, %v) = %v, want %v", test.name, x, y, test.got.Ranges(), want.Ranges())
+			}
+		}
+	}
+}
+
+func TestCellBitsetAtLevel(t *testing.T) {
+	id := CellIDFromFace(2).ChildBeginAtLevel(4).Next()
+	s := CellBitsetFromCellUnion(CellUnion{id}, 4)
+
+	finer := s.AtLevel(6)
+	if got, want := finer.Cardinality(), uint64(16); got != want {
+		t.Errorf("AtLevel(6).Cardinality() = %d, want %d", got, want)
+	}
+	if got := finer.CellUnion(); !got.Equal(CellUnion{id}) {
+		t.Errorf("AtLevel(6).CellUnion() = %v, want %v", got, CellUnion{id})
+	}
+
+	coarser := s.AtLevel(2)
+	if !coarser.Equal(CellBitsetFromCellUnion(CellUnion{id.Parent(2)}, 2)) {
+		t.Errorf("AtLevel(2) = %v, want the single cell %v", coarser.Ranges(), id.Parent(2))
+	}
+
+	// Operations on sets of different levels use the finer level.
+	other := CellBitsetFromCellUnion(CellUnion{id.ChildBeginAtLevel(7)}, 7)
+	if got := s.Intersection(other); got.Level() != 7 || got.Cardinality() != 1 {
+		t.Errorf("Intersection of levels 4 and 7 = %v, want 1 cell at level 7", got)
+	}
+}
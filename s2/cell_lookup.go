@@ -0,0 +1,108 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "sort"
+
+// CellLookup is a read-optimized table that answers "which regions contain
+// this point or cell" for a fixed set of regions, each represented by a
+// covering. The coverings are flattened into a single table of cells sorted
+// by CellID, so a query only needs one binary search for each level at which
+// some covering has a cell (at most 31), regardless of the number of
+// regions. Typical lookups take well under a microsecond.
+//
+// The results are exact with respect to the coverings, not the regions
+// themselves. A covering from RegionCoverer.Covering may contain points
+// outside the region, so the results may include regions that do not
+// actually contain the point; an interior covering never does, but may miss
+// points near the region boundary. When exact answers are needed, the
+// candidates can be checked against the original geometry, for example with
+// a ContainsPointQuery, or the regions can be indexed in a ShapeIndex
+// instead.
+//
+// A CellLookup is immutable once built and safe for concurrent use.
+type CellLookup struct {
+	// cells holds the cells of all coverings in increasing order, and
+	// regionIDs holds the region that each cell belongs to. Cells that are
+	// shared by several regions are repeated, in increasing order of
+	// region ID.
+	cells     []CellID
+	regionIDs []int32
+	// levels has bit i set if some cell in the table is at level i.
+	levels uint32
+}
+
+// NewCellLookup returns a CellLookup for the given coverings, keyed by
+// region ID. The coverings do not need to be normalized.
+func NewCellLookup(coverings map[int32]CellUnion) *CellLookup {
+	type entry struct {
+		id       CellID
+		regionID int32
+	}
+	var entries []entry
+	for regionID, covering := range coverings {
+		cu := append(CellUnion(nil), covering...)
+		cu.Normalize()
+		for _, id := range cu {
+			entries = append(entries, entry{id, regionID})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].id != entries[j].id {
+			return entries[i].id < entries[j].id
+		}
+		return entries[i].regionID < entries[j].regionID
+	})
+
+	l := &CellLookup{
+		cells:     make([]CellID, len(entries)),
+		regionIDs: make([]int32, len(entries)),
+	}
+	for i, e := range entries {
+		l.cells[i] = e.id
+		l.regionIDs[i] = e.regionID
+		l.levels |= 1 << uint(e.id.Level())
+	}
+	return l
+}
+
+// Len returns the number of cells in the table.
+func (l *CellLookup) Len() int { return len(l.cells) }
+
+// RegionsContainingCell returns the IDs of the regions whose coverings
+// contain the given cell, in increasing order.
+func (l *CellLookup) RegionsContainingCell(id CellID) []int32 {
+	var ids []int32
+	// Since the coverings are normalized, each region has at most one cell
+	// among the ancestors of id, so no region is returned twice.
+	for level := 0; level <= id.Level(); level++ {
+		if l.levels&(1<<uint(level)) == 0 {
+			continue
+		}
+		ancestor := id.Parent(level)
+		i := sort.Search(len(l.cells), func(i int) bool { return l.cells[i] >= ancestor })
+		for ; i < len(l.cells) && l.cells[i] == ancestor; i++ {
+			ids = append(ids, l.regionIDs[i])
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// RegionsContainingPoint returns the IDs of the regions whose coverings
+// contain the given point, in increasing order.
+func (l *CellLookup) RegionsContainingPoint(p Point) []int32 {
+	return l.RegionsContainingCell(cellIDFromPoint(p))
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestCellLookupEmpty(t *testing.T) {
+	l := NewCellLookup(nil)
+	if got := l.Len(); got != 0 {
+		t.Errorf("Len() = %d, want 0", got)
+	}
+	if got := l.RegionsContainingPoint(PointFromCoords(1, 0, 0)); got != nil {
+		t.Errorf("RegionsContainingPoint() = %v, want nil", got)
+	}
+}
+
+func TestCellLookupNested(t *testing.T) {
+	face := CellIDFromFace(2)
+	child := face.Children()[1]
+	grandchild := child.Children()[3]
+	l := NewCellLookup(map[int32]CellUnion{
+		7: {face},
+		3: {child, child.Children()[0]},
+		5: {grandchild},
+		9: {face.Next()},
+	})
+	if got, want := l.Len(), 4; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+	tests := []struct {
+		id   CellID
+		want []int32
+	}{
+		{face, []int32{7}},
+		{child, []int32{3, 7}},
+		{grandchild, []int32{3, 5, 7}},
+		{grandchild.ChildBeginAtLevel(MaxLevel), []int32{3, 5, 7}},
+		{face.Children()[0], []int32{7}},
+		{face.Next().ChildBeginAtLevel(10), []int32{9}},
+		{face.Prev(), nil},
+	}
+	for _, test := range tests {
+		if got := l.RegionsContainingCell(test.id); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("RegionsContainingCell(%v) = %v, want %v", test.id, got, test.want)
+		}
+	}
+}
+
+func TestCellLookupMatchesCoverings(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randPoint := func() Point {
+		return PointFromCoords(r.NormFloat64(), r.NormFloat64(), r.NormFloat64())
+	}
+
+	coverer := &RegionCoverer{MaxLevel: 20, MaxCells: 16}
+	coverings := make(map[int32]CellUnion)
+	for i := int32(0); i < 50; i++ {
+		c := CapFromCenterAngle(randPoint(), s1.Angle(0.1+0.5*r.Float64()))
+		coverings[i*2] = coverer.Covering(c)
+	}
+	l := NewCellLookup(coverings)
+
+	for i := 0; i < 1000; i++ {
+		p := randPoint()
+		var want []int32
+		for id := int32(0); id < 100; id += 2 {
+			if cu := coverings[id]; cu.ContainsPoint(p) {
+				want = append(want, id)
+			}
+		}
+		if got := l.RegionsContainingPoint(p); !reflect.DeepEqual(got, want) {
+			t.Errorf("RegionsContainingPoint(%v) = %v, want %v", p, got, want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "sort"
+
+// CellRangeIndex is an interval index over ranges of leaf cells, each with
+// an associated value. A range is given by its first and last leaf CellIDs,
+// which for a single cell are its RangeMin and RangeMax. The index supports
+// stabbing queries, which find the ranges that contain a given cell, and
+// overlap queries, which find the ranges that intersect a given range.
+//
+// The index is built lazily on the first query after ranges are added, in
+// O(n log n) time. Queries take O(log n + k) time, where k is the number of
+// results. A CellRangeIndex is not safe for concurrent use while ranges are
+// being added, since the first query after an Add modifies the index.
+//
+// Results are visited in increasing order of the start of their range, and
+// in insertion order for equal starts. The zero value is an empty index.
+type CellRangeIndex[V any] struct {
+	entries []cellRangeEntry[V]
+	// maxHi[i] is the largest hi among the entries in the implicit subtree
+	// rooted at i, where the subtree of the range [begin, end) is rooted at
+	// its midpoint. It is nil if the index needs to be rebuilt.
+	maxHi []CellID
+}
+
+// cellRangeEntry is a range in a CellRangeIndex and its value.
+type cellRangeEntry[V any] struct {
+	lo, hi CellID
+	value  V
+}
+
+// Len returns the number of ranges in the index.
+func (x *CellRangeIndex[V]) Len() int { return len(x.entries) }
+
+// Add adds the range of leaf cells covered by the given cell.
+func (x *CellRangeIndex[V]) Add(id CellID, value V) {
+	x.AddRange(id.RangeMin(), id.RangeMax(), value)
+}
+
+// AddRange adds the range of leaf cells from lo to hi inclusive. Ranges with
+// lo > hi are empty and never returned by queries.
+func (x *CellRangeIndex[V]) AddRange(lo, hi CellID, value V) {
+	x.entries = append(x.entries, cellRangeEntry[V]{lo, hi, value})
+	x.maxHi = nil
+}
+
+// build sorts the entries and computes the subtree maxima.
+func (x *CellRangeIndex[V]) build() {
+	if x.maxHi != nil || len(x.entries) == 0 {
+		return
+	}
+	sort.SliceStable(x.entries, func(i, j int) bool { return x.entries[i].lo < x.entries[j].lo })
+	x.maxHi = make([]CellID, len(x.entries))
+	x.buildRange(0, len(x.entries))
+}
+
+// buildRange computes maxHi for the subtree of [begin, end) and returns it.
+func (x *CellRangeIndex[V]) buildRange(begin, end int) CellID {
+	if begin >= end {
+		return 0
+	}
+	mid := begin + (end-begin)/2
+	m := x.entries[mid].hi
+	if left := x.buildRange(begin, mid); left > m {
+		m = left
+	}
+	if right := x.buildRange(mid+1, end); right > m {
+		m = right
+	}
+	x.maxHi[mid] = m
+	return m
+}
+
+// VisitContaining calls the visitor for each range that contains all the
+// leaf cells of the given cell. It returns false if the visitor stopped
+// the traversal by returning false, and true otherwise.
+func (x *CellRangeIndex[V]) VisitContaining(id CellID, visitor func(lo, hi CellID, value V) bool) bool {
+	lo, hi := id.RangeMin(), id.RangeMax()
+	return x.visit(lo, hi, func(e *cellRangeEntry[V]) bool {
+		if e.lo > lo || e.hi < hi {
+			return true
+		}
+		return visitor(e.lo, e.hi, e.value)
+	})
+}
+
+// VisitIntersecting calls the visitor for each range that has at least one
+// leaf cell in common with the range from lo to hi inclusive. It returns
+// false if the visitor stopped the traversal by returning false, and true
+// otherwise.
+func (x *CellRangeIndex[V]) VisitIntersecting(lo, hi CellID, visitor func(lo, hi CellID, value V) bool) bool {
+	return x.visit(lo, hi, func(e *cellRangeEntry[V]) bool {
+		return visitor(e.lo, e.hi, e.value)
+	})
+}
+
+// visit calls f for each non-empty entry that overlaps [lo, hi].
+func (x *CellRangeIndex[V]) visit(lo, hi CellID, f func(e *cellRangeEntry[V]) bool) bool {
+	if lo > hi {
+		return true
+	}
+	x.build()
+	return x.visitRange(0, len(x.entries), lo, hi, f)
+}
+
+func (x *CellRangeIndex[V]) visitRange(begin, end int, lo, hi CellID, f func(e *cellRangeEntry[V]) bool) bool {
+	if begin >= end {
+		return true
+	}
+	mid := begin + (end-begin)/2
+	// No range in this subtree ends at or after lo.
+	if x.maxHi[mid] < lo {
+		return true
+	}
+	if !x.visitRange(begin, mid, lo, hi, f) {
+		return false
+	}
+	// This entry and all those after it start after hi.
+	e := &x.entries[mid]
+	if e.lo > hi {
+		return true
+	}
+	if e.lo <= e.hi && e.hi >= lo && !f(e) {
+		return false
+	}
+	return x.visitRange(mid+1, end, lo, hi, f)
+}
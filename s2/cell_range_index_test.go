@@ -0,0 +1,128 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCellRangeIndexEmpty(t *testing.T) {
+	var x CellRangeIndex[int]
+	called := false
+	x.VisitContaining(CellIDFromFace(0), func(lo, hi CellID, v int) bool {
+		called = true
+		return true
+	})
+	x.VisitIntersecting(CellIDFromFace(0).RangeMin(), CellIDFromFace(5).RangeMax(), func(lo, hi CellID, v int) bool {
+		called = true
+		return true
+	})
+	if called || x.Len() != 0 {
+		t.Errorf("empty CellRangeIndex returned results")
+	}
+}
+
+func TestCellRangeIndexRandom(t *testing.T) {
+	type result struct {
+		lo, hi CellID
+		v      int
+	}
+	var x CellRangeIndex[int]
+	var cells []CellID
+	var all []result
+	for i := 0; i < 500; i++ {
+		// Use a single face and few levels so that ranges nest and overlap.
+		level := randomUniformInt(8)
+		id := CellIDFromFace(3).ChildBeginAtLevel(level).Advance(int64(randomUniformInt(1 << uint(2*level))))
+		cells = append(cells, id)
+		x.Add(id, i)
+		all = append(all, result{id.RangeMin(), id.RangeMax(), i})
+	}
+	// Some arbitrary ranges, including empty ones which are never returned.
+	for i := 500; i < 550; i++ {
+		lo, hi := cells[randomUniformInt(len(cells))].RangeMin(), cells[randomUniformInt(len(cells))].RangeMax()
+		x.AddRange(lo, hi, i)
+		if lo <= hi {
+			all = append(all, result{lo, hi, i})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].lo < all[j].lo })
+	if x.Len() != 550 {
+		t.Errorf("Len() = %d, want 550", x.Len())
+	}
+
+	for i := 0; i < 100; i++ {
+		q := cells[randomUniformInt(500)]
+		if oneIn(2) {
+			q = q.ChildBeginAtLevel(minInt(MaxLevel, q.Level()+randomUniformInt(4)))
+		}
+		var wantContaining, wantIntersecting []result
+		for _, r := range all {
+			if r.lo <= q.RangeMin() && q.RangeMax() <= r.hi {
+				wantContaining = append(wantContaining, r)
+			}
+			if r.lo <= q.RangeMax() && q.RangeMin() <= r.hi {
+				wantIntersecting = append(wantIntersecting, r)
+			}
+		}
+		var gotContaining, gotIntersecting []result
+		x.VisitContaining(q, func(lo, hi CellID, v int) bool {
+			gotContaining = append(gotContaining, result{lo, hi, v})
+			return true
+		})
+		x.VisitIntersecting(q.RangeMin(), q.RangeMax(), func(lo, hi CellID, v int) bool {
+			gotIntersecting = append(gotIntersecting, result{lo, hi, v})
+			return true
+		})
+		if !reflect.DeepEqual(gotContaining, wantContaining) {
+			t.Errorf("VisitContaining(%v) = %v, want %v", q, gotContaining, wantContaining)
+		}
+		if !reflect.DeepEqual(gotIntersecting, wantIntersecting) {
+			t.Errorf("VisitIntersecting(%v) = %v, want %v", q, gotIntersecting, wantIntersecting)
+		}
+	}
+}
+
+func TestCellRangeIndexStop(t *testing.T) {
+	var x CellRangeIndex[string]
+	face := CellIDFromFace(1)
+	x.Add(face, "face")
+	x.Add(face.ChildBeginAtLevel(2), "child")
+	x.Add(face.ChildBeginAtLevel(4), "grandchild")
+
+	var got []string
+	if x.VisitContaining(face.ChildBeginAtLevel(6), func(lo, hi CellID, v string) bool {
+		got = append(got, v)
+		return len(got) < 2
+	}) {
+		t.Errorf("VisitContaining returned true after the visitor stopped")
+	}
+	if want := []string{"face", "child"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("VisitContaining visited %v, want %v", got, want)
+	}
+
+	// Adding a range after a query rebuilds the index.
+	x.Add(CellIDFromFace(0), "other")
+	got = nil
+	x.VisitIntersecting(CellIDFromFace(0).RangeMin(), face.RangeMin(), func(lo, hi CellID, v string) bool {
+		got = append(got, v)
+		return true
+	})
+	if want := []string{"other", "face", "child", "grandchild"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("VisitIntersecting visited %v, want %v", got, want)
+	}
+}
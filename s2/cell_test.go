@@ -236,11 +236,11 @@ func testCellChildren(t *testing.T, cell Cell) {
 		// where the cell size at a given level is maximal.
 		maxSizeUV := 0.3964182625366691
 		specialUV := []r2.Point{
-			{dblEpsilon, dblEpsilon}, // Face center
-			{dblEpsilon, 1},          // Edge midpoint
+			{DblEpsilon, DblEpsilon}, // Face center
+			{DblEpsilon, 1},          // Edge midpoint
 			{1, 1},                   // Face corner
 			{maxSizeUV, maxSizeUV},   // Largest cell area
-			{dblEpsilon, maxSizeUV},  // Longest edge/diagonal
+			{DblEpsilon, maxSizeUV},  // Longest edge/diagonal
 		}
 		forceSubdivide := false
 		for _, uv := range specialUV {
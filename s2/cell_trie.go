@@ -0,0 +1,187 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+// CellTrie is a map from CellIDs to values that follows the cell hierarchy,
+// so that the values stored at all ancestors or all descendants of a query
+// cell can be found efficiently. Each node of the trie corresponds to a
+// cell, and its children are that cell's children in Hilbert curve order.
+//
+// This is useful, for example, to find all the coverings that intersect a
+// given cell: a covering cell intersects the query cell exactly when it is
+// an ancestor or a descendant of it (or the cell itself). Looking up each
+// ancestor in a Go map is easy, but finding the descendants is not.
+//
+// A cell may hold any number of values. Values are returned in insertion
+// order within a cell. The zero value is an empty trie ready to use.
+type CellTrie[V any] struct {
+	faces [NumFaces]*cellTrieNode[V]
+	size  int
+}
+
+type cellTrieNode[V any] struct {
+	children [4]*cellTrieNode[V]
+	values   []V
+	// numValues is the total number of values in this subtree.
+	numValues int
+}
+
+// Len returns the number of values in the trie.
+func (t *CellTrie[V]) Len() int { return t.size }
+
+// Insert adds the value to the given cell. Any values already stored at the
+// cell are retained.
+func (t *CellTrie[V]) Insert(id CellID, value V) {
+	node := &t.faces[id.Face()]
+	for level := 0; ; level++ {
+		if *node == nil {
+			*node = &cellTrieNode[V]{}
+		}
+		(*node).numValues++
+		if level == id.Level() {
+			break
+		}
+		node = &(*node).children[id.ChildPosition(level+1)]
+	}
+	(*node).values = append((*node).values, value)
+	t.size++
+}
+
+// find returns the node for the given cell, or nil if there is none.
+func (t *CellTrie[V]) find(id CellID) *cellTrieNode[V] {
+	node := t.faces[id.Face()]
+	for level := 1; node != nil && level <= id.Level(); level++ {
+		node = node.children[id.ChildPosition(level)]
+	}
+	return node
+}
+
+// Values returns the values stored at exactly the given cell.
+func (t *CellTrie[V]) Values(id CellID) []V {
+	if node := t.find(id); node != nil {
+		return node.values
+	}
+	return nil
+}
+
+// Remove removes all values stored at exactly the given cell, and returns
+// the number of values removed.
+func (t *CellTrie[V]) Remove(id CellID) int {
+	var path [MaxLevel + 1]*cellTrieNode[V]
+	node := t.faces[id.Face()]
+	for level := 0; node != nil; level++ {
+		path[level] = node
+		if level == id.Level() {
+			break
+		}
+		node = node.children[id.ChildPosition(level+1)]
+	}
+	if node == nil || len(node.values) == 0 {
+		return 0
+	}
+
+	n := len(node.values)
+	node.values = nil
+	t.size -= n
+	// Update the counts along the path and prune the empty nodes.
+	for level := id.Level(); level >= 0; level-- {
+		path[level].numValues -= n
+		if path[level].numValues > 0 {
+			continue
+		}
+		if level == 0 {
+			t.faces[id.Face()] = nil
+		} else {
+			path[level-1].children[id.ChildPosition(level)] = nil
+		}
+	}
+	return n
+}
+
+// VisitAncestors calls f for each value stored at the given cell or any of
+// its ancestors, starting with the face cell and ending with the given
+// cell. Iteration stops if f returns false, and VisitAncestors returns
+// whether the iteration completed.
+func (t *CellTrie[V]) VisitAncestors(id CellID, f func(id CellID, value V) bool) bool {
+	node := t.faces[id.Face()]
+	for level := 0; node != nil; level++ {
+		ancestor := id.Parent(level)
+		for _, v := range node.values {
+			if !f(ancestor, v) {
+				return false
+			}
+		}
+		if level == id.Level() {
+			break
+		}
+		node = node.children[id.ChildPosition(level+1)]
+	}
+	return true
+}
+
+// VisitDescendants calls f for each value stored at the given cell or any
+// of its descendants, in Hilbert curve order with each cell visited before
+// its descendants. Iteration stops if f returns false, and
+// VisitDescendants returns whether the iteration completed.
+func (t *CellTrie[V]) VisitDescendants(id CellID, f func(id CellID, value V) bool) bool {
+	return t.find(id).visit(id, f)
+}
+
+// VisitIntersecting calls f for each value stored at a cell that intersects
+// the given cell, i.e. at an ancestor or descendant of the cell or the cell
+// itself. Ancestors are visited first, followed by the cell itself and its
+// descendants. Iteration stops if f returns false, and VisitIntersecting
+// returns whether the iteration completed.
+func (t *CellTrie[V]) VisitIntersecting(id CellID, f func(id CellID, value V) bool) bool {
+	if !id.isFace() && !t.VisitAncestors(id.immediateParent(), f) {
+		return false
+	}
+	return t.VisitDescendants(id, f)
+}
+
+// Visit calls f for every value in the trie, in Hilbert curve order with
+// each cell visited before its descendants. Iteration stops if f returns
+// false, and Visit returns whether the iteration completed.
+func (t *CellTrie[V]) Visit(f func(id CellID, value V) bool) bool {
+	for face, node := range t.faces {
+		if !node.visit(CellIDFromFace(face), f) {
+			return false
+		}
+	}
+	return true
+}
+
+// visit calls f for the values in the subtree rooted at this node, which
+// corresponds to the given cell. It may be called on a nil node.
+func (n *cellTrieNode[V]) visit(id CellID, f func(id CellID, value V) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, v := range n.values {
+		if !f(id, v) {
+			return false
+		}
+	}
+	if len(n.values) == n.numValues {
+		return true
+	}
+	children := id.Children()
+	for i, child := range n.children {
+		if !child.visit(children[i], f) {
+			return false
+		}
+	}
+	return true
+}
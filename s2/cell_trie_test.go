@@ -0,0 +1,134 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func collectCellTrie(visit func(f func(CellID, int) bool) bool) []int {
+	var got []int
+	visit(func(_ CellID, v int) bool {
+		got = append(got, v)
+		return true
+	})
+	return got
+}
+
+func TestCellTrieBasic(t *testing.T) {
+	var trie CellTrie[int]
+	leaf := cellIDFromPoint(PointFromLatLng(LatLngFromDegrees(40, -74)))
+	trie.Insert(leaf.Parent(3), 3)
+	trie.Insert(leaf.Parent(10), 10)
+	trie.Insert(leaf.Parent(10), 11)
+	trie.Insert(leaf.Parent(10).Next(), 12)
+	trie.Insert(leaf, 30)
+	trie.Insert(leaf.Parent(3).Next(), 99)
+
+	if got, want := trie.Len(), 6; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+	if got, want := trie.Values(leaf.Parent(10)), []int{10, 11}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Values(%v) = %v, want %v", leaf.Parent(10), got, want)
+	}
+	if got := trie.Values(leaf.Parent(11)); got != nil {
+		t.Errorf("Values(%v) = %v, want nil", leaf.Parent(11), got)
+	}
+
+	query := leaf.Parent(12)
+	if got, want := collectCellTrie(func(f func(CellID, int) bool) bool { return trie.VisitAncestors(query, f) }), []int{3, 10, 11}; !reflect.DeepEqual(got, want) {
+		t.Errorf("VisitAncestors(%v) = %v, want %v", query, got, want)
+	}
+	if got, want := collectCellTrie(func(f func(CellID, int) bool) bool { return trie.VisitDescendants(query, f) }), []int{30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("VisitDescendants(%v) = %v, want %v", query, got, want)
+	}
+	if got, want := collectCellTrie(func(f func(CellID, int) bool) bool { return trie.VisitIntersecting(query, f) }), []int{3, 10, 11, 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("VisitIntersecting(%v) = %v, want %v", query, got, want)
+	}
+	if got, want := collectCellTrie(func(f func(CellID, int) bool) bool { return trie.VisitIntersecting(leaf.Parent(3), f) }), []int{3, 10, 11, 30, 12}; !reflect.DeepEqual(got, want) {
+		t.Errorf("VisitIntersecting(%v) = %v, want %v", leaf.Parent(3), got, want)
+	}
+
+	// Stopping early.
+	count := 0
+	if trie.Visit(func(CellID, int) bool { count++; return count < 2 }) {
+		t.Errorf("Visit should report that the iteration was stopped")
+	}
+	if count != 2 {
+		t.Errorf("Visit called f %d times after stopping, want 2", count)
+	}
+
+	if got, want := trie.Remove(leaf.Parent(10)), 2; got != want {
+		t.Errorf("Remove(%v) = %d, want %d", leaf.Parent(10), got, want)
+	}
+	if got, want := trie.Remove(leaf.Parent(10)), 0; got != want {
+		t.Errorf("second Remove(%v) = %d, want %d", leaf.Parent(10), got, want)
+	}
+	if got, want := collectCellTrie(func(f func(CellID, int) bool) bool { return trie.VisitIntersecting(query, f) }), []int{3, 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("VisitIntersecting(%v) after Remove = %v, want %v", query, got, want)
+	}
+	for _, id := range []CellID{leaf.Parent(3), leaf.Parent(10).Next(), leaf, leaf.Parent(3).Next()} {
+		trie.Remove(id)
+	}
+	if trie.Len() != 0 {
+		t.Errorf("Len() = %d after removing everything, want 0", trie.Len())
+	}
+	for face, node := range trie.faces {
+		if node != nil {
+			t.Errorf("face %d was not pruned after removing everything", face)
+		}
+	}
+}
+
+func TestCellTrieRandom(t *testing.T) {
+	var trie CellTrie[int]
+	var ids []CellID
+	for i := 0; i < 500; i++ {
+		id := randomCellIDForLevel(randomUniformInt(12))
+		ids = append(ids, id)
+		trie.Insert(id, i)
+	}
+
+	for i := 0; i < 100; i++ {
+		id := ids[randomUniformInt(len(ids))]
+		query := id.Parent(randomUniformInt(id.Level() + 1))
+		if randomUniformInt(2) == 0 {
+			query = randomCellIDForLevel(randomUniformInt(12))
+		}
+		var want []int
+		for j, id := range ids {
+			if id.Intersects(query) {
+				want = append(want, j)
+			}
+		}
+		got := collectCellTrie(func(f func(CellID, int) bool) bool { return trie.VisitIntersecting(query, f) })
+		sort.Ints(got)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("VisitIntersecting(%v) = %v, want %v", query, got, want)
+		}
+	}
+
+	// Visit returns the values in Hilbert curve order of their cells.
+	var prev CellID
+	trie.Visit(func(id CellID, _ int) bool {
+		if prev != 0 && id.RangeMin() < prev.RangeMin() {
+			t.Errorf("Visit returned %v after %v", id, prev)
+		}
+		prev = id
+		return true
+	})
+}
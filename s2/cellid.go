@@ -73,6 +73,21 @@ func (c cellIDs) Len() int           { return len(c) }
 func (c cellIDs) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
 func (c cellIDs) Less(i, j int) bool { return c[i] < c[j] }
 
+// dedupCellIDs returns the unique slice of CellIDs from the sorted input list.
+// The input slice is reused for the result.
+func dedupCellIDs(ids []CellID) []CellID {
+	if len(ids) == 0 {
+		return ids
+	}
+	out := ids[:1]
+	for _, id := range ids[1:] {
+		if id != out[len(out)-1] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
 const (
 	// FaceBits is the number of bits used to encode the face number.
 	FaceBits = 3
@@ -130,6 +145,24 @@ func CellIDFromToken(s string) CellID {
 	return CellID(n)
 }
 
+// CellIDFromTokenOrError is like CellIDFromToken, but returns an error if the
+// token is not a hex string of at most 16 digits or does not denote a valid
+// cell, instead of returning the invalid CellID(0) or an invalid cell. In
+// particular, the token "X" of CellID(0) is rejected.
+func CellIDFromTokenOrError(s string) (CellID, error) {
+	if len(s) == 0 || len(s) > 16 {
+		return 0, fmt.Errorf("s2: invalid cell token %q: must have 1 to 16 hex digits", s)
+	}
+	if _, err := strconv.ParseUint(s, 16, 64); err != nil {
+		return 0, fmt.Errorf("s2: invalid cell token %q: not a hex number", s)
+	}
+	ci := CellIDFromToken(s)
+	if !ci.IsValid() {
+		return 0, fmt.Errorf("s2: cell token %q is not a valid cell", s)
+	}
+	return ci, nil
+}
+
 // ToToken returns a hex-encoded string of the uint64 cell id, with leading
 // zeros included but trailing zeros stripped.
 func (ci CellID) ToToken() string {
@@ -366,8 +399,8 @@ func CellIDFromString(s string) CellID {
 
 // Point returns the center of the s2 cell on the sphere as a Point.
 // The maximum directional error in Point (compared to the exact
-// mathematical result) is 1.5 * dblEpsilon radians, and the maximum length
-// error is 2 * dblEpsilon (the same as Normalize).
+// mathematical result) is 1.5 * DblEpsilon radians, and the maximum length
+// error is 2 * DblEpsilon (the same as Normalize).
 func (ci CellID) Point() Point { return Point{ci.rawPoint().Normalize()} }
 
 // LatLng returns the center of the s2 cell on the sphere as a LatLng.
@@ -271,20 +271,6 @@ func TestCellIDVertexNeighbors(t *testing.T) {
 	}
 }
 
-// dedupCellIDs returns the unique slice of CellIDs from the sorted input list.
-func dedupCellIDs(ids []CellID) []CellID {
-	var out []CellID
-	var prev CellID
-	for _, id := range ids {
-		if id != prev {
-			out = append(out, id)
-		}
-		prev = id
-	}
-
-	return out
-}
-
 func TestCellIDAllNeighbors(t *testing.T) {
 	// Check that AllNeighbors produces results that are consistent
 	// with VertexNeighbors for a bunch of random cells.
@@ -1036,3 +1022,21 @@ func TestCellIDCenterFaceSiTi(t *testing.T) {
 // TODO(roberts): Remaining tests to convert.
 // Coverage
 // TraversalOrder
+
+func TestCellIDFromTokenOrError(t *testing.T) {
+	for _, token := range []string{"1", "3", "14", "89c25", "89c259", "b", "b112966aaaaaaaab"} {
+		got, err := CellIDFromTokenOrError(token)
+		if err != nil {
+			t.Errorf("CellIDFromTokenOrError(%q) returned error: %v", token, err)
+			continue
+		}
+		if want := CellIDFromToken(token); got != want {
+			t.Errorf("CellIDFromTokenOrError(%q) = %v, want %v", token, got, want)
+		}
+	}
+	for _, token := range []string{"", "X", "0", "876b e99", "876bee99\n", "0x1", "d", "ffffffffffffffff", "10000000000000000"} {
+		if got, err := CellIDFromTokenOrError(token); err == nil {
+			t.Errorf("CellIDFromTokenOrError(%q) = %v, want error", token, got)
+		}
+	}
+}
@@ -413,6 +413,34 @@ func (cu *CellUnion) Intersects(o CellUnion) bool {
 	return false
 }
 
+// IntersectionPoint returns a point contained by both this CellUnion and the
+// given one, and reports whether there is such a point (i.e. whether they
+// intersect). The point is the center of the smaller of the first pair of
+// intersecting cells found, which is contained by the larger one.
+func (cu *CellUnion) IntersectionPoint(o CellUnion) (Point, bool) {
+	for _, id := range *cu {
+		// As in IntersectsCellID, only the cells on either side of id in o
+		// can intersect it, and two cells that intersect are nested.
+		i := sort.Search(len(o), func(i int) bool { return id < o[i] })
+		if i != len(o) && o[i].RangeMin() <= id.RangeMax() {
+			return smallerCellCenter(id, o[i]), true
+		}
+		if i != 0 && o[i-1].RangeMax() >= id.RangeMin() {
+			return smallerCellCenter(id, o[i-1]), true
+		}
+	}
+	return Point{}, false
+}
+
+// smallerCellCenter returns the center of whichever of the two nested cells
+// is smaller, which is contained by both of them.
+func smallerCellCenter(a, b CellID) Point {
+	if a.Level() >= b.Level() {
+		return a.Point()
+	}
+	return b.Point()
+}
+
 // lowerBound returns the index in this CellUnion to the first element whose value
 // is not considered to go before the given cell id. (i.e., either it is equivalent
 // or comes after the given id.) If there is no match, then end is returned.
@@ -504,6 +532,138 @@ func (cu *CellUnion) ExpandByRadius(minRadius s1.Angle, maxLevelDiff int) {
 	cu.ExpandAtLevel(minInt(minLevel+maxLevelDiff, radiusLevel))
 }
 
+// Coarsen reduces the number of cells in this CellUnion to at most maxCells
+// by replacing runs of cells with their lowest common ancestor, as long as
+// the total area added (the false positives of the coarser union) is at most
+// maxAddedArea steradians. The result always contains the original union.
+// Merges that add the least area are made first, so the union may end up
+// with more than maxCells cells if the budget runs out. Cells on different
+// faces are never merged. Areas are measured with ExactArea. It returns the
+// area that was added.
+//
+// This is useful for reducing the storage used by coverings that were
+// computed with a large MaxCells.
+func (cu *CellUnion) Coarsen(maxCells int, maxAddedArea float64) float64 {
+	cu.Normalize()
+	maxCells = maxInt(1, maxCells)
+	if len(*cu) <= maxCells {
+		return 0
+	}
+
+	// The cells are kept in a doubly linked list in CellID order, where
+	// merged runs are replaced by a new node for their ancestor. Candidate
+	// merges are the runs spanned by the lowest common ancestor of two
+	// adjacent cells, and are ordered by the area they would add.
+	type node struct {
+		id         CellID
+		area       float64
+		prev, next int
+		alive      bool
+	}
+	nodes := make([]node, len(*cu))
+	for i, id := range *cu {
+		nodes[i] = node{id: id, area: CellFromCellID(id).ExactArea(), prev: i - 1, next: i + 1, alive: true}
+	}
+	nodes[len(nodes)-1].next = -1
+
+	type merge struct {
+		ancestor   CellID
+		cost       float64
+		left, next int
+	}
+	// run returns the first and last nodes of the run of cells contained by
+	// the given ancestor that includes node i, and their total area.
+	run := func(ancestor CellID, i int) (lo, hi int, area float64) {
+		lo, hi = i, i
+		for nodes[lo].prev >= 0 && ancestor.Contains(nodes[nodes[lo].prev].id) {
+			lo = nodes[lo].prev
+		}
+		for nodes[hi].next >= 0 && ancestor.Contains(nodes[nodes[hi].next].id) {
+			hi = nodes[hi].next
+		}
+		for j := lo; ; j = nodes[j].next {
+			area += nodes[j].area
+			if j == hi {
+				break
+			}
+		}
+		return lo, hi, area
+	}
+	queue := newBinaryHeap(func(a, b merge) bool { return a.cost < b.cost })
+	addCandidate := func(i int) {
+		if i < 0 || nodes[i].next < 0 {
+			return
+		}
+		j := nodes[i].next
+		level, ok := nodes[i].id.CommonAncestorLevel(nodes[j].id)
+		if !ok {
+			return
+		}
+		ancestor := nodes[i].id.Parent(level)
+		_, _, area := run(ancestor, i)
+		cost := maxFloat64(0, CellFromCellID(ancestor).ExactArea()-area)
+		queue.push(merge{ancestor, cost, i, j})
+	}
+	for i := range nodes {
+		addCandidate(i)
+	}
+
+	added := 0.0
+	numCells := len(nodes)
+	for numCells > maxCells && queue.len() > 0 {
+		m := queue.pop()
+		if !nodes[m.left].alive || nodes[m.left].next != m.next {
+			continue // One of the cells has since been merged.
+		}
+		// Cells merged since the candidate was queued can only have made
+		// the run larger, so the cost is at most the queued one.
+		lo, hi, area := run(m.ancestor, m.left)
+		cost := maxFloat64(0, CellFromCellID(m.ancestor).ExactArea()-area)
+		if added+cost > maxAddedArea {
+			break
+		}
+		merged := node{
+			id:    m.ancestor,
+			area:  CellFromCellID(m.ancestor).ExactArea(),
+			prev:  nodes[lo].prev,
+			next:  nodes[hi].next,
+			alive: true,
+		}
+		for j := lo; ; j = nodes[j].next {
+			nodes[j].alive = false
+			numCells--
+			if j == hi {
+				break
+			}
+		}
+		k := len(nodes)
+		nodes = append(nodes, merged)
+		if merged.prev >= 0 {
+			nodes[merged.prev].next = k
+		}
+		if merged.next >= 0 {
+			nodes[merged.next].prev = k
+		}
+		numCells++
+		added += cost
+		addCandidate(merged.prev)
+		addCandidate(k)
+	}
+
+	// Collect the remaining cells, starting from the first one in the list.
+	first := 0
+	for !nodes[first].alive || nodes[first].prev >= 0 {
+		first++
+	}
+	result := make(CellUnion, 0, numCells)
+	for j := first; j >= 0; j = nodes[j].next {
+		result = append(result, nodes[j].id)
+	}
+	*cu = result
+	cu.Normalize()
+	return added
+}
+
 // Equal reports whether the two CellUnions are equal.
 func (cu CellUnion) Equal(o CellUnion) bool {
 	if len(cu) != len(o) {
@@ -579,7 +739,7 @@ func (cu *CellUnion) decode(d *decoder) {
 		return
 	}
 	const maxCells = 1000000
-	if n > maxCells {
+	if n < 0 || n > maxCells {
 		d.err = fmt.Errorf("too many cells (%d; max is %d)", n, maxCells)
 		return
 	}
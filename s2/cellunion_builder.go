@@ -0,0 +1,104 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+// minCellUnionBuilderBatch is the smallest number of pending operations
+// that causes a CellUnionBuilder to fold them into its normalized cells.
+const minCellUnionBuilderBatch = 64
+
+// CellUnionBuilder accumulates a CellUnion from a stream of cells that are
+// added and removed one at a time, keeping it normalized incrementally.
+//
+// Operations are buffered and folded into the normalized cells in batches
+// whose size is proportional to the size of the union, so the amortized cost
+// of each operation is logarithmic instead of requiring a full Normalize
+// after every insertion. Adding a cell unions it with the cells so far, and
+// removing a cell subtracts it, so the order of operations is respected.
+//
+// The zero value is an empty builder ready to use.
+type CellUnionBuilder struct {
+	// cells is the normalized union of all the operations that have been
+	// applied so far.
+	cells CellUnion
+
+	// pending holds operations not yet applied to cells, which are all
+	// additions or all removals as given by removing.
+	pending  CellUnion
+	removing bool
+}
+
+// Add adds the given cell to the union. The cell must be valid.
+func (b *CellUnionBuilder) Add(id CellID) {
+	b.push(id, false)
+}
+
+// Remove removes the region of the given cell from the union, splitting
+// any larger cell that contains it. The cell must be valid.
+func (b *CellUnionBuilder) Remove(id CellID) {
+	b.push(id, true)
+}
+
+// push queues the operation, first applying any pending operations of the
+// other kind so that operations take effect in order.
+func (b *CellUnionBuilder) push(id CellID, remove bool) {
+	if len(b.pending) > 0 && b.removing != remove {
+		b.flush()
+	}
+	b.removing = remove
+	b.pending = append(b.pending, id)
+	if len(b.pending) >= maxInt(minCellUnionBuilderBatch, len(b.cells)) {
+		b.flush()
+	}
+}
+
+// flush applies the pending operations to the normalized cells.
+func (b *CellUnionBuilder) flush() {
+	if len(b.pending) == 0 {
+		return
+	}
+	if b.removing {
+		b.pending.Normalize()
+		b.cells = CellUnionFromDifference(b.cells, b.pending)
+	} else {
+		b.cells = append(b.cells, b.pending...)
+		b.cells.Normalize()
+	}
+	b.pending = b.pending[:0]
+}
+
+// Len returns the number of cells in the normalized union.
+func (b *CellUnionBuilder) Len() int {
+	b.flush()
+	return len(b.cells)
+}
+
+// ContainsCellID reports whether the union contains the given cell.
+func (b *CellUnionBuilder) ContainsCellID(id CellID) bool {
+	b.flush()
+	return b.cells.ContainsCellID(id)
+}
+
+// CellUnion returns the normalized union of the cells added so far, minus
+// those removed. The builder can continue to be used afterwards.
+func (b *CellUnionBuilder) CellUnion() CellUnion {
+	b.flush()
+	return append(CellUnion(nil), b.cells...)
+}
+
+// Reset removes all the cells from the builder.
+func (b *CellUnionBuilder) Reset() {
+	b.cells = b.cells[:0]
+	b.pending = b.pending[:0]
+}
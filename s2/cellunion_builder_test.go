@@ -0,0 +1,78 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "testing"
+
+func TestCellUnionBuilderMatchesNormalize(t *testing.T) {
+	var b CellUnionBuilder
+	if got := b.CellUnion(); len(got) != 0 || b.Len() != 0 {
+		t.Errorf("zero CellUnionBuilder = %v, want empty", got)
+	}
+
+	// Mostly additions with some removals, all within one face so that they
+	// overlap often.
+	face := CellIDFromFace(2)
+	var want CellUnion
+	for i := 0; i < 2000; i++ {
+		level := 3 + randomUniformInt(4)
+		id := face.ChildBeginAtLevel(level).Advance(int64(randomUniformInt(1 << uint(2*level))))
+		if oneIn(5) {
+			b.Remove(id)
+			want = CellUnionFromDifference(want, CellUnion{id})
+		} else {
+			b.Add(id)
+			want = append(want, id)
+			want.Normalize()
+		}
+		if i%97 == 0 || i == 1999 {
+			if got := b.CellUnion(); !got.Equal(want) {
+				t.Fatalf("after %d operations, CellUnion() = %v, want %v", i+1, got, want)
+			}
+			if b.Len() != len(want) {
+				t.Errorf("after %d operations, Len() = %d, want %d", i+1, b.Len(), len(want))
+			}
+			if len(want) > 0 && !b.ContainsCellID(want[0]) {
+				t.Errorf("ContainsCellID(%v) = false, want true", want[0])
+			}
+		}
+	}
+
+	b.Reset()
+	if b.Len() != 0 {
+		t.Errorf("Len() after Reset = %d, want 0", b.Len())
+	}
+}
+
+func TestCellUnionBuilderOrder(t *testing.T) {
+	id := CellIDFromFace(1).ChildBeginAtLevel(3)
+	var b CellUnionBuilder
+	b.Add(id)
+	b.Remove(id.ChildBeginAtLevel(4))
+	b.Add(id.ChildBeginAtLevel(5))
+	want := CellUnionFromDifference(CellUnion{id}, CellUnion{id.ChildBeginAtLevel(4)})
+	want = append(want, id.ChildBeginAtLevel(5))
+	want.Normalize()
+	if got := b.CellUnion(); !got.Equal(want) {
+		t.Errorf("CellUnion() = %v, want %v", got, want)
+	}
+
+	// The returned union is a copy.
+	got := b.CellUnion()
+	got[0] = CellIDFromFace(5)
+	if b.ContainsCellID(CellIDFromFace(5)) {
+		t.Errorf("modifying the result of CellUnion() changed the builder")
+	}
+}
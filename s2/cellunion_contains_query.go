@@ -0,0 +1,80 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "math/bits"
+
+// CellUnionContainsQuery answers repeated point and cell containment queries
+// against a fixed CellUnion. It precomputes a hash set of the union's cells
+// together with a bitmap of the levels at which they occur, so that each query
+// only needs to look up one ancestor of the probe cell per occupied level
+// rather than binary searching the whole union. For unions produced by a
+// RegionCoverer this is typically a handful of lookups regardless of the
+// number of cells.
+//
+// The union does not need to be normalized. Invalid cell IDs are ignored.
+// The query holds no reference to the original union, so later changes to it
+// are not reflected in the results.
+//
+// A CellUnionContainsQuery is safe for concurrent use by multiple goroutines.
+type CellUnionContainsQuery struct {
+	// levels has bit i set if the union has a cell at level i.
+	levels uint32
+	cells  map[CellID]struct{}
+}
+
+// NewCellUnionContainsQuery returns a query for the cells of the given union.
+func NewCellUnionContainsQuery(cu CellUnion) *CellUnionContainsQuery {
+	q := &CellUnionContainsQuery{
+		cells: make(map[CellID]struct{}, len(cu)),
+	}
+	for _, id := range cu {
+		if !id.IsValid() {
+			continue
+		}
+		q.levels |= 1 << uint(id.Level())
+		q.cells[id] = struct{}{}
+	}
+	return q
+}
+
+// ContainsCellID reports whether the union contains the given cell ID.
+// As with CellUnion, containment is defined with respect to regions, so
+// a cell is contained if it or any of its ancestors is in the union.
+func (q *CellUnionContainsQuery) ContainsCellID(id CellID) bool {
+	if !id.IsValid() {
+		return false
+	}
+	// Only levels at or above the probe cell's level can hold an ancestor.
+	levels := q.levels & (1<<uint(id.Level()+1) - 1)
+	for levels != 0 {
+		level := bits.TrailingZeros32(levels)
+		levels &= levels - 1
+		if _, ok := q.cells[id.Parent(level)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsCell reports whether the union contains the given cell.
+func (q *CellUnionContainsQuery) ContainsCell(c Cell) bool {
+	return q.ContainsCellID(c.id)
+}
+
+// ContainsPoint reports whether the union contains the given point.
+func (q *CellUnionContainsQuery) ContainsPoint(p Point) bool {
+	return q.ContainsCellID(cellIDFromPoint(p))
+}
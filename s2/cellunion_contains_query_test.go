@@ -0,0 +1,126 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestCellUnionContainsQueryEmpty(t *testing.T) {
+	q := NewCellUnionContainsQuery(nil)
+	if q.ContainsCellID(CellIDFromFace(0)) {
+		t.Errorf("empty query ContainsCellID(%v) = true, want false", CellIDFromFace(0))
+	}
+	if p := PointFromCoords(1, 0, 0); q.ContainsPoint(p) {
+		t.Errorf("empty query ContainsPoint(%v) = true, want false", p)
+	}
+}
+
+func TestCellUnionContainsQueryBasic(t *testing.T) {
+	parent := CellIDFromFace(2).ChildBeginAtLevel(5)
+	leaf := CellIDFromFace(4).ChildBeginAtLevel(MaxLevel)
+	// The union is deliberately not normalized, and includes an invalid ID.
+	cu := CellUnion{leaf, parent, parent.Children()[1], 0}
+	q := NewCellUnionContainsQuery(cu)
+
+	tests := []struct {
+		id   CellID
+		want bool
+	}{
+		{parent, true},
+		{parent.Children()[3], true},
+		{parent.ChildBeginAtLevel(MaxLevel), true},
+		{parent.Parent(4), false},
+		{parent.Next(), false},
+		{leaf, true},
+		{leaf.Parent(20), false},
+		{leaf.Next(), false},
+		{0, false},
+		{SentinelCellID, false},
+	}
+	for _, test := range tests {
+		if got := q.ContainsCellID(test.id); got != test.want {
+			t.Errorf("ContainsCellID(%v) = %v, want %v", test.id, got, test.want)
+		}
+	}
+
+	if p := parent.ChildBeginAtLevel(9).Point(); !q.ContainsPoint(p) {
+		t.Errorf("ContainsPoint(%v) = false, want true", p)
+	}
+	if c := CellFromCellID(parent.Children()[0]); !q.ContainsCell(c) {
+		t.Errorf("ContainsCell(%v) = false, want true", c)
+	}
+}
+
+func TestCellUnionContainsQueryMatchesCellUnion(t *testing.T) {
+	for iter := 0; iter < 100; iter++ {
+		var cu CellUnion
+		if iter%2 == 0 {
+			cu = randomCellUnion(1 + randomUniformInt(100))
+			cu.Normalize()
+		} else {
+			rc := &RegionCoverer{MinLevel: 0, MaxLevel: MaxLevel, LevelMod: 1, MaxCells: 1 + randomUniformInt(200)}
+			cu = rc.Covering(randomCap(1e-10, 0.1))
+		}
+		q := NewCellUnionContainsQuery(cu)
+
+		for j := 0; j < 200; j++ {
+			var id CellID
+			switch j % 3 {
+			case 0:
+				id = randomCellID()
+			case 1:
+				// Probe near the union so that positive results are common.
+				c := cu[randomUniformInt(len(cu))]
+				level := randomUniformInt(MaxLevel + 1)
+				if level <= c.Level() {
+					id = c.Parent(level)
+				} else {
+					id = c.ChildBeginAtLevel(level)
+				}
+			case 2:
+				id = cellIDFromPoint(randomPoint())
+			}
+			if got, want := q.ContainsCellID(id), cu.ContainsCellID(id); got != want {
+				t.Errorf("iteration %d: ContainsCellID(%v) = %v, want %v", iter, id, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkCellUnionContainsQuery(b *testing.B) {
+	rc := &RegionCoverer{MinLevel: 0, MaxLevel: MaxLevel, LevelMod: 1, MaxCells: 1000}
+	c := CapFromCenterAngle(PointFromCoords(1, 1, 1), s1.Degree)
+	cu := rc.Covering(c)
+	q := NewCellUnionContainsQuery(cu)
+
+	var points []Point
+	for i := 0; i < 1000; i++ {
+		points = append(points, samplePointFromCap(c))
+	}
+
+	b.Run("CellUnion", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			cu.ContainsPoint(points[i%len(points)])
+		}
+	})
+	b.Run("Query", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q.ContainsPoint(points[i%len(points)])
+		}
+	})
+}
@@ -1054,3 +1054,95 @@ func BenchmarkCellUnionFromRange(b *testing.B) {
 		CellUnionFromRange(x, y)
 	}
 }
+
+func TestCellUnionCoarsen(t *testing.T) {
+	rc := &RegionCoverer{MaxLevel: 20, MaxCells: 200}
+	for i := 0; i < 20; i++ {
+		region := CapFromCenterAngle(randomPoint(), s1.Angle(0.001+0.1*randomFloat64()))
+		original := rc.Covering(region)
+		original.Normalize()
+		originalArea := original.ExactArea()
+
+		for _, test := range []struct {
+			maxCells int
+			budget   float64
+		}{
+			{len(original), 0},
+			{10, 0},
+			{10, 0.1 * originalArea},
+			{10, math.Inf(1)},
+			{1, math.Inf(1)},
+		} {
+			cu := append(CellUnion(nil), original...)
+			added := cu.Coarsen(test.maxCells, test.budget)
+			if !cu.IsNormalized() {
+				t.Errorf("Coarsen(%d, %v) = %v is not normalized", test.maxCells, test.budget, cu)
+			}
+			if !cu.Contains(original) {
+				t.Errorf("Coarsen(%d, %v) = %v does not contain %v", test.maxCells, test.budget, cu, original)
+			}
+			if added > test.budget {
+				t.Errorf("Coarsen(%d, %v) added %v, over budget", test.maxCells, test.budget, added)
+			}
+			if got := cu.ExactArea() - originalArea; math.Abs(got-added) > 1e-12+1e-9*originalArea {
+				t.Errorf("Coarsen(%d, %v) reported adding %v, want %v", test.maxCells, test.budget, added, got)
+			}
+			faces := make(map[int]bool)
+			for _, id := range original {
+				faces[id.Face()] = true
+			}
+			if math.IsInf(test.budget, 1) && len(cu) > maxInt(test.maxCells, len(faces)) {
+				t.Errorf("Coarsen(%d, Inf) returned %d cells", test.maxCells, len(cu))
+			}
+			if test.budget == 0 && !cu.Equal(original) {
+				t.Errorf("Coarsen(%d, 0) = %v, want %v", test.maxCells, cu, original)
+			}
+		}
+	}
+}
+
+func TestCellUnionIntersectionPoint(t *testing.T) {
+	face0 := CellIDFromFace(0)
+	child := face0.ChildBeginAtLevel(5).Next()
+	tests := []struct {
+		a, b CellUnion
+		want bool
+	}{
+		{nil, nil, false},
+		{nil, CellUnion{face0}, false},
+		{CellUnion{face0}, CellUnion{CellIDFromFace(1)}, false},
+		{CellUnion{face0}, CellUnion{face0}, true},
+		{CellUnion{face0}, CellUnion{child}, true},
+		{CellUnion{face0.ChildBeginAtLevel(2), CellIDFromFace(1)}, CellUnion{child, CellIDFromFace(3)}, true},
+		{CellUnion{child.Next()}, CellUnion{child, child.Prev()}, false},
+	}
+	for _, test := range tests {
+		for _, c := range [][2]CellUnion{{test.a, test.b}, {test.b, test.a}} {
+			p, ok := c[0].IntersectionPoint(c[1])
+			if ok != test.want {
+				t.Errorf("%v.IntersectionPoint(%v) = _, %v, want %v", c[0], c[1], ok, test.want)
+				continue
+			}
+			if ok && (!c[0].ContainsPoint(p) || !c[1].ContainsPoint(p)) {
+				t.Errorf("%v.IntersectionPoint(%v) = %v, not contained by both unions", c[0], c[1], p)
+			}
+		}
+	}
+}
+
+func TestCellUnionIntersectionPointRandom(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		a := randomCellUnion(10)
+		b := randomCellUnion(10)
+		a.Normalize()
+		b.Normalize()
+		p, ok := a.IntersectionPoint(b)
+		if ok != a.Intersects(b) {
+			t.Errorf("%v.IntersectionPoint(%v) = _, %v, want %v", a, b, ok, !ok)
+			continue
+		}
+		if ok && (!a.ContainsPoint(p) || !b.ContainsPoint(p)) {
+			t.Errorf("%v.IntersectionPoint(%v) = %v, not contained by both unions", a, b, p)
+		}
+	}
+}
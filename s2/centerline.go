@@ -0,0 +1,157 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// centerlineNode is a ridge cell in the centerline graph of a polygon.
+type centerlineNode struct {
+	center    Point
+	dist      s1.Angle
+	neighbors []int
+}
+
+// Centerline returns an approximation of the centerline (the main branch of
+// the medial axis) of the polygon, which is useful for labeling elongated
+// regions such as rivers and road casings.
+//
+// The polygon is sampled with cells whose edges are about resolution long.
+// The cells along the ridge of the distance from the boundary are kept,
+// which thins the polygon down to a band one or two cells wide, and the
+// longest path through each connected part of the band is returned as a
+// Polyline through the cell centers. Side branches of the medial axis are
+// not returned, nor are parts of the ridge no longer than the polygon is
+// wide around them. The vertices are within about resolution of the true
+// centerline, and the cost is proportional to the area of the polygon
+// divided by resolution squared, so the resolution should be chosen
+// relative to the width of the polygon.
+func (p *Polygon) Centerline(resolution s1.Angle) []Polyline {
+	if p.IsEmpty() || p.IsFull() || resolution <= 0 {
+		return nil
+	}
+	level := AvgEdgeMetric.ClosestLevel(resolution.Radians())
+
+	// Compute the distance to the boundary from every cell at the chosen
+	// level whose center is inside the polygon. After denormalizing, every
+	// cell of the covering is at that level.
+	query := NewClosestEdgeQuery(p.index, NewClosestEdgeQueryOptions().IncludeInteriors(false))
+	covering := (&RegionCoverer{MaxLevel: level, LevelMod: 1, MaxCells: 8}).Covering(p)
+	covering.Denormalize(level, 1)
+	dist := make(map[CellID]s1.ChordAngle)
+	var inside []CellID
+	for _, id := range covering {
+		if center := id.Point(); p.ContainsPoint(center) {
+			dist[id] = query.Distance(NewMinDistanceToPointTarget(center))
+			inside = append(inside, id)
+		}
+	}
+	distOf := func(id CellID) s1.ChordAngle {
+		if d, ok := dist[id]; ok {
+			return d
+		}
+		return s1.NegativeChordAngle
+	}
+
+	// Keep the cells that are at least as far from the boundary as both of
+	// their neighbors in one of the two axis directions.
+	index := make(map[CellID]int)
+	var ridge []CellID
+	for _, id := range inside {
+		d, n := dist[id], id.EdgeNeighbors()
+		if (d >= distOf(n[0]) && d >= distOf(n[2])) || (d >= distOf(n[1]) && d >= distOf(n[3])) {
+			index[id] = len(ridge)
+			ridge = append(ridge, id)
+		}
+	}
+	nodes := make([]centerlineNode, len(ridge))
+	for i, id := range ridge {
+		nodes[i].center, nodes[i].dist = id.Point(), dist[id].Angle()
+		for _, n := range id.AllNeighbors(level) {
+			if j, ok := index[n]; ok {
+				nodes[i].neighbors = append(nodes[i].neighbors, j)
+			}
+		}
+	}
+
+	// Each connected part of the ridge is reduced to an approximation of its
+	// longest path: the path between the node farthest from an arbitrary
+	// start, and the node farthest from that one. Parts that are no longer
+	// than the width of the polygon around them are isolated pieces of the
+	// branches toward corners, and are dropped.
+	var lines []Polyline
+	seen := make([]bool, len(nodes))
+	for start := range nodes {
+		if seen[start] {
+			continue
+		}
+		_, from := centerlineFarthest(nodes, start, seen)
+		prev, to := centerlineFarthest(nodes, from, nil)
+		var line Polyline
+		var width s1.Angle
+		for i := to; i >= 0; i = prev[i] {
+			line = append(line, nodes[i].center)
+			width = maxAngle(width, 2*nodes[i].dist)
+		}
+		if len(line) >= 2 && line.Length() > width {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// centerlineFarthest computes the shortest paths through the graph from the
+// given node, and returns the previous node on the path to each node (or -1
+// for the start and unreached nodes) and the farthest node. If seen is not
+// nil, the reached nodes are marked in it.
+func centerlineFarthest(nodes []centerlineNode, start int, seen []bool) (prev []int, farthest int) {
+	type entry struct {
+		node int
+		dist float64
+	}
+	dist := make([]float64, len(nodes))
+	prev = make([]int, len(nodes))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		prev[i] = -1
+	}
+	dist[start] = 0
+	farthest = start
+	var maxDist float64
+	queue := newBinaryHeap(func(a, b entry) bool { return a.dist < b.dist })
+	queue.push(entry{start, 0})
+	for queue.len() > 0 {
+		e := queue.pop()
+		if e.dist > dist[e.node] {
+			continue
+		}
+		if seen != nil {
+			seen[e.node] = true
+		}
+		if e.dist > maxDist {
+			farthest, maxDist = e.node, e.dist
+		}
+		for _, n := range nodes[e.node].neighbors {
+			if d := e.dist + float64(nodes[e.node].center.Distance(nodes[n].center)); d < dist[n] {
+				dist[n], prev[n] = d, e.node
+				queue.push(entry{n, d})
+			}
+		}
+	}
+	return prev, farthest
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestPolygonCenterline(t *testing.T) {
+	// A long thin rectangle, whose centerline runs along the equator.
+	p := makePolygon("-1:0, -1:20, 1:20, 1:0", true)
+	const resolution = s1.Degree / 8
+	lines := p.Centerline(resolution)
+	if len(lines) != 1 {
+		t.Fatalf("Centerline of a rectangle returned %d lines, want 1", len(lines))
+	}
+	line := lines[0]
+	for _, v := range line {
+		if lat := LatLngFromPoint(v).Lat; lat.Abs() > 2*resolution {
+			t.Errorf("centerline vertex %v is %v degrees from the center", v, lat.Degrees())
+		}
+	}
+	// The medial axis of a rectangle ends one half-width from the short
+	// sides, at 1:1 and 1:19, and the longest path follows its branches to
+	// near the corners.
+	if got := line.Length(); got < 17*s1.Degree || got > 21*s1.Degree {
+		t.Errorf("centerline length = %v degrees, want about 18 to 20", got.Degrees())
+	}
+
+	// A bent river-like shape yields a single line that stays inside.
+	river := makePolygon("0:0, 0:10, 10:10, 10:9, 1:9, 1:0", true)
+	lines = river.Centerline(s1.Degree / 8)
+	if len(lines) != 1 {
+		t.Fatalf("Centerline of a bent band returned %d lines, want 1", len(lines))
+	}
+	for _, v := range lines[0] {
+		if !river.ContainsPoint(v) {
+			t.Errorf("centerline vertex %v is outside the polygon", v)
+		}
+	}
+	if got := lines[0].Length(); got < 17*s1.Degree {
+		t.Errorf("centerline length = %v degrees, want at least 17", got.Degrees())
+	}
+
+	if got := (&Polygon{}).Centerline(resolution); got != nil {
+		t.Errorf("Centerline of the empty polygon = %v, want nil", got)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"sort"
+
+	"github.com/golang/geo/s1"
+)
+
+// ChainInterpolationResult is a position along the edges of a
+// ChainInterpolationQuery.
+type ChainInterpolationResult struct {
+	// Point is the point at the position.
+	Point Point
+	// EdgeID is the ID within the shape of the edge that contains the point.
+	EdgeID int
+	// Distance is the distance along the edges from their start to the point.
+	Distance s1.Angle
+}
+
+// ChainInterpolationQuery finds the point at a given distance or fraction
+// along a chain of edges of a Shape, and the inverse, the distance along the
+// chain of the point closest to a given point. This is known as linear
+// referencing, and is typically used with polylines such as roads.
+//
+// The edges used are either those of a single chain of the shape, or all the
+// edges of the shape in order, in which case the query treats consecutive
+// chains as though they were joined. Distances are measured along the edges,
+// and the query precomputes the cumulative lengths of the edges so that each
+// interpolation takes logarithmic time.
+type ChainInterpolationQuery struct {
+	shape       Shape
+	firstEdgeID int
+
+	// cumulative[i] is the total length of the first i edges, so it has one
+	// more element than the number of edges.
+	cumulative []s1.Angle
+}
+
+// NewChainInterpolationQuery returns a query for the edges of the given chain
+// of the shape. If chainID is negative, all the edges of the shape are used.
+func NewChainInterpolationQuery(shape Shape, chainID int) *ChainInterpolationQuery {
+	first, limit := 0, shape.NumEdges()
+	if chainID >= 0 {
+		chain := shape.Chain(chainID)
+		first, limit = chain.Start, chain.Start+chain.Length
+	}
+
+	q := &ChainInterpolationQuery{
+		shape:       shape,
+		firstEdgeID: first,
+		cumulative:  make([]s1.Angle, 1, limit-first+1),
+	}
+	var length s1.Angle
+	for e := first; e < limit; e++ {
+		edge := shape.Edge(e)
+		length += edge.V0.Distance(edge.V1)
+		q.cumulative = append(q.cumulative, length)
+	}
+	return q
+}
+
+// numEdges returns the number of edges used by the query.
+func (q *ChainInterpolationQuery) numEdges() int { return len(q.cumulative) - 1 }
+
+// Length returns the total length of the edges of the query.
+func (q *ChainInterpolationQuery) Length() s1.Angle {
+	return q.cumulative[len(q.cumulative)-1]
+}
+
+// AtDistance returns the point at the given distance along the edges of the
+// query. Distances less than zero or greater than the length are clamped to
+// the start or the end of the edges respectively. It returns false if the
+// query has no edges.
+func (q *ChainInterpolationQuery) AtDistance(distance s1.Angle) (ChainInterpolationResult, bool) {
+	n := q.numEdges()
+	if n == 0 {
+		return ChainInterpolationResult{}, false
+	}
+
+	// Find the first edge whose end is at least the given distance along.
+	i := sort.Search(n, func(i int) bool { return q.cumulative[i+1] >= distance })
+	switch {
+	case distance <= 0:
+		return ChainInterpolationResult{q.shape.Edge(q.firstEdgeID).V0, q.firstEdgeID, 0}, true
+	case i == n:
+		edgeID := q.firstEdgeID + n - 1
+		return ChainInterpolationResult{q.shape.Edge(edgeID).V1, edgeID, q.Length()}, true
+	}
+
+	edgeID := q.firstEdgeID + i
+	edge := q.shape.Edge(edgeID)
+	p := InterpolateAtDistance(distance-q.cumulative[i], edge.V0, edge.V1)
+	return ChainInterpolationResult{p, edgeID, distance}, true
+}
+
+// AtFraction returns the point at the given fraction of the length along the
+// edges of the query. Fractions are clamped to the range [0, 1]. It returns
+// false if the query has no edges.
+func (q *ChainInterpolationQuery) AtFraction(fraction float64) (ChainInterpolationResult, bool) {
+	return q.AtDistance(s1.Angle(fraction) * q.Length())
+}
+
+// Slice returns the polyline along the edges of the query from the point at
+// beginFraction to the point at endFraction, including the vertices between
+// them. If beginFraction is greater than endFraction, the points are in
+// reverse order. It returns nil if the query has no edges.
+func (q *ChainInterpolationQuery) Slice(beginFraction, endFraction float64) []Point {
+	reverse := beginFraction > endFraction
+	if reverse {
+		beginFraction, endFraction = endFraction, beginFraction
+	}
+	begin, ok := q.AtFraction(beginFraction)
+	if !ok {
+		return nil
+	}
+	end, _ := q.AtFraction(endFraction)
+
+	points := []Point{begin.Point}
+	for e := begin.EdgeID; e < end.EdgeID; e++ {
+		if v := q.shape.Edge(e).V1; v != points[len(points)-1] {
+			points = append(points, v)
+		}
+	}
+	if end.Point != points[len(points)-1] || len(points) == 1 {
+		points = append(points, end.Point)
+	}
+
+	if reverse {
+		for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+			points[i], points[j] = points[j], points[i]
+		}
+	}
+	return points
+}
+
+// Project returns the point on the edges of the query that is closest to the
+// given point, together with its distance along the edges. This is the
+// inverse of AtDistance for points on the edges. It returns false if the
+// query has no edges.
+func (q *ChainInterpolationQuery) Project(point Point) (ChainInterpolationResult, bool) {
+	n := q.numEdges()
+	if n == 0 {
+		return ChainInterpolationResult{}, false
+	}
+
+	minDist := s1.InfChordAngle()
+	var result ChainInterpolationResult
+	for i := 0; i < n; i++ {
+		edgeID := q.firstEdgeID + i
+		edge := q.shape.Edge(edgeID)
+		dist, less := UpdateMinDistance(point, edge.V0, edge.V1, minDist)
+		if !less {
+			continue
+		}
+		minDist = dist
+		p := Project(point, edge.V0, edge.V1)
+		result = ChainInterpolationResult{p, edgeID, q.cumulative[i] + edge.V0.Distance(p)}
+	}
+	return result, true
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestChainInterpolationQueryAtDistance(t *testing.T) {
+	// A polyline along the equator with edges of 1, 2 and 3 degrees.
+	line := makeLaxPolyline("0:0, 0:1, 0:3, 0:6")
+	q := NewChainInterpolationQuery(line, 0)
+
+	if got, want := q.Length(), 6*s1.Degree; !float64Near(float64(got), float64(want), 1e-15) {
+		t.Errorf("Length() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		distance s1.Angle
+		point    string
+		edgeID   int
+		want     s1.Angle
+	}{
+		{-1 * s1.Degree, "0:0", 0, 0},
+		{0, "0:0", 0, 0},
+		{0.5 * s1.Degree, "0:0.5", 0, 0.5 * s1.Degree},
+		{1 * s1.Degree, "0:1", 0, 1 * s1.Degree},
+		{2 * s1.Degree, "0:2", 1, 2 * s1.Degree},
+		{4.5 * s1.Degree, "0:4.5", 2, 4.5 * s1.Degree},
+		{6 * s1.Degree, "0:6", 2, 6 * s1.Degree},
+		{7 * s1.Degree, "0:6", 2, 6 * s1.Degree},
+	}
+	for _, test := range tests {
+		got, ok := q.AtDistance(test.distance)
+		if !ok {
+			t.Errorf("AtDistance(%v) = false, want true", test.distance)
+			continue
+		}
+		if want := parsePoint(test.point); !got.Point.ApproxEqual(want) {
+			t.Errorf("AtDistance(%v).Point = %v, want %v", test.distance, got.Point, want)
+		}
+		if got.EdgeID != test.edgeID {
+			t.Errorf("AtDistance(%v).EdgeID = %d, want %d", test.distance, got.EdgeID, test.edgeID)
+		}
+		if !float64Near(float64(got.Distance), float64(test.want), 1e-15) {
+			t.Errorf("AtDistance(%v).Distance = %v, want %v", test.distance, got.Distance, test.want)
+		}
+
+		// Projecting the point recovers its distance.
+		proj, _ := q.Project(got.Point)
+		if !float64Near(float64(proj.Distance), float64(test.want), 1e-14) {
+			t.Errorf("Project(%v).Distance = %v, want %v", got.Point, proj.Distance, test.want)
+		}
+	}
+
+	got, _ := q.AtFraction(0.25)
+	if want := parsePoint("0:1.5"); !got.Point.ApproxEqual(want) || got.EdgeID != 1 {
+		t.Errorf("AtFraction(0.25) = %v, want %v on edge 1", got, want)
+	}
+}
+
+func TestChainInterpolationQueryChains(t *testing.T) {
+	polygon := makeLaxPolygon("0:0, 0:1, 1:1, 1:0; 5:5, 5:7, 7:7, 7:5")
+
+	// The second chain starts at edge 4 and is twice as long as the first.
+	first := NewChainInterpolationQuery(polygon, 0)
+	second := NewChainInterpolationQuery(polygon, 1)
+	all := NewChainInterpolationQuery(polygon, -1)
+	if got, want := all.Length(), first.Length()+second.Length(); !float64Near(float64(got), float64(want), 1e-15) {
+		t.Errorf("Length() of all chains = %v, want %v", got, want)
+	}
+
+	got, _ := second.AtFraction(0)
+	if want := parsePoint("5:5"); got.Point != want || got.EdgeID != 4 {
+		t.Errorf("second chain AtFraction(0) = %v, want %v on edge 4", got, want)
+	}
+	got, _ = second.AtFraction(1)
+	if want := parsePoint("5:5"); !got.Point.ApproxEqual(want) || got.EdgeID != 7 {
+		t.Errorf("second chain AtFraction(1) = %v, want %v on edge 7", got, want)
+	}
+	got, _ = all.AtDistance(first.Length())
+	if want := parsePoint("0:0"); !got.Point.ApproxEqual(want) || got.EdgeID != 3 {
+		t.Errorf("all chains AtDistance(%v) = %v, want %v on edge 3", first.Length(), got, want)
+	}
+
+	// Projection onto a single chain ignores the other chains.
+	proj, _ := first.Project(parsePoint("6:6"))
+	if want := parsePoint("1:1"); !proj.Point.ApproxEqual(want) {
+		t.Errorf("first chain Project(6:6) = %v, want %v", proj.Point, want)
+	}
+}
+
+func TestChainInterpolationQuerySlice(t *testing.T) {
+	line := makeLaxPolyline("0:0, 0:1, 0:3, 0:6")
+	q := NewChainInterpolationQuery(line, 0)
+
+	tests := []struct {
+		begin, end float64
+		want       string
+	}{
+		{0, 1, "0:0, 0:1, 0:3, 0:6"},
+		{1, 0, "0:6, 0:3, 0:1, 0:0"},
+		{0.25, 0.75, "0:1.5, 0:3, 0:4.5"},
+		{0.75, 0.25, "0:4.5, 0:3, 0:1.5"},
+		{0.1, 0.15, "0:0.6, 0:0.9"},
+		{0.5, 0.5, "0:3, 0:3"},
+	}
+	for _, test := range tests {
+		got := q.Slice(test.begin, test.end)
+		want := parsePoints(test.want)
+		if len(got) != len(want) {
+			t.Errorf("Slice(%v, %v) = %v, want %v", test.begin, test.end, got, want)
+			continue
+		}
+		for i := range want {
+			if !got[i].ApproxEqual(want[i]) {
+				t.Errorf("Slice(%v, %v)[%d] = %v, want %v", test.begin, test.end, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestChainInterpolationQueryEmpty(t *testing.T) {
+	q := NewChainInterpolationQuery(makeLaxPolyline(""), -1)
+	if got := q.Length(); got != 0 {
+		t.Errorf("Length() = %v, want 0", got)
+	}
+	if _, ok := q.AtFraction(0.5); ok {
+		t.Errorf("AtFraction(0.5) = true, want false")
+	}
+	if _, ok := q.Project(parsePoint("0:0")); ok {
+		t.Errorf("Project(0:0) = true, want false")
+	}
+	if got := q.Slice(0, 1); got != nil {
+		t.Errorf("Slice(0, 1) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"sort"
+
+	"github.com/golang/geo/s1"
+)
+
+// ClosestPointQueryOptions holds the options for controlling how
+// ClosestPointQuery operates. Like EdgeQueryOptions, they can be chained
+// together builder-style:
+//
+//	query = NewClosestPointQuery(index, NewClosestPointQueryOptions().
+//		MaxResults(5).
+//		DistanceLimit(s1.ChordAngleFromAngle(s1.Degree)))
+//
+// If you pass a nil as the options you get the default values for the options.
+type ClosestPointQueryOptions struct {
+	common *queryOptions
+}
+
+// NewClosestPointQueryOptions returns the default options, which return all
+// the points in the index.
+func NewClosestPointQueryOptions() *ClosestPointQueryOptions {
+	return &ClosestPointQueryOptions{
+		common: newQueryOptions(minDistance(0)),
+	}
+}
+
+// MaxResults specifies that at most MaxResults points should be returned.
+// This must be at least 1.
+func (c *ClosestPointQueryOptions) MaxResults(n int) *ClosestPointQueryOptions {
+	c.common = c.common.MaxResults(n)
+	return c
+}
+
+// DistanceLimit specifies that only points whose distance to the target is
+// less than limit should be returned. To include points whose distance is
+// equal, specify the limit as limit.Successor().
+func (c *ClosestPointQueryOptions) DistanceLimit(limit s1.ChordAngle) *ClosestPointQueryOptions {
+	c.common = c.common.DistanceLimit(limit)
+	return c
+}
+
+// MaxError specifies that points up to dist further away than the true
+// closest points may be substituted in the result set, as long as such
+// points satisfy all the remaining search criteria. This option only has an
+// effect if MaxResults is also specified.
+func (c *ClosestPointQueryOptions) MaxError(dist s1.ChordAngle) *ClosestPointQueryOptions {
+	c.common = c.common.MaxError(dist)
+	return c
+}
+
+// Region specifies that only points contained by the given region should be
+// returned, or nil for no region limits. If the region is a disc around the
+// target point, it is faster to set a DistanceLimit instead.
+func (c *ClosestPointQueryOptions) Region(r Region) *ClosestPointQueryOptions {
+	c.common = c.common.Region(r)
+	return c
+}
+
+// UseBruteForce sets or disables the use of brute force in a query.
+func (c *ClosestPointQueryOptions) UseBruteForce(x bool) *ClosestPointQueryOptions {
+	c.common = c.common.UseBruteForce(x)
+	return c
+}
+
+// PointQueryResult is a point found by a ClosestPointQuery, along with its
+// payload and its distance to the target.
+type PointQueryResult[T any] struct {
+	distance distance
+	point    Point
+	data     T
+
+	// pos is the position of the point in the sorted index, which identifies
+	// it even when the same point was added several times.
+	pos int
+}
+
+// Distance reports the distance between the point and the target.
+func (r PointQueryResult[T]) Distance() s1.ChordAngle { return r.distance.chordAngle() }
+
+// Point returns the point.
+func (r PointQueryResult[T]) Point() Point { return r.point }
+
+// Data returns the payload of the point.
+func (r PointQueryResult[T]) Data() T { return r.data }
+
+// less orders results first by distance and then by point, using the
+// position in the index to break ties between equal points.
+func (r PointQueryResult[T]) less(other PointQueryResult[T]) bool {
+	if r.distance.chordAngle() != other.distance.chordAngle() {
+		return r.distance.less(other.distance)
+	}
+	if c := r.point.Cmp(other.point.Vector); c != 0 {
+		return c < 0
+	}
+	return r.pos < other.pos
+}
+
+func (r PointQueryResult[T]) position() int { return r.pos }
+
+// minPointsToEnqueue is the number of points below which the points of a
+// cell are processed directly rather than subdividing the cell further.
+const minPointsToEnqueue = 13
+
+// ClosestPointQuery finds the points of a PointIndex that are closest to a
+// given target, which may be a Point, Edge, Cell, or geometry collection
+// (see NewMinDistanceToPointTarget and friends). It can find the k nearest
+// points, all points within a given distance, or both, optionally limited to
+// the points contained by a Region. Distances are measured on the sphere.
+//
+// By default all points are returned, so you should always specify either
+// MaxResults or DistanceLimit options or both.
+//
+// This type is not safe for concurrent use. However, several queries may use
+// the same index concurrently once it has been built (see PointIndex.Build).
+type ClosestPointQuery[T any] struct {
+	index *PointIndex[T]
+	opts  *queryOptions
+
+	// The state of the current search. The options may differ from opts for
+	// methods such as IsDistanceLess.
+	target     distanceTarget
+	searchOpts *queryOptions
+
+	// True if maxError must be subtracted from cell distances in order to
+	// ensure that such distances are measured conservatively (see EdgeQuery).
+	useConservativeCellDistance bool
+
+	// The distance beyond which we can safely ignore further candidate points.
+	// It starts as the DistanceLimit and shrinks once enough results have
+	// been found.
+	distanceLimit distance
+
+	// The results are collected in results when MaxResults is 1 or unbounded,
+	// and otherwise in resultSet, which retains only the best MaxResults.
+	results   []PointQueryResult[T]
+	resultSet *topKResults[PointQueryResult[T], int]
+
+	queue *queryQueue
+}
+
+// NewClosestPointQuery returns a query for finding the points of the given
+// index closest to a target.
+func NewClosestPointQuery[T any](index *PointIndex[T], opts *ClosestPointQueryOptions) *ClosestPointQuery[T] {
+	if opts == nil {
+		opts = NewClosestPointQueryOptions()
+	}
+	return &ClosestPointQuery[T]{
+		index: index,
+		opts:  opts.common,
+		queue: newQueryQueue(),
+	}
+}
+
+// FindPoints returns the points that satisfy the query options, sorted by
+// increasing distance to the target.
+func (q *ClosestPointQuery[T]) FindPoints(target distanceTarget) []PointQueryResult[T] {
+	return q.findPoints(target, q.opts)
+}
+
+// FindPoint returns the point closest to the target that satisfies the query
+// options, or false if there is no such point.
+func (q *ClosestPointQuery[T]) FindPoint(target distanceTarget) (PointQueryResult[T], bool) {
+	opts := *q.opts
+	opts.MaxResults(1)
+	results := q.findPoints(target, &opts)
+	if len(results) == 0 {
+		return PointQueryResult[T]{distance: target.distance().infinity(), pos: -1}, false
+	}
+	return results[0], true
+}
+
+// Distance reports the distance to the closest point that satisfies the
+// query options, or s1.InfChordAngle if there is none.
+//
+// Use IsDistanceLess if you only want to compare the distance against a
+// threshold value, since it is often much faster.
+func (q *ClosestPointQuery[T]) Distance(target distanceTarget) s1.ChordAngle {
+	r, _ := q.FindPoint(target)
+	return r.Distance()
+}
+
+// IsDistanceLess reports whether the distance to the closest point that
+// satisfies the query options is less than the given limit.
+func (q *ClosestPointQuery[T]) IsDistanceLess(target distanceTarget, limit s1.ChordAngle) bool {
+	opts := *q.opts
+	opts.MaxResults(1).DistanceLimit(limit).MaxError(s1.StraightChordAngle)
+	return len(q.findPoints(target, &opts)) > 0
+}
+
+// findPoints returns the points that satisfy the given options.
+func (q *ClosestPointQuery[T]) findPoints(target distanceTarget, opts *queryOptions) []PointQueryResult[T] {
+	q.index.Build()
+	q.target = target
+	q.searchOpts = opts
+	q.distanceLimit = target.distance().fromChordAngle(opts.distanceLimit)
+	q.results = nil
+	q.resultSet = nil
+	if opts.maxResults > 1 && opts.maxResults != maxQueryResults {
+		q.resultSet = newTopKResults(opts.maxResults, PointQueryResult[T].less, PointQueryResult[T].position)
+	}
+
+	if q.distanceLimit != target.distance().zero() {
+		// See EdgeQuery.findEdgesInternal for the conditions under which cell
+		// distances must be measured conservatively.
+		targetUsesMaxError := opts.maxError != target.distance().zero().chordAngle() &&
+			target.setMaxError(opts.maxError)
+		q.useConservativeCellDistance = targetUsesMaxError &&
+			(q.distanceLimit == target.distance().infinity() ||
+				target.distance().zero().less(q.distanceLimit.sub(target.distance().fromChordAngle(opts.maxError))))
+
+		if opts.useBruteForce || q.index.NumPoints() <= target.maxBruteForceIndexSize() {
+			for pos := range q.index.entries {
+				q.maybeAddResult(pos)
+			}
+		} else {
+			q.findPointsOptimized()
+		}
+	}
+
+	results := q.results
+	if q.resultSet != nil {
+		results = q.resultSet.sorted()
+	} else {
+		sort.Slice(results, func(i, j int) bool { return results[i].less(results[j]) })
+	}
+	q.target = nil
+	q.searchOpts = nil
+	q.results = nil
+	q.resultSet = nil
+	return results
+}
+
+// findPointsOptimized visits the cells of the index in order of increasing
+// distance to the target, subdividing them until they contain few points.
+func (q *ClosestPointQuery[T]) findPointsOptimized() {
+	for face := 0; face < 6; face++ {
+		q.processOrEnqueue(CellIDFromFace(face))
+	}
+	for q.queue.size() > 0 {
+		entry := q.queue.pop()
+		if !entry.distance.less(q.distanceLimit) {
+			q.queue.reset()
+			break
+		}
+		for _, child := range entry.id.Children() {
+			q.processOrEnqueue(child)
+		}
+	}
+}
+
+// processOrEnqueue processes the points of the given cell directly if there
+// are only a few of them, and otherwise adds the cell to the queue if it may
+// contain points closer than the current distance limit.
+func (q *ClosestPointQuery[T]) processOrEnqueue(id CellID) {
+	lo, hi := q.index.cellRange(id)
+	if lo == hi {
+		return
+	}
+	var cell Cell
+	if q.searchOpts.region != nil {
+		cell = CellFromCellID(id)
+		if !q.searchOpts.region.IntersectsCell(cell) {
+			return
+		}
+	}
+	if hi-lo <= minPointsToEnqueue || id.IsLeaf() {
+		for pos := lo; pos < hi; pos++ {
+			q.maybeAddResult(pos)
+		}
+		return
+	}
+	if q.searchOpts.region == nil {
+		cell = CellFromCellID(id)
+	}
+	dist, ok := q.target.updateDistanceToCell(cell, q.distanceLimit)
+	if !ok {
+		return
+	}
+	if q.useConservativeCellDistance {
+		// Ensure that dist is a lower bound on the true distance to the cell.
+		dist = dist.sub(q.target.distance().fromChordAngle(q.searchOpts.maxError))
+	}
+	q.queue.push(&queryQueueEntry{distance: dist, id: id})
+}
+
+// maybeAddResult adds the point at the given position in the index to the
+// results if it is closer than the distance limit and within the region.
+func (q *ClosestPointQuery[T]) maybeAddResult(pos int) {
+	e := &q.index.entries[pos]
+	dist, ok := q.target.updateDistanceToPoint(e.point, q.distanceLimit)
+	if !ok {
+		return
+	}
+	if q.searchOpts.region != nil && !q.searchOpts.region.ContainsPoint(e.point) {
+		return
+	}
+
+	r := PointQueryResult[T]{distance: dist, point: e.point, data: e.data, pos: pos}
+	maxError := q.target.distance().fromChordAngle(q.searchOpts.maxError)
+	switch {
+	case q.searchOpts.maxResults == 1:
+		// Any point that passes the distance limit is closer than the
+		// previous result, so only the latest one needs to be kept.
+		q.results = append(q.results[:0], r)
+		q.distanceLimit = dist.sub(maxError)
+	case q.resultSet != nil:
+		q.resultSet.add(r)
+		if q.resultSet.full() {
+			q.distanceLimit = q.resultSet.worst().distance.sub(maxError)
+		}
+	default:
+		q.results = append(q.results, r)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestClosestPointQueryNoPoints(t *testing.T) {
+	index := NewPointIndex[struct{}]()
+	query := NewClosestPointQuery(index, nil)
+	target := NewMinDistanceToPointTarget(PointFromCoords(1, 0, 0))
+	if got := query.FindPoints(target); len(got) != 0 {
+		t.Errorf("FindPoints(%v) = %v, want no results", target, got)
+	}
+	if _, ok := query.FindPoint(target); ok {
+		t.Errorf("FindPoint(%v) found a point, want none", target)
+	}
+	if got, want := query.Distance(target), s1.InfChordAngle(); got != want {
+		t.Errorf("Distance(%v) = %v, want %v", target, got, want)
+	}
+	if query.IsDistanceLess(target, s1.StraightChordAngle) {
+		t.Errorf("IsDistanceLess(%v, %v) = true, want false", target, s1.StraightChordAngle)
+	}
+}
+
+func TestClosestPointQueryBasic(t *testing.T) {
+	index := NewPointIndex[string]()
+	for _, s := range []string{"0:1", "0:2", "0:3", "5:5", "0:-1"} {
+		index.Add(parsePoint(s), s)
+	}
+	query := NewClosestPointQuery(index, NewClosestPointQueryOptions().
+		MaxResults(2).
+		DistanceLimit(s1.ChordAngleFromAngle(2.5*s1.Degree)))
+	target := NewMinDistanceToPointTarget(parsePoint("0:0"))
+
+	results := query.FindPoints(target)
+	if len(results) != 2 {
+		t.Fatalf("FindPoints(%v) returned %d results, want 2", target, len(results))
+	}
+	// The two points 1 degree away are ordered by point.
+	got := map[string]bool{results[0].Data(): true, results[1].Data(): true}
+	if !got["0:1"] || !got["0:-1"] {
+		t.Errorf("FindPoints(%v) = %v and %v, want 0:1 and 0:-1", target, results[0].Data(), results[1].Data())
+	}
+	for _, r := range results {
+		if d := r.Distance().Angle().Degrees(); !float64Near(d, 1, 1e-13) {
+			t.Errorf("result %v distance = %v, want 1", r.Data(), d)
+		}
+		if r.Point() != parsePoint(r.Data()) {
+			t.Errorf("result %v point = %v, want %v", r.Data(), r.Point(), parsePoint(r.Data()))
+		}
+	}
+
+	if got, want := query.Distance(target).Angle().Degrees(), 1.0; !float64Near(got, want, 1e-13) {
+		t.Errorf("Distance(%v) = %v, want %v", target, got, want)
+	}
+	if !query.IsDistanceLess(target, s1.ChordAngleFromAngle(1.5*s1.Degree)) {
+		t.Errorf("IsDistanceLess(%v, 1.5) = false, want true", target)
+	}
+	if query.IsDistanceLess(target, s1.ChordAngleFromAngle(0.5*s1.Degree)) {
+		t.Errorf("IsDistanceLess(%v, 0.5) = true, want false", target)
+	}
+	// The options are unchanged by Distance and IsDistanceLess.
+	if got := query.FindPoints(target); len(got) != 2 {
+		t.Errorf("FindPoints(%v) after IsDistanceLess returned %d results, want 2", target, len(got))
+	}
+
+	// Only points in the region are returned.
+	query = NewClosestPointQuery(index, NewClosestPointQueryOptions().
+		MaxResults(1).
+		Region(rectFromDegrees(-1, 1.5, 1, 10)))
+	r, ok := query.FindPoint(target)
+	if !ok || r.Data() != "0:2" {
+		t.Errorf("FindPoint(%v) with a region = %v, %v, want 0:2", target, r.Data(), ok)
+	}
+}
+
+func TestClosestPointQueryOptimizedMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	index := NewPointIndex[int]()
+	center := parsePoint("20:30")
+	for i := 0; i < 2000; i++ {
+		// Cluster most of the points around the center so that the cells
+		// near the target need to be subdivided.
+		p := randomPointFromRand(rng)
+		if i%4 != 0 {
+			p = Point{center.Add(p.Mul(0.1)).Normalize()}
+		}
+		index.Add(p, i)
+		if i%100 == 0 {
+			// Duplicate points are all returned.
+			index.Add(p, -i)
+		}
+	}
+
+	shapeIndex := makeShapeIndex("# 19:29, 21:32 #")
+	targets := []distanceTarget{
+		NewMinDistanceToPointTarget(center),
+		NewMinDistanceToPointTarget(parsePoint("-60:100")),
+		NewMinDistanceToEdgeTarget(Edge{parsePoint("10:10"), parsePoint("25:35")}),
+		NewMinDistanceToCellTarget(CellFromCellID(cellIDFromPoint(center).Parent(8))),
+		NewMinDistanceToShapeIndexTarget(shapeIndex),
+	}
+	tests := []struct {
+		maxResults int
+		limit      s1.ChordAngle
+		region     Region
+	}{
+		{1, s1.InfChordAngle(), nil},
+		{10, s1.InfChordAngle(), nil},
+		{100, s1.ChordAngleFromAngle(2 * s1.Degree), nil},
+		{maxQueryResults, s1.ChordAngleFromAngle(s1.Degree), nil},
+		{5, s1.InfChordAngle(), rectFromDegrees(15, 25, 25, 30)},
+		{maxQueryResults, s1.ChordAngleFromAngle(10 * s1.Degree), CapFromCenterAngle(parsePoint("22:32"), 2*s1.Degree)},
+	}
+	for _, target := range targets {
+		for _, test := range tests {
+			opts := NewClosestPointQueryOptions().MaxResults(test.maxResults).DistanceLimit(test.limit).Region(test.region)
+			got := NewClosestPointQuery(index, opts).FindPoints(target)
+			want := NewClosestPointQuery(index, opts.UseBruteForce(true)).FindPoints(target)
+			if len(got) != len(want) {
+				t.Errorf("%T, %+v: FindPoints returned %d results, want %d", target, test, len(got), len(want))
+				continue
+			}
+			for i := range got {
+				if got[i].Distance() != want[i].Distance() {
+					t.Errorf("%T, %+v: result %d distance = %v, want %v", target, test, i, got[i].Distance(), want[i].Distance())
+				}
+				if got[i].Distance() >= test.limit {
+					t.Errorf("%T, %+v: result %d distance %v is not below the limit", target, test, i, got[i].Distance())
+				}
+				if test.region != nil && !test.region.ContainsPoint(got[i].Point()) {
+					t.Errorf("%T, %+v: result %d is outside the region", target, test, i)
+				}
+			}
+		}
+	}
+}
@@ -151,14 +151,14 @@ func (q *ContainsPointQuery) ShapeContains(shape Shape, p Point) bool {
 	return q.shapeContains(clipped, q.iter.Center(), p)
 }
 
-// shapeVisitorFunc is a type of function that can be called against shaped in an index.
-type shapeVisitorFunc func(shape Shape) bool
+// ShapeVisitorFunc is a type of function that can be called against shapes in an index.
+type ShapeVisitorFunc func(shape Shape) bool
 
-// visitContainingShapes visits all shapes in the given index that contain the
+// VisitContainingShapes visits all shapes in the given index that contain the
 // given point p, terminating early if the given visitor function returns false,
-// in which case visitContainingShapes returns false. Each shape is
+// in which case VisitContainingShapes returns false. Each shape is
 // visited at most once.
-func (q *ContainsPointQuery) visitContainingShapes(p Point, f shapeVisitorFunc) bool {
+func (q *ContainsPointQuery) VisitContainingShapes(p Point, f ShapeVisitorFunc) bool {
 	// This function returns false only if the algorithm terminates early
 	// because the visitor function returned false.
 	if !q.iter.LocatePoint(p) {
@@ -178,13 +178,54 @@ func (q *ContainsPointQuery) visitContainingShapes(p Point, f shapeVisitorFunc)
 // ContainingShapes returns a slice of all shapes that contain the given point.
 func (q *ContainsPointQuery) ContainingShapes(p Point) []Shape {
 	var shapes []Shape
-	q.visitContainingShapes(p, func(shape Shape) bool {
+	q.VisitContainingShapes(p, func(shape Shape) bool {
 		shapes = append(shapes, shape)
 		return true
 	})
 	return shapes
 }
 
-// TODO(roberts): Remaining methods from C++
-// type edgeVisitorFunc func(shape ShapeEdge) bool
-// func (q *ContainsPointQuery) visitIncidentEdges(p Point, v edgeVisitorFunc) bool
+// containingShapeIDs returns the ids of all shapes that contain the given point.
+func (q *ContainsPointQuery) containingShapeIDs(p Point) []int32 {
+	if !q.iter.LocatePoint(p) {
+		return nil
+	}
+	var ids []int32
+	for _, clipped := range q.iter.IndexCell().shapes {
+		if q.shapeContains(clipped, q.iter.Center(), p) {
+			ids = append(ids, clipped.shapeID)
+		}
+	}
+	return ids
+}
+
+// EdgeVisitorFunc is a type of function that can be called against shape edges.
+type EdgeVisitorFunc func(shapeEdge ShapeEdge) bool
+
+// VisitIncidentEdges visits all edges in the index that are incident to the
+// point p (i.e., p is one of the edge endpoints), terminating early if the
+// given visitor function returns false, in which case VisitIncidentEdges
+// returns false. Each edge is visited at most once.
+func (q *ContainsPointQuery) VisitIncidentEdges(p Point, f EdgeVisitorFunc) bool {
+	if !q.iter.LocatePoint(p) {
+		return true
+	}
+
+	cell := q.iter.IndexCell()
+	for _, clipped := range cell.shapes {
+		if clipped.numEdges() == 0 {
+			continue
+		}
+		shape := q.index.Shape(clipped.shapeID)
+		for _, edgeID := range clipped.edges {
+			edge := shape.Edge(edgeID)
+			if (edge.V0 == p || edge.V1 == p) && !f(ShapeEdge{
+				ID:   ShapeEdgeID{clipped.shapeID, int32(edgeID)},
+				Edge: edge,
+			}) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -16,6 +16,7 @@ package s2
 
 import (
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/golang/geo/s1"
@@ -174,5 +175,72 @@ func TestContainsPointQueryContainingShapes(t *testing.T) {
 	}
 }
 
-// TODO(roberts): Remaining tests
-// TestContainsPointQueryVisitIncidentEdges
+func TestContainsPointQueryVisitIncidentEdges(t *testing.T) {
+	index := makeShapeIndex("0:0 | 1:1 # 1:1, 1:2 # 1:2, 1:3, 2:2")
+	query := NewContainsPointQuery(index, VertexModelSemiOpen)
+
+	tests := []struct {
+		point string
+		want  []ShapeEdgeID
+	}{
+		{"0:0", []ShapeEdgeID{{0, 0}}},
+		{"1:1", []ShapeEdgeID{{0, 1}, {1, 0}}},
+		{"1:2", []ShapeEdgeID{{1, 0}, {2, 0}, {2, 2}}},
+		{"1:3", []ShapeEdgeID{{2, 0}, {2, 1}}},
+		{"2:2", []ShapeEdgeID{{2, 1}, {2, 2}}},
+	}
+
+	for _, test := range tests {
+		p := parsePoint(test.point)
+		var got []ShapeEdgeID
+		if !query.VisitIncidentEdges(p, func(e ShapeEdge) bool {
+			if e.Edge.V0 != p && e.Edge.V1 != p {
+				t.Errorf("VisitIncidentEdges(%s) visited edge %v which is not incident to the point", test.point, e.ID)
+			}
+			got = append(got, e.ID)
+			return true
+		}) {
+			t.Errorf("VisitIncidentEdges(%s) = false, want true", test.point)
+		}
+		sort.Slice(got, func(i, j int) bool { return got[i].Cmp(got[j]) < 0 })
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("VisitIncidentEdges(%s) visited %v, want %v", test.point, got, test.want)
+		}
+
+		// Returning false from the visitor stops the traversal.
+		count := 0
+		if query.VisitIncidentEdges(p, func(ShapeEdge) bool {
+			count++
+			return false
+		}) {
+			t.Errorf("VisitIncidentEdges(%s) with a visitor returning false = true, want false", test.point)
+		}
+		if count != 1 {
+			t.Errorf("VisitIncidentEdges(%s) visited %d edges after stopping, want 1", test.point, count)
+		}
+	}
+}
+
+func TestContainsPointQueryVisitContainingShapes(t *testing.T) {
+	index := makeShapeIndex("# # 0:0, 0:5, 5:5, 5:0 | 1:1, 1:4, 4:4, 4:1")
+	query := NewContainsPointQuery(index, VertexModelSemiOpen)
+
+	p := parsePoint("2:2")
+	var got []Shape
+	if !query.VisitContainingShapes(p, func(shape Shape) bool {
+		got = append(got, shape)
+		return true
+	}) {
+		t.Errorf("VisitContainingShapes(%v) = false, want true", p)
+	}
+	if want := []Shape{index.Shape(0), index.Shape(1)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("VisitContainingShapes(%v) visited %v, want %v", p, got, want)
+	}
+
+	if query.VisitContainingShapes(p, func(Shape) bool { return false }) {
+		t.Errorf("VisitContainingShapes(%v) with a visitor returning false = true, want false", p)
+	}
+	if !query.VisitContainingShapes(parsePoint("10:10"), func(Shape) bool { return false }) {
+		t.Errorf("VisitContainingShapes of an uncontained point = false, want true")
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"sort"
+)
+
+// This file contains content hashes for geometry, for use in deduplication,
+// caching, and change detection. Unlike the Hash methods, content hashes are
+// SHA-256 digests of a documented canonical encoding, so they can be
+// reproduced by other systems and languages:
+//
+//	Loop:      0x01, uint64 number of vertices, then each vertex starting
+//	           with the lexicographically smallest rotation of the vertices.
+//	Polygon:   0x02, uint64 number of loops, then the content hash of each
+//	           loop, sorted in increasing byte order.
+//	Polyline:  0x03, uint64 number of vertices, then each vertex in order.
+//	CellUnion: 0x04, uint64 number of cells, then each CellID of the
+//	           normalized cell union in increasing order.
+//
+// Integers are encoded as 8 bytes in big-endian order, and vertices as their
+// X, Y and Z coordinates, each encoded as the big-endian IEEE 754 bits of the
+// coordinate with -0 replaced by 0 and all NaNs replaced by a single NaN.
+//
+// Geometry that differs only in the starting vertex of its loops, in the order
+// of its polygon loops, or in the normalization of its cell union has the same
+// content hash. Loop orientation and polyline direction are significant.
+
+// Tags that start the canonical encoding of each type.
+const (
+	contentHashLoop      byte = 1
+	contentHashPolygon   byte = 2
+	contentHashPolyline  byte = 3
+	contentHashCellUnion byte = 4
+)
+
+// contentHasher writes the canonical encoding of values to a SHA-256 hash.
+type contentHasher struct {
+	h   hash.Hash
+	buf [8]byte
+}
+
+func newContentHasher(tag byte) *contentHasher {
+	c := &contentHasher{h: sha256.New()}
+	c.h.Write([]byte{tag})
+	return c
+}
+
+func (c *contentHasher) writeUint64(x uint64) {
+	binary.BigEndian.PutUint64(c.buf[:], x)
+	c.h.Write(c.buf[:])
+}
+
+func (c *contentHasher) writePoint(p Point) {
+	c.writeUint64(hashFloat64Bits(p.X))
+	c.writeUint64(hashFloat64Bits(p.Y))
+	c.writeUint64(hashFloat64Bits(p.Z))
+}
+
+func (c *contentHasher) sum() [sha256.Size]byte {
+	var sum [sha256.Size]byte
+	c.h.Sum(sum[:0])
+	return sum
+}
+
+// ContentHash returns the SHA-256 content hash of the loop, which does not
+// depend on which vertex the loop starts with.
+func (l *Loop) ContentHash() [sha256.Size]byte {
+	n := len(l.vertices)
+	c := newContentHasher(contentHashLoop)
+	c.writeUint64(uint64(n))
+	start := minRotation(l.vertices)
+	for i := 0; i < n; i++ {
+		c.writePoint(l.vertices[(start+i)%n])
+	}
+	return c.sum()
+}
+
+// minRotation returns the index of the vertex that starts the
+// lexicographically smallest rotation of the given vertices.
+func minRotation(vertices []Point) int {
+	n := len(vertices)
+	best := 0
+	for i := 1; i < n; i++ {
+		for j := 0; j < n; j++ {
+			c := vertices[(i+j)%n].Cmp(vertices[(best+j)%n].Vector)
+			if c < 0 {
+				best = i
+			}
+			if c != 0 {
+				break
+			}
+		}
+	}
+	return best
+}
+
+// ContentHash returns the SHA-256 content hash of the polygon, which does not
+// depend on the order of its loops or on which vertex each loop starts with.
+func (p *Polygon) ContentHash() [sha256.Size]byte {
+	loops := make([][sha256.Size]byte, len(p.loops))
+	for i, l := range p.loops {
+		loops[i] = l.ContentHash()
+	}
+	sort.Slice(loops, func(i, j int) bool { return bytes.Compare(loops[i][:], loops[j][:]) < 0 })
+
+	c := newContentHasher(contentHashPolygon)
+	c.writeUint64(uint64(len(loops)))
+	for _, sum := range loops {
+		c.h.Write(sum[:])
+	}
+	return c.sum()
+}
+
+// ContentHash returns the SHA-256 content hash of the polyline.
+func (p *Polyline) ContentHash() [sha256.Size]byte {
+	c := newContentHasher(contentHashPolyline)
+	c.writeUint64(uint64(len(*p)))
+	for _, v := range *p {
+		c.writePoint(v)
+	}
+	return c.sum()
+}
+
+// ContentHash returns the SHA-256 content hash of the cell union, which is
+// the same for all cell unions that cover the same set of leaf cells.
+func (cu *CellUnion) ContentHash() [sha256.Size]byte {
+	norm := append(CellUnion(nil), *cu...)
+	norm.Normalize()
+
+	c := newContentHasher(contentHashCellUnion)
+	c.writeUint64(uint64(len(norm)))
+	for _, id := range norm {
+		c.writeUint64(uint64(id))
+	}
+	return c.sum()
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+)
+
+func TestContentHashEncoding(t *testing.T) {
+	// Check the hashes against the documented encoding.
+	var buf []byte
+	putUint64 := func(x uint64) {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], x)
+		buf = append(buf, b[:]...)
+	}
+	putPoint := func(p Point) {
+		putUint64(math.Float64bits(p.X))
+		putUint64(math.Float64bits(p.Y))
+		putUint64(math.Float64bits(p.Z))
+	}
+
+	a := PointFromCoords(1, 0, 0)
+	b := PointFromCoords(0, 1, 0)
+	c := PointFromCoords(0, 0, 1)
+
+	buf = []byte{1}
+	putUint64(3)
+	putPoint(c)
+	putPoint(a)
+	putPoint(b)
+	if got, want := LoopFromPoints([]Point{a, b, c}).ContentHash(), sha256.Sum256(buf); got != want {
+		t.Errorf("Loop.ContentHash() = %x, want %x", got, want)
+	}
+
+	buf = []byte{3}
+	putUint64(2)
+	putPoint(a)
+	putPoint(b)
+	if got, want := (&Polyline{a, b}).ContentHash(), sha256.Sum256(buf); got != want {
+		t.Errorf("Polyline.ContentHash() = %x, want %x", got, want)
+	}
+
+	buf = []byte{4}
+	putUint64(1)
+	putUint64(uint64(CellIDFromFace(2)))
+	if got, want := (&CellUnion{CellIDFromFace(2)}).ContentHash(), sha256.Sum256(buf); got != want {
+		t.Errorf("CellUnion.ContentHash() = %x, want %x", got, want)
+	}
+
+	loop := LoopFromPoints([]Point{a, b, c})
+	sum := loop.ContentHash()
+	buf = []byte{2}
+	putUint64(1)
+	buf = append(buf, sum[:]...)
+	if got, want := PolygonFromLoops([]*Loop{loop}).ContentHash(), sha256.Sum256(buf); got != want {
+		t.Errorf("Polygon.ContentHash() = %x, want %x", got, want)
+	}
+}
+
+func TestContentHashLoop(t *testing.T) {
+	loop := makeLoop("0:0, 0:10, 10:10, 10:0")
+	tests := []struct {
+		other *Loop
+		want  bool
+	}{
+		{makeLoop("0:0, 0:10, 10:10, 10:0"), true},
+		{makeLoop("10:10, 10:0, 0:0, 0:10"), true},
+		{makeLoop("10:0, 0:0, 0:10, 10:10"), true},
+		{makeLoop("0:0, 10:0, 10:10, 0:10"), false},
+		{makeLoop("0:0, 0:10, 10:10, 10:1"), false},
+		{makeLoop("0:0, 0:10, 10:10"), false},
+	}
+	for _, test := range tests {
+		if got := loop.ContentHash() == test.other.ContentHash(); got != test.want {
+			t.Errorf("%v.ContentHash() == %v.ContentHash() = %v, want %v", loop, test.other, got, test.want)
+		}
+	}
+
+	// Loops with repeated vertices start with the smallest rotation.
+	l1 := makeLoop("0:0, 0:10, 0:0, 0:20")
+	l2 := makeLoop("0:0, 0:20, 0:0, 0:10")
+	if l1.ContentHash() != l2.ContentHash() {
+		t.Errorf("%v.ContentHash() != %v.ContentHash(), want equal", l1, l2)
+	}
+
+	if EmptyLoop().ContentHash() == FullLoop().ContentHash() {
+		t.Errorf("EmptyLoop().ContentHash() == FullLoop().ContentHash(), want different")
+	}
+
+	// The coordinates 0 and -0 have the same content hash.
+	negZero := math.Copysign(0, -1)
+	l1 = LoopFromPoints([]Point{PointFromCoords(1, 0, 0), PointFromCoords(0, 1, 0), PointFromCoords(0, 0, 1)})
+	l2 = LoopFromPoints([]Point{{r3.Vector{X: 1, Y: negZero, Z: negZero}}, PointFromCoords(0, 1, 0), PointFromCoords(0, 0, 1)})
+	if l1.ContentHash() != l2.ContentHash() {
+		t.Errorf("%v.ContentHash() != %v.ContentHash(), want equal", l1, l2)
+	}
+}
+
+func TestContentHashPolygon(t *testing.T) {
+	p1 := makePolygon("0:0, 0:10, 10:10, 10:0; 20:20, 20:30, 30:30, 30:20", true)
+	p2 := makePolygon("30:30, 30:20, 20:20, 20:30; 10:10, 10:0, 0:0, 0:10", true)
+	if p1.ContentHash() != p2.ContentHash() {
+		t.Errorf("polygons with reordered loops have different content hashes")
+	}
+
+	p3 := makePolygon("0:0, 0:10, 10:10, 10:0", true)
+	if p1.ContentHash() == p3.ContentHash() {
+		t.Errorf("polygons with different loops have the same content hash")
+	}
+	if p3.ContentHash() == makeLoop("0:0, 0:10, 10:10, 10:0").ContentHash() {
+		t.Errorf("a polygon and its only loop have the same content hash")
+	}
+	if PolygonFromLoops(nil).ContentHash() == FullPolygon().ContentHash() {
+		t.Errorf("the empty and full polygons have the same content hash")
+	}
+}
+
+func TestContentHashPolyline(t *testing.T) {
+	p1 := makePolyline("0:0, 0:10, 10:10")
+	if p1.ContentHash() != makePolyline("0:0, 0:10, 10:10").ContentHash() {
+		t.Errorf("equal polylines have different content hashes")
+	}
+	if p1.ContentHash() == makePolyline("10:10, 0:10, 0:0").ContentHash() {
+		t.Errorf("reversed polylines have the same content hash")
+	}
+}
+
+func TestContentHashCellUnion(t *testing.T) {
+	parent := CellIDFromFace(3).ChildBeginAtLevel(5)
+	var children CellUnion
+	for ci := parent.ChildBegin(); ci != parent.ChildEnd(); ci = ci.Next() {
+		children = append(children, ci)
+	}
+	children[0], children[3] = children[3], children[0]
+
+	cu := CellUnion{parent}
+	if cu.ContentHash() != children.ContentHash() {
+		t.Errorf("%v.ContentHash() != %v.ContentHash(), want equal", cu, children)
+	}
+	if children[0] != parent.ChildEnd().Prev() {
+		t.Errorf("ContentHash modified the cell union")
+	}
+
+	other := CellUnion{parent.Next()}
+	if cu.ContentHash() == other.ContentHash() {
+		t.Errorf("%v.ContentHash() == %v.ContentHash(), want different", cu, other)
+	}
+}
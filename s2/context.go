@@ -0,0 +1,48 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "context"
+
+// cancelCheckInterval is the number of iterations between checks for
+// cancellation in long running loops. Checking a context involves a mutex,
+// so we avoid doing it on every iteration.
+const cancelCheckInterval = 64
+
+// cancelChecker polls a context for cancellation from within the inner loops
+// of long running operations. The zero value never reports cancellation.
+type cancelChecker struct {
+	ctx   context.Context
+	count int
+	// err is the error from the context once cancellation has been observed.
+	err error
+}
+
+// canceled reports whether the context has been canceled. The context is
+// checked on the first call and then periodically; once cancellation has
+// been observed, canceled returns true on every subsequent call.
+func (c *cancelChecker) canceled() bool {
+	if c.err != nil {
+		return true
+	}
+	if c.ctx == nil {
+		return false
+	}
+	if c.count%cancelCheckInterval == 0 {
+		c.err = c.ctx.Err()
+	}
+	c.count++
+	return c.err != nil
+}
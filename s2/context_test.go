@@ -0,0 +1,146 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestCancelChecker(t *testing.T) {
+	var zero cancelChecker
+	for i := 0; i < 2*cancelCheckInterval; i++ {
+		if zero.canceled() {
+			t.Fatalf("zero cancelChecker reported cancellation")
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := cancelChecker{ctx: ctx}
+	if c.canceled() {
+		t.Errorf("canceled() = true before cancellation")
+	}
+	cancel()
+	n := 0
+	for !c.canceled() {
+		n++
+	}
+	if n >= cancelCheckInterval {
+		t.Errorf("cancellation observed after %d calls, want fewer than %d", n, cancelCheckInterval)
+	}
+	if c.err != context.Canceled {
+		t.Errorf("err = %v, want %v", c.err, context.Canceled)
+	}
+}
+
+func TestRegionCovererContext(t *testing.T) {
+	rc := &RegionCoverer{MaxLevel: 30, MaxCells: 200}
+	region := Region(CapFromCenterAngle(PointFromLatLng(LatLngFromDegrees(10, 20)), 5*s1.Degree))
+
+	got, err := rc.CoveringContext(context.Background(), region)
+	if err != nil {
+		t.Fatalf("CoveringContext returned error %v", err)
+	}
+	if want := rc.Covering(region); !got.Equal(want) {
+		t.Errorf("CoveringContext() = %v, want %v", got, want)
+	}
+	gotInterior, err := rc.InteriorCoveringContext(context.Background(), region)
+	if err != nil {
+		t.Fatalf("InteriorCoveringContext returned error %v", err)
+	}
+	if want := rc.InteriorCovering(region); !gotInterior.Equal(want) {
+		t.Errorf("InteriorCoveringContext() = %v, want %v", gotInterior, want)
+	}
+
+	// A canceled context still yields a valid covering.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	covering, err := rc.CoveringContext(ctx, region)
+	if err != context.Canceled {
+		t.Errorf("CoveringContext with canceled context returned error %v, want %v", err, context.Canceled)
+	}
+	if len(covering) == 0 {
+		t.Fatalf("CoveringContext with canceled context returned an empty covering")
+	}
+	for i := 0; i < 100; i++ {
+		p := samplePointFromCap(region.(Cap))
+		if !covering.ContainsPoint(p) {
+			t.Errorf("partial covering %v does not contain %v", covering, p)
+		}
+	}
+	interior, err := rc.InteriorCoveringContext(ctx, region)
+	if err != context.Canceled {
+		t.Errorf("InteriorCoveringContext with canceled context returned error %v, want %v", err, context.Canceled)
+	}
+	for _, id := range interior {
+		if !region.ContainsCell(CellFromCellID(id)) {
+			t.Errorf("partial interior covering cell %v is not contained by the region", id)
+		}
+	}
+}
+
+// cancelAfterContext is a context that reports cancellation once its Err
+// method has been called more than n times.
+type cancelAfterContext struct {
+	context.Context
+	n, calls int
+}
+
+func (c *cancelAfterContext) Err() error {
+	if c.calls++; c.calls > c.n {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestEdgeQueryFindEdgesContext(t *testing.T) {
+	index := NewShapeIndex()
+	for i := 0; i < 20; i++ {
+		index.Add(newFractal().makeLoop(randomFrame(), s1.Degree))
+	}
+	target := NewMinDistanceToPointTarget(randomPoint())
+	opts := NewClosestEdgeQueryOptions().MaxResults(5)
+
+	got, err := NewClosestEdgeQuery(index, opts).FindEdgesContext(context.Background(), target)
+	if err != nil {
+		t.Fatalf("FindEdgesContext returned error %v", err)
+	}
+	if want := NewClosestEdgeQuery(index, opts).FindEdges(target); len(got) != len(want) {
+		t.Errorf("FindEdgesContext() returned %d results, want %d", len(got), len(want))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, bruteForce := range []bool{false, true} {
+		query := NewClosestEdgeQuery(index, NewClosestEdgeQueryOptions().MaxResults(5).UseBruteForce(bruteForce))
+		if _, err := query.FindEdgesContext(ctx, target); err != context.Canceled {
+			t.Errorf("bruteForce=%v: FindEdgesContext with canceled context returned error %v, want %v", bruteForce, err, context.Canceled)
+		}
+		// Cancellation during the search is observed by the search loops. If
+		// the optimized search finishes first, its results are complete.
+		full := NewClosestEdgeQuery(index, NewClosestEdgeQueryOptions().MaxResults(5).UseBruteForce(bruteForce)).FindEdges(target)
+		got, err := query.FindEdgesContext(&cancelAfterContext{Context: context.Background(), n: 1}, target)
+		if err != context.Canceled && (bruteForce || !reflect.DeepEqual(got, full)) {
+			t.Errorf("bruteForce=%v: FindEdgesContext canceled during the search = %v, %v", bruteForce, got, err)
+		}
+		// Later queries are not affected by the earlier context.
+		if got := query.FindEdges(target); !reflect.DeepEqual(got, full) {
+			t.Errorf("bruteForce=%v: FindEdges after a canceled query = %v, want %v", bruteForce, got, full)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+// ConvexDecomposition returns a set of convex loops whose interiors are
+// disjoint and whose union is the interior of the polygon. This is useful for
+// accelerating point location and for exporting to systems that only support
+// convex primitives.
+//
+// The polygon is first triangulated by ear clipping, and then adjacent
+// triangles are merged wherever the result remains convex (the
+// Hertel-Mehlhorn algorithm). The number of pieces is at most four times the
+// minimum possible number of convex pieces.
+//
+// Every shell of the polygon together with its holes must be contained
+// within a hemisphere. An error is returned for polygons that cannot be
+// decomposed, such as the full polygon.
+func (p *Polygon) ConvexDecomposition() ([]*Loop, error) {
+	rings, err := polygonRings(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var pieces []*Loop
+	for _, ring := range rings {
+		triangles, err := ring.triangulate()
+		if err != nil {
+			return nil, err
+		}
+		for _, piece := range mergeConvexPieces(ring, triangles) {
+			vertices := make([]Point, len(piece))
+			for i, v := range piece {
+				vertices[i] = ring[v]
+			}
+			pieces = append(pieces, LoopFromPoints(vertices))
+		}
+	}
+	return pieces, nil
+}
+
+// mergeConvexPieces merges the triangles of a triangulated ring into convex
+// pieces by removing diagonals whose removal leaves both endpoints convex.
+// Each piece is returned as a counterclockwise list of ring vertex indices.
+func mergeConvexPieces(ring polygonRing, triangles [][3]int) [][]int {
+	type edge struct{ v0, v1 int }
+
+	pieces := make([][]int, len(triangles))
+	owner := make(map[edge]int)
+	for i, t := range triangles {
+		pieces[i] = []int{t[0], t[1], t[2]}
+		for j := 0; j < 3; j++ {
+			owner[edge{t[j], t[(j+1)%3]}] = i
+		}
+	}
+
+	// position returns the index of vertex v within the given piece.
+	position := func(piece []int, v int) int {
+		for i, u := range piece {
+			if u == v {
+				return i
+			}
+		}
+		return -1
+	}
+
+	// Diagonals are considered in the order they were created by the ear
+	// clipping, i.e. the closing edge of each triangle.
+	for _, t := range triangles {
+		u, v := t[0], t[2]
+		pid, ok := owner[edge{u, v}]
+		if !ok {
+			continue
+		}
+		qid, ok := owner[edge{v, u}]
+		if !ok || pid == qid {
+			continue
+		}
+		p, q := pieces[pid], pieces[qid]
+		np, nq := len(p), len(q)
+		pu, pv := position(p, u), position(p, v)
+		qv, qu := position(q, v), position(q, u)
+
+		// After the merge, u is preceded by its predecessor in p and
+		// followed by its successor in q, and vice versa for v.
+		if RobustSign(ring[p[(pu+np-1)%np]], ring[u], ring[q[(qu+1)%nq]]) != CounterClockwise ||
+			RobustSign(ring[q[(qv+nq-1)%nq]], ring[v], ring[p[(pv+1)%np]]) != CounterClockwise {
+			continue
+		}
+
+		merged := make([]int, 0, np+nq-2)
+		for i := 0; i < np; i++ {
+			merged = append(merged, p[(pv+i)%np])
+		}
+		for i := 1; i < nq-1; i++ {
+			merged = append(merged, q[(qu+i)%nq])
+		}
+
+		delete(owner, edge{u, v})
+		delete(owner, edge{v, u})
+		for i := range q {
+			if e := (edge{q[i], q[(i+1)%nq]}); e.v0 != v || e.v1 != u {
+				owner[e] = pid
+			}
+		}
+		pieces[pid] = merged
+		pieces[qid] = nil
+	}
+
+	var result [][]int
+	for _, piece := range pieces {
+		if piece != nil {
+			result = append(result, piece)
+		}
+	}
+	return result
+}
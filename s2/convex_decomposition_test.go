@@ -0,0 +1,135 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+// loopIsConvex reports whether every vertex of the loop turns left.
+func loopIsConvex(l *Loop) bool {
+	for i := 0; i < l.NumVertices(); i++ {
+		if RobustSign(l.Vertex(i), l.Vertex(i+1), l.Vertex(i+2)) == Clockwise {
+			return false
+		}
+	}
+	return true
+}
+
+// checkConvexDecomposition verifies that the pieces are convex, have
+// disjoint interiors, and exactly cover the polygon.
+func checkConvexDecomposition(t *testing.T, p *Polygon, pieces []*Loop) {
+	t.Helper()
+	area := 0.0
+	for i, piece := range pieces {
+		if !loopIsConvex(piece) {
+			t.Errorf("piece %d (%v) is not convex", i, piece)
+		}
+		area += piece.Area()
+	}
+	if !float64Near(area, p.Area(), 1e-13) {
+		t.Errorf("total area of pieces = %v, want %v", area, p.Area())
+	}
+
+	rect := p.RectBound()
+	for i := 0; i < 200; i++ {
+		pt := samplePointFromRect(rect)
+		count := 0
+		for _, piece := range pieces {
+			if piece.ContainsPoint(pt) {
+				count++
+			}
+		}
+		if want := p.ContainsPoint(pt); (count == 1) != want || count > 1 {
+			t.Errorf("point %v is contained by %d pieces, polygon contains it: %v", pt, count, want)
+		}
+	}
+}
+
+func TestPolygonConvexDecompositionConvexInput(t *testing.T) {
+	p := makePolygon("0:0, 0:10, 10:10, 10:0", true)
+	pieces, err := p.ConvexDecomposition()
+	if err != nil {
+		t.Fatalf("%v.ConvexDecomposition() failed: %v", p, err)
+	}
+	if len(pieces) != 1 {
+		t.Errorf("%v.ConvexDecomposition() returned %d pieces, want 1", p, len(pieces))
+	}
+	checkConvexDecomposition(t, p, pieces)
+}
+
+func TestPolygonConvexDecompositionNonConvex(t *testing.T) {
+	tests := []string{
+		// An L shape.
+		"0:0, 0:10, 5:10, 5:5, 10:5, 10:0",
+		// A comb with several teeth.
+		"0:0, 0:10, 5:10, 5:8, 1:8, 1:6, 5:6, 5:4, 1:4, 1:2, 5:2, 5:0",
+		// A star.
+		"0:5, 2:6, 2:8, 3:6, 5:5, 3:4, 2:2, 2:4",
+	}
+	for _, test := range tests {
+		p := makePolygon(test, true)
+		pieces, err := p.ConvexDecomposition()
+		if err != nil {
+			t.Fatalf("%v.ConvexDecomposition() failed: %v", p, err)
+		}
+		if len(pieces) < 2 {
+			t.Errorf("%v.ConvexDecomposition() returned %d pieces, want at least 2", p, len(pieces))
+		}
+		checkConvexDecomposition(t, p, pieces)
+	}
+}
+
+func TestPolygonConvexDecompositionWithHoles(t *testing.T) {
+	p := makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 2:4, 4:4, 4:2; 6:6, 6:8, 8:8, 8:6", true)
+	pieces, err := p.ConvexDecomposition()
+	if err != nil {
+		t.Fatalf("%v.ConvexDecomposition() failed: %v", p, err)
+	}
+	checkConvexDecomposition(t, p, pieces)
+
+	// A shell nested inside a hole of another shell.
+	p = makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 2:8, 8:8, 8:2; 4:4, 4:6, 6:6, 6:4", true)
+	pieces, err = p.ConvexDecomposition()
+	if err != nil {
+		t.Fatalf("%v.ConvexDecomposition() failed: %v", p, err)
+	}
+	checkConvexDecomposition(t, p, pieces)
+}
+
+func TestPolygonConvexDecompositionRegularLoops(t *testing.T) {
+	center := PointFromLatLng(LatLngFromDegrees(30, 40))
+	p := PolygonFromLoops([]*Loop{
+		RegularLoop(center, 10*s1.Degree, 50),
+		RegularLoop(center, 5*s1.Degree, 20),
+	})
+	pieces, err := p.ConvexDecomposition()
+	if err != nil {
+		t.Fatalf("ConvexDecomposition() failed: %v", err)
+	}
+	checkConvexDecomposition(t, p, pieces)
+}
+
+func TestPolygonConvexDecompositionEmptyAndFull(t *testing.T) {
+	pieces, err := (&Polygon{}).ConvexDecomposition()
+	if err != nil || len(pieces) != 0 {
+		t.Errorf("empty polygon ConvexDecomposition() = %v, %v, want no pieces and no error", pieces, err)
+	}
+	if _, err := FullPolygon().ConvexDecomposition(); err == nil {
+		t.Errorf("FullPolygon().ConvexDecomposition() should have failed")
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+// CoveredPolygon is a polygon together with a covering and an interior
+// covering of it, which are used to answer relation tests between polygons
+// without looking at their edges when possible. This is useful when testing
+// many pairs of polygons of which most are far apart or nested well inside
+// each other, such as when joining two large sets of polygons: most pairs
+// are then decided by comparing a few cells, and only pairs whose
+// boundaries are close together need the exact tests of Polygon.
+//
+// The results are always the same as those of the corresponding Polygon
+// methods. The polygon must not be modified after the CoveredPolygon is
+// created.
+type CoveredPolygon struct {
+	Polygon *Polygon
+
+	// covering contains the polygon, and interior is contained by it.
+	covering, interior CellUnion
+}
+
+// NewCoveredPolygon returns a CoveredPolygon for the given polygon, with
+// coverings computed by the given coverer; if it is nil, a default with
+// MaxCells 8 is used. More cells decide more pairs without the exact tests,
+// but make the cell comparisons slower.
+func NewCoveredPolygon(p *Polygon, coverer *RegionCoverer) *CoveredPolygon {
+	if coverer == nil {
+		coverer = &RegionCoverer{MaxLevel: MaxLevel, LevelMod: 1, MaxCells: 8}
+	}
+	c := &CoveredPolygon{Polygon: p}
+	switch {
+	case p.IsEmpty():
+	case p.IsFull():
+		// The full polygon has no index for the coverer to use.
+		for face := 0; face < NumFaces; face++ {
+			c.covering = append(c.covering, CellIDFromFace(face))
+		}
+		c.interior = c.covering
+	default:
+		c.covering = coverer.Covering(p)
+		c.interior = coverer.InteriorCovering(p)
+	}
+	return c
+}
+
+// Intersects reports whether the polygon intersects the other polygon, as
+// by Polygon.Intersects.
+func (c *CoveredPolygon) Intersects(o *CoveredPolygon) bool {
+	if result, ok := c.intersectsByCoverings(o); ok {
+		return result
+	}
+	return c.Polygon.Intersects(o.Polygon)
+}
+
+// Contains reports whether the polygon contains the other polygon, as by
+// Polygon.Contains.
+func (c *CoveredPolygon) Contains(o *CoveredPolygon) bool {
+	if result, ok := c.containsByCoverings(o); ok {
+		return result
+	}
+	return c.Polygon.Contains(o.Polygon)
+}
+
+// intersectsByCoverings reports whether the polygons intersect, if this can
+// be decided from their coverings alone, and whether it could.
+func (c *CoveredPolygon) intersectsByCoverings(o *CoveredPolygon) (result, ok bool) {
+	if !c.covering.Intersects(o.covering) {
+		return false, true
+	}
+	// Interior coverings intersect only if they share some area, which is
+	// then inside both polygons.
+	if c.interior.Intersects(o.interior) {
+		return true, true
+	}
+	return false, false
+}
+
+// containsByCoverings reports whether this polygon contains the other one, if
+// this can be decided from their coverings alone, and whether it could.
+func (c *CoveredPolygon) containsByCoverings(o *CoveredPolygon) (result, ok bool) {
+	if o.Polygon.IsEmpty() {
+		return false, false
+	}
+	if c.interior.Contains(o.covering) {
+		return true, true
+	}
+	// Some part of the other polygon lies outside the covering of this one.
+	if !c.covering.Contains(o.interior) || !c.covering.Intersects(o.covering) {
+		return false, true
+	}
+	return false, false
+}
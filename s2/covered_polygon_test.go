@@ -0,0 +1,119 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+// coveredPolygonTestSet returns n regular polygons with random radii whose
+// centers are within a few degrees of each other, so that the set contains
+// disjoint, overlapping and nested pairs.
+func coveredPolygonTestSet(rng *rand.Rand, n, numVertices int) []*Polygon {
+	polygons := make([]*Polygon, n)
+	for i := range polygons {
+		center := PointFromLatLng(LatLngFromDegrees(4*rng.Float64(), 4*rng.Float64()))
+		radius := s1.Angle(0.05+1.5*rng.Float64()) * s1.Degree
+		polygons[i] = PolygonFromLoops([]*Loop{RegularLoop(center, radius, numVertices)})
+	}
+	return polygons
+}
+
+func TestCoveredPolygonRelations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	polygons := coveredPolygonTestSet(rng, 40, 16)
+	polygons = append(polygons,
+		PolygonFromLoops(nil),
+		FullPolygon(),
+		makePolygon("0:0, 0:4, 4:4, 4:0; 1:1, 3:1, 3:3, 1:3", true),
+		makePolygon("1.5:1.5, 1.5:2.5, 2.5:2.5, 2.5:1.5", true),
+		makePolygon("0:0, 0:4, 4:4, 4:0", true),
+	)
+	for _, coverer := range []*RegionCoverer{
+		nil,
+		{MaxLevel: 20, LevelMod: 1, MaxCells: 1},
+		{MaxLevel: MaxLevel, LevelMod: 1, MaxCells: 32},
+	} {
+		covered := make([]*CoveredPolygon, len(polygons))
+		for i, p := range polygons {
+			covered[i] = NewCoveredPolygon(p, coverer)
+		}
+		for i, a := range covered {
+			for j, b := range covered {
+				if got, want := a.Intersects(b), a.Polygon.Intersects(b.Polygon); got != want {
+					t.Errorf("coverer %v: polygons %d and %d: Intersects = %v, want %v", coverer, i, j, got, want)
+				}
+				if got, want := a.Contains(b), a.Polygon.Contains(b.Polygon); got != want {
+					t.Errorf("coverer %v: polygons %d and %d: Contains = %v, want %v", coverer, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestCoveredPolygonFastPaths(t *testing.T) {
+	outer := NewCoveredPolygon(makePolygon("0:0, 0:10, 10:10, 10:0", true), nil)
+	inner := NewCoveredPolygon(makePolygon("4:4, 4:6, 6:6, 6:4", true), nil)
+	far := NewCoveredPolygon(makePolygon("40:40, 40:41, 41:41, 41:40", true), nil)
+
+	if result, ok := outer.containsByCoverings(inner); !ok || !result {
+		t.Errorf("outer.containsByCoverings(inner) = %v, %v, want true, true", result, ok)
+	}
+	if result, ok := inner.containsByCoverings(outer); !ok || result {
+		t.Errorf("inner.containsByCoverings(outer) = %v, %v, want false, true", result, ok)
+	}
+	if result, ok := outer.intersectsByCoverings(inner); !ok || !result {
+		t.Errorf("outer.intersectsByCoverings(inner) = %v, %v, want true, true", result, ok)
+	}
+	if result, ok := outer.intersectsByCoverings(far); !ok || result {
+		t.Errorf("outer.intersectsByCoverings(far) = %v, %v, want false, true", result, ok)
+	}
+	if result, ok := far.containsByCoverings(outer); !ok || result {
+		t.Errorf("far.containsByCoverings(outer) = %v, %v, want false, true", result, ok)
+	}
+}
+
+func BenchmarkCoveredPolygonRelations(b *testing.B) {
+	// Benchmark all pairwise relation tests within a set of nearby polygons,
+	// comparing the plain Polygon methods with the CoveredPolygon ones.
+	const numPolygons = 64
+	for _, numVertices := range []int{16, 256, 4096} {
+		rng := rand.New(rand.NewSource(1))
+		polygons := coveredPolygonTestSet(rng, numPolygons, numVertices)
+		covered := make([]*CoveredPolygon, numPolygons)
+		for i, p := range polygons {
+			covered[i] = NewCoveredPolygon(p, nil)
+		}
+
+		b.Run(fmt.Sprintf("Polygon/%d", numVertices), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				p, q := polygons[i%numPolygons], polygons[(i/numPolygons)%numPolygons]
+				p.Intersects(q)
+				p.Contains(q)
+			}
+		})
+		b.Run(fmt.Sprintf("CoveredPolygon/%d", numVertices), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				p, q := covered[i%numPolygons], covered[(i/numPolygons)%numPolygons]
+				p.Intersects(q)
+				p.Contains(q)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Type tags mixed into region fingerprints, so that regions of different
+// types with the same coordinates have different fingerprints.
+const (
+	fingerprintPoint uint64 = iota + 1
+	fingerprintCap
+	fingerprintRect
+	fingerprintCell
+	fingerprintCellUnion
+	fingerprintLoop
+	fingerprintPolygon
+	fingerprintPolyline
+	fingerprintRegionUnion
+)
+
+// RegionFingerprint returns a 64-bit fingerprint of the geometry of the given
+// region, and reports whether the region type is supported. Like the Hash
+// methods, fingerprints are stable across releases and platforms.
+//
+// The supported types are Point, Cap, Rect, Cell, *CellUnion, *Loop,
+// *Polygon, *Polyline, and RegionUnions of supported types. Regions with the
+// same geometry and type have the same fingerprint; distinct regions have
+// different fingerprints with very high probability.
+func RegionFingerprint(region Region) (uint64, bool) {
+	h := uint64(hashSeed)
+	switch r := region.(type) {
+	case Point:
+		return hashCombine(hashCombine(h, fingerprintPoint), r.Hash()), true
+	case Cap:
+		h = hashCombine(h, fingerprintCap)
+		h = hashCombine(h, r.center.Hash())
+		return hashCombine(h, hashFloat64Bits(float64(r.radius))), true
+	case Rect:
+		h = hashCombine(h, fingerprintRect)
+		h = hashCombine(h, hashFloat64Bits(r.Lat.Lo))
+		h = hashCombine(h, hashFloat64Bits(r.Lat.Hi))
+		h = hashCombine(h, hashFloat64Bits(r.Lng.Lo))
+		return hashCombine(h, hashFloat64Bits(r.Lng.Hi)), true
+	case Cell:
+		return hashCombine(hashCombine(h, fingerprintCell), r.id.Hash()), true
+	case *CellUnion:
+		h = hashCombine(h, fingerprintCellUnion)
+		h = hashCombine(h, uint64(len(*r)))
+		for _, id := range *r {
+			h = hashCombine(h, id.Hash())
+		}
+		return h, true
+	case *Loop:
+		return hashLoopVertices(hashCombine(h, fingerprintLoop), r), true
+	case *Polygon:
+		h = hashCombine(h, fingerprintPolygon)
+		h = hashCombine(h, uint64(len(r.loops)))
+		for _, l := range r.loops {
+			h = hashLoopVertices(h, l)
+		}
+		return h, true
+	case *Polyline:
+		h = hashCombine(h, fingerprintPolyline)
+		h = hashCombine(h, uint64(len(*r)))
+		for _, p := range *r {
+			h = hashCombine(h, p.Hash())
+		}
+		return h, true
+	case RegionUnion:
+		h = hashCombine(h, fingerprintRegionUnion)
+		h = hashCombine(h, uint64(len(r)))
+		for _, sub := range r {
+			f, ok := RegionFingerprint(sub)
+			if !ok {
+				return 0, false
+			}
+			h = hashCombine(h, f)
+		}
+		return h, true
+	}
+	return 0, false
+}
+
+// hashLoopVertices returns the hash of h followed by the vertices of l.
+func hashLoopVertices(h uint64, l *Loop) uint64 {
+	h = hashCombine(h, uint64(len(l.vertices)))
+	for _, v := range l.vertices {
+		h = hashCombine(h, v.Hash())
+	}
+	return h
+}
+
+// coveringCacheKey identifies a covering by the fingerprint of the region
+// and the effective coverer parameters.
+type coveringCacheKey struct {
+	fingerprint uint64
+	minLevel    int
+	maxLevel    int
+	levelMod    int
+	maxCells    int
+	interior    bool
+}
+
+// coveringCacheEntry is the value stored in each element of the LRU list.
+type coveringCacheEntry struct {
+	key      coveringCacheKey
+	covering CellUnion
+}
+
+// CoveringCacheStats reports the effectiveness of a CoveringCache.
+type CoveringCacheStats struct {
+	// Hits is the number of coverings that were found in the cache.
+	Hits int
+	// Misses is the number of coverings that had to be computed, including
+	// those of regions that have no fingerprint.
+	Misses int
+	// Evictions is the number of coverings discarded to stay within capacity.
+	Evictions int
+}
+
+// CoveringCache is a least recently used cache of the coverings computed by a
+// RegionCoverer, for use when the same regions are covered repeatedly with
+// the same parameters. Coverings are keyed by the RegionFingerprint of the
+// region together with the coverer parameters; regions without a fingerprint
+// are never cached.
+//
+// To use it, set the Cache field of a RegionCoverer. A cache may be shared by
+// several RegionCoverers with different parameters, and it is safe for
+// concurrent use.
+type CoveringCache struct {
+	mu       sync.Mutex
+	capacity int
+	lru      *list.List // Front is the most recently used.
+	entries  map[coveringCacheKey]*list.Element
+	stats    CoveringCacheStats
+}
+
+// NewCoveringCache returns a cache that holds at most capacity coverings.
+// A capacity less than one disables caching.
+func NewCoveringCache(capacity int) *CoveringCache {
+	return &CoveringCache{
+		capacity: capacity,
+		lru:      list.New(),
+		entries:  make(map[coveringCacheKey]*list.Element),
+	}
+}
+
+// Len returns the number of coverings in the cache.
+func (c *CoveringCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Stats returns the hit, miss, and eviction counts of the cache so far.
+func (c *CoveringCache) Stats() CoveringCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Clear removes all coverings from the cache. The stats are not reset.
+func (c *CoveringCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	c.entries = make(map[coveringCacheKey]*list.Element)
+}
+
+// get returns a copy of the cached covering for the given key, if any.
+func (c *CoveringCache) get(key coveringCacheKey) (CellUnion, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	c.stats.Hits++
+	c.lru.MoveToFront(e)
+	return append(CellUnion(nil), e.Value.(*coveringCacheEntry).covering...), true
+}
+
+// recordMiss counts a covering that was computed without consulting the cache.
+func (c *CoveringCache) recordMiss() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Misses++
+}
+
+// put adds a copy of the covering to the cache, evicting the least recently
+// used coverings if the cache is full.
+func (c *CoveringCache) put(key coveringCacheKey, covering CellUnion) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.capacity < 1 {
+		return
+	}
+	if e, ok := c.entries[key]; ok {
+		// Another goroutine computed the same covering concurrently.
+		c.lru.MoveToFront(e)
+		return
+	}
+	entry := &coveringCacheEntry{key, append(CellUnion(nil), covering...)}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		delete(c.entries, oldest.Value.(*coveringCacheEntry).key)
+		c.lru.Remove(oldest)
+		c.stats.Evictions++
+	}
+}
+
+// cachedCellUnion returns the normalized covering or interior covering of the
+// region, looking it up in the coverer's cache first.
+func (rc *RegionCoverer) cachedCellUnion(region Region, interior bool) CellUnion {
+	c := rc.newCoverer()
+	c.interiorCovering = interior
+
+	var key coveringCacheKey
+	fingerprint, ok := RegionFingerprint(region)
+	if ok {
+		key = coveringCacheKey{fingerprint, c.minLevel, c.MaxLevel, c.levelMod, c.maxCells, interior}
+		if cu, ok := rc.Cache.get(key); ok {
+			return cu
+		}
+	} else {
+		rc.Cache.recordMiss()
+	}
+
+	c.coveringInternal(region)
+	cu := c.result
+	cu.Normalize()
+	if ok {
+		rc.Cache.put(key, cu)
+	}
+	return cu
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/golang/geo/s1"
+)
+
+func TestRegionFingerprint(t *testing.T) {
+	cellUnion := CellUnion{CellIDFromFace(1), CellIDFromFace(2)}
+	regions := []Region{
+		PointFromCoords(1, 0, 0),
+		CapFromCenterAngle(PointFromCoords(1, 0, 0), s1.Degree),
+		rectFromDegrees(0, 0, 10, 10),
+		CellFromCellID(CellIDFromFace(1)),
+		&cellUnion,
+		makeLoop("0:0, 0:10, 10:0"),
+		makePolygon("0:0, 0:10, 10:0", true),
+		makePolyline("0:0, 0:10, 10:0"),
+		RegionUnion{rectFromDegrees(0, 0, 10, 10), PointFromCoords(1, 0, 0)},
+	}
+
+	seen := make(map[uint64]int)
+	for i, r := range regions {
+		f, ok := RegionFingerprint(r)
+		if !ok {
+			t.Errorf("RegionFingerprint(%T) not supported", r)
+			continue
+		}
+		if j, ok := seen[f]; ok {
+			t.Errorf("RegionFingerprint(%T) = RegionFingerprint(%T)", r, regions[j])
+		}
+		seen[f] = i
+	}
+
+	tests := []struct {
+		a, b Region
+		want bool
+	}{
+		{makeLoop("0:0, 0:10, 10:0"), makeLoop("0:0, 0:10, 10:0"), true},
+		{makeLoop("0:0, 0:10, 10:0"), makeLoop("0:0, 0:10, 10:1"), false},
+		{makePolygon("0:0, 0:10, 10:0", true), makePolygon("0:0, 0:10, 10:0", true), true},
+		{makePolygon("0:0, 0:10, 10:0", true), makeLoop("0:0, 0:10, 10:0"), false},
+		{makePolyline("0:0, 0:10"), makePolyline("0:0, 0:10"), true},
+		{makePolyline("0:0, 0:10"), makePolyline("0:10, 0:0"), false},
+		{CapFromPoint(PointFromCoords(1, 0, 0)), PointFromCoords(1, 0, 0), false},
+		{rectFromDegrees(0, 0, 10, 10), rectFromDegrees(0, 0, 10, 11), false},
+		// The coordinates 0 and -0 have the same fingerprint.
+		{PointFromCoords(1, 0, 0), Point{r3.Vector{X: 1, Y: math.Copysign(0, -1)}}, true},
+	}
+	for _, test := range tests {
+		fa, _ := RegionFingerprint(test.a)
+		fb, _ := RegionFingerprint(test.b)
+		if got := fa == fb; got != test.want {
+			t.Errorf("RegionFingerprint(%v) == RegionFingerprint(%v) = %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+
+	unsupported := []Region{
+		NewShapeIndex().Region(),
+		RegionUnion{rectFromDegrees(0, 0, 10, 10), NewShapeIndex().Region()},
+	}
+	for _, r := range unsupported {
+		if _, ok := RegionFingerprint(r); ok {
+			t.Errorf("RegionFingerprint(%T) supported, want unsupported", r)
+		}
+	}
+}
+
+func TestCoveringCache(t *testing.T) {
+	cache := NewCoveringCache(2)
+	rc := &RegionCoverer{MaxLevel: 20, LevelMod: 1, MaxCells: 8, Cache: cache}
+	plain := &RegionCoverer{MaxLevel: 20, LevelMod: 1, MaxCells: 8}
+
+	loop := makeLoop("0:0, 0:10, 10:0")
+	want := plain.Covering(loop)
+	if got := rc.Covering(loop); !reflect.DeepEqual(got, want) {
+		t.Errorf("cached Covering(%v) = %v, want %v", loop, got, want)
+	}
+	if got, want := cache.Stats(), (CoveringCacheStats{Misses: 1}); got != want {
+		t.Errorf("after the first covering, cache.Stats() = %+v, want %+v", got, want)
+	}
+
+	// Covering an equal but distinct region is a cache hit, and modifying
+	// the returned covering does not modify the cached covering.
+	got := rc.Covering(makeLoop("0:0, 0:10, 10:0"))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cached Covering(%v) = %v, want %v", loop, got, want)
+	}
+	got[0] = CellIDFromFace(5)
+	if got := rc.Covering(loop); !reflect.DeepEqual(got, want) {
+		t.Errorf("after modifying a result, cached Covering(%v) = %v, want %v", loop, got, want)
+	}
+	if got, want := cache.Stats(), (CoveringCacheStats{Hits: 2, Misses: 1}); got != want {
+		t.Errorf("after covering the loop again, cache.Stats() = %+v, want %+v", got, want)
+	}
+
+	// Interior coverings and coverings with other parameters are cached
+	// separately. This evicts the least recently used covering of the loop.
+	if got, want := rc.InteriorCovering(loop), plain.InteriorCovering(loop); !reflect.DeepEqual(got, want) {
+		t.Errorf("cached InteriorCovering(%v) = %v, want %v", loop, got, want)
+	}
+	rc.MaxCells = 4
+	plain.MaxCells = 4
+	if got, want := rc.Covering(loop), plain.Covering(loop); !reflect.DeepEqual(got, want) {
+		t.Errorf("cached Covering(%v) with MaxCells 4 = %v, want %v", loop, got, want)
+	}
+	if got, want := cache.Stats(), (CoveringCacheStats{Hits: 2, Misses: 3, Evictions: 1}); got != want {
+		t.Errorf("after covering with other parameters, cache.Stats() = %+v, want %+v", got, want)
+	}
+	if got := cache.Len(); got != 2 {
+		t.Errorf("cache.Len() = %d, want 2", got)
+	}
+
+	// Regions without a fingerprint are covered but not cached.
+	index := NewShapeIndex()
+	index.Add(loop)
+	region := index.Region()
+	if got, want := rc.Covering(region), plain.Covering(region); !reflect.DeepEqual(got, want) {
+		t.Errorf("cached Covering(ShapeIndexRegion) = %v, want %v", got, want)
+	}
+	if got := cache.Len(); got != 2 {
+		t.Errorf("after covering an unsupported region, cache.Len() = %d, want 2", got)
+	}
+
+	cache.Clear()
+	if got := cache.Len(); got != 0 {
+		t.Errorf("after Clear, cache.Len() = %d, want 0", got)
+	}
+
+	// A cache with no capacity computes every covering.
+	rc.Cache = NewCoveringCache(0)
+	rc.Covering(loop)
+	rc.Covering(loop)
+	if got, want := rc.Cache.Stats(), (CoveringCacheStats{Misses: 2}); got != want {
+		t.Errorf("with no capacity, cache.Stats() = %+v, want %+v", got, want)
+	}
+}
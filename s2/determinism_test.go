@@ -0,0 +1,151 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+// These tests check that results do not depend on map iteration order or on
+// the platform. The expected values are golden values that must be the same
+// on every architecture.
+
+func TestDeterminismCoveringGolden(t *testing.T) {
+	rc := &RegionCoverer{MaxLevel: 20, MaxCells: 8}
+	tests := []struct {
+		region Region
+		want   []string
+	}{
+		{
+			region: CapFromCenterAngle(PointFromLatLng(LatLngFromDegrees(37.7749, -122.4194)), 0.001),
+			want:   []string{"80857ff", "808581", "808586c", "8085874", "808f7c2b", "808f7dc", "808f7f", "808f81"},
+		},
+		{
+			region: makePolygon("0:0, 0:10, 10:10, 10:0", true),
+			want:   []string{"05555555555", "0fd4", "0fdc", "0fe4", "0ffc", "104", "1a9", "1ab"},
+		},
+	}
+	for _, test := range tests {
+		var got []string
+		for _, id := range rc.Covering(test.region) {
+			got = append(got, id.ToToken())
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Covering(%v) = %q, want %q", test.region, got, test.want)
+		}
+	}
+}
+
+func TestDeterminismEdgeQueryWithMaxError(t *testing.T) {
+	// With both MaxResults and MaxError set, which of several nearly
+	// equidistant edges are returned depends on the order in which edges
+	// are visited. That order must not depend on map iteration.
+	index := NewShapeIndex()
+	for i := 0; i < 30; i++ {
+		points := PointVector{PointFromLatLng(LatLngFromDegrees(float64(i%3)*1e-3, float64(i/3)*1e-3))}
+		index.Add(&points)
+	}
+	target := NewMinDistanceToPointTarget(PointFromLatLng(LatLngFromDegrees(0, 0)))
+
+	for _, bruteForce := range []bool{true, false} {
+		opts := NewClosestEdgeQueryOptions().
+			MaxResults(5).
+			MaxError(s1.ChordAngleFromAngle(s1.Degree)).
+			UseBruteForce(bruteForce)
+		want := NewClosestEdgeQuery(index, opts).FindEdges(target)
+		for i := 0; i < 20; i++ {
+			if got := NewClosestEdgeQuery(index, opts).FindEdges(target); !reflect.DeepEqual(got, want) {
+				t.Fatalf("bruteForce=%v: run %d returned %v, previously %v", bruteForce, i, got, want)
+			}
+		}
+	}
+}
+
+// polygonTokens returns the tokens of the cells at the given level that
+// contain the vertices of each loop of the polygon.
+func polygonTokens(p *Polygon, level int) [][]string {
+	var tokens [][]string
+	for _, l := range p.Loops() {
+		var loop []string
+		for _, v := range l.Vertices() {
+			loop = append(loop, cellIDFromPoint(v).Parent(level).ToToken())
+		}
+		tokens = append(tokens, loop)
+	}
+	return tokens
+}
+
+// The BooleanOperation and Builder golden tests snap their output to cell
+// centers, so that the vertices are the same on every platform even though
+// computed intersection points may differ in the last bits.
+
+func TestDeterminismBooleanOperationGolden(t *testing.T) {
+	snapper := CellIDSnapperForLevel(20)
+	a := indexFromPolygons(makePolygon("0:0, 0:4, 4:4, 4:0; 1:1, 1:2, 2:2, 2:1", true))
+	b := indexFromPolygons(makePolygon("2:2, 2:6, 6:6, 6:2", true), makePolygon("-1:3, -1:5, 5:5, 5:3", true))
+	tests := []struct {
+		opType BooleanOperationType
+		want   [][]string
+	}{
+		{BooleanOperationUnion, [][]string{
+			{"10000000001", "100829e02ab", "1aa6646ad59", "1aa1e3be55f", "100d3240333", "1072466b911", "104115c93b3", "10231212da5", "101997430ef", "101e1e1d7d5"},
+			{"10014415409", "10066f42859", "10051011a45", "10036e17ca9"},
+		}},
+		{BooleanOperationIntersection, [][]string{
+			{"100829e02ab", "100a0a08281", "100dc612e59", "1014143d0d9", "101723ea8a1", "101997430ef", "10051011a45", "100fb1ea333"},
+		}},
+		{BooleanOperationDifference, [][]string{
+			{"10000000001", "100829e02ab", "100fb1ea333", "10051011a45", "101997430ef", "101e1e1d7d5"},
+			{"10036e17ca9", "10014415409", "10066f42859", "10051011a45"},
+		}},
+		{BooleanOperationSymmetricDifference, [][]string{
+			{"10000000001", "100829e02ab", "100fb1ea333", "10051011a45", "101997430ef", "101e1e1d7d5"},
+			{"10036e17ca9", "10014415409", "10066f42859", "10051011a45"},
+			{"100a0a08281", "100829e02ab", "1aa6646ad59", "1aa1e3be55f", "100d3240333", "1072466b911", "104115c93b3", "10231212da5", "101997430ef", "101723ea8a1", "1014143d0d9", "100dc612e59"},
+		}},
+	}
+	for _, test := range tests {
+		// Repeat the operation, since map iteration order differs between
+		// runs.
+		for i := 0; i < 10; i++ {
+			result, err := NewBooleanOperation(test.opType, snapper).Build(a, b)
+			if err != nil {
+				t.Fatalf("%v: Build returned error: %v", test.opType, err)
+			}
+			if got := polygonTokens(result, 20); !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("%v: run %d returned loops %q, want %q", test.opType, i, got, test.want)
+			}
+		}
+	}
+}
+
+func TestDeterminismBuilderGolden(t *testing.T) {
+	want := [][]string{{"10000000001", "100a0a08281", "1014143d0d9", "10231212da5", "101e1e1d7d5"}}
+	for i := 0; i < 10; i++ {
+		b := NewBuilder(BuilderOptions{Snapper: CellIDSnapperForLevel(20), SplitCrossingEdges: true})
+		b.AddPolygon(makePolygon("0:0, 0:4, 4:4, 4:0", true))
+		b.AddPolygon(makePolygon("4:0, 4:4, 6:2", true))
+		result, err := b.BuildPolygon()
+		if err != nil {
+			t.Fatalf("BuildPolygon returned error: %v", err)
+		}
+		if got := polygonTokens(result, 20); !reflect.DeepEqual(got, want) {
+			t.Fatalf("run %d returned loops %q, want %q", i, got, want)
+		}
+	}
+}
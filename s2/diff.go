@@ -0,0 +1,198 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// GeometryDiff describes the differences between an old and a new version
+// of some geometry, ignoring changes smaller than a tolerance. It is meant
+// for auditing updates to datasets of boundaries, so the areas it reports
+// are approximations. Use Polygon.Difference for the exact areas.
+type GeometryDiff struct {
+	// AddedCells and RemovedCells approximate the areas that are inside
+	// the new geometry but not the old, and inside the old geometry but
+	// not the new. Each is a set of cells whose centers are in the area.
+	// Near the boundaries the cells are about the size of the tolerance,
+	// and cells within the tolerance of the boundary they moved away from
+	// are left out. The cells may therefore extend up to about the
+	// tolerance beyond the exact area, and they miss a strip of about the
+	// tolerance along its edges.
+	AddedCells, RemovedCells CellUnion
+
+	// AddedBoundary and RemovedBoundary are the parts of the boundaries of
+	// the new and old geometry that are farther than the tolerance from any
+	// edge of the other geometry. The parts are sampled at intervals of half
+	// the tolerance, so their ends are approximate.
+	AddedBoundary, RemovedBoundary []Polyline
+}
+
+// IsEmpty reports whether no differences were found.
+func (d *GeometryDiff) IsEmpty() bool {
+	return len(d.AddedCells) == 0 && len(d.RemovedCells) == 0 &&
+		len(d.AddedBoundary) == 0 && len(d.RemovedBoundary) == 0
+}
+
+// DiffPolygons returns the differences between the old polygon a and the
+// new polygon b that are larger than the tolerance, which must be positive.
+func DiffPolygons(a, b *Polygon, tolerance s1.Angle) *GeometryDiff {
+	return DiffShapeIndexes(polygonIndex(a), polygonIndex(b), tolerance)
+}
+
+// polygonIndex returns the index of the polygon, which is nil for the zero
+// Polygon.
+func polygonIndex(p *Polygon) *ShapeIndex {
+	if p.index == nil {
+		return NewShapeIndex()
+	}
+	return p.index
+}
+
+// DiffShapeIndexes returns the differences between the geometry in the old
+// index a and the new index b that are larger than the tolerance, which
+// must be positive. Areas are those of the polygons in each index, under
+// the semi-open vertex model, and boundaries are the edges of all the
+// polylines and polygons.
+//
+// The cost is proportional to the length of the boundaries divided by the
+// tolerance, since cells near the boundaries are refined down to about the
+// size of the tolerance.
+func DiffShapeIndexes(a, b *ShapeIndex, tolerance s1.Angle) *GeometryDiff {
+	d := &differ{
+		a:         newDiffSide(a),
+		b:         newDiffSide(b),
+		tolerance: s1.ChordAngleFromAngle(tolerance),
+		maxLevel:  AvgEdgeMetric.ClosestLevel(tolerance.Radians()),
+	}
+	for face := 0; face < 6; face++ {
+		d.diffCell(CellFromCellID(CellIDFromFace(face)))
+	}
+	d.diff.AddedCells.Normalize()
+	d.diff.RemovedCells.Normalize()
+	d.diff.AddedBoundary = d.movedBoundary(b, d.a, tolerance)
+	d.diff.RemovedBoundary = d.movedBoundary(a, d.b, tolerance)
+	return &d.diff
+}
+
+// diffSide holds the queries on one of the indexes being compared.
+type diffSide struct {
+	contains *ContainsPointQuery
+	boundary *EdgeQuery
+}
+
+func newDiffSide(index *ShapeIndex) diffSide {
+	return diffSide{
+		contains: NewContainsPointQuery(index, VertexModelSemiOpen),
+		boundary: NewClosestEdgeQuery(index, NewClosestEdgeQueryOptions().IncludeInteriors(false)),
+	}
+}
+
+// isFarFromBoundary reports whether p is farther than limit from every edge.
+func (s diffSide) isFarFromBoundary(p Point, limit s1.ChordAngle) bool {
+	return !s.boundary.IsDistanceLess(NewMinDistanceToPointTarget(p), limit.Successor())
+}
+
+type differ struct {
+	a, b      diffSide
+	tolerance s1.ChordAngle
+	maxLevel  int
+	diff      GeometryDiff
+}
+
+// diffCell adds the parts of the cell that are in the symmetric difference
+// of the areas to the diff.
+func (d *differ) diffCell(cell Cell) {
+	center := cell.Center()
+	target := NewMinDistanceToCellTarget(cell)
+	nearA := d.a.boundary.IsDistanceLess(target, d.tolerance)
+	nearB := d.b.boundary.IsDistanceLess(target, d.tolerance)
+	if !nearA && !nearB {
+		// Neither boundary comes near the cell, so each area either
+		// contains the whole cell or none of it.
+		d.addCell(cell.ID(), d.a.contains.Contains(center), d.b.contains.Contains(center))
+		return
+	}
+	if cell.Level() >= d.maxLevel {
+		inA, inB := d.a.contains.Contains(center), d.b.contains.Contains(center)
+		// A cell that changed sides is only counted if it is farther than
+		// the tolerance from the boundary it moved across.
+		if inB && !inA && d.a.isFarFromBoundary(center, d.tolerance) ||
+			inA && !inB && d.b.isFarFromBoundary(center, d.tolerance) {
+			d.addCell(cell.ID(), inA, inB)
+		}
+		return
+	}
+	children, _ := cell.Children()
+	for _, child := range children {
+		d.diffCell(child)
+	}
+}
+
+func (d *differ) addCell(id CellID, inA, inB bool) {
+	switch {
+	case inB && !inA:
+		d.diff.AddedCells = append(d.diff.AddedCells, id)
+	case inA && !inB:
+		d.diff.RemovedCells = append(d.diff.RemovedCells, id)
+	}
+}
+
+// movedBoundary returns the parts of the edges of the index that are
+// farther than the tolerance from the boundary of the other side.
+func (d *differ) movedBoundary(index *ShapeIndex, other diffSide, tolerance s1.Angle) []Polyline {
+	var lines []Polyline
+	var run Polyline
+	flush := func() {
+		if len(run) >= 2 {
+			lines = append(lines, run)
+		}
+		run = nil
+	}
+	add := func(p Point) {
+		if !other.isFarFromBoundary(p, d.tolerance) {
+			flush()
+			return
+		}
+		if len(run) == 0 || run[len(run)-1] != p {
+			run = append(run, p)
+		}
+	}
+
+	for id := int32(0); id < index.nextID; id++ {
+		shape := index.Shape(id)
+		if shape == nil || shape.Dimension() == 0 {
+			continue
+		}
+		for c := 0; c < shape.NumChains(); c++ {
+			chain := shape.Chain(c)
+			for e := 0; e < chain.Length; e++ {
+				edge := shape.ChainEdge(c, e)
+				n := maxInt(1, int(math.Ceil(float64(edge.V0.Distance(edge.V1)/(tolerance/2)))))
+				for i := 0; i < n; i++ {
+					add(Interpolate(float64(i)/float64(n), edge.V0, edge.V1))
+				}
+			}
+			if chain.Length > 0 {
+				last := shape.ChainEdge(c, chain.Length-1)
+				add(last.V1)
+			}
+			flush()
+		}
+	}
+	return lines
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestDiffPolygons(t *testing.T) {
+	const tolerance = s1.Degree / 20
+	old := makePolygon("0:0, 0:4, 4:4, 4:0", true)
+
+	// Unchanged geometry, and geometry whose vertices moved by less than the
+	// tolerance, has no differences.
+	for _, p := range []*Polygon{old, makePolygon("0:0, 0:4.01, 4:4, 4:0.01", true)} {
+		if d := DiffPolygons(old, p, tolerance); !d.IsEmpty() {
+			t.Errorf("DiffPolygons(%v, %v) = %+v, want empty", old, p, d)
+		}
+	}
+
+	// Moving the eastern side from lng 4 to lng 5 adds a strip of area and
+	// moves that part of the boundary.
+	grown := makePolygon("0:0, 0:5, 4:5, 4:0", true)
+	d := DiffPolygons(old, grown, tolerance)
+	if len(d.RemovedCells) != 0 || len(d.RemovedBoundary) != 1 {
+		t.Errorf("DiffPolygons(old, grown) removed %v and boundary %v, want no area and one line", d.RemovedCells, d.RemovedBoundary)
+	}
+	strip := makePolygon("0:4, 0:5, 4:5, 4:4", true)
+	if got, want := d.AddedCells.ExactArea(), strip.Area(); got < 0.8*want || got > want {
+		t.Errorf("added area = %v, want about %v", got, want)
+	}
+	// The cells approximate the exact difference, which is the strip, to
+	// within about the tolerance along its boundary of 10 degrees.
+	exact, err := grown.Difference(old)
+	if err != nil {
+		t.Fatalf("grown.Difference(old) returned error: %v", err)
+	}
+	maxErr := (10 * s1.Degree).Radians() * tolerance.Radians()
+	if got, want := d.AddedCells.ExactArea(), exact.Area(); math.Abs(got-want) > maxErr {
+		t.Errorf("added area = %v, want %v within %v", got, want, maxErr)
+	}
+	for _, id := range d.AddedCells {
+		if !strip.ContainsPoint(id.Point()) {
+			t.Errorf("added cell %v is outside the strip", id)
+		}
+	}
+	// The new boundary that moved consists of the eastern side and the
+	// parts of the northern and southern sides east of lng 4, which are
+	// connected through the polygon's vertices.
+	if len(d.AddedBoundary) != 1 {
+		t.Fatalf("AddedBoundary = %v, want one line", d.AddedBoundary)
+	}
+	if got := d.AddedBoundary[0].Length(); got < 5.5*s1.Degree || got > 6*s1.Degree {
+		t.Errorf("moved boundary length = %v degrees, want about 6", got.Degrees())
+	}
+
+	// The reverse diff swaps added and removed.
+	r := DiffPolygons(grown, old, tolerance)
+	if !r.RemovedCells.Equal(d.AddedCells) || len(r.AddedCells) != 0 {
+		t.Errorf("DiffPolygons(grown, old) = %+v, want the reverse of %+v", r, d)
+	}
+
+	if d := DiffPolygons(&Polygon{}, old, tolerance); len(d.AddedCells) == 0 || len(d.RemovedBoundary) != 0 {
+		t.Errorf("DiffPolygons(empty, %v) = %+v, want all area added", old, d)
+	}
+}
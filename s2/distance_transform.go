@@ -0,0 +1,161 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/golang/geo/s1"
+)
+
+// CellDistance is a cell together with its approximate distance from a set
+// of sources.
+type CellDistance struct {
+	ID       CellID
+	Distance s1.Angle
+}
+
+// DistanceTransform labels each cell of a covering with its approximate
+// distance from a set of source cells. The covering is expanded or coarsened
+// to cells at the given level, and the cells that intersect a source cell
+// start at distance zero. Distances then propagate between neighboring cells
+// of the covering (including those that only share a vertex), with each step
+// costing the distance between the cell centers. The result approximates the
+// shortest path distance within the covering from the nearest source cell
+// center. Since paths are restricted to 8 directions, it can overestimate
+// this distance by about 10%, and it is also subject to an error
+// proportional to the cell size at the given level.
+//
+// To measure distances from a shape rather than from cells, use a covering of
+// the shape (e.g. from NewShapeRegion) as the sources.
+//
+// Cells that cannot be reached from a source, or whose distance would exceed
+// maxDistance, are omitted. If maxDistance is not positive, there is no
+// limit. The result is sorted by cell ID.
+//
+// The level must be in the range [0, MaxLevel], and coarse covering cells are
+// expanded to every descendant at that level, so the covering should not be
+// much coarser than the level.
+func DistanceTransform(covering, sources []CellID, level int, maxDistance s1.Angle) []CellDistance {
+	if maxDistance <= 0 {
+		maxDistance = s1.InfAngle()
+	}
+
+	// dist holds the best known distance of each cell of the covering.
+	dist := make(map[CellID]s1.Angle)
+	for _, id := range covering {
+		if !id.IsValid() {
+			continue
+		}
+		if id.Level() >= level {
+			dist[id.Parent(level)] = s1.InfAngle()
+			continue
+		}
+		for c, end := id.ChildBeginAtLevel(level), id.ChildEndAtLevel(level); c != end; c = c.Next() {
+			dist[c] = s1.InfAngle()
+		}
+	}
+
+	var src CellUnion
+	for _, id := range sources {
+		if id.IsValid() {
+			src = append(src, id)
+		}
+	}
+	src.Normalize()
+
+	var pq cellDistanceQueue
+	for id := range dist {
+		if src.IntersectsCellID(id) {
+			dist[id] = 0
+			pq = append(pq, CellDistance{id, 0})
+		}
+	}
+	heap.Init(&pq)
+
+	for pq.Len() > 0 {
+		cur := heap.Pop(&pq).(CellDistance)
+		if cur.Distance > dist[cur.ID] {
+			// A shorter path to this cell was already processed.
+			continue
+		}
+		center := cur.ID.Point()
+		for _, n := range cur.ID.AllNeighbors(level) {
+			d, ok := dist[n]
+			if !ok {
+				continue
+			}
+			if nd := cur.Distance + center.Distance(n.Point()); nd < d && nd <= maxDistance {
+				dist[n] = nd
+				heap.Push(&pq, CellDistance{n, nd})
+			}
+		}
+	}
+
+	var result []CellDistance
+	for id, d := range dist {
+		if d < s1.InfAngle() {
+			result = append(result, CellDistance{id, d})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// DistanceBands groups the cells of a distance transform into bands of the
+// given width, as for drawing isochrones. Band i is a normalized CellUnion of
+// the cells whose distance is in the range [i*width, (i+1)*width). The width
+// must be positive.
+func DistanceBands(cells []CellDistance, width s1.Angle) []CellUnion {
+	var bands []CellUnion
+	for _, c := range cells {
+		band := int(c.Distance / width)
+		for len(bands) <= band {
+			bands = append(bands, nil)
+		}
+		bands[band] = append(bands[band], c.ID)
+	}
+	for i := range bands {
+		bands[i].Normalize()
+	}
+	return bands
+}
+
+// cellDistanceQueue is a priority queue of cells ordered by increasing
+// distance.
+type cellDistanceQueue []CellDistance
+
+func (q cellDistanceQueue) Len() int {
+	return len(q)
+}
+
+func (q cellDistanceQueue) Less(i, j int) bool {
+	return q[i].Distance < q[j].Distance
+}
+
+func (q cellDistanceQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *cellDistanceQueue) Push(x any) {
+	*q = append(*q, x.(CellDistance))
+}
+
+func (q *cellDistanceQueue) Pop() any {
+	item := (*q)[len(*q)-1]
+	*q = (*q)[:len(*q)-1]
+	return item
+}
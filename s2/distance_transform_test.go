@@ -0,0 +1,100 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestDistanceTransformSingleSource(t *testing.T) {
+	const level = 12
+	region := CapFromCenterAngle(PointFromLatLng(LatLngFromDegrees(10, 20)), 0.5*s1.Degree)
+	rc := &RegionCoverer{MinLevel: 8, MaxLevel: level, MaxCells: 50}
+	covering := rc.Covering(region)
+	source := cellIDFromPoint(region.Center()).Parent(level)
+
+	got := DistanceTransform(covering, []CellID{source.ChildBeginAtLevel(20)}, level, 0)
+
+	// Every cell of the covering at the level is reachable.
+	want := covering.LeafCellsCovered() >> uint(2*(MaxLevel-level))
+	if int64(len(got)) != want {
+		t.Errorf("len(DistanceTransform(...)) = %d, want %d", len(got), want)
+	}
+	for i, c := range got {
+		if i > 0 && got[i-1].ID >= c.ID {
+			t.Errorf("result not sorted at %d: %v, %v", i, got[i-1].ID, c.ID)
+		}
+		// Paths between cell centers are never shorter than the direct
+		// distance. On a square grid, moving in 8 directions overestimates
+		// it by at most 1/cos(22.5 degrees), or about 8%; cells are not
+		// quite square, so allow a little more.
+		direct := source.Point().Distance(c.ID.Point())
+		if c.Distance < direct*(1-1e-12) || c.Distance > 1.15*direct {
+			t.Errorf("cell %v distance = %v, want in [%v, %v]", c.ID, c.Distance, direct, 1.15*direct)
+		}
+		if (c.Distance == 0) != (c.ID == source) {
+			t.Errorf("cell %v distance = %v, want zero only for the source", c.ID, c.Distance)
+		}
+	}
+}
+
+func TestDistanceTransformMaxDistanceAndBands(t *testing.T) {
+	const level = 10
+	center := CellIDFromFace(3).ChildBeginAtLevel(level).Advance(12345)
+	covering := []CellID{center.Parent(5)}
+	sources := []CellID{center}
+	maxDistance := 10 * center.Point().Distance(center.EdgeNeighbors()[0].Point())
+
+	got := DistanceTransform(covering, sources, level, maxDistance)
+	if len(got) <= 1 {
+		t.Fatalf("DistanceTransform(...) returned %d cells, want more than 1", len(got))
+	}
+	for _, c := range got {
+		if c.Distance > maxDistance {
+			t.Errorf("cell %v distance = %v, want <= %v", c.ID, c.Distance, maxDistance)
+		}
+	}
+
+	width := maxDistance / 4
+	bands := DistanceBands(got, width)
+	if len(bands) != 4 && len(bands) != 5 {
+		t.Errorf("len(DistanceBands(...)) = %d, want 4 or 5", len(bands))
+	}
+	var leaves int64
+	for _, b := range bands {
+		leaves += b.LeafCellsCovered()
+	}
+	if want := int64(len(got)) << uint(2*(MaxLevel-level)); leaves != want {
+		t.Errorf("DistanceBands(...) covers %d leaf cells, want %d", leaves, want)
+	}
+	for _, c := range got {
+		band := int(c.Distance / width)
+		if !bands[band].ContainsCellID(c.ID) {
+			t.Errorf("band %d does not contain cell %v at distance %v", band, c.ID, c.Distance)
+		}
+	}
+}
+
+func TestDistanceTransformUnreachable(t *testing.T) {
+	const level = 10
+	a := CellIDFromFace(0).ChildBeginAtLevel(level)
+	b := CellIDFromFace(3).ChildBeginAtLevel(level)
+	got := DistanceTransform([]CellID{a, b.ChildBeginAtLevel(level + 2)}, []CellID{a.Parent(2)}, level, 0)
+	if len(got) != 1 || got[0] != (CellDistance{a, 0}) {
+		t.Errorf("DistanceTransform(...) = %v, want [{%v 0}]", got, a)
+	}
+}
@@ -25,5 +25,52 @@ and preserves spatial locality (nearby cells have close IDs).
 
 More information including an in-depth introduction to S2 can be found on the
 S2 website https://s2geometry.io/
+
+# Determinism
+
+Coverings, closest edge query results (including which results are retained
+when MaxResults and MaxError are both used), and the polygons built by
+BooleanOperation and Builder (including the order of their loops and
+vertices) do not depend on the order in which Go happens to iterate over
+maps: given the same inputs and options, they are the same every time they
+are computed. These are the operations checked by the determinism tests;
+other operations are written the same way, but are not tested for it.
+
+The geometric predicates (such as RobustSign and the edge crossing tests) are
+exact, and so return identical answers on every platform. Operations whose
+outputs are determined by predicates, such as coverings and the CellIDs and
+shape and edge IDs returned by queries, are therefore portable as well. The
+polygons built by BooleanOperation and Builder contain computed intersection
+points, so they are only portable when snapped to cell centers or integer
+coordinates, and then only as long as no vertex is almost exactly halfway
+between two snap sites.
+
+On some architectures, such as arm64, the Go compiler may fuse a multiply and
+an add into a single instruction, which skips the rounding of the product. The
+floating-point steps of the predicates, the dot and cross products in r2 and
+r3, and the area and centroid computations built on them (PointArea,
+Loop.Area, Polygon.Area, TrueCentroid and so on) round each product with an
+explicit float64 conversion, which the Go specification guarantees prevents
+fusion. These results are therefore reproducible bit for bit across amd64 and
+arm64, apart from any differences in the standard library's math functions
+(such as math.Atan2 and math.Tan) between platforms. This is always in effect
+and needs no build tag. Other constructed values, such as intersection points
+and interpolated distances, are computed in ordinary float64 arithmetic and
+may differ in the last bits between platforms.
+
+# Portability
+
+The packages are pure Go and build for WebAssembly with GOOS=js or
+GOOS=wasip1 and GOARCH=wasm, so coverings, containment and the other
+operations can be used in a browser. They avoid cgo, unsafe and
+reflection, which are the features that alternative compilers such as
+TinyGo most often lack or support only partially; for example,
+PolygonToGeoJSON writes its output directly rather than with
+encoding/json.
+
+The exact predicates fall back to math/big when float64 arithmetic cannot
+determine the answer. This is rare, so it has little cost at run time, but
+it does add math/big to the binary. It is not behind a build tag, since
+without it the predicates would no longer be robust.
 */
 package s2
@@ -0,0 +1,214 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"github.com/golang/geo/s1"
+)
+
+// SimplifyEdgeChains simplifies the chains of a set of directed edges while
+// preserving their topology, and returns the simplified edges. This is the
+// "simplify edge chains" step of the C++ S2Builder, which is the only way to
+// simplify many features consistently in one pass: shared boundaries are
+// simplified once, so adjacent features still share them afterwards.
+//
+// The input must be noded, meaning that edges meet only at shared endpoints
+// (see NodePolylines). An edge chain is a maximal sequence of edges whose
+// interior vertices each have exactly one incoming and one outgoing edge;
+// other vertices, such as the endpoints of polylines and the vertices where
+// three or more edges meet, are never removed or moved.
+//
+// Each chain is replaced by a subsequence of its vertices such that
+//
+//   - every removed vertex is within tolerance of the edge that replaces it,
+//   - the replacing edge does not cross any other input edge, and
+//   - no vertex outside the chain is within tolerance of the replacing edge.
+//
+// The last two conditions ensure that simplification does not move a chain
+// past any other vertex or edge, as long as the tolerance is small compared
+// to the edge lengths. Closed chains (cycles in which every vertex has one
+// incoming and one outgoing edge) keep at least three edges.
+//
+// The output edges are grouped by chain, in the direction of the input edges.
+func SimplifyEdgeChains(edges []Edge, tolerance s1.Angle) []Edge {
+	s := newEdgeChainSimplifier(edges, tolerance)
+	return s.run()
+}
+
+// edgeChainSimplifier holds the state of SimplifyEdgeChains.
+type edgeChainSimplifier struct {
+	edges     []Edge
+	tolerance s1.Angle
+
+	// outEdge maps each interior vertex of a chain to its outgoing edge.
+	outEdge map[Point]int
+	used    []bool
+
+	// edgeShape contains one single edge line per input edge, so that its
+	// edge IDs are the same as those of the input.
+	edgeShape *MultiPolyline
+	crossings *CrossingEdgeQuery
+
+	vertices PointVector
+	closest  *EdgeQuery
+
+	result []Edge
+}
+
+func newEdgeChainSimplifier(edges []Edge, tolerance s1.Angle) *edgeChainSimplifier {
+	s := &edgeChainSimplifier{
+		edges:     edges,
+		tolerance: tolerance,
+		outEdge:   make(map[Point]int),
+		used:      make([]bool, len(edges)),
+	}
+
+	inDegree := make(map[Point]int)
+	outDegree := make(map[Point]int)
+	lines := make([][]Point, len(edges))
+	for i, e := range edges {
+		outDegree[e.V0]++
+		inDegree[e.V1]++
+		lines[i] = []Point{e.V0, e.V1}
+	}
+	for i, e := range edges {
+		if inDegree[e.V0] == 1 && outDegree[e.V0] == 1 {
+			s.outEdge[e.V0] = i
+		}
+	}
+	for v := range outDegree {
+		s.vertices = append(s.vertices, v)
+	}
+	for v := range inDegree {
+		if outDegree[v] == 0 {
+			s.vertices = append(s.vertices, v)
+		}
+	}
+
+	edgeIndex := NewShapeIndex()
+	s.edgeShape = MultiPolylineFromPoints(lines)
+	edgeIndex.Add(s.edgeShape)
+	s.crossings = NewCrossingEdgeQuery(edgeIndex)
+
+	vertexIndex := NewShapeIndex()
+	vertexIndex.Add(&s.vertices)
+	limit := s1.ChordAngleFromAngle(tolerance).Successor()
+	s.closest = NewClosestEdgeQuery(vertexIndex, NewClosestEdgeQueryOptions().DistanceLimit(limit))
+	return s
+}
+
+// isInterior reports whether v is an interior vertex of some chain.
+func (s *edgeChainSimplifier) isInterior(v Point) bool {
+	_, ok := s.outEdge[v]
+	return ok
+}
+
+func (s *edgeChainSimplifier) run() []Edge {
+	// First simplify the chains that start at a vertex that is kept.
+	for i, e := range s.edges {
+		if s.used[i] || s.isInterior(e.V0) {
+			continue
+		}
+		chain := []int{i}
+		s.used[i] = true
+		for v := e.V1; s.isInterior(v); {
+			next := s.outEdge[v]
+			chain = append(chain, next)
+			s.used[next] = true
+			v = s.edges[next].V1
+		}
+		s.simplifyChain(chain)
+	}
+
+	// The remaining edges form closed chains. These are split into three
+	// parts that are simplified separately, so that each keeps at least
+	// three edges.
+	for i := range s.edges {
+		if s.used[i] {
+			continue
+		}
+		chain := []int{i}
+		s.used[i] = true
+		for next := s.outEdge[s.edges[i].V1]; !s.used[next]; next = s.outEdge[s.edges[next].V1] {
+			chain = append(chain, next)
+			s.used[next] = true
+		}
+		n := len(chain)
+		if n <= 3 {
+			for _, e := range chain {
+				s.result = append(s.result, s.edges[e])
+			}
+			continue
+		}
+		s.simplifyChain(chain[:n/3])
+		s.simplifyChain(chain[n/3 : 2*n/3])
+		s.simplifyChain(chain[2*n/3:])
+	}
+	return s.result
+}
+
+// simplifyChain appends the simplified edges of the given chain of edge IDs
+// to the result. Starting at the first vertex, each output edge is extended
+// along the chain for as long as it can replace the edges it skips.
+func (s *edgeChainSimplifier) simplifyChain(chain []int) {
+	for i := 0; i < len(chain); {
+		end := i + 1
+		for end < len(chain) && s.canReplace(chain[i:end+1]) {
+			end++
+		}
+		s.result = append(s.result, Edge{s.edges[chain[i]].V0, s.edges[chain[end-1]].V1})
+		i = end
+	}
+}
+
+// canReplace reports whether the given subchain of edge IDs can be replaced
+// by a single edge between its endpoints.
+func (s *edgeChainSimplifier) canReplace(sub []int) bool {
+	a, b := s.edges[sub[0]].V0, s.edges[sub[len(sub)-1]].V1
+	if a == b || a == (Point{a.Mul(-1)}) {
+		return false
+	}
+	inSub := func(id int) bool {
+		for _, e := range sub {
+			if e == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, e := range sub[:len(sub)-1] {
+		if DistanceFromSegment(s.edges[e].V1, a, b) > s.tolerance {
+			return false
+		}
+	}
+	for _, e := range s.crossings.Crossings(a, b, s.edgeShape, CrossingTypeInterior) {
+		if !inSub(e) {
+			return false
+		}
+	}
+	for _, r := range s.closest.FindEdges(NewMinDistanceToEdgeTarget(Edge{a, b})) {
+		v := s.vertices[r.EdgeID()]
+		if v == a || v == b {
+			continue
+		}
+		// The interior vertices of the subchain are removed, and no other
+		// chain has them as vertices.
+		if e, ok := s.outEdge[v]; !ok || !inSub(e) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+// edgesFromPolylines returns the edges of the given polylines.
+func edgesFromPolylines(lines ...string) []Edge {
+	var edges []Edge
+	for _, s := range lines {
+		l := makePolyline(s)
+		for i := 0; i < l.NumEdges(); i++ {
+			edges = append(edges, l.Edge(i))
+		}
+	}
+	return edges
+}
+
+func TestSimplifyEdgeChains(t *testing.T) {
+	tests := []struct {
+		desc      string
+		edges     []Edge
+		tolerance s1.Angle
+		want      []Edge
+	}{
+		{
+			desc:      "no edges",
+			tolerance: s1.Degree,
+		},
+		{
+			desc:      "zigzag within tolerance",
+			edges:     edgesFromPolylines("0:0, 0.1:1, -0.1:2, 0.1:3, 0:4"),
+			tolerance: 0.5 * s1.Degree,
+			want:      edgesFromPolylines("0:0, 0:4"),
+		},
+		{
+			desc:      "bend outside tolerance",
+			edges:     edgesFromPolylines("0:0, 0.1:1, 0:2, 2:3, 0:4"),
+			tolerance: 0.5 * s1.Degree,
+			want:      edgesFromPolylines("0:0, 0:2, 2:3, 0:4"),
+		},
+		{
+			desc:      "junction vertices are kept",
+			edges:     edgesFromPolylines("0:0, 0.1:1, 0:2", "0:2, 0.1:3, 0:4", "0:2, 1:2.1, 2:2"),
+			tolerance: 0.5 * s1.Degree,
+			want:      edgesFromPolylines("0:0, 0:2", "0:2, 0:4", "0:2, 2:2"),
+		},
+		{
+			desc: "chain is not moved past another vertex",
+			edges: edgesFromPolylines("0:0, 0.4:1, 0.4:2, 0:3",
+				"0.2:1.5, 3:1.5"),
+			tolerance: 0.5 * s1.Degree,
+			want: edgesFromPolylines("0:0, 0.4:1, 0.4:2, 0:3",
+				"0.2:1.5, 3:1.5"),
+		},
+		{
+			desc:      "chain is simplified away from other vertices",
+			edges:     edgesFromPolylines("0:0, 0.4:1, 0.4:2, 0:3", "-0.6:1.5, -3:1.5"),
+			tolerance: 0.5 * s1.Degree,
+			want:      edgesFromPolylines("0:0, 0:3", "-0.6:1.5, -3:1.5"),
+		},
+		{
+			desc:      "closed chain is simplified in three parts",
+			edges:     edgesFromPolylines("0:0, 0:1, 0:2, 1:2, 2:2, 2:1, 2:0, 1:0, 0:0"),
+			tolerance: 0.1 * s1.Degree,
+			want:      edgesFromPolylines("0:0, 0:2, 2:2, 2:1, 2:0, 0:0"),
+		},
+		{
+			desc:      "closed chain smaller than tolerance is unchanged",
+			edges:     edgesFromPolylines("0:0, 0:0.1, 0.1:0.1, 0.1:0, 0:0"),
+			tolerance: s1.Degree,
+			want:      edgesFromPolylines("0:0, 0:0.1, 0.1:0.1, 0.1:0, 0:0"),
+		},
+		{
+			desc:      "triangle is unchanged",
+			edges:     edgesFromPolylines("0:0, 0:0.1, 0.1:0.1, 0:0"),
+			tolerance: s1.Degree,
+			want:      edgesFromPolylines("0:0, 0:0.1, 0.1:0.1, 0:0"),
+		},
+	}
+	for _, test := range tests {
+		got := SimplifyEdgeChains(test.edges, test.tolerance)
+		if len(got) != len(test.want) {
+			t.Errorf("%s: SimplifyEdgeChains(...) = %v, want %v", test.desc, got, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("%s: SimplifyEdgeChains(...)[%d] = %v, want %v", test.desc, i, got[i], test.want[i])
+			}
+		}
+	}
+}
+
+func TestSimplifyEdgeChainsSharedBoundary(t *testing.T) {
+	// Two adjacent polygons share a wiggly boundary. After simplifying all
+	// of their edges together, the boundary must still be shared.
+	var boundary []Point
+	for i := 0; i <= 20; i++ {
+		lat := 0.0
+		if i%2 == 1 {
+			lat = 0.05
+		}
+		boundary = append(boundary, PointFromLatLng(LatLngFromDegrees(lat, float64(i)*0.5)))
+	}
+	var edges []Edge
+	for i := 0; i+1 < len(boundary); i++ {
+		edges = append(edges, Edge{boundary[i], boundary[i+1]})
+	}
+	edges = append(edges, edgesFromPolylines("0:10, 5:10, 5:0, 0:0", "0:10, -5:10, -5:0, 0:0")...)
+	got := SimplifyEdgeChains(edges, 0.2*s1.Degree)
+
+	// The shared boundary becomes a single edge, and each other chain is
+	// unchanged.
+	want := append([]Edge{{boundary[0], boundary[20]}}, edgesFromPolylines("0:10, 5:10, 5:0, 0:0", "0:10, -5:10, -5:0, 0:0")...)
+	if len(got) != len(want) {
+		t.Fatalf("SimplifyEdgeChains(...) = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("SimplifyEdgeChains(...)[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
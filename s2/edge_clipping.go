@@ -33,48 +33,49 @@ import (
 )
 
 const (
-	// edgeClipErrorUVCoord is the maximum error in a u- or v-coordinate
-	// compared to the exact result, assuming that the points A and B are in
-	// the rectangle [-1,1]x[1,1] or slightly outside it (by 1e-10 or less).
-	edgeClipErrorUVCoord = 2.25 * dblEpsilon
-
-	// edgeClipErrorUVDist is the maximum distance from a clipped point to
-	// the corresponding exact result. It is equal to the error in a single
-	// coordinate because at most one coordinate is subject to error.
-	edgeClipErrorUVDist = 2.25 * dblEpsilon
-
-	// faceClipErrorRadians is the maximum angle between a returned vertex
-	// and the nearest point on the exact edge AB. It is equal to the
+	// EdgeClipErrorUVCoord is the maximum error in a u- or v-coordinate
+	// returned by ClipEdge compared to the exact result, assuming that the
+	// points A and B are in the rectangle [-1,1]x[-1,1] or slightly outside
+	// it (by 1e-10 or less).
+	EdgeClipErrorUVCoord = 2.25 * DblEpsilon
+
+	// EdgeClipErrorUVDist is the maximum distance from a point clipped by
+	// ClipEdge to the corresponding exact result. It is equal to the error in
+	// a single coordinate because at most one coordinate is subject to error.
+	EdgeClipErrorUVDist = 2.25 * DblEpsilon
+
+	// FaceClipErrorRadians is the maximum angle between a vertex returned by
+	// ClipToFace and the nearest point on the exact edge AB. It is equal to the
 	// maximum directional error in PointCross, plus the error when
 	// projecting points onto a cube face.
-	faceClipErrorRadians = 3 * dblEpsilon
+	FaceClipErrorRadians = 3 * DblEpsilon
 
-	// faceClipErrorDist is the same angle expressed as a maximum distance
+	// FaceClipErrorUVDist is the same angle expressed as a maximum distance
 	// in (u,v)-space. In other words, a returned vertex is at most this far
 	// from the exact edge AB projected into (u,v)-space.
-	faceClipErrorUVDist = 9 * dblEpsilon
+	FaceClipErrorUVDist = 9 * DblEpsilon
 
-	// faceClipErrorUVCoord is the maximum angle between a returned vertex
+	// FaceClipErrorUVCoord is the maximum angle between a returned vertex
 	// and the nearest point on the exact edge AB expressed as the maximum error
 	// in an individual u- or v-coordinate. In other words, for each
 	// returned vertex there is a point on the exact edge AB whose u- and
 	// v-coordinates differ from the vertex by at most this amount.
-	faceClipErrorUVCoord = 9.0 * (1.0 / math.Sqrt2) * dblEpsilon
+	FaceClipErrorUVCoord = 9.0 * (1.0 / math.Sqrt2) * DblEpsilon
 
-	// intersectsRectErrorUVDist is the maximum error when computing if a point
+	// IntersectsRectErrorUVDist is the maximum error when computing if a point
 	// intersects with a given Rect. If some point of AB is inside the
 	// rectangle by at least this distance, the result is guaranteed to be true;
 	// if all points of AB are outside the rectangle by at least this distance,
 	// the result is guaranteed to be false. This bound assumes that rect is
 	// a subset of the rectangle [-1,1]x[-1,1] or extends slightly outside it
 	// (e.g., by 1e-10 or less).
-	intersectsRectErrorUVDist = 3 * math.Sqrt2 * dblEpsilon
+	IntersectsRectErrorUVDist = 3 * math.Sqrt2 * DblEpsilon
 )
 
 // ClipToFace returns the (u,v) coordinates for the portion of the edge AB that
 // intersects the given face, or false if the edge AB does not intersect.
 // This method guarantees that the clipped vertices lie within the [-1,1]x[-1,1]
-// cube face rectangle and are within faceClipErrorUVDist of the line AB, but
+// cube face rectangle and are within FaceClipErrorUVDist of the line AB, but
 // the results may differ from those produced by FaceSegments.
 func ClipToFace(a, b Point, face int) (aUV, bUV r2.Point, intersects bool) {
 	return ClipToPaddedFace(a, b, face, 0.0)
@@ -141,7 +142,7 @@ func ClipEdge(a, b r2.Point, clip r2.Rect) (aClip, bClip r2.Point, intersects bo
 	// Compute the bounding rectangle of AB, clip it, and then extract the new
 	// endpoints from the clipped bound.
 	bound := r2.RectFromPoints(a, b)
-	if bound, intersects = clipEdgeBound(a, b, clip, bound); !intersects {
+	if bound, intersects = ClipEdgeBound(a, b, clip, bound); !intersects {
 		return aClip, bClip, false
 	}
 	ai := 0
@@ -308,7 +309,7 @@ func clipDestination(a, b, scaledN, aTan, bTan pointUVW, scaleUV float64) (r2.Po
 	var uv r2.Point
 
 	// Optimization: if B is within the safe region of the face, use it.
-	maxSafeUVCoord := 1 - faceClipErrorUVCoord
+	maxSafeUVCoord := 1 - FaceClipErrorUVCoord
 	if b.Z > 0 {
 		uv = r2.Point{b.X / b.Z, b.Y / b.Z}
 		if math.Max(math.Abs(uv.X), math.Abs(uv.Y)) <= maxSafeUVCoord {
@@ -416,9 +417,12 @@ func clipBoundAxis(a0, b0 float64, bound0 r1.Interval, a1, b1 float64, bound1 r1
 	return bound0, bound1, true
 }
 
-// edgeIntersectsRect reports whether the edge defined by AB intersects the
-// given closed rectangle to within the error bound.
-func edgeIntersectsRect(a, b r2.Point, r r2.Rect) bool {
+// EdgeIntersectsRect reports whether the edge AB in (u,v)-space intersects
+// the given closed rectangle to within IntersectsRectErrorUVDist. If some
+// point of AB is inside the rectangle by at least that distance the result is
+// true, and if all points of AB are outside it by at least that distance the
+// result is false.
+func EdgeIntersectsRect(a, b r2.Point, r r2.Rect) bool {
 	// First check whether the bounds of a Rect around AB intersects the given rect.
 	if !r.Intersects(r2.RectFromPoints(a, b)) {
 		return false
@@ -445,24 +449,24 @@ func edgeIntersectsRect(a, b r2.Point, r r2.Rect) bool {
 	return (max >= 0) && (min <= 0)
 }
 
-// clippedEdgeBound returns the bounding rectangle of the portion of the edge defined
-// by AB intersected by clip. The resulting bound may be empty. This is a convenience
-// function built on top of clipEdgeBound.
-func clippedEdgeBound(a, b r2.Point, clip r2.Rect) r2.Rect {
+// ClippedEdgeBound returns the bounding rectangle of the portion of the edge AB
+// in (u,v)-space that intersects clip. The resulting bound may be empty. This
+// is a convenience function built on top of ClipEdgeBound.
+func ClippedEdgeBound(a, b r2.Point, clip r2.Rect) r2.Rect {
 	bound := r2.RectFromPoints(a, b)
-	if b1, intersects := clipEdgeBound(a, b, clip, bound); intersects {
+	if b1, intersects := ClipEdgeBound(a, b, clip, bound); intersects {
 		return b1
 	}
 	return r2.EmptyRect()
 }
 
-// clipEdgeBound clips an edge AB to sequence of rectangles efficiently.
+// ClipEdgeBound clips an edge AB to sequence of rectangles efficiently.
 // It represents the clipped edges by their bounding boxes rather than as a pair of
 // endpoints. Specifically, let A'B' be some portion of an edge AB, and let bound be
 // a tight bound of A'B'. This function returns the bound that is a tight bound
 // of A'B' intersected with a given rectangle. If A'B' does not intersect clip,
 // it returns false and the original bound.
-func clipEdgeBound(a, b r2.Point, clip, bound r2.Rect) (r2.Rect, bool) {
+func ClipEdgeBound(a, b r2.Point, clip, bound r2.Rect) (r2.Rect, bool) {
 	// negSlope indicates which diagonal of the bounding box is spanned by AB: it
 	// is false if AB has positive slope, and true if AB has negative slope. This is
 	// used to determine which interval endpoints need to be updated each time
@@ -509,7 +513,7 @@ type FaceSegment struct {
 // unit length.
 //
 // This function guarantees that the returned segments form a continuous path
-// from A to B, and that all vertices are within faceClipErrorUVDist of the
+// from A to B, and that all vertices are within FaceClipErrorUVDist of the
 // line AB. All vertices lie within the [-1,1]x[-1,1] cube face rectangles.
 // The results are consistent with Sign, i.e. the edge is well-defined even its
 // endpoints are antipodal.
@@ -584,7 +588,7 @@ func FaceSegments(a, b Point) []FaceSegment {
 func moveOriginToValidFace(face int, a, ab Point, aUV r2.Point) (int, r2.Point) {
 	// Fast path: if the origin is sufficiently far inside the face, it is
 	// always safe to use it.
-	const maxSafeUVCoord = 1 - faceClipErrorUVCoord
+	const maxSafeUVCoord = 1 - FaceClipErrorUVCoord
 	if math.Max(math.Abs((aUV).X), math.Abs((aUV).Y)) <= maxSafeUVCoord {
 		return face, aUV
 	}
@@ -600,7 +604,7 @@ func moveOriginToValidFace(face int, a, ab Point, aUV r2.Point) (int, r2.Point)
 		aTangent := ab.Normalize().Cross(a.Vector)
 
 		// We can use the given face.
-		if exit.Sub(a.Vector).Dot(aTangent) >= -faceClipErrorRadians {
+		if exit.Sub(a.Vector).Dot(aTangent) >= -FaceClipErrorRadians {
 			return face, aUV
 		}
 	}
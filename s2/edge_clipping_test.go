@@ -129,7 +129,7 @@ func testClipToPaddedFace(t *testing.T, a, b Point) {
 	}
 
 	biunit := r2.Rect{r1.Interval{-1, 1}, r1.Interval{-1, 1}}
-	const errorRadians = faceClipErrorRadians
+	const errorRadians = FaceClipErrorRadians
 
 	// The first and last vertices should approximately equal A and B.
 	if aPrime := faceUVToXYZ(segments[0].face, segments[0].a.X, segments[0].a.Y); a.Angle(aPrime) > errorRadians {
@@ -194,7 +194,7 @@ func testClipToPaddedFace(t *testing.T, a, b Point) {
 	if expectedAngles.IsInverted() {
 		expectedAngles = s1.Interval{expectedAngles.Hi, expectedAngles.Lo}
 	}
-	maxAngles := expectedAngles.Expanded(faceClipErrorRadians)
+	maxAngles := expectedAngles.Expanded(FaceClipErrorRadians)
 	var actualAngles s1.Interval
 
 	for face := 0; face < 6; face++ {
@@ -208,19 +208,19 @@ func testClipToPaddedFace(t *testing.T, a, b Point) {
 
 		desc := fmt.Sprintf("on face %d, a=%v, b=%v, aClip=%v, bClip=%v,", face, a, b, aClip, bClip)
 
-		if got := math.Abs(aClip.Dot(norm.Vector)); got > faceClipErrorRadians {
-			t.Errorf("%s abs(%v.Dot(%v)) = %v, want <= %v", desc, aClip, norm, got, faceClipErrorRadians)
+		if got := math.Abs(aClip.Dot(norm.Vector)); got > FaceClipErrorRadians {
+			t.Errorf("%s abs(%v.Dot(%v)) = %v, want <= %v", desc, aClip, norm, got, FaceClipErrorRadians)
 		}
-		if got := math.Abs(bClip.Dot(norm.Vector)); got > faceClipErrorRadians {
-			t.Errorf("%s abs(%v.Dot(%v)) = %v, want <= %v", desc, bClip, norm, got, faceClipErrorRadians)
+		if got := math.Abs(bClip.Dot(norm.Vector)); got > FaceClipErrorRadians {
+			t.Errorf("%s abs(%v.Dot(%v)) = %v, want <= %v", desc, bClip, norm, got, FaceClipErrorRadians)
 		}
 
-		if float64(aClip.Angle(a.Vector)) > faceClipErrorRadians {
+		if float64(aClip.Angle(a.Vector)) > FaceClipErrorRadians {
 			if got := math.Max(math.Abs(aUV.X), math.Abs(aUV.Y)); !float64Eq(got, 1+padding) {
 				t.Errorf("%s the largest component of %v = %v, want %v", desc, aUV, got, 1+padding)
 			}
 		}
-		if float64(bClip.Angle(b.Vector)) > faceClipErrorRadians {
+		if float64(bClip.Angle(b.Vector)) > FaceClipErrorRadians {
 			if got := math.Max(math.Abs(bUV.X), math.Abs(bUV.Y)); !float64Eq(got, 1+padding) {
 				t.Errorf("%s the largest component of %v = %v, want %v", desc, bUV, got, 1+padding)
 			}
@@ -241,7 +241,7 @@ func testClipToPaddedFace(t *testing.T, a, b Point) {
 		}
 		actualAngles = actualAngles.Union(faceAngles)
 	}
-	if !actualAngles.Expanded(faceClipErrorRadians).ContainsInterval(expectedAngles) {
+	if !actualAngles.Expanded(FaceClipErrorRadians).ContainsInterval(expectedAngles) {
 		t.Errorf("the union of all angle segments should be larger than the expected angle")
 	}
 }
@@ -289,7 +289,7 @@ func TestEdgeClippingClipToPaddedFace(t *testing.T) {
 func getFraction(t *testing.T, x, a, b r2.Point) float64 {
 	// A bound for the error in edge clipping plus the error in the calculation
 	// (which is similar to EdgeIntersectsRect).
-	errorDist := (edgeClipErrorUVDist + intersectsRectErrorUVDist)
+	errorDist := (EdgeClipErrorUVDist + IntersectsRectErrorUVDist)
 	if a == b {
 		return 0.0
 	}
@@ -367,7 +367,7 @@ func checkPointOnBoundary(t *testing.T, p, a r2.Point, clip r2.Rect) {
 func TestEdgeClippingClipEdge(t *testing.T) {
 	// A bound for the error in edge clipping plus the error in the
 	// EdgeIntersectsRect calculation below.
-	errorDist := (edgeClipErrorUVDist + intersectsRectErrorUVDist)
+	errorDist := (EdgeClipErrorUVDist + IntersectsRectErrorUVDist)
 	testRects := []r2.Rect{
 		// Test clipping against random rectangles.
 		r2.RectFromPoints(
@@ -401,12 +401,12 @@ func TestEdgeClippingClipEdge(t *testing.T) {
 
 			aClip, bClip, intersects := ClipEdge(a, b, r)
 			if !intersects {
-				if edgeIntersectsRect(a, b, r.ExpandedByMargin(-errorDist)) {
-					t.Errorf("edgeIntersectsRect(%v, %v, %v.ExpandedByMargin(%v) = true, want false", a, b, r, -errorDist)
+				if EdgeIntersectsRect(a, b, r.ExpandedByMargin(-errorDist)) {
+					t.Errorf("EdgeIntersectsRect(%v, %v, %v.ExpandedByMargin(%v) = true, want false", a, b, r, -errorDist)
 				}
 			} else {
-				if !edgeIntersectsRect(a, b, r.ExpandedByMargin(errorDist)) {
-					t.Errorf("edgeIntersectsRect(%v, %v, %v.ExpandedByMargin(%v) = false, want true", a, b, r, errorDist)
+				if !EdgeIntersectsRect(a, b, r.ExpandedByMargin(errorDist)) {
+					t.Errorf("EdgeIntersectsRect(%v, %v, %v.ExpandedByMargin(%v) = false, want true", a, b, r, errorDist)
 				}
 
 				// Check that the clipped points lie on the edge AB, and
@@ -420,21 +420,21 @@ func TestEdgeClippingClipEdge(t *testing.T) {
 				checkPointOnBoundary(t, bClip, b, r)
 			}
 
-			// Choose an random initial bound to pass to clipEdgeBound.
+			// Choose an random initial bound to pass to ClipEdgeBound.
 			initialClip := r2.RectFromPoints(choosePointInRect(a, b), choosePointInRect(a, b))
-			bound := clippedEdgeBound(a, b, initialClip)
+			bound := ClippedEdgeBound(a, b, initialClip)
 			if bound.IsEmpty() {
-				// Precondition of clipEdgeBound not met
+				// Precondition of ClipEdgeBound not met
 				continue
 			}
 			maxBound := bound.Intersection(r)
-			if bound, intersects := clipEdgeBound(a, b, r, bound); !intersects {
-				if edgeIntersectsRect(a, b, maxBound.ExpandedByMargin(-errorDist)) {
-					t.Errorf("edgeIntersectsRect(%v, %v, %v.ExpandedByMargin(%v) = true, want false", a, b, maxBound.ExpandedByMargin(-errorDist), -errorDist)
+			if bound, intersects := ClipEdgeBound(a, b, r, bound); !intersects {
+				if EdgeIntersectsRect(a, b, maxBound.ExpandedByMargin(-errorDist)) {
+					t.Errorf("EdgeIntersectsRect(%v, %v, %v.ExpandedByMargin(%v) = true, want false", a, b, maxBound.ExpandedByMargin(-errorDist), -errorDist)
 				}
 			} else {
-				if !edgeIntersectsRect(a, b, maxBound.ExpandedByMargin(errorDist)) {
-					t.Errorf("edgeIntersectsRect(%v, %v, %v.ExpandedByMargin(%v) = false, want true", a, b, maxBound.ExpandedByMargin(errorDist), errorDist)
+				if !EdgeIntersectsRect(a, b, maxBound.ExpandedByMargin(errorDist)) {
+					t.Errorf("EdgeIntersectsRect(%v, %v, %v.ExpandedByMargin(%v) = false, want true", a, b, maxBound.ExpandedByMargin(errorDist), errorDist)
 				}
 				// check that the bound is as large as possible.
 				ai := 0
@@ -53,8 +53,16 @@ type EdgeCrosser struct {
 
 // NewEdgeCrosser returns an EdgeCrosser with the fixed edge AB.
 func NewEdgeCrosser(a, b Point) *EdgeCrosser {
+	e := &EdgeCrosser{}
+	e.init(a, b)
+	return e
+}
+
+// init sets the fixed edge of the crosser to AB, so that a single crosser can
+// be reused for many edges without allocating.
+func (e *EdgeCrosser) init(a, b Point) {
 	norm := a.PointCross(b)
-	return &EdgeCrosser{
+	*e = EdgeCrosser{
 		a:        a,
 		b:        b,
 		aXb:      Point{a.Cross(b.Vector)},
@@ -179,11 +187,11 @@ func (e *EdgeCrosser) crossingSign(d Point, bda Direction) Crossing {
 
 	// The error in RobustCrossProd is insignificant. The maximum error in
 	// the call to CrossProd (i.e., the maximum norm of the error vector) is
-	// (0.5 + 1/sqrt(3)) * dblEpsilon. The maximum error in each call to
-	// DotProd below is dblEpsilon. (There is also a small relative error
+	// (0.5 + 1/sqrt(3)) * DblEpsilon. The maximum error in each call to
+	// DotProd below is DblEpsilon. (There is also a small relative error
 	// term that is insignificant because we are comparing the result against a
 	// constant that is very close to zero.)
-	maxError := (1.5 + 1/math.Sqrt(3)) * dblEpsilon
+	maxError := (1.5 + 1/math.Sqrt(3)) * DblEpsilon
 	if (e.c.Dot(e.aTangent.Vector) > maxError && d.Dot(e.aTangent.Vector) > maxError) || (e.c.Dot(e.bTangent.Vector) > maxError && d.Dot(e.bTangent.Vector) > maxError) {
 		return DoNotCross
 	}
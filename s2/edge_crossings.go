@@ -23,21 +23,24 @@ import (
 )
 
 const (
-	// intersectionError can be set somewhat arbitrarily, because the algorithm
-	// uses more precision if necessary in order to achieve the specified error.
-	// The only strict requirement is that intersectionError >= dblEpsilon
-	// radians. However, using a larger error tolerance makes the algorithm more
-	// efficient because it reduces the number of cases where exact arithmetic is
-	// needed.
-	intersectionError = s1.Angle(8 * dblError)
-
-	// intersectionMergeRadius is used to ensure that intersection points that
+	// IntersectionError is the maximum angle between the point returned by
+	// Intersection and the true intersection point of the two edges.
+	//
+	// It can be set somewhat arbitrarily, because the algorithm uses more
+	// precision if necessary in order to achieve the specified error. The only
+	// strict requirement is that IntersectionError >= DblEpsilon radians.
+	// However, using a larger error tolerance makes the algorithm more
+	// efficient because it reduces the number of cases where exact arithmetic
+	// is needed.
+	IntersectionError = s1.Angle(8 * DblError)
+
+	// IntersectionMergeRadius is used to ensure that intersection points that
 	// are supposed to be coincident are merged back together into a single
 	// vertex. This is required in order for various polygon operations (union,
 	// intersection, etc) to work correctly. It is twice the intersection error
 	// because two coincident intersection points might have errors in
 	// opposite directions.
-	intersectionMergeRadius = 2 * intersectionError
+	IntersectionMergeRadius = 2 * IntersectionError
 )
 
 // A Crossing indicates how edges cross.
@@ -172,12 +175,12 @@ func Intersection(a0, a1, b0, b1 Point) Point {
 	// It is difficult to compute the intersection point of two edges accurately
 	// when the angle between the edges is very small. Previously we handled
 	// this by only guaranteeing that the returned intersection point is within
-	// intersectionError of each edge. However, this means that when the edges
+	// IntersectionError of each edge. However, this means that when the edges
 	// cross at a very small angle, the computed result may be very far from the
 	// true intersection point.
 	//
 	// Instead this function now guarantees that the result is always within
-	// intersectionError of the true intersection. This requires using more
+	// IntersectionError of the true intersection. This requires using more
 	// sophisticated techniques and in some cases extended precision.
 	//
 	//  - intersectionStable computes the intersection point using
@@ -188,6 +191,7 @@ func Intersection(a0, a1, b0, b1 Point) Point {
 	//    arithmetic and converts the final result back to an Point.
 	pt, ok := intersectionStable(a0, a1, b0, b1)
 	if !ok {
+		reportRobustness(ExactArithmeticFallback, "Intersection")
 		pt = intersectionExact(a0, a1, b0, b1)
 	}
 
@@ -253,14 +257,14 @@ func projection(x, aNorm r3.Vector, aNormLen float64, a0, a1 Point) (proj, bound
 
 	// This calculation bounds the error from all sources: the computation of
 	// the normal, the subtraction of one endpoint, and the dot product itself.
-	// dblError appears because the input points are assumed to be
+	// DblError appears because the input points are assumed to be
 	// normalized in double precision.
 	//
 	// For reference, the bounds that went into this calculation are:
-	// ||N'-N|| <= ((1 + 2 * sqrt(3))||N|| + 32 * sqrt(3) * dblError) * epsilon
+	// ||N'-N|| <= ((1 + 2 * sqrt(3))||N|| + 32 * sqrt(3) * DblError) * epsilon
 	// |(A.B)'-(A.B)| <= (1.5 * (A.B) + 1.5 * ||A|| * ||B||) * epsilon
 	// ||(X-Y)'-(X-Y)|| <= ||X-Y|| * epsilon
-	bound = (((3.5+2*math.Sqrt(3))*aNormLen+32*math.Sqrt(3)*dblError)*dist + 1.5*math.Abs(proj)) * epsilon
+	bound = (((3.5+2*math.Sqrt(3))*aNormLen+32*math.Sqrt(3)*DblError)*dist + 1.5*math.Abs(proj)) * epsilon
 	return proj, bound
 }
 
@@ -277,7 +281,7 @@ func compareEdges(a0, a1, b0, b1 Point) bool {
 }
 
 // intersectionStable returns the intersection point of the edges (a0,a1) and
-// (b0,b1) if it can be computed to within an error of at most intersectionError
+// (b0,b1) if it can be computed to within an error of at most IntersectionError
 // by this function.
 //
 // The intersection point is not guaranteed to have the correct sign because we
@@ -340,7 +344,7 @@ func intersectionStableSorted(a0, a1, b0, b1 Point) (Point, bool) {
 	// Finally we normalize the result, compute the corresponding error, and
 	// check whether the total error is acceptable.
 	xLen := x.Norm()
-	maxError := intersectionError
+	maxError := IntersectionError
 	if err > (float64(maxError)-epsilon)*xLen {
 		return pt, false
 	}
@@ -365,8 +369,8 @@ func intersectionExact(a0, a1, b0, b1 Point) Point {
 	xP := aNormP.Cross(bNormP)
 
 	// The final Normalize() call is done in double precision, which creates a
-	// directional error of up to 2*dblError. (Precise conversion and Normalize()
-	// each contribute up to dblError of directional error.)
+	// directional error of up to 2*DblError. (Precise conversion and Normalize()
+	// each contribute up to DblError of directional error.)
 	x := xP.Vector()
 
 	if x == (r3.Vector{}) {
@@ -33,7 +33,7 @@ func testIntersectionExact(a0, a1, b0, b1 Point) Point {
 	return x
 }
 
-var distanceAbsError = s1.Angle(3 * dblEpsilon)
+var distanceAbsError = s1.Angle(3 * DblEpsilon)
 
 func TestEdgeutilIntersectionError(t *testing.T) {
 	// We repeatedly construct two edges that cross near a random point "p", and
@@ -83,12 +83,12 @@ func TestEdgeutilIntersectionError(t *testing.T) {
 			}
 		}
 
-		// Each constructed edge should be at most 1.5 * dblEpsilon away from the
+		// Each constructed edge should be at most 1.5 * DblEpsilon away from the
 		// original point P.
-		if got, want := DistanceFromSegment(p, a, b), s1.Angle(1.5*dblEpsilon)+distanceAbsError; got > want {
+		if got, want := DistanceFromSegment(p, a, b), s1.Angle(1.5*DblEpsilon)+distanceAbsError; got > want {
 			t.Errorf("DistanceFromSegment(%v, %v, %v) = %v, want %v", p, a, b, got, want)
 		}
-		if got, want := DistanceFromSegment(p, c, d), s1.Angle(1.5*dblEpsilon)+distanceAbsError; got > want {
+		if got, want := DistanceFromSegment(p, c, d), s1.Angle(1.5*DblEpsilon)+distanceAbsError; got > want {
 			t.Errorf("DistanceFromSegment(%v, %v, %v) = %v, want %v", p, c, d, got, want)
 		}
 
@@ -96,13 +96,13 @@ func TestEdgeutilIntersectionError(t *testing.T) {
 		// also close to the original point P. (It might not be very close to P
 		// if the angle between the edges is very small.)
 		expected := testIntersectionExact(a, b, c, d)
-		if got, want := DistanceFromSegment(expected, a, b), s1.Angle(3*dblEpsilon)+distanceAbsError; got > want {
+		if got, want := DistanceFromSegment(expected, a, b), s1.Angle(3*DblEpsilon)+distanceAbsError; got > want {
 			t.Errorf("DistanceFromSegment(%v, %v, %v) = %v, want %v", expected, a, b, got, want)
 		}
-		if got, want := DistanceFromSegment(expected, c, d), s1.Angle(3*dblEpsilon)+distanceAbsError; got > want {
+		if got, want := DistanceFromSegment(expected, c, d), s1.Angle(3*DblEpsilon)+distanceAbsError; got > want {
 			t.Errorf("DistanceFromSegment(%v, %v, %v) = %v, want %v", expected, c, d, got, want)
 		}
-		if got, want := expected.Distance(p), s1.Angle(3*dblEpsilon/slope)+intersectionError; got > want {
+		if got, want := expected.Distance(p), s1.Angle(3*DblEpsilon/slope)+IntersectionError; got > want {
 			t.Errorf("%v.Distance(%v) = %v, want %v", expected, p, got, want)
 		}
 
@@ -111,13 +111,13 @@ func TestEdgeutilIntersectionError(t *testing.T) {
 		distAB := DistanceFromSegment(actual, a, b)
 		distCD := DistanceFromSegment(actual, c, d)
 		pointDist := expected.Distance(actual)
-		if got, want := distAB, intersectionError+distanceAbsError; got > want {
+		if got, want := distAB, IntersectionError+distanceAbsError; got > want {
 			t.Errorf("DistanceFromSegment(%v, %v, %v) = %v want <= %v", actual, a, b, got, want)
 		}
-		if got, want := distCD, intersectionError+distanceAbsError; got > want {
+		if got, want := distCD, IntersectionError+distanceAbsError; got > want {
 			t.Errorf("DistanceFromSegment(%v, %v, %v) = %v want <= %v", actual, c, d, got, want)
 		}
-		if got, want := pointDist, intersectionError; got > want {
+		if got, want := pointDist, IntersectionError; got > want {
 			t.Errorf("%v.Distance(%v) = %v want <= %v", expected, actual, got, want)
 		}
 		maxEdgeDist = maxAngle(maxEdgeDist, maxAngle(distAB, distCD))
@@ -94,7 +94,12 @@ func UpdateMinInteriorDistance(x, a, b Point, minDist s1.ChordAngle) (s1.ChordAn
 //
 // This requires that all points are unit length.
 func Project(x, a, b Point) Point {
-	aXb := a.PointCross(b)
+	return projectWithCross(x, a, b, a.PointCross(b))
+}
+
+// projectWithCross is the same as Project, but takes a precomputed value
+// of the (not necessarily unit length) robust cross product of A and B.
+func projectWithCross(x, a, b, aXb Point) Point {
 	// Find the closest point to X along the great circle through AB.
 	p := x.Sub(aXb.Mul(x.Dot(aXb.Vector) / aXb.Vector.Norm2()))
 
@@ -156,19 +161,139 @@ func InterpolateAtDistance(ax s1.Angle, a, b Point) Point {
 	return Point{(a.Mul(math.Cos(aRad)).Add(tangent.Mul(math.Sin(aRad) / tangent.Norm()))).Normalize()}
 }
 
-// minUpdateDistanceMaxError returns the maximum error in the result of
+// InterpolateAtChordAngle is like InterpolateAtDistance, but takes the
+// distance as a ChordAngle, which avoids converting it to an Angle when it
+// comes from other ChordAngle computations. The distance must not be
+// negative or infinite. Like all ChordAngles, distances close to π are
+// represented with less accuracy.
+func InterpolateAtChordAngle(ax s1.ChordAngle, a, b Point) Point {
+	normal := a.PointCross(b)
+	tangent := normal.Vector.Cross(a.Vector)
+	return Point{(a.Mul(ax.Cos()).Add(tangent.Mul(ax.Sin() / tangent.Norm()))).Normalize()}
+}
+
+// EdgeChordAngles returns the length of each edge of the chain of vertices
+// as a ChordAngle, which is cheaper than an Angle to compute and compare.
+// Lengths can be accumulated with ChordAngle.Add as long as the total stays
+// below π.
+func EdgeChordAngles(vertices []Point) []s1.ChordAngle {
+	if len(vertices) < 2 {
+		return nil
+	}
+	lengths := make([]s1.ChordAngle, len(vertices)-1)
+	for i := range lengths {
+		lengths[i] = ChordAngleBetweenPoints(vertices[i], vertices[i+1])
+	}
+	return lengths
+}
+
+// PointOnRayPerpendicularError is the maximum error in the result of
+// PointOnRay when its direction is exactly perpendicular to its origin,
+// and bounds the error of PointToLeft and PointToRight.
+const PointOnRayPerpendicularError = s1.Angle(3 * DblEpsilon)
+
+// PointOnLineError is the maximum error in the result of PointOnLine.
+const PointOnLineError = s1.Angle(6 * DblEpsilon)
+
+// PointOnRay returns the point at distance r from origin along the great
+// circle through origin in the direction dir, which must be perpendicular
+// to origin. The error is at most PointOnRayPerpendicularError plus the
+// angle by which dir differs from being perpendicular. Unlike
+// InterpolateAtDistance, the distance may be negative or larger than π.
+func PointOnRay(origin, dir Point, r s1.Angle) Point {
+	return Point{origin.Mul(math.Cos(r.Radians())).Add(dir.Mul(math.Sin(r.Radians()))).Normalize()}
+}
+
+// PointOnLine returns the point at distance r from A along the great circle
+// from A towards B, with an error of at most PointOnLineError. A and B
+// must be distinct, but may be antipodal, in which case the direction is
+// arbitrary.
+func PointOnLine(a, b Point, r s1.Angle) Point {
+	dir := Point{a.PointCross(b).Cross(a.Vector).Normalize()}
+	return PointOnRay(a, dir, r)
+}
+
+// PointOnRayAtChordAngle is like PointOnRay, but takes the distance as a
+// ChordAngle, which must not be negative or infinite.
+func PointOnRayAtChordAngle(origin, dir Point, r s1.ChordAngle) Point {
+	return Point{origin.Mul(r.Cos()).Add(dir.Mul(r.Sin())).Normalize()}
+}
+
+// PointOnLineAtChordAngle is like PointOnLine, but takes the distance as a
+// ChordAngle, which must not be negative or infinite.
+func PointOnLineAtChordAngle(a, b Point, r s1.ChordAngle) Point {
+	dir := Point{a.PointCross(b).Cross(a.Vector).Normalize()}
+	return PointOnRayAtChordAngle(a, dir, r)
+}
+
+// PointToLeft returns the point at distance r from A, in the direction
+// perpendicular to the edge AB and to its left. This is useful for offset
+// geometry such as arrowheads or labels placed beside an edge.
+func PointToLeft(a, b Point, r s1.Angle) Point {
+	return PointOnRay(a, Point{a.PointCross(b).Normalize()}, r)
+}
+
+// PointToRight returns the point at distance r from A, in the direction
+// perpendicular to the edge AB and to its right.
+func PointToRight(a, b Point, r s1.Angle) Point {
+	return PointOnRay(a, Point{b.PointCross(a).Normalize()}, r)
+}
+
+// Midpoint returns the point halfway between A and B along the shortest
+// arc between them. If A and B are antipodal, the result is an arbitrary
+// point at distance π/2 from both.
+func Midpoint(a, b Point) Point {
+	// Normalizing the sum is the most accurate method unless the points are
+	// nearly antipodal, where the sum suffers from cancellation.
+	if a.Dot(b.Vector) > -0.5 {
+		return Point{a.Add(b.Vector).Normalize()}
+	}
+	return InterpolateAtDistance(0.5*a.Distance(b), a, b)
+}
+
+// PerpendicularBisector returns the unit normal of the great circle whose
+// points are equidistant from A and B. Points X with X.Dot(normal) > 0 are
+// closer to A than to B. The difference A-B is used rather than a cross
+// product, so the result is accurate even when A and B are very close
+// together. If A and B are equal, the result is the normal of an arbitrary
+// great circle through A.
+func PerpendicularBisector(a, b Point) Point {
+	if a == b {
+		return Point{a.Ortho()}
+	}
+	// A-B is only exactly perpendicular to A+B if A and B have exactly the
+	// same length, so remove the component along A+B, which otherwise
+	// dominates when A and B are very close. When they are far apart A+B is
+	// itself inaccurate, but the correction is not needed.
+	d := a.Sub(b.Vector)
+	if a.Dot(b.Vector) > 0 {
+		m := a.Add(b.Vector)
+		d = d.Sub(m.Mul(d.Dot(m) / m.Norm2()))
+	}
+	return Point{d.Normalize()}
+}
+
+// ReflectAcross returns the reflection of the point X across the great
+// circle with the given normal, which need not be unit length. The result is
+// at the same distance from the great circle as X, on the other side.
+func ReflectAcross(x, normal Point) Point {
+	n := normal.Vector
+	return Point{x.Sub(n.Mul(2 * x.Dot(n) / n.Norm2())).Normalize()}
+}
+
+// MinUpdateDistanceMaxError returns the maximum error in the result of
 // UpdateMinDistance (and the associated functions such as
 // UpdateMinInteriorDistance, IsDistanceLess, etc), assuming that all
 // input points are normalized to within the bounds guaranteed by r3.Vector's
 // Normalize. The error can be added or subtracted from an s1.ChordAngle
 // using its Expanded method.
-func minUpdateDistanceMaxError(dist s1.ChordAngle) float64 {
+func MinUpdateDistanceMaxError(dist s1.ChordAngle) float64 {
 	// There are two cases for the maximum error in UpdateMinDistance(),
 	// depending on whether the closest point is interior to the edge.
-	return math.Max(minUpdateInteriorDistanceMaxError(dist), dist.MaxPointError())
+	return math.Max(MinUpdateInteriorDistanceMaxError(dist), dist.MaxPointError())
 }
 
-// minUpdateInteriorDistanceMaxError returns the maximum error in the result of
+// MinUpdateInteriorDistanceMaxError returns the maximum error in the result of
 // UpdateMinInteriorDistance, assuming that all input points are normalized
 // to within the bounds guaranteed by Point's Normalize. The error can be added
 // or subtracted from an s1.ChordAngle using its Expanded method.
@@ -184,7 +309,7 @@ func minUpdateDistanceMaxError(dist s1.ChordAngle) float64 {
 // TODO(roberts): Currently the error bound does not hold for edges whose endpoints
 // are antipodal to within about 1e-15 radians (less than 1 micron). This could
 // be fixed by extending PointCross to use higher precision when necessary.
-func minUpdateInteriorDistanceMaxError(dist s1.ChordAngle) float64 {
+func MinUpdateInteriorDistanceMaxError(dist s1.ChordAngle) float64 {
 	// If a point is more than 90 degrees from an edge, then the minimum
 	// distance is always to one of the endpoints, not to the edge interior.
 	if dist >= s1.RightChordAngle {
@@ -198,7 +323,7 @@ func minUpdateInteriorDistanceMaxError(dist s1.ChordAngle) float64 {
 	a := math.Sqrt(b * (2 - b))
 	return ((2.5+2*math.Sqrt(3)+8.5*a)*a +
 		(2+2*math.Sqrt(3)/3+6.5*(1-b))*b +
-		(23+16/math.Sqrt(3))*dblEpsilon) * dblEpsilon
+		(23+16/math.Sqrt(3))*DblEpsilon) * DblEpsilon
 }
 
 // updateMinDistance computes the distance from a point X to a line segment AB,
@@ -252,21 +377,21 @@ func interiorDist(x, a, b Point, minDist s1.ChordAngle, alwaysUpdate bool) (s1.C
 	//
 	// There are two sources of error in the expression above (*).  The first is
 	// that points are not normalized exactly; they are only guaranteed to be
-	// within 2 * dblEpsilon of unit length.  Under the assumption that the two
+	// within 2 * DblEpsilon of unit length.  Under the assumption that the two
 	// sides of (*) are nearly equal, the total error due to normalization errors
 	// can be shown to be at most
 	//
-	//        2 * dblEpsilon * (XA^2 + XB^2 + AB^2) + 8 * dblEpsilon ^ 2 .
+	//        2 * DblEpsilon * (XA^2 + XB^2 + AB^2) + 8 * DblEpsilon ^ 2 .
 	//
 	// The other source of error is rounding of results in the calculation of (*).
-	// Each of XA^2, XB^2, AB^2 has a maximum relative error of 2.5 * dblEpsilon,
-	// plus an additional relative error of 0.5 * dblEpsilon in the final
-	// subtraction which we further bound as 0.25 * dblEpsilon * (XA^2 + XB^2 +
+	// Each of XA^2, XB^2, AB^2 has a maximum relative error of 2.5 * DblEpsilon,
+	// plus an additional relative error of 0.5 * DblEpsilon in the final
+	// subtraction which we further bound as 0.25 * DblEpsilon * (XA^2 + XB^2 +
 	// AB^2) for convenience.  This yields a final error bound of
 	//
-	//        4.75 * dblEpsilon * (XA^2 + XB^2 + AB^2) + 8 * dblEpsilon ^ 2 .
+	//        4.75 * DblEpsilon * (XA^2 + XB^2 + AB^2) + 8 * DblEpsilon ^ 2 .
 	ab2 := a.Sub(b.Vector).Norm2()
-	maxError := (4.75*dblEpsilon*(xa2+xb2+ab2) + 8*dblEpsilon*dblEpsilon)
+	maxError := (4.75*DblEpsilon*(xa2+xb2+ab2) + 8*DblEpsilon*DblEpsilon)
 	if math.Abs(xa2-xb2) >= ab2+maxError {
 		return minDist, false
 	}
@@ -299,7 +424,7 @@ func interiorDist(x, a, b Point, minDist s1.ChordAngle, alwaysUpdate bool) (s1.C
 	// test we did initially.
 	//
 	// TODO(roberts): Ensure that the errors in test are accurately reflected in the
-	// minUpdateInteriorDistanceMaxError.
+	// MinUpdateInteriorDistanceMaxError.
 	cx := c.Cross(x.Vector)
 	if a.Sub(x.Vector).Dot(cx) >= 0 || b.Sub(x.Vector).Dot(cx) <= 0 {
 		return minDist, false
@@ -406,3 +531,87 @@ func EdgePairClosestPoints(a0, a1, b0, b1 Point) (Point, Point) {
 		panic("illegal case reached")
 	}
 }
+
+// IsEdgeBNearEdgeA reports whether every point on edge B=b0b1 is no further
+// than tolerance from some point on edge A=a0a1. Equivalently, it reports
+// whether the Hausdorff distance from B to A is no greater than tolerance.
+// The tolerance must be positive and less than 90 degrees.
+func IsEdgeBNearEdgeA(a0, a1, b0, b1 Point, tolerance s1.Angle) bool {
+	// The point on edge B=b0b1 furthest from edge A=a0a1 is either b0, b1, or
+	// some interior point on B. If it is an interior point on B, then it must be
+	// one of the two points where the great circle containing B (circ(B)) is
+	// furthest from the great circle containing A (circ(A)). At these points,
+	// the distance between circ(B) and circ(A) is the angle between the planes
+	// containing them.
+	aOrtho := Point{a0.PointCross(a1).Normalize()}
+	aNearestB0 := projectWithCross(b0, a0, a1, aOrtho)
+	aNearestB1 := projectWithCross(b1, a0, a1, aOrtho)
+
+	// If aNearestB0 and aNearestB1 have opposite orientation from a0 and a1,
+	// we invert aOrtho so that it points in the same direction as
+	// aNearestB0 x aNearestB1. This helps us handle the case where A and B are
+	// oppositely oriented but otherwise might be near each other. We check
+	// orientation and invert rather than computing aNearestB0 x aNearestB1
+	// because those two points might be equal, and have an unhelpful cross
+	// product.
+	if RobustSign(aOrtho, aNearestB0, aNearestB1) == Clockwise {
+		aOrtho = Point{aOrtho.Mul(-1)}
+	}
+
+	// To check if all points on B are within tolerance of A, we first check to
+	// see if the endpoints of B are near A. If they are not, B is not near A.
+	if b0.Distance(aNearestB0) > tolerance || b1.Distance(aNearestB1) > tolerance {
+		return false
+	}
+
+	// If b0 and b1 are both within tolerance of A, we check to see if the angle
+	// between the planes containing B and A is greater than tolerance. If it is
+	// not, no point on B can be further than tolerance from A (recall that we
+	// already know that b0 and b1 are close to A, and edges are all shorter
+	// than 180 degrees). The angle between the planes containing circ(A) and
+	// circ(B) is the angle between their normal vectors.
+	bOrtho := Point{b0.PointCross(b1).Normalize()}
+	planarAngle := aOrtho.Distance(bOrtho)
+	if planarAngle <= tolerance {
+		return true
+	}
+
+	// As planarAngle approaches Pi, the projection of aOrtho onto the plane
+	// of B approaches the null vector, and normalizing it is numerically
+	// unstable. This makes it unreliable or impossible to identify pairs of
+	// points where circ(A) is furthest from circ(B). At this point in the
+	// algorithm, this can only occur for two reasons:
+	//
+	//  1.) b0 and b1 are closest to A at distinct endpoints of A, in which case
+	//      the opposite orientation of aOrtho and bOrtho means that A and B are
+	//      in opposite hemispheres and hence not close to each other.
+	//
+	//  2.) b0 and b1 are closest to A at the same endpoint of A, in which case
+	//      the orientation of aOrtho was chosen arbitrarily to be that of a0
+	//      cross a1. B must be shorter than 2*tolerance and all points in B are
+	//      close to one endpoint of A, and hence to A.
+	//
+	// The logic applies when planarAngle is robustly greater than Pi/2, but
+	// may be more computationally expensive than the logic beyond, so we choose
+	// a value close to Pi.
+	if planarAngle >= s1.Angle(math.Pi-0.01) {
+		return (b0.Distance(a0) < b0.Distance(a1)) == (b1.Distance(a0) < b1.Distance(a1))
+	}
+
+	// Finally, if either of the two points on circ(B) where circ(B) is furthest
+	// from circ(A) lie on edge B, edge B is not near edge A.
+	//
+	// The normalized projection of aOrtho onto the plane of circ(B) is one of
+	// the two points along circ(B) where it is furthest from circ(A). The other
+	// is -1 times the normalized projection.
+	furthest := Point{aOrtho.Sub(bOrtho.Mul(aOrtho.Dot(bOrtho.Vector))).Normalize()}
+	furthestInv := Point{furthest.Mul(-1)}
+
+	// A point p lies on B if you can proceed from bOrtho to b0 to p to b1 and
+	// back to bOrtho without ever turning right. We test this for furthest and
+	// furthestInv, and return true if neither point lies on B.
+	return !((RobustSign(bOrtho, b0, furthest) == CounterClockwise &&
+		RobustSign(furthest, b1, bOrtho) == CounterClockwise) ||
+		(RobustSign(bOrtho, b0, furthestInv) == CounterClockwise &&
+			RobustSign(furthestInv, b1, bOrtho) == CounterClockwise))
+}
@@ -473,19 +473,19 @@ func TestEdgeDistanceMinUpdateDistanceMaxError(t *testing.T) {
 		{math.Pi, 0},
 	}
 
-	// This checks that the error returned by minUpdateDistanceMaxError for
+	// This checks that the error returned by MinUpdateDistanceMaxError for
 	// the distance actual (measured in radians) corresponds to a distance error
 	// of less than maxErr (measured in radians).
 	//
 	// The reason for the awkward phraseology above is that the value returned by
-	// minUpdateDistanceMaxError is not a distance; it represents an error in
+	// MinUpdateDistanceMaxError is not a distance; it represents an error in
 	// the *squared* distance.
 	for _, test := range tests {
 		ca := s1.ChordAngleFromAngle(test.actual)
-		bound := ca.Expanded(minUpdateDistanceMaxError(ca)).Angle()
+		bound := ca.Expanded(MinUpdateDistanceMaxError(ca)).Angle()
 
 		if got := s1.Angle(bound.Radians()) - test.actual; got > test.maxErr {
-			t.Errorf("minUpdateDistanceMaxError(%v)-%v = %v> %v, want <=", ca, got, test.actual, test.maxErr)
+			t.Errorf("MinUpdateDistanceMaxError(%v)-%v = %v> %v, want <=", ca, got, test.actual, test.maxErr)
 		}
 	}
 }
@@ -789,3 +789,168 @@ func TestEdgeDistancesEdgePairMaxDistance(t *testing.T) {
 }
 
 // TestEdgeDistancesEdgeBNearEdgeA
+
+func TestEdgeDistancesMidpoint(t *testing.T) {
+	tests := []struct {
+		a, b Point
+	}{
+		{parsePoint("0:0"), parsePoint("0:10")},
+		{parsePoint("10:20"), parsePoint("-30:150")},
+		{parsePoint("1:1"), parsePoint("1:1")},
+		{parsePoint("0:0"), parsePoint("0:179.9999")},
+		{parsePoint("0:0"), Point{parsePoint("0:0").Mul(-1)}},
+	}
+	for _, test := range tests {
+		m := Midpoint(test.a, test.b)
+		da, db := m.Distance(test.a), m.Distance(test.b)
+		if math.Abs(float64(da-db)) > 1e-14 || math.Abs(float64(da+db-test.a.Distance(test.b))) > 1e-14 {
+			t.Errorf("Midpoint(%v, %v) = %v at distances %v and %v", test.a, test.b, m, da, db)
+		}
+		if !m.IsUnit() {
+			t.Errorf("Midpoint(%v, %v) = %v is not unit length", test.a, test.b, m)
+		}
+	}
+}
+
+func TestEdgeDistancesPerpendicularBisector(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		a := randomPoint()
+		b := Point{a.Add(randomPoint().Mul(1e-10)).Normalize()}
+		if oneIn(2) {
+			b = randomPoint()
+		}
+		n := PerpendicularBisector(a, b)
+		if !n.IsUnit() {
+			t.Errorf("PerpendicularBisector(%v, %v) = %v is not unit length", a, b, n)
+		}
+		if a.Dot(n.Vector) <= 0 || b.Dot(n.Vector) >= 0 {
+			t.Errorf("PerpendicularBisector(%v, %v) = %v does not separate the points", a, b, n)
+		}
+		if d := Midpoint(a, b).Dot(n.Vector); math.Abs(d) > 1e-14 {
+			t.Errorf("the midpoint of %v and %v is %v from the bisector %v", a, b, d, n)
+		}
+
+		// Reflecting across the bisector swaps the points.
+		if got := ReflectAcross(a, n); !got.approxEqual(b, 1e-14) {
+			t.Errorf("ReflectAcross(%v, %v) = %v, want %v", a, n, got, b)
+		}
+		x := randomPoint()
+		if got := ReflectAcross(ReflectAcross(x, Point{n.Mul(3)}), n); !got.approxEqual(x, 1e-14) {
+			t.Errorf("reflecting %v twice gives %v", x, got)
+		}
+	}
+	if a := parsePoint("3:4"); PerpendicularBisector(a, a).Dot(a.Vector) > 1e-15 {
+		t.Errorf("PerpendicularBisector of equal points should be a great circle through them")
+	}
+}
+
+func TestEdgeDistancesPointOnLine(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		a := randomPoint()
+		b := randomPoint()
+		if oneIn(2) {
+			// Nearby points, where the direction is harder to compute.
+			b = Point{a.Add(b.Mul(1e-12)).Normalize()}
+		}
+		r := s1.Angle(math.Pi * randomFloat64())
+		normal := Point{a.PointCross(b).Normalize()}
+
+		p := PointOnLine(a, b, r)
+		if d := math.Abs(float64(p.Distance(a) - r)); d > float64(PointOnLineError)+1e-15*float64(r) {
+			t.Errorf("PointOnLine(%v, %v, %v) is %v from A, error %v", a, b, r, p.Distance(a), d)
+		}
+		if d := math.Abs(p.Dot(normal.Vector)); d > float64(PointOnLineError) {
+			t.Errorf("PointOnLine(%v, %v, %v) is %v from the great circle", a, b, r, d)
+		}
+		if r < math.Pi/2 && p.Distance(b) > a.Distance(b)+r {
+			t.Errorf("PointOnLine(%v, %v, %v) goes away from B", a, b, r)
+		}
+
+		left, right := PointToLeft(a, b, r), PointToRight(a, b, r)
+		if r > 1e-10 && (!Sign(a, b, left) || !Sign(b, a, right)) {
+			t.Errorf("PointToLeft/PointToRight(%v, %v, %v) are on the wrong sides", a, b, r)
+		}
+		for _, q := range []Point{left, right} {
+			if d := math.Abs(float64(q.Distance(a) - r)); d > float64(PointOnRayPerpendicularError)+1e-15*float64(r) {
+				t.Errorf("offset point %v is %v from A, want %v", q, q.Distance(a), r)
+			}
+		}
+	}
+}
+
+func TestEdgeDistancesChordAngleVariants(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		a, b := randomPoint(), randomPoint()
+		// Avoid distances close to π, which ChordAngle cannot represent
+		// accurately.
+		r := s1.Angle(0.9 * math.Pi * randomFloat64())
+		c := s1.ChordAngleFromAngle(r)
+		if got, want := InterpolateAtChordAngle(c, a, b), InterpolateAtDistance(r, a, b); !got.approxEqual(want, 1e-14) {
+			t.Errorf("InterpolateAtChordAngle(%v, %v, %v) = %v, want %v", c, a, b, got, want)
+		}
+		if got, want := PointOnLineAtChordAngle(a, b, c), PointOnLine(a, b, r); !got.approxEqual(want, 1e-14) {
+			t.Errorf("PointOnLineAtChordAngle(%v, %v, %v) = %v, want %v", a, b, c, got, want)
+		}
+	}
+
+	vertices := parsePoints("0:0, 0:10, 10:10, 10:10")
+	lengths := EdgeChordAngles(vertices)
+	if len(lengths) != 3 {
+		t.Fatalf("EdgeChordAngles(%v) has %d lengths, want 3", vertices, len(lengths))
+	}
+	var total s1.ChordAngle
+	for _, l := range lengths {
+		total = total.Add(l)
+	}
+	want := makePolyline("0:0, 0:10, 10:10").Length()
+	if got := total.Angle(); math.Abs(float64(got-want)) > 1e-14 {
+		t.Errorf("sum of EdgeChordAngles = %v, want %v", got, want)
+	}
+	if got := EdgeChordAngles(vertices[:1]); got != nil {
+		t.Errorf("EdgeChordAngles of a single vertex = %v, want nil", got)
+	}
+}
+
+func TestEdgeDistancesIsEdgeBNearEdgeA(t *testing.T) {
+	tests := []struct {
+		a, b      string
+		tolerance float64 // degrees
+		want      bool
+	}{
+		// Edge is near itself.
+		{"5:5, 10:-5", "5:5, 10:-5", 1e-6, true},
+		// Edge is near its reverse.
+		{"5:5, 10:-5", "10:-5, 5:5", 1e-6, true},
+		// Short edge is near long edge.
+		{"10:0, -10:0", "2:1, -2:1", 1.0, true},
+		// Long edges cannot be near shorter edges.
+		{"2:1, -2:1", "10:0, -10:0", 1.0, false},
+		// Orthogonal crossing edges are not near each other...
+		{"10:0, -10:0", "0:1.5, 0:-1.5", 1.0, false},
+		// ... unless all points on B are within tolerance of A.
+		{"10:0, -10:0", "0:1.5, 0:-1.5", 2.0, true},
+		// Very long edges whose endpoints are close may have interior points
+		// that are far apart, e.g. two consecutive lines of longitude.
+		{"89:1, -89:1", "89:2, -89:2", 0.5, false},
+		{"89:1, -89:1", "89:2, -89:2", 1.5, true},
+		// These arcs are nearly 180 degrees long and their endpoints are less
+		// than 1 degree apart, but their midpoints are on opposite sides of
+		// the sphere.
+		{"0:-179.75, 0:-0.25", "0:179.75, 0:0.25", 1.0, false},
+		// B is within 1 degree of A everywhere, but the southern endpoint of
+		// B is too far from A for the planar angle test to apply.
+		{"40:0, -5:0", "39:0.975, -1:0.975", 1.0, true},
+		// Same as above, but with B reversed.
+		{"40:0, -5:0", "-1:0.975, 39:0.975", 1.0, true},
+		// B is parallel to A but more than 1 degree away from it.
+		{"40:0, -5:0", "39:1.5, -1:1.5", 1.0, false},
+	}
+	for _, test := range tests {
+		a := parsePoints(test.a)
+		b := parsePoints(test.b)
+		tolerance := s1.Angle(test.tolerance) * s1.Degree
+		if got := IsEdgeBNearEdgeA(a[0], a[1], b[0], b[1], tolerance); got != test.want {
+			t.Errorf("IsEdgeBNearEdgeA(%v, %v, %v) = %v, want %v", test.a, test.b, tolerance, got, test.want)
+		}
+	}
+}
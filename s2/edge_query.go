@@ -15,6 +15,7 @@
 package s2
 
 import (
+	"context"
 	"sort"
 
 	"github.com/golang/geo/s1"
@@ -132,6 +133,9 @@ func newEdgeQueryResult(target distanceTarget) EdgeQueryResult {
 	}
 }
 
+// shapeEdgeID returns the shape and edge this result is for.
+func (e EdgeQueryResult) shapeEdgeID() ShapeEdgeID { return ShapeEdgeID{e.shapeID, e.edgeID} }
+
 // IsInterior reports if this result represents the interior of a Shape.
 func (e EdgeQueryResult) IsInterior() bool {
 	return e.shapeID >= 0 && e.edgeID < 0
@@ -202,8 +206,12 @@ type EdgeQuery struct {
 	// but it can also be updated by the algorithm (see maybeAddResult).
 	distanceLimit distance
 
-	// The current set of results of the query.
-	results []EdgeQueryResult
+	// The current set of results of the query. When both MaxResults and
+	// DistanceLimit are unbounded or MaxResults is 1, the results are
+	// collected in results; otherwise they are kept in resultSet, which
+	// retains only the best MaxResults results seen so far.
+	results   []EdgeQueryResult
+	resultSet *topKResults[EdgeQueryResult, ShapeEdgeID]
 
 	// This field is true when duplicates must be avoided explicitly. This
 	// is achieved by maintaining a separate set keyed by (shapeID, edgeID)
@@ -229,6 +237,10 @@ type EdgeQuery struct {
 	iter                *ShapeIndexIterator
 	maxDistanceCovering []CellID
 	initialCells        []CellID
+
+	// cancel reports whether the context of the current query, if any, has
+	// been canceled.
+	cancel cancelChecker
 }
 
 // NewClosestEdgeQuery returns an EdgeQuery that is used for finding the
@@ -304,6 +316,22 @@ func (e *EdgeQuery) FindEdges(target distanceTarget) []EdgeQueryResult {
 	return e.findEdges(target, e.opts)
 }
 
+// FindEdgesContext is like FindEdges, but stops searching if the given
+// context is canceled. In that case it returns the context's error along
+// with the results found so far, which satisfy the DistanceLimit but are not
+// necessarily the closest (or furthest) edges to the target. If the context
+// is already canceled, no search is done and no results are returned.
+func (e *EdgeQuery) FindEdgesContext(ctx context.Context, target distanceTarget) ([]EdgeQueryResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	e.cancel = cancelChecker{ctx: ctx}
+	results := e.findEdges(target, e.opts)
+	err := e.cancel.err
+	e.cancel = cancelChecker{}
+	return results, err
+}
+
 // Distance reports the distance to the target. If the index or target is empty,
 // returns the EdgeQuery's maximal sentinel.
 //
@@ -323,11 +351,9 @@ func (e *EdgeQuery) Distance(target distanceTarget) s1.ChordAngle {
 //
 //	query.IsDistanceLess(target, limit.Successor())
 func (e *EdgeQuery) IsDistanceLess(target distanceTarget, limit s1.ChordAngle) bool {
-	opts := e.opts
-	opts = opts.MaxResults(1).
-		DistanceLimit(limit).
-		MaxError(s1.StraightChordAngle)
-	return !e.findEdge(target, opts).IsEmpty()
+	opts := *e.opts
+	opts.DistanceLimit(limit).MaxError(s1.StraightChordAngle)
+	return !e.findEdge(target, &opts).IsEmpty()
 }
 
 // IsDistanceGreater reports if the distance to target is greater than limit.
@@ -335,7 +361,7 @@ func (e *EdgeQuery) IsDistanceLess(target distanceTarget, limit s1.ChordAngle) b
 // This method is usually much faster than Distance, since it is much
 // less work to determine whether the maximum distance is above or below a
 // threshold than it is to calculate the actual maximum distance.
-// If you wish to check if the distance is less than or equal to the limit, use:
+// If you wish to check if the distance is greater than or equal to the limit, use:
 //
 //	query.IsDistanceGreater(target, limit.Predecessor())
 func (e *EdgeQuery) IsDistanceGreater(target distanceTarget, limit s1.ChordAngle) bool {
@@ -354,13 +380,17 @@ func (e *EdgeQuery) IsDistanceGreater(target distanceTarget, limit s1.ChordAngle
 // distance is definitely greater than "snap radius", then the geometries
 // are guaranteed to not intersect after snapping.
 func (e *EdgeQuery) IsConservativeDistanceLessOrEqual(target distanceTarget, limit s1.ChordAngle) bool {
-	return e.IsDistanceLess(target, limit.Expanded(minUpdateDistanceMaxError(limit)))
+	opts := *e.opts
+	opts.ClosestConservativeDistanceLimit(limit).MaxError(s1.StraightChordAngle)
+	return !e.findEdge(target, &opts).IsEmpty()
 }
 
 // IsConservativeDistanceGreaterOrEqual reports if the distance to the target is greater
 // than or equal to the given limit with some small tolerance.
 func (e *EdgeQuery) IsConservativeDistanceGreaterOrEqual(target distanceTarget, limit s1.ChordAngle) bool {
-	return e.IsDistanceGreater(target, limit.Expanded(-minUpdateDistanceMaxError(limit)))
+	opts := *e.opts
+	opts.FurthestConservativeDistanceLimit(limit).MaxError(s1.StraightChordAngle)
+	return !e.findEdge(target, &opts).IsEmpty()
 }
 
 // findEdges returns the closest edges to the given target that satisfy the given options.
@@ -369,12 +399,20 @@ func (e *EdgeQuery) IsConservativeDistanceGreaterOrEqual(target distanceTarget,
 // entries with edgeID == -1. This indicates that the target intersects the
 // indexed polygon with the given shapeID.
 func (e *EdgeQuery) findEdges(target distanceTarget, opts *queryOptions) []EdgeQueryResult {
+	// The search uses opts as the current options. Restore the query's own
+	// options afterwards, so that the temporary options used by Distance and
+	// friends do not leak into later calls.
+	queryOpts := e.opts
 	e.findEdgesInternal(target, opts)
-	// TODO(roberts): Revisit this if there is a heap or other sorted and
-	// uniquing datastructure we can use instead of just a slice.
+	e.opts = queryOpts
+	if e.resultSet != nil {
+		e.results = e.resultSet.sorted()
+		e.resultSet = nil
+		return e.results
+	}
 	e.results = sortAndUniqueResults(e.results)
-	if len(e.results) > e.opts.maxResults {
-		e.results = e.results[:e.opts.maxResults]
+	if len(e.results) > opts.maxResults {
+		e.results = e.results[:opts.maxResults]
 	}
 	return e.results
 }
@@ -401,8 +439,10 @@ func sortAndUniqueResults(results []EdgeQueryResult) []EdgeQueryResult {
 // This is primarily to ease the usage of a number of the methods in the DistanceTargets
 // and in EdgeQuery.
 func (e *EdgeQuery) findEdge(target distanceTarget, opts *queryOptions) EdgeQueryResult {
-	opts.MaxResults(1)
-	e.findEdges(target, opts)
+	// Copy the options so that the caller's maxResults is left unchanged.
+	o := *opts
+	o.MaxResults(1)
+	e.findEdges(target, &o)
 	if len(e.results) > 0 {
 		return e.results[0]
 	}
@@ -418,6 +458,10 @@ func (e *EdgeQuery) findEdgesInternal(target distanceTarget, opts *queryOptions)
 	e.testedEdges = make(map[ShapeEdgeID]uint32)
 	e.distanceLimit = target.distance().fromChordAngle(opts.distanceLimit)
 	e.results = make([]EdgeQueryResult, 0)
+	e.resultSet = nil
+	if opts.maxResults > 1 && opts.maxResults != maxQueryResults {
+		e.resultSet = newTopKResults(opts.maxResults, EdgeQueryResult.Less, EdgeQueryResult.shapeEdgeID)
+	}
 
 	if e.distanceLimit == target.distance().zero() {
 		return
@@ -489,18 +533,30 @@ func (e *EdgeQuery) findEdgesInternal(target distanceTarget, opts *queryOptions)
 }
 
 func (e *EdgeQuery) addResult(r EdgeQueryResult) {
-	e.results = append(e.results, r)
-	if e.opts.maxResults == 1 {
+	switch {
+	case e.opts.maxResults == 1:
 		// Optimization for the common case where only the closest edge is wanted.
+		e.results = append(e.results, r)
 		e.distanceLimit = r.distance.sub(e.target.distance().fromChordAngle(e.opts.maxError))
+	case e.resultSet != nil:
+		// Once MaxResults results have been found, further results must be
+		// better than the worst one retained so far (by at least maxError).
+		e.resultSet.add(r)
+		if e.resultSet.full() {
+			e.distanceLimit = e.resultSet.worst().distance.sub(e.target.distance().fromChordAngle(e.opts.maxError))
+		}
+	default:
+		e.results = append(e.results, r)
 	}
-	// TODO(roberts): Add the other if/else cases when a different data structure
-	// is used for the results.
 }
 
 func (e *EdgeQuery) maybeAddResult(shape Shape, edgeID int32) {
-	if _, ok := e.testedEdges[ShapeEdgeID{e.index.idForShape(shape), edgeID}]; e.avoidDuplicates && !ok {
-		return
+	if e.avoidDuplicates {
+		id := ShapeEdgeID{e.index.idForShape(shape), edgeID}
+		if _, ok := e.testedEdges[id]; ok {
+			return
+		}
+		e.testedEdges[id] = 0
 	}
 	edge := shape.Edge(int(edgeID))
 	dist := e.distanceLimit
@@ -511,14 +567,18 @@ func (e *EdgeQuery) maybeAddResult(shape Shape, edgeID int32) {
 }
 
 func (e *EdgeQuery) findEdgesBruteForce() {
-	// Range over all shapes in the index. Does order matter here? if so
-	// switch to for i = 0 .. n?
-	for _, shape := range e.index.shapes {
-		// TODO(roberts): can this happen if we are only ranging over current entries?
+	// Range over all shapes in the index in order of ID. The order matters
+	// when MaxError is used, since it determines which edges are pruned, and
+	// ranging over the shapes map would make the results nondeterministic.
+	for id := int32(0); id < e.index.nextID; id++ {
+		shape := e.index.Shape(id)
 		if shape == nil {
 			continue
 		}
 		for edgeID := int32(0); edgeID < int32(shape.NumEdges()); edgeID++ {
+			if e.cancel.canceled() {
+				return
+			}
 			e.maybeAddResult(shape, edgeID)
 		}
 	}
@@ -533,7 +593,7 @@ func (e *EdgeQuery) findEdgesOptimized() {
 		// remove it before adding any new entries to the queue.
 		entry := e.queue.pop()
 
-		if !entry.distance.less(e.distanceLimit) {
+		if !entry.distance.less(e.distanceLimit) || e.cancel.canceled() {
 			e.queue.reset() // Clear any remaining entries.
 			break
 		}
@@ -720,7 +780,6 @@ func (e *EdgeQuery) initCovering() {
 			cellLast := next.clone()
 			cellLast.Prev()
 			e.addInitialRange(cellFirst, cellLast)
-			break
 		}
 
 	}
@@ -796,6 +855,20 @@ func (e *EdgeQuery) processOrEnqueue(id CellID, indexCell *ShapeIndexCell) {
 	})
 }
 
-// TODO(roberts): Remaining pieces
-// GetEdge
-// Project
+// Edge returns the edge of the given result, which must not be an interior
+// result (see EdgeQueryResult.IsInterior).
+func (e *EdgeQuery) Edge(result EdgeQueryResult) Edge {
+	return e.index.Shape(result.shapeID).Edge(int(result.edgeID))
+}
+
+// Project returns the point on the edge of the given result that is closest
+// to the given point. This is useful for finding the closest point on the
+// indexed geometry to a MinDistanceToPointTarget. If the result is interior
+// to a polygon, the point itself is returned.
+func (e *EdgeQuery) Project(point Point, result EdgeQueryResult) Point {
+	if result.edgeID < 0 {
+		return point
+	}
+	edge := e.Edge(result)
+	return Project(point, edge.V0, edge.V1)
+}
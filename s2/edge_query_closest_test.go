@@ -213,6 +213,80 @@ func TestClosestEdgeQueryTargetPolygonContainingIndexedPoints(t *testing.T) {
 	}
 }
 
+func TestClosestEdgeQueryProject(t *testing.T) {
+	index := makeShapeIndex("# 0:0, 0:10 | 2:5, 10:5 # 20:20, 20:25, 25:25, 25:20")
+	query := NewClosestEdgeQuery(index, NewClosestEdgeQueryOptions().MaxResults(1))
+
+	tests := []struct {
+		point   string
+		shapeID int32
+	}{
+		{"1:3", 0},
+		{"7:4", 1},
+		{"-1:-1", 0},
+		// Points inside a polygon project to themselves.
+		{"22:22", 2},
+	}
+	for _, test := range tests {
+		p := parsePoint(test.point)
+		results := query.FindEdges(NewMinDistanceToPointTarget(p))
+		if len(results) != 1 {
+			t.Errorf("FindEdges(%s) returned %d results, want 1", test.point, len(results))
+			continue
+		}
+		r := results[0]
+		if r.ShapeID() != test.shapeID {
+			t.Errorf("FindEdges(%s).ShapeID() = %d, want %d", test.point, r.ShapeID(), test.shapeID)
+		}
+		got := query.Project(p, r)
+		if r.IsInterior() {
+			if got != p {
+				t.Errorf("Project(%s) = %v, want the point itself", test.point, got)
+			}
+			continue
+		}
+		edge := query.Edge(r)
+		if d := DistanceFromSegment(got, edge.V0, edge.V1); d > 1e-15 {
+			t.Errorf("Project(%s) is %v from the closest edge, want 0", test.point, d)
+		}
+		if d := p.Distance(got); !float64Near(d.Radians(), r.Distance().Angle().Radians(), 1e-15) {
+			t.Errorf("Project(%s) is %v from the point, want %v", test.point, d, r.Distance().Angle())
+		}
+	}
+}
+
+func TestClosestEdgeQueryShapeIndexTarget(t *testing.T) {
+	// Index enough edges that the optimized algorithm is used, and check the
+	// k nearest edges to a ShapeIndex target against brute force.
+	index := NewShapeIndex()
+	for i := 0; i < 10; i++ {
+		center := PointFromLatLng(LatLngFromDegrees(float64(3*i), float64(4*i)))
+		index.Add(RegularLoop(center, s1.Degree, 20))
+	}
+	targetIndex := makeShapeIndex("# 1:5, 8:12 | 20:20, 21:21 #")
+
+	for _, maxResults := range []int{1, 3, 10} {
+		for _, limit := range []s1.Angle{s1.Degree, 5 * s1.Degree} {
+			opts := NewClosestEdgeQueryOptions().
+				MaxResults(maxResults).
+				DistanceLimit(s1.ChordAngleFromAngle(limit)).
+				IncludeInteriors(false)
+			target := NewMinDistanceToShapeIndexTarget(targetIndex)
+			got := NewClosestEdgeQuery(index, opts).FindEdges(target)
+			want := NewClosestEdgeQuery(index, opts.UseBruteForce(true)).FindEdges(target)
+			if len(got) != len(want) || len(want) == 0 {
+				t.Errorf("maxResults %d, limit %v: FindEdges returned %d results, want %d", maxResults, limit, len(got), len(want))
+				continue
+			}
+			for i := range got {
+				if got[i].Distance() != want[i].Distance() {
+					t.Errorf("maxResults %d, limit %v: result %d distance = %v, want %v", maxResults, limit, i, got[i].Distance(), want[i].Distance())
+				}
+			}
+		}
+	}
+}
+
 // BenchmarkEdgeQueryFindEdges encapulates the benchmarks into a more standard
 // form to cut down on repeated copy and paste with all the combinations of
 // benchmarks for EdgeQuery.
@@ -323,6 +397,130 @@ func BenchmarkEdgeQueryFindEdges(b *testing.B) {
 				centerSeparationFraction: -2.0,
 			},
 		},
+		{
+			// Test searching with a distance limit of 1% of the index radius.
+			benchCase: "ClosestToPointMaxDistPoint01",
+			opts: &edgeQueryBenchmarkOptions{
+				includeInteriors:         false,
+				targetType:               queryTypePoint,
+				numTargetEdges:           0,
+				chooseTargetFromIndex:    false,
+				radiusKm:                 1000,
+				maxDistanceFraction:      0.01,
+				maxErrorFraction:         -1,
+				targetRadiusFraction:     0.0,
+				centerSeparationFraction: -2.0,
+			},
+		},
+		{
+			// Test searching for the edges near a vertex of the index.
+			benchCase: "ClosestNearVertex",
+			opts: &edgeQueryBenchmarkOptions{
+				includeInteriors:         false,
+				targetType:               queryTypePoint,
+				numTargetEdges:           0,
+				chooseTargetFromIndex:    true,
+				radiusKm:                 1000,
+				maxDistanceFraction:      -1,
+				maxErrorFraction:         -1,
+				targetRadiusFraction:     0.0,
+				centerSeparationFraction: -2.0,
+			},
+		},
+		{
+			// Test searching for the edges near an edge of the index.
+			benchCase: "ClosestToEdgeNearEdge",
+			opts: &edgeQueryBenchmarkOptions{
+				includeInteriors:         false,
+				targetType:               queryTypeEdge,
+				numTargetEdges:           0,
+				chooseTargetFromIndex:    true,
+				radiusKm:                 1000,
+				maxDistanceFraction:      -1,
+				maxErrorFraction:         -1,
+				targetRadiusFraction:     -1.0,
+				centerSeparationFraction: -2.0,
+			},
+		},
+		{
+			// Test searching for the edges near an index cell.
+			benchCase: "ClosestToCellInIndex",
+			opts: &edgeQueryBenchmarkOptions{
+				includeInteriors:         false,
+				targetType:               queryTypeCell,
+				numTargetEdges:           0,
+				chooseTargetFromIndex:    true,
+				radiusKm:                 1000,
+				maxDistanceFraction:      -1,
+				maxErrorFraction:         -1,
+				targetRadiusFraction:     -1.0,
+				centerSeparationFraction: -2.0,
+			},
+		},
+		{
+			// Test searching from a small index that abuts the indexed
+			// geometry.
+			benchCase: "ClosestToSmallAbuttingIndex",
+			opts: &edgeQueryBenchmarkOptions{
+				includeInteriors:         false,
+				targetType:               queryTypeIndex,
+				numTargetEdges:           4,
+				chooseTargetFromIndex:    false,
+				radiusKm:                 1000,
+				maxDistanceFraction:      -1,
+				maxErrorFraction:         -1,
+				targetRadiusFraction:     0.1,
+				centerSeparationFraction: 1.1,
+			},
+		},
+		{
+			// Test searching from an index of about the same size that
+			// abuts the indexed geometry.
+			benchCase: "ClosestToSameSizeAbuttingIndex",
+			opts: &edgeQueryBenchmarkOptions{
+				includeInteriors:         false,
+				targetType:               queryTypeIndex,
+				numTargetEdges:           48,
+				chooseTargetFromIndex:    false,
+				radiusKm:                 1000,
+				maxDistanceFraction:      -1,
+				maxErrorFraction:         -1,
+				targetRadiusFraction:     1.0,
+				centerSeparationFraction: 2.0,
+			},
+		},
+		{
+			// Test searching from an index of about the same size that is
+			// contained by the indexed geometry.
+			benchCase: "ClosestToSameSizeContainedIndex",
+			opts: &edgeQueryBenchmarkOptions{
+				includeInteriors:         true,
+				targetType:               queryTypeIndex,
+				numTargetEdges:           48,
+				chooseTargetFromIndex:    false,
+				radiusKm:                 1000,
+				maxDistanceFraction:      -1,
+				maxErrorFraction:         -1,
+				targetRadiusFraction:     0.8,
+				centerSeparationFraction: 0.1,
+			},
+		},
+		{
+			// Test searching from an index of about the same size that is
+			// far from the indexed geometry.
+			benchCase: "ClosestToSameSizeDistantIndex",
+			opts: &edgeQueryBenchmarkOptions{
+				includeInteriors:         false,
+				targetType:               queryTypeIndex,
+				numTargetEdges:           48,
+				chooseTargetFromIndex:    false,
+				radiusKm:                 1000,
+				maxDistanceFraction:      -1,
+				maxErrorFraction:         -1,
+				targetRadiusFraction:     1.0,
+				centerSeparationFraction: 4.0,
+			},
+		},
 	}
 
 	for _, bench := range benchmarks {
@@ -352,19 +550,11 @@ func BenchmarkEdgeQueryFindEdges(b *testing.B) {
 // TestClosestEdgeQueryConservativeCellDistanceIsUsed) {
 //
 // Add the remaining Benchmarking cases for each generator type.
-// FindClosestMaxDistPow10
-// FindClosestNearVertex
 // FindClosestNearVertexMaxDistPow10
 // FindClosestToEdgeInterior
-// FindClosestToEdgeNearEdge
 // FindClosestToCellInterior
-// FindClosestToCellInIndex
-// FindClosestToSmallAbuttingIndex
 // FindClosestFromSmallAbuttingIndex
-// FindClosestToSameSizeAbuttingIndex
-// FindClosestToSameSizeContainedIndex
 // FindClosestToSameSizeContainingIndex
-// FindClosestToSameSizeDistantIndex
 // IsDistanceLessSameSizeDistantIndexFalse
 // IsDistanceLessSameSizeDistantIndexTrue
 // FindClosestToSmallIndexEdgeSample
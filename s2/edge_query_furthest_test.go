@@ -263,6 +263,71 @@ func TestFurthestEdgeQueryFullPolygonTarget(t *testing.T) {
 	}
 }
 
+func TestFurthestEdgeQueryDistanceEqualToLimit(t *testing.T) {
+	// Tests the behavior of IsDistanceGreater and
+	// IsConservativeDistanceGreaterOrEqual when the distance to the target
+	// exactly equals the chosen limit.
+	p0 := parsePoint("23:12")
+	p1 := parsePoint("47:11")
+	index := NewShapeIndex()
+	pv := PointVector([]Point{p0})
+	index.Add(Shape(&pv))
+	query := NewFurthestEdgeQuery(index, nil)
+
+	// Start with two antipodal points and a straight angle distance.
+	target0 := NewMaxDistanceToPointTarget(Point{p0.Mul(-1)})
+	dist0 := s1.StraightChordAngle
+	if query.IsDistanceGreater(target0, dist0) {
+		t.Errorf("query.IsDistanceGreater(%v, %v) = true, want false", target0, dist0)
+	}
+	if !query.IsDistanceGreater(target0, dist0.Predecessor()) {
+		t.Errorf("query.IsDistanceGreater(%v, %v) = false, want true", target0, dist0.Predecessor())
+	}
+	if !query.IsConservativeDistanceGreaterOrEqual(target0, dist0) {
+		t.Errorf("query.IsConservativeDistanceGreaterOrEqual(%v, %v) = false, want true", target0, dist0)
+	}
+
+	// Now try two points separated by a non-zero distance.
+	target1 := NewMaxDistanceToPointTarget(p1)
+	dist1 := ChordAngleBetweenPoints(p0, p1)
+	if query.IsDistanceGreater(target1, dist1) {
+		t.Errorf("query.IsDistanceGreater(%v, %v) = true, want false", target1, dist1)
+	}
+	if !query.IsDistanceGreater(target1, dist1.Predecessor()) {
+		t.Errorf("query.IsDistanceGreater(%v, %v) = false, want true", target1, dist1.Predecessor())
+	}
+	if !query.IsConservativeDistanceGreaterOrEqual(target1, dist1) {
+		t.Errorf("query.IsConservativeDistanceGreaterOrEqual(%v, %v) = false, want true", target1, dist1)
+	}
+}
+
+func TestFurthestEdgeQueryOptionsUnchangedByDistance(t *testing.T) {
+	// Distance and IsDistanceGreater search with temporary options, which
+	// must not replace the options used by later calls to FindEdges.
+	index := makeShapeIndex("0:1 | 0:2 | 0:3 # #")
+	query := NewFurthestEdgeQuery(index, NewFurthestEdgeQueryOptions().MaxResults(3))
+	target := NewMaxDistanceToPointTarget(parsePoint("0:4"))
+
+	if got, want := query.Distance(target).Angle().Degrees(), 3.0; !float64Near(got, want, epsilon) {
+		t.Errorf("query.Distance(%v) = %v, want %v", target, got, want)
+	}
+	if !query.IsDistanceGreater(target, s1.ChordAngleFromAngle(2.5*s1.Degree)) {
+		t.Errorf("query.IsDistanceGreater(%v, 2.5) = false, want true", target)
+	}
+	if !query.IsConservativeDistanceGreaterOrEqual(target, s1.ChordAngleFromAngle(3*s1.Degree)) {
+		t.Errorf("query.IsConservativeDistanceGreaterOrEqual(%v, 3) = false, want true", target)
+	}
+	results := query.FindEdges(target)
+	if len(results) != 3 {
+		t.Fatalf("query.FindEdges(%v) returned %d results, want 3", target, len(results))
+	}
+	for i, want := range []float64{3, 2, 1} {
+		if got := results[i].Distance().Angle().Degrees(); !float64Near(got, want, epsilon) {
+			t.Errorf("result %d distance = %v, want %v", i, got, want)
+		}
+	}
+}
+
 /*
 func TestFurthestEdgeQueryCircleEdges(t *testing.T) {
 	testEdgeQueryWithGenerator(t,
@@ -289,6 +354,5 @@ func TestFurthestEdgeQueryPointCloudEdges(t *testing.T) {
 
 // TODO(roberts): Remaining tests to implement.
 //
-// func TestFurthestEdgeQueryDistanceEqualToLimit(t *testing.T) {}
 // func TestFurthestEdgeQueryTrueDistanceGreaterThanChordAngleDistance(t *testing.T) { }
 // func TestFurthestEdgeQueryFullLaxPolygonTarget(t *testing.T) {}
@@ -68,6 +68,28 @@ func TestEdgeQueryMaxDistance(t *testing.T) {
 	}
 }
 
+func TestEdgeQueryAvoidDuplicates(t *testing.T) {
+	// Targets that use MaxError make the optimized search avoid duplicate
+	// edges explicitly, which must not discard the edges seen for the first
+	// time.
+	index := NewShapeIndex()
+	index.Add(RegularLoop(parsePoint("0:0"), s1.Degree, 100))
+	targetIndex := NewShapeIndex()
+	targetIndex.Add(RegularLoop(parsePoint("0:3"), s1.Degree, 10))
+
+	opts := NewClosestEdgeQueryOptions().MaxResults(5).MaxError(s1.ChordAngleFromAngle(1e-6 * s1.Degree))
+	got := NewClosestEdgeQuery(index, opts).FindEdges(NewMinDistanceToShapeIndexTarget(targetIndex))
+	want := NewClosestEdgeQuery(index, opts.UseBruteForce(true)).FindEdges(NewMinDistanceToShapeIndexTarget(targetIndex))
+	if len(got) != len(want) || len(want) != 5 {
+		t.Fatalf("FindEdges returned %d results, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i].Distance() != want[i].Distance() {
+			t.Errorf("FindEdges()[%d].Distance() = %v, want %v", i, got[i].Distance(), want[i].Distance())
+		}
+	}
+}
+
 func TestEdgeQuerySortAndUnique(t *testing.T) {
 	tests := []struct {
 		have []EdgeQueryResult
@@ -176,6 +198,24 @@ func TestEdgeQuerySortAndUnique(t *testing.T) {
 	}
 }
 
+func TestEdgeQueryIndexSpanningManyFaces(t *testing.T) {
+	// The top-level cells of the index covering span several faces, and the
+	// edges of the middle faces must not be skipped.
+	index := NewShapeIndex()
+	for face := 0; face < 6; face++ {
+		index.Add(RegularLoop(CellIDFromFace(face).Point(), 0.1, 50))
+	}
+	for face := 0; face < 6; face++ {
+		target := NewMinDistanceToPointTarget(RegularLoop(CellIDFromFace(face).Point(), 0.1, 50).Vertex(0))
+		opts := NewClosestEdgeQueryOptions().DistanceLimit(s1.ChordAngleFromAngle(0.01))
+		got := len(NewClosestEdgeQuery(index, opts).FindEdges(target))
+		want := len(NewClosestEdgeQuery(index, opts.UseBruteForce(true)).FindEdges(target))
+		if got != want || want == 0 {
+			t.Errorf("face %d: FindEdges returned %d edges, want %d", face, got, want)
+		}
+	}
+}
+
 // For various tests and benchmarks on the edge query code, there are a number of
 // ShapeIndex generators that can be used.
 type shapeIndexGeneratorFunc func(c Cap, numEdges int, index *ShapeIndex)
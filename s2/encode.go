@@ -16,6 +16,7 @@ package s2
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/binary"
 	"io"
 	"math"
@@ -29,6 +30,13 @@ const (
 	// encodingCompressedVersion is the current version of the
 	// compressed format.
 	encodingCompressedVersion = int8(4)
+
+	// encodingIndexedVersion marks an encoding that is followed by the
+	// ShapeIndex of the encoded object, as written by ShapeIndex.Encode, so
+	// that the index does not need to be rebuilt after decoding. The marker
+	// is an extension to the formats used by C++ and other S2 libraries,
+	// which cannot decode it.
+	encodingIndexedVersion = int8(16)
 )
 
 // encoder handles the specifics of encoding for S2 types.
@@ -46,6 +54,13 @@ func (e *encoder) writeUvarint(x uint64) {
 	_, e.err = e.w.Write(buf[:n])
 }
 
+func (e *encoder) writeBytes(b []byte) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = e.w.Write(b)
+}
+
 func (e *encoder) writeBool(x bool) {
 	if e.err != nil {
 		return
@@ -132,6 +147,30 @@ func asByteReader(r io.Reader) byteReader {
 	return bufio.NewReader(r)
 }
 
+// sliceReader is a byteReader over a byte slice. Decoders reading from it
+// can refer to its data in place rather than copying it; see readBytes.
+type sliceReader struct {
+	data []byte
+}
+
+func (r *sliceReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 && len(p) > 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func (r *sliceReader) ReadByte() (byte, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b, nil
+}
+
 type decoder struct {
 	r   byteReader // the real reader passed to Decode
 	err error
@@ -211,3 +250,55 @@ func (d *decoder) readUvarint() (x uint64) {
 	x, d.err = binary.ReadUvarint(d.r)
 	return
 }
+
+// readBytes reads the next n bytes. If the decoder reads from a sliceReader
+// the result refers to its data, which must then not be modified; otherwise
+// the buffer grows as the bytes are read, so that a corrupt length cannot
+// cause a large allocation.
+func (d *decoder) readBytes(n uint64) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if sr, ok := d.r.(*sliceReader); ok {
+		if n > uint64(len(sr.data)) {
+			d.err = io.ErrUnexpectedEOF
+			return nil
+		}
+		b := sr.data[:n:n]
+		sr.data = sr.data[n:]
+		return b
+	}
+	if n > math.MaxInt64 {
+		d.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, d.r, int64(n)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		d.err = err
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// maxDecodePrealloc is the largest number of elements that decoders allocate
+// space for before reading them. Longer sequences grow as they are read, so
+// that a corrupt or malicious count cannot cause a large allocation unless
+// the input is correspondingly large.
+const maxDecodePrealloc = 1 << 16
+
+// readPoints reads n points, each written as three float64 coordinates. If
+// an error occurs, the points read so far are returned.
+func (d *decoder) readPoints(n uint64) []Point {
+	points := make([]Point, 0, minUint64(n, maxDecodePrealloc))
+	for i := uint64(0); i < n && d.err == nil; i++ {
+		var p Point
+		p.X = d.readFloat64()
+		p.Y = d.readFloat64()
+		p.Z = d.readFloat64()
+		points = append(points, p)
+	}
+	return points
+}
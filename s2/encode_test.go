@@ -21,9 +21,11 @@ import (
 	"io"
 	"math"
 	"reflect"
+	"runtime"
 	"testing"
 
 	"github.com/golang/geo/r3"
+	"github.com/golang/geo/s1"
 )
 
 type encodableRegion interface {
@@ -311,28 +313,107 @@ func TestLoopEncodeDecode(t *testing.T) {
 
 func TestLoopEncodeDecodeFuzzed(t *testing.T) {
 	for i := 3; i < 100; i++ {
-		var pts []Point
-		for j := 0; j < i; j++ {
-			pts = append(pts, randomPoint())
-		}
-		loop := LoopFromPoints(pts)
-		if err := loop.Validate(); err != nil {
-			t.Fatalf("loop(%v).Validate: %v", loop, err)
-		}
-		polygon := PolygonFromLoops([]*Loop{loop})
+		// Randomly placed vertices almost always produce self-intersecting
+		// loops, so use a regular loop with a random center and radius.
+		radius := s1.Angle(1e-3+randomFloat64()) * s1.Radian
+		loop := RegularLoop(randomPoint(), radius, i)
 		var buf bytes.Buffer
-		if err := polygon.Encode(&buf); err != nil {
+		if err := loop.Encode(&buf); err != nil {
 			t.Fatal(err)
 		}
 		got := new(Loop)
 		if err := got.Decode(&buf); err != nil {
-			// TODO(nsch): Uncomment the next line as soon as decoding of all encoded loops works.
-			// t.Fatalf("decode(encode(%v)): %v", loop, err)
-			continue
+			t.Fatalf("decode(encode(%v)): %v", loop, err)
+		}
+
+		if !reflect.DeepEqual(got, loop) {
+			t.Errorf("decode(encode()) = %v, want %v", got, loop)
+		}
+		if err := got.Validate(); err != nil {
+			t.Errorf("decode(encode(%v)).Validate: %v", loop, err)
+		}
+	}
+}
+
+func TestLoopDecodeErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := FullLoop().Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buf.Bytes()
+
+	// Loops have no compressed standalone encoding, so any other version
+	// is rejected.
+	for _, version := range []int8{0, encodingCompressedVersion, encodingIndexedVersion} {
+		b := append([]byte{byte(version)}, encoded[1:]...)
+		if err := new(Loop).Decode(bytes.NewReader(b)); err == nil {
+			t.Errorf("Decode with version %d succeeded, want error", version)
+		}
+	}
+	for n := 0; n < len(encoded); n++ {
+		if err := new(Loop).Decode(bytes.NewReader(encoded[:n])); err == nil {
+			t.Errorf("Decode of %d of %d bytes succeeded, want error", n, len(encoded))
+		}
+	}
+}
+
+func TestPolygonEncodeWithIndex(t *testing.T) {
+	polygons := []*Polygon{
+		PolygonFromLoops(nil),
+		makePolygon("0:0, 0:10, 10:0; 1:1, 1:2, 2:1", true),
+		PolygonFromLoops([]*Loop{newFractal().makeLoop(randomFrame(), 0.1)}),
+	}
+	for _, want := range polygons {
+		var buf bytes.Buffer
+		if err := want.EncodeWithIndex(&buf); err != nil {
+			t.Fatalf("EncodeWithIndex(%v) failed: %v", want, err)
+		}
+		encoded := buf.Bytes()
+
+		// The index is written in the format of ShapeIndex.Encode.
+		var indexBuf bytes.Buffer
+		if err := want.index.Encode(&indexBuf); err != nil {
+			t.Fatalf("index.Encode() failed: %v", err)
+		}
+		if !bytes.HasSuffix(encoded, indexBuf.Bytes()) {
+			t.Errorf("EncodeWithIndex(%v) does not end with the ShapeIndex encoding", want)
 		}
 
-		if !reflect.DeepEqual(got, polygon) {
-			t.Errorf("decode(encode()) = %v, want %v", got, polygon)
+		got := new(Polygon)
+		if err := got.Decode(bytes.NewReader(encoded)); err != nil {
+			t.Fatalf("Decode(EncodeWithIndex(%v)) failed: %v", want, err)
+		}
+		if got.NumLoops() != want.NumLoops() {
+			t.Fatalf("Decode(EncodeWithIndex(%v)) has %d loops, want %d", want, got.NumLoops(), want.NumLoops())
+		}
+		for i := 0; i < want.NumLoops(); i++ {
+			if !got.Loop(i).Equal(want.Loop(i)) {
+				t.Errorf("Decode(EncodeWithIndex(%v)).Loop(%d) = %v, want %v", want, i, got.Loop(i), want.Loop(i))
+			}
+		}
+		if !got.index.IsFresh() {
+			t.Errorf("decoded polygon index should not need to be rebuilt")
+		}
+		if gotCells, wantCells := CellUnion(got.index.cells), CellUnion(want.index.cells); !gotCells.Equal(wantCells) {
+			t.Errorf("decoded index cells = %v, want %v", got.index.cells, want.index.cells)
+		}
+		for _, id := range want.index.cells {
+			if !reflect.DeepEqual(got.index.cellMap[id], want.index.cellMap[id]) {
+				t.Errorf("decoded index cell %v = %v, want %v", id, got.index.cellMap[id], want.index.cellMap[id])
+			}
+		}
+		for i := 0; i < 100; i++ {
+			p := randomPoint()
+			if got.ContainsPoint(p) != want.ContainsPoint(p) {
+				t.Errorf("decoded polygon ContainsPoint(%v) = %v, want %v", p, got.ContainsPoint(p), want.ContainsPoint(p))
+			}
+		}
+
+		// Truncated encodings fail to decode.
+		if len(encoded) > 2 {
+			if err := new(Polygon).Decode(bytes.NewReader(encoded[:len(encoded)-1])); err == nil {
+				t.Errorf("Decode of truncated encoding of %v succeeded, want error", want)
+			}
 		}
 	}
 }
@@ -354,3 +435,139 @@ func BenchmarkRectDecode(b *testing.B) {
 		}
 	}
 }
+
+func TestDecodeCorruptCounts(t *testing.T) {
+	uvarint := func(x uint64) string {
+		var b []byte
+		for ; x >= 0x80; x >>= 7 {
+			b = append(b, byte(x)|0x80)
+		}
+		return hex.EncodeToString(append(b, byte(x)))
+	}
+	// Inputs that claim many more elements than they contain.
+	tests := []struct {
+		name  string
+		value decodableRegion
+		data  string
+	}{
+		{"CellUnion with negative count", &CellUnion{}, "01FFFFFFFFFFFFFFFF"},
+		{"Loop", &Loop{}, "0180F0FA02"},
+		{"Polyline", &Polyline{}, "0180F0FA02"},
+		{"MultiPolyline", &MultiPolyline{}, "010100000080F0FA02"},
+		{"Polygon", &Polygon{}, "010100809698000180F0FA02"},
+		{"compressed Polygon", &Polygon{}, "041E" + uvarint(maxEncodedLoops) + uvarint(maxEncodedVertices) + uvarint(maxEncodedVertices*NumFaces)},
+		{"compressed Polygon with huge count", &Polygon{}, "041E" + uvarint(math.MaxUint64)},
+	}
+	for _, test := range tests {
+		b, err := hex.DecodeString(test.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		if err := test.value.Decode(bytes.NewReader(b)); err == nil {
+			t.Errorf("%s: Decode(%s) succeeded, want error", test.name, test.data)
+		}
+		runtime.ReadMemStats(&after)
+		if n := after.TotalAlloc - before.TotalAlloc; n > 1<<24 {
+			t.Errorf("%s: Decode(%s) allocated %d bytes", test.name, test.data, n)
+		}
+	}
+}
+
+// codableRegion is a type that can be both encoded and decoded.
+type codableRegion interface {
+	encodableRegion
+	decodableRegion
+}
+
+// fuzzDecode checks that decoding arbitrary data into a new value from
+// newValue never panics, and that any value that decodes successfully can
+// be encoded again. The seeds are hex encodings of valid values.
+func fuzzDecode(f *testing.F, newValue func() codableRegion, seeds ...string) {
+	for _, s := range seeds {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			f.Fatalf("invalid seed %q: %v", s, err)
+		}
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		v := newValue()
+		if err := v.Decode(bytes.NewReader(data)); err != nil {
+			return
+		}
+		if err := v.Encode(io.Discard); err != nil {
+			t.Errorf("Encode of decoded %x failed: %v", data, err)
+		}
+	})
+}
+
+// addEncodedSeeds adds the encodings of the given values to the seed corpus.
+func addEncodedSeeds(f *testing.F, values ...encodableRegion) {
+	for _, v := range values {
+		var buf bytes.Buffer
+		if err := v.Encode(&buf); err != nil {
+			f.Fatalf("Encode(%v) failed: %v", v, err)
+		}
+		f.Add(buf.Bytes())
+	}
+}
+
+func FuzzCapDecode(f *testing.F) {
+	fuzzDecode(f, func() codableRegion { return &Cap{} },
+		encodedCapEmpty, encodedCapFull, encodedCapFromPoint, encodedCapFromCenterHeight)
+}
+
+func FuzzCellIDDecode(f *testing.F) {
+	fuzzDecode(f, func() codableRegion { return new(CellID) },
+		encodedCellIDFace0, encodedCellIDFace5MaxLevel, encodedCellIDFacePosLevel, encodedCellIDInvalid)
+}
+
+func FuzzCellDecode(f *testing.F) {
+	fuzzDecode(f, func() codableRegion { return &Cell{} },
+		encodedCellFromPoint, encodedCellFromLatLng, encodedCellFace0)
+}
+
+func FuzzCellUnionDecode(f *testing.F) {
+	fuzzDecode(f, func() codableRegion { return &CellUnion{} },
+		encodedCellUnionEmpty, encodedCellUnionFace1, encodedCellUnionFromCells)
+}
+
+func FuzzLoopDecode(f *testing.F) {
+	fuzzDecode(f, func() codableRegion { return &Loop{} },
+		encodedLoopEmpty, encodedLoopFull, encodedLoopCross)
+}
+
+func FuzzPointDecode(f *testing.F) {
+	fuzzDecode(f, func() codableRegion { return &Point{} },
+		encodedPointOrigin, encodedPointTesting)
+}
+
+func FuzzPolygonDecode(f *testing.F) {
+	withIndex := makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 8:2, 8:8, 2:8", true)
+	var buf bytes.Buffer
+	if err := withIndex.EncodeWithIndex(&buf); err != nil {
+		f.Fatal(err)
+	}
+	f.Add(buf.Bytes())
+	addEncodedSeeds(f, PolygonFromLoops([]*Loop{LoopFromCell(CellFromCellID(CellIDFromFace(2).ChildBeginAtLevel(10)))}))
+	fuzzDecode(f, func() codableRegion { return &Polygon{} },
+		encodedPolygonEmpty, encodedPolygonFull, encodedPolygon1Loops, encodedPolygon2Loops)
+}
+
+func FuzzPolylineDecode(f *testing.F) {
+	fuzzDecode(f, func() codableRegion { return &Polyline{} },
+		encodedPolylineEmpty, encodedPolylineSemiEquator, encodedPolyline3Segments)
+}
+
+func FuzzMultiPolylineDecode(f *testing.F) {
+	addEncodedSeeds(f, MultiPolylineFromPoints(nil),
+		MultiPolylineFromPoints([][]Point{parsePoints("0:0, 0:10"), parsePoints("1:1"), parsePoints("1:1, 2:2, 3:3")}))
+	fuzzDecode(f, func() codableRegion { return &MultiPolyline{} })
+}
+
+func FuzzRectDecode(f *testing.F) {
+	fuzzDecode(f, func() codableRegion { return &Rect{} },
+		encodedRectEmpty, encodedRectFull, encodedRectCentersize)
+}
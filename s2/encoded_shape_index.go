@@ -0,0 +1,518 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// shapeIndexEncodingVersion is the version of the format written by
+// ShapeIndex.Encode, which is the current version of the C++
+// MutableS2ShapeIndex encoding.
+const shapeIndexEncodingVersion = 0
+
+// Encode encodes the contents of the index, building it first if necessary,
+// in the format of the C++ MutableS2ShapeIndex::Encode, so that indexes can
+// be exchanged with the C++ library. The shapes themselves are not encoded.
+// They must be encoded separately, and supplied in the order of their shape
+// IDs when the index is decoded.
+//
+// The format consists of a varint holding maxEdgesPerCell<<2 | version,
+// followed by the cell IDs and the contents of the cells, each encoded so
+// that any cell can be found and decoded without reading the others. See
+// NewEncodedShapeIndex.
+func (s *ShapeIndex) Encode(w io.Writer) error {
+	e := &encoder{w: w}
+	s.encode(e)
+	return e.err
+}
+
+// encode writes the encoding described at Encode.
+func (s *ShapeIndex) encode(e *encoder) {
+	s.maybeApplyUpdates()
+
+	n := s.numCells()
+	ids := make([]CellID, n)
+	ends := make([]int, n)
+	var data bytes.Buffer
+	ce := &encoder{w: &data}
+	for i := 0; i < n; i++ {
+		ids[i] = s.cellID(i)
+		encodeIndexCellCompact(ce, s.indexCell(i), int(s.nextID))
+		ends[i] = data.Len()
+	}
+	cells := make([][]byte, n)
+	start := 0
+	for i, end := range ends {
+		cells[i] = data.Bytes()[start:end]
+		start = end
+	}
+
+	if err := s.DecodeErr(); err != nil {
+		e.err = err
+		return
+	}
+	e.writeUvarint(uint64(s.maxEdgesPerCell)<<2 | shapeIndexEncodingVersion)
+	encodeCellIDVector(e, ids)
+	encodeStringVector(e, cells)
+}
+
+// Decode replaces the contents of the index with an encoding written by
+// Encode (or by the C++ MutableS2ShapeIndex::Encode) together with the given
+// shapes. The shape with ID i must be shapes[i]; shapes that had been removed
+// from the encoded index should be nil. Unlike NewEncodedShapeIndex, all the
+// cells are decoded and checked immediately.
+func (s *ShapeIndex) Decode(r io.Reader, shapes []Shape) error {
+	d := &decoder{r: asByteReader(r)}
+	s.decode(d, shapes)
+	return d.err
+}
+
+// decode reads the encoding described at Decode, setting d.err if it is
+// invalid, in which case the index is not modified.
+func (s *ShapeIndex) decode(d *decoder, shapes []Shape) {
+	maxEdgesPerCell, encoded := decodeEncodedCells(d, shapes)
+	if d.err != nil {
+		return
+	}
+
+	cells := make([]CellID, encoded.numCells)
+	cellMap := make(map[CellID]*ShapeIndexCell, encoded.numCells)
+	for i := range cells {
+		id := encoded.cellID(i)
+		cell, err := encoded.decodeCell(i)
+		if err != nil {
+			d.err = err
+			return
+		}
+		cells[i] = id
+		cellMap[id] = cell
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Reset()
+	s.initShapes(maxEdgesPerCell, shapes)
+	s.cells = cells
+	s.cellMap = cellMap
+}
+
+// NewEncodedShapeIndex returns a ShapeIndex whose contents are read from the
+// given encoding, as written by ShapeIndex.Encode, along with the given
+// shapes. The shape with ID i must be shapes[i]; shapes that had been removed
+// from the encoded index should be nil. Any data after the encoding is
+// ignored.
+//
+// This is the analog of the C++ EncodedS2ShapeIndex. Only the header and
+// the cell IDs are read up front, which is much cheaper than decoding the
+// cells. Each cell is decoded the first time it is visited and then kept, so
+// repeated queries over the same region are as fast as on a decoded index.
+// The data is used directly rather than copied, which makes it suitable for
+// indexes that are memory-mapped from files. The data must not be modified
+// while the index is in use.
+//
+// The returned index can be used with any query, and can be updated like
+// any other index, in which case it is fully decoded first. The cell IDs are
+// checked up front, but since the contents of the cells are only decoded
+// when they are visited, errors in them, or shapes that do not match the
+// encoding, are not detected by this function. A cell that cannot be decoded
+// is treated as empty, so queries may give incomplete results, and the error
+// is reported by DecodeErr. Use ShapeIndex.Decode to check the whole
+// encoding up front.
+func NewEncodedShapeIndex(data []byte, shapes []Shape) (*ShapeIndex, error) {
+	d := &decoder{r: &sliceReader{data: data}}
+	maxEdgesPerCell, encoded := decodeEncodedCells(d, shapes)
+	if d.err != nil {
+		return nil, d.err
+	}
+	encoded.cache = make([]atomic.Value, encoded.numCells)
+	s := NewShapeIndex()
+	s.initShapes(maxEdgesPerCell, shapes)
+	s.encoded = encoded
+	return s, nil
+}
+
+// initShapes sets the options and shapes of an empty index that is being
+// decoded. The index must not have pending updates.
+func (s *ShapeIndex) initShapes(maxEdgesPerCell int, shapes []Shape) {
+	s.maxEdgesPerCell = maxEdgesPerCell
+	for id, shape := range shapes {
+		if shape != nil {
+			s.shapes[int32(id)] = shape
+		}
+	}
+	s.nextID = int32(len(shapes))
+	s.pendingAdditionsPos = s.nextID
+}
+
+// DecodeErr returns the first error found when decoding a cell of an index
+// created by NewEncodedShapeIndex, or nil if there was none. Cells are decoded
+// when they are visited, so the error is only known after a query or update
+// has visited the invalid cell.
+func (s *ShapeIndex) DecodeErr() error {
+	s.decodeErrMu.Lock()
+	defer s.decodeErrMu.Unlock()
+	return s.decodeErr
+}
+
+// encodedIndexCell returns the contents of the i-th encoded cell, decoding
+// them on first use. If the encoding is invalid, it records the error for
+// DecodeErr and returns an empty cell, which is not kept.
+func (s *ShapeIndex) encodedIndexCell(i int) *ShapeIndexCell {
+	if cell, ok := s.encoded.cache[i].Load().(*ShapeIndexCell); ok {
+		return cell
+	}
+	cell, err := s.encoded.decodeCell(i)
+	if err == nil {
+		// Concurrent queries may decode the same cell; keep the first
+		// result so that they all see the same cell.
+		s.encoded.cache[i].CompareAndSwap(nil, cell)
+		return s.encoded.cache[i].Load().(*ShapeIndexCell)
+	}
+	s.decodeErrMu.Lock()
+	if s.decodeErr == nil {
+		s.decodeErr = fmt.Errorf("invalid encoded index cell %v: %v", s.encoded.cellID(i), err)
+	}
+	s.decodeErrMu.Unlock()
+	return NewShapeIndexCell(0)
+}
+
+// encodedCells provides access to the cells of an encoded index, which are
+// decoded on demand.
+type encodedCells struct {
+	numCells int
+	ids      cellIDVector
+	cells    stringVector
+	shapes   []Shape
+
+	// cache holds the *ShapeIndexCell of each cell that has been decoded.
+	// atomic.Value is used so that queries can run concurrently.
+	cache []atomic.Value
+}
+
+// decodeEncodedCells reads the header of an index encoded by
+// ShapeIndex.Encode, and returns its maxEdgesPerCell and cells. It checks
+// that the cell IDs are valid and sorted, but does not decode the cells.
+func decodeEncodedCells(d *decoder, shapes []Shape) (int, *encodedCells) {
+	maxEdgesVersion := d.readUvarint()
+	if d.err != nil {
+		return 0, nil
+	}
+	if version := maxEdgesVersion & 3; version != shapeIndexEncodingVersion {
+		d.err = fmt.Errorf("unsupported version %d", version)
+		return 0, nil
+	}
+	ids := decodeCellIDVector(d)
+	cells := decodeStringVector(d)
+	if d.err != nil {
+		return 0, nil
+	}
+	if ids.size() != cells.size() {
+		d.err = fmt.Errorf("encoded index has %d cell IDs but %d cells", ids.size(), cells.size())
+		return 0, nil
+	}
+	for i, prev := 0, CellID(0); i < ids.size(); i++ {
+		id := ids.get(i)
+		if !id.IsValid() || (i > 0 && prev.RangeMax() >= id.RangeMin()) {
+			d.err = fmt.Errorf("invalid or unsorted index cell %v", id)
+			return 0, nil
+		}
+		prev = id
+	}
+	return int(maxEdgesVersion >> 2), &encodedCells{
+		numCells: ids.size(),
+		ids:      ids,
+		cells:    cells,
+		shapes:   shapes,
+	}
+}
+
+// cellID returns the ID of the i-th cell.
+func (e *encodedCells) cellID(i int) CellID {
+	return e.ids.get(i)
+}
+
+// decodeCell decodes the contents of the i-th cell, checking them against
+// the shapes of the index.
+func (e *encodedCells) decodeCell(i int) (*ShapeIndexCell, error) {
+	data, err := e.cells.get(i)
+	if err != nil {
+		return nil, err
+	}
+	d := &decoder{r: &sliceReader{data: data}}
+	cell := decodeIndexCellCompact(d, e.cellID(i), e.shapes)
+	return cell, d.err
+}
+
+// decodeAllCells replaces the encoded cells of the index with their decoded
+// contents, so that the index can be updated.
+func (s *ShapeIndex) decodeAllCells() {
+	n := s.encoded.numCells
+	s.cells = make([]CellID, n)
+	s.cellMap = make(map[CellID]*ShapeIndexCell, n)
+	for i := 0; i < n; i++ {
+		id := s.encoded.cellID(i)
+		s.cells[i] = id
+		s.cellMap[id] = s.indexCell(i)
+	}
+	s.encoded = nil
+}
+
+// encodeIndexCellCompact encodes the clipped shapes of the given index cell
+// in the format of the C++ S2ShapeIndexCell::Encode, where numShapeIDs is
+// the number of shape IDs in the index. The format is designed to be
+// especially compact when the index has only one shape, when the cell has
+// only one shape, and when the edges of a shape in the cell are contiguous.
+func encodeIndexCellCompact(e *encoder, cell *ShapeIndexCell, numShapeIDs int) {
+	if numShapeIDs == 1 {
+		// Shape IDs are not needed, and every cell has exactly one shape.
+		clipped := cell.shapes[0]
+		n := len(clipped.edges)
+		center := boolToUint64(clipped.containsCenter)
+		switch {
+		case n >= 2 && n <= 17 && clipped.edges[n-1]-clipped.edges[0] == n-1:
+			// A contiguous range of edges, the most common case.
+			// Bit 0 is 0, bit 1 is containsCenter, bits 2-5 are
+			// (numEdges - 2) and bits 6+ are the first edge ID.
+			e.writeUvarint(uint64(clipped.edges[0])<<6 | uint64(n-2)<<2 | center<<1)
+		case n == 1:
+			// Bits 0-1 are 1, bit 2 is containsCenter and bits 3+ are
+			// the edge ID.
+			e.writeUvarint(uint64(clipped.edges[0])<<3 | center<<2 | 1)
+		default:
+			// Bits 0-1 are 3, bit 2 is containsCenter and bits 3+ are
+			// the number of edges, followed by the edges.
+			e.writeUvarint(uint64(n)<<3 | center<<2 | 3)
+			encodeClippedEdges(e, clipped.edges)
+		}
+		return
+	}
+
+	if len(cell.shapes) > 1 {
+		// This tag is distinct from those of the cases below.
+		e.writeUvarint(uint64(len(cell.shapes))<<3 | 3)
+	}
+	// Shape IDs are delta encoded.
+	shapeIDBase := int32(0)
+	for _, clipped := range cell.shapes {
+		delta := uint64(clipped.shapeID - shapeIDBase)
+		shapeIDBase = clipped.shapeID + 1
+		n := len(clipped.edges)
+		center := boolToUint64(clipped.containsCenter)
+		switch {
+		case n >= 1 && n <= 16 && clipped.edges[n-1]-clipped.edges[0] == n-1:
+			// A contiguous range of edges. Bit 0 is 0, bit 1 is
+			// containsCenter and bits 2+ are the first edge ID. In the
+			// next value bits 0-3 are (numEdges - 1) and bits 4+ are
+			// the shape ID delta.
+			e.writeUvarint(uint64(clipped.edges[0])<<2 | center<<1)
+			e.writeUvarint(delta<<4 | uint64(n-1))
+		case n == 0:
+			// Bits 0-2 are 7, bit 3 is containsCenter and bits 4+ are
+			// the shape ID delta.
+			e.writeUvarint(delta<<4 | center<<3 | 7)
+		default:
+			// Bits 0-1 are 1, bit 2 is containsCenter and bits 3+ are
+			// the shape ID delta, followed by the number of edges and
+			// the edges.
+			e.writeUvarint(delta<<3 | center<<2 | 1)
+			e.writeUvarint(uint64(n))
+			encodeClippedEdges(e, clipped.edges)
+		}
+	}
+}
+
+// encodeClippedEdges encodes the given sorted edge IDs as a sequence of
+// contiguous ranges, each starting at a delta from the end of the previous
+// one. The low 3 bits of each value hold (count - 1) and the higher bits the
+// delta, except that counts of 8 or more are written as (count - 8)<<3 | 7
+// followed by the delta. A single edge at the end is written as just its
+// delta.
+func encodeClippedEdges(e *encoder, edges []int) {
+	base := 0
+	for i := 0; i < len(edges); i++ {
+		edge := edges[i]
+		delta := uint64(edge - base)
+		if i+1 == len(edges) {
+			e.writeUvarint(delta)
+			break
+		}
+		count := 1
+		for ; i+1 < len(edges) && edges[i+1] == edge+count; i++ {
+			count++
+		}
+		if count < 8 {
+			e.writeUvarint(delta<<3 | uint64(count-1))
+		} else {
+			e.writeUvarint(uint64(count-8)<<3 | 7)
+			e.writeUvarint(delta)
+		}
+		base = edge + count
+	}
+}
+
+// decodeIndexCellCompact decodes an index cell with the given ID written by
+// encodeIndexCellCompact for an index with the given shapes, checking the
+// clipped shapes against them. It returns nil and sets d.err if the
+// encoding is invalid.
+func decodeIndexCellCompact(d *decoder, id CellID, shapes []Shape) *ShapeIndexCell {
+	cell := NewShapeIndexCell(0)
+	if len(shapes) == 1 {
+		header := d.readUvarint()
+		var clipped *clippedShape
+		switch {
+		case d.err != nil:
+		case header&1 == 0:
+			clipped = newDecodedClippedShape(d, id, shapes, 0, header>>2&15+2)
+			setContiguousEdges(d, id, shapes, clipped, header>>6)
+			clipped.containsCenter = header&2 != 0
+		case header&2 == 0:
+			clipped = newDecodedClippedShape(d, id, shapes, 0, 1)
+			setContiguousEdges(d, id, shapes, clipped, header>>3)
+			clipped.containsCenter = header&4 != 0
+		default:
+			clipped = newDecodedClippedShape(d, id, shapes, 0, header>>3)
+			decodeClippedEdges(d, id, shapes, clipped)
+			clipped.containsCenter = header&4 != 0
+		}
+		if d.err != nil {
+			return nil
+		}
+		cell.add(clipped)
+		return cell
+	}
+
+	header := d.readUvarint()
+	numClipped := uint64(1)
+	if header&7 == 3 {
+		numClipped = header >> 3
+		header = d.readUvarint()
+	}
+	if d.err == nil && numClipped > uint64(len(shapes)) {
+		d.err = fmt.Errorf("index cell %v has %d shapes, but the index has only %d", id, numClipped, len(shapes))
+	}
+	shapeID := uint64(0)
+	for j := uint64(0); j < numClipped && d.err == nil; j, shapeID = j+1, shapeID+1 {
+		if j > 0 {
+			header = d.readUvarint()
+		}
+		var clipped *clippedShape
+		switch {
+		case d.err != nil:
+			return nil
+		case header&1 == 0:
+			v := d.readUvarint()
+			shapeID += v >> 4
+			clipped = newDecodedClippedShape(d, id, shapes, shapeID, v&15+1)
+			setContiguousEdges(d, id, shapes, clipped, header>>2)
+			clipped.containsCenter = header&2 != 0
+		case header&7 == 7:
+			shapeID += header >> 4
+			clipped = newDecodedClippedShape(d, id, shapes, shapeID, 0)
+			clipped.containsCenter = header&8 != 0
+		default:
+			n := d.readUvarint()
+			shapeID += header >> 3
+			clipped = newDecodedClippedShape(d, id, shapes, shapeID, n)
+			decodeClippedEdges(d, id, shapes, clipped)
+			clipped.containsCenter = header&4 != 0
+		}
+		cell.add(clipped)
+	}
+	if d.err != nil {
+		return nil
+	}
+	return cell
+}
+
+// newDecodedClippedShape returns a clipped shape with the given shape ID and
+// number of edges, after checking them against the given shapes. If they are
+// invalid it sets d.err, and returns a clipped shape with no edges so that
+// the caller can continue without checking for errors.
+func newDecodedClippedShape(d *decoder, id CellID, shapes []Shape, shapeID, numEdges uint64) *clippedShape {
+	if d.err != nil {
+		return newClippedShape(0, 0)
+	}
+	if shapeID >= uint64(len(shapes)) || shapes[shapeID] == nil {
+		d.err = fmt.Errorf("index cell %v refers to unknown shape %d", id, shapeID)
+		return newClippedShape(0, 0)
+	}
+	if shapeEdges := shapes[shapeID].NumEdges(); numEdges > uint64(shapeEdges) {
+		d.err = fmt.Errorf("index cell %v has %d edges of shape %d, which has only %d", id, numEdges, shapeID, shapeEdges)
+		return newClippedShape(0, 0)
+	}
+	return newClippedShape(int32(shapeID), int(numEdges))
+}
+
+// setContiguousEdges sets the edges of the clipped shape to the range
+// starting at the given edge ID, checking that they belong to its shape.
+func setContiguousEdges(d *decoder, id CellID, shapes []Shape, clipped *clippedShape, start uint64) {
+	if d.err != nil || len(clipped.edges) == 0 {
+		return
+	}
+	if end := start + uint64(len(clipped.edges)); end > uint64(shapes[clipped.shapeID].NumEdges()) || end < start {
+		d.err = fmt.Errorf("index cell %v refers to edges [%d, %d) of shape %d, which has only %d edges",
+			id, start, end, clipped.shapeID, shapes[clipped.shapeID].NumEdges())
+		return
+	}
+	for i := range clipped.edges {
+		clipped.edges[i] = int(start) + i
+	}
+}
+
+// decodeClippedEdges reads the edges of the clipped shape written by
+// encodeClippedEdges, checking that they belong to its shape.
+func decodeClippedEdges(d *decoder, id CellID, shapes []Shape, clipped *clippedShape) {
+	numEdges := uint64(shapes[clipped.shapeID].NumEdges())
+	edge := uint64(0)
+	for i := 0; i < len(clipped.edges) && d.err == nil; {
+		delta := d.readUvarint()
+		count := uint64(1)
+		if i+1 < len(clipped.edges) {
+			count = delta&7 + 1
+			delta >>= 3
+			if count == 8 {
+				count = delta + 8
+				delta = d.readUvarint()
+			}
+		}
+		edge += delta
+		switch {
+		case d.err != nil:
+		case count > uint64(len(clipped.edges)-i):
+			d.err = fmt.Errorf("index cell %v has too many edges of shape %d", id, clipped.shapeID)
+		case edge+count > numEdges || edge+count < edge:
+			d.err = fmt.Errorf("index cell %v refers to edge %d of shape %d, which has only %d edges",
+				id, edge+count-1, clipped.shapeID, numEdges)
+		default:
+			for ; count > 0; count-- {
+				clipped.edges[i] = int(edge)
+				i++
+				edge++
+			}
+		}
+	}
+}
+
+// boolToUint64 returns 1 if b is true and 0 otherwise.
+func boolToUint64(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
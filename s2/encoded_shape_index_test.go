@@ -0,0 +1,540 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+// encodedShapeIndexTestShapes returns a mix of shapes near the given center
+// that is large enough to produce an index with many cells.
+func encodedShapeIndexTestShapes(center Point) []Shape {
+	line := Polyline(RegularLoop(center, 0.007, 200).Vertices())
+	points := PointVector(RegularLoop(center, 0.002, 50).Vertices())
+	return []Shape{
+		concentricLoopsPolygon(center, 5, 200),
+		&line,
+		&points,
+	}
+}
+
+// checkShapeIndexCellsEqual checks that the two indexes have the same cells.
+func checkShapeIndexCellsEqual(t *testing.T, got, want *ShapeIndex) {
+	t.Helper()
+	it, wit := got.Iterator(), want.Iterator()
+	for ; !wit.Done(); it.Next() {
+		if it.Done() {
+			t.Fatalf("index is missing cell %v", wit.CellID())
+		}
+		if it.CellID() != wit.CellID() {
+			t.Fatalf("index cell = %v, want %v", it.CellID(), wit.CellID())
+		}
+		if !reflect.DeepEqual(it.IndexCell(), wit.IndexCell()) {
+			t.Errorf("index cell %v = %v, want %v", it.CellID(), it.IndexCell(), wit.IndexCell())
+		}
+		wit.Next()
+	}
+	if !it.Done() {
+		t.Errorf("index has extra cell %v", it.CellID())
+	}
+}
+
+func TestEncodedShapeIndex(t *testing.T) {
+	center := randomPoint()
+	shapes := encodedShapeIndexTestShapes(center)
+	index := NewShapeIndex()
+	for _, shape := range shapes {
+		index.Add(shape)
+	}
+	var buf bytes.Buffer
+	if err := index.Encode(&buf); err != nil {
+		t.Fatalf("index.Encode() returned error: %v", err)
+	}
+	encoded, err := NewEncodedShapeIndex(buf.Bytes(), shapes)
+	if err != nil {
+		t.Fatalf("NewEncodedShapeIndex() returned error: %v", err)
+	}
+	if !encoded.IsFresh() {
+		t.Errorf("encoded index should be fresh")
+	}
+	if got, want := encoded.NumEdges(), index.NumEdges(); got != want {
+		t.Errorf("encoded.NumEdges() = %d, want %d", got, want)
+	}
+	checkShapeIndexCellsEqual(t, encoded, index)
+
+	// Queries give the same results on both indexes.
+	query := NewContainsPointQuery(index, VertexModelSemiOpen)
+	encodedQuery := NewContainsPointQuery(encoded, VertexModelSemiOpen)
+	opts := NewClosestEdgeQueryOptions().MaxResults(3)
+	edgeQuery := NewClosestEdgeQuery(index, opts)
+	encodedEdgeQuery := NewClosestEdgeQuery(encoded, opts)
+	c := CapFromCenterAngle(center, 0.01)
+	for i := 0; i < 100; i++ {
+		p := samplePointFromCap(c)
+		if got, want := encodedQuery.Contains(p), query.Contains(p); got != want {
+			t.Errorf("encoded query Contains(%v) = %v, want %v", p, got, want)
+		}
+		target := NewMinDistanceToPointTarget(p)
+		if got, want := encodedEdgeQuery.FindEdges(target), edgeQuery.FindEdges(target); !reflect.DeepEqual(got, want) {
+			t.Errorf("encoded query FindEdges(%v) = %v, want %v", p, got, want)
+		}
+	}
+
+	// Re-encoding gives the same bytes.
+	var buf2 bytes.Buffer
+	if err := encoded.Encode(&buf2); err != nil {
+		t.Fatalf("encoded.Encode() returned error: %v", err)
+	}
+	if !bytes.Equal(buf2.Bytes(), buf.Bytes()) {
+		t.Errorf("re-encoding the encoded index gave different bytes")
+	}
+}
+
+func TestEncodedShapeIndexConcurrentQueries(t *testing.T) {
+	center := randomPoint()
+	shapes := encodedShapeIndexTestShapes(center)
+	index := NewShapeIndex()
+	for _, shape := range shapes {
+		index.Add(shape)
+	}
+	var buf bytes.Buffer
+	if err := index.Encode(&buf); err != nil {
+		t.Fatalf("index.Encode() returned error: %v", err)
+	}
+	encoded, err := NewEncodedShapeIndex(buf.Bytes(), shapes)
+	if err != nil {
+		t.Fatalf("NewEncodedShapeIndex() returned error: %v", err)
+	}
+
+	// Several goroutines visit every cell at once. Each cell is decoded
+	// only once, so they all see the same cells.
+	const numGoroutines = 8
+	got := make([][]*ShapeIndexCell, numGoroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < numGoroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for it := encoded.Iterator(); !it.Done(); it.Next() {
+				got[g] = append(got[g], it.IndexCell())
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	var want []*ShapeIndexCell
+	for it := encoded.Iterator(); !it.Done(); it.Next() {
+		want = append(want, it.IndexCell())
+	}
+	if len(want) != index.numCells() {
+		t.Fatalf("encoded index has %d cells, want %d", len(want), index.numCells())
+	}
+	for g := range got {
+		if len(got[g]) != len(want) {
+			t.Errorf("goroutine %d visited %d cells, want %d", g, len(got[g]), len(want))
+			continue
+		}
+		for i, cell := range got[g] {
+			if cell != want[i] {
+				t.Errorf("goroutine %d: cell %v was decoded again", g, encoded.cellID(i))
+			}
+		}
+	}
+	checkShapeIndexCellsEqual(t, encoded, index)
+}
+
+func TestEncodedShapeIndexEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewShapeIndex().Encode(&buf); err != nil {
+		t.Fatalf("Encode() returned error: %v", err)
+	}
+	index, err := NewEncodedShapeIndex(buf.Bytes(), nil)
+	if err != nil {
+		t.Fatalf("NewEncodedShapeIndex() returned error: %v", err)
+	}
+	if it := index.Iterator(); !it.Done() {
+		t.Errorf("empty encoded index has cell %v", it.CellID())
+	}
+}
+
+func TestEncodedShapeIndexUpdate(t *testing.T) {
+	shapes := encodedShapeIndexTestShapes(randomPoint())
+	index := NewShapeIndex()
+	for _, shape := range shapes[:2] {
+		index.Add(shape)
+	}
+	var buf bytes.Buffer
+	if err := index.Encode(&buf); err != nil {
+		t.Fatalf("index.Encode() returned error: %v", err)
+	}
+	encoded, err := NewEncodedShapeIndex(buf.Bytes(), shapes[:2])
+	if err != nil {
+		t.Fatalf("NewEncodedShapeIndex() returned error: %v", err)
+	}
+
+	// Adding a shape to the encoded index gives the same result as adding it
+	// to the original one.
+	index.Add(shapes[2])
+	if got, want := encoded.Add(shapes[2]), int32(2); got != want {
+		t.Errorf("encoded.Add() = %d, want %d", got, want)
+	}
+	checkShapeIndexCellsEqual(t, encoded, index)
+}
+
+func TestEncodedShapeIndexAddAfterRemoved(t *testing.T) {
+	shapes := encodedShapeIndexTestShapes(randomPoint())
+	index := NewShapeIndex()
+	for _, shape := range shapes {
+		index.Add(shape)
+	}
+	index.Remove(shapes[2])
+	var buf bytes.Buffer
+	if err := index.Encode(&buf); err != nil {
+		t.Fatalf("index.Encode() returned error: %v", err)
+	}
+	data := buf.Bytes()
+	decodedShapes := []Shape{shapes[0], shapes[1], nil}
+
+	encoded, err := NewEncodedShapeIndex(data, decodedShapes)
+	if err != nil {
+		t.Fatalf("NewEncodedShapeIndex() returned error: %v", err)
+	}
+	decoded := NewShapeIndex()
+	if err := decoded.Decode(bytes.NewReader(data), decodedShapes); err != nil {
+		t.Fatalf("Decode() returned error: %v", err)
+	}
+
+	// A shape added after a removed one is indexed, with the next unused ID.
+	added := PointVector{PointFromCoords(1, 0, 0)}
+	index.Add(&added)
+	for _, got := range []*ShapeIndex{encoded, decoded} {
+		if id := got.Add(&added); id != 3 {
+			t.Errorf("Add() = %d, want 3", id)
+		}
+		checkShapeIndexCellsEqual(t, got, index)
+		if !NewContainsPointQuery(got, VertexModelClosed).Contains(added[0]) {
+			t.Errorf("index does not contain the added point %v", added[0])
+		}
+	}
+}
+
+func TestEncodedShapeIndexErrors(t *testing.T) {
+	shapes := encodedShapeIndexTestShapes(randomPoint())
+	index := NewShapeIndex()
+	for _, shape := range shapes {
+		index.Add(shape)
+	}
+	var buf bytes.Buffer
+	if err := index.Encode(&buf); err != nil {
+		t.Fatalf("index.Encode() returned error: %v", err)
+	}
+	data := buf.Bytes()
+
+	badVersion := append([]byte{data[0] | 1}, data[1:]...)
+	tests := []struct {
+		desc string
+		data []byte
+	}{
+		{"no data", nil},
+		{"bad version", badVersion},
+		{"truncated cell IDs", data[:4]},
+		{"truncated cells", data[:len(data)-1]},
+	}
+	for _, test := range tests {
+		if _, err := NewEncodedShapeIndex(test.data, shapes); err == nil {
+			t.Errorf("%s: NewEncodedShapeIndex() = nil error, want error", test.desc)
+		}
+		if err := NewShapeIndex().Decode(bytes.NewReader(test.data), shapes); err == nil {
+			t.Errorf("%s: Decode() = nil error, want error", test.desc)
+		}
+	}
+
+	// Decode checks the cells against the shapes, which NewEncodedShapeIndex
+	// does not.
+	if err := NewShapeIndex().Decode(bytes.NewReader(data), shapes[:2]); err == nil {
+		t.Errorf("Decode() with too few shapes = nil error, want error")
+	}
+
+	// Cell IDs are checked up front.
+	var unsorted bytes.Buffer
+	e := &encoder{w: &unsorted}
+	e.writeUvarint(10 << 2)
+	encodeCellIDVector(e, []CellID{CellIDFromFace(1), CellIDFromFace(0)})
+	encodeStringVector(e, [][]byte{{0}, {0}})
+	if _, err := NewEncodedShapeIndex(unsorted.Bytes(), shapes[:1]); err == nil {
+		t.Errorf("NewEncodedShapeIndex() with unsorted cells = nil error, want error")
+	}
+
+	// Cell contents are only checked when they are visited, and cells that
+	// are invalid are treated as empty. Here the given shape has fewer edges
+	// than the encoded index refers to.
+	small := RegularLoop(randomPoint(), 0.001, 3)
+	bad, err := NewEncodedShapeIndex(data, []Shape{small, shapes[1], shapes[2]})
+	if err != nil {
+		t.Fatalf("NewEncodedShapeIndex() returned error: %v", err)
+	}
+	if err := bad.DecodeErr(); err != nil {
+		t.Errorf("DecodeErr() before visiting any cells = %v, want nil", err)
+	}
+	for it := bad.Iterator(); !it.Done(); it.Next() {
+		it.IndexCell()
+	}
+	if bad.DecodeErr() == nil {
+		t.Errorf("DecodeErr() after visiting an invalid cell = nil, want error")
+	}
+	if err := bad.Encode(new(bytes.Buffer)); err == nil {
+		t.Errorf("Encode() of an index with an invalid cell = nil error, want error")
+	}
+}
+
+func BenchmarkNewEncodedShapeIndex(b *testing.B) {
+	center := randomPoint()
+	var shapes []Shape
+	for i := 0; i < 10; i++ {
+		shapes = append(shapes, concentricLoopsPolygon(center, 10, 1000))
+	}
+	index := NewShapeIndex()
+	for _, shape := range shapes {
+		index.Add(shape)
+	}
+	var buf bytes.Buffer
+	if err := index.Encode(&buf); err != nil {
+		b.Fatal(err)
+	}
+	data := buf.Bytes()
+	p := samplePointFromCap(CapFromCenterAngle(center, s1.Angle(0.005)))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := NewEncodedShapeIndex(data, shapes)
+		if err != nil {
+			b.Fatal(err)
+		}
+		NewContainsPointQuery(encoded, VertexModelSemiOpen).Contains(p)
+	}
+}
+
+// BenchmarkShapeIndexRepeatedQueries compares queries on an index created
+// by NewEncodedShapeIndex with the same queries on an index created by
+// ShapeIndex.Decode. The encoded index decodes each cell on its first visit,
+// after which it should be as fast as the decoded one.
+func BenchmarkShapeIndexRepeatedQueries(b *testing.B) {
+	center := randomPoint()
+	var shapes []Shape
+	for i := 0; i < 10; i++ {
+		shapes = append(shapes, concentricLoopsPolygon(center, 10, 1000))
+	}
+	index := NewShapeIndex()
+	for _, shape := range shapes {
+		index.Add(shape)
+	}
+	var buf bytes.Buffer
+	if err := index.Encode(&buf); err != nil {
+		b.Fatal(err)
+	}
+	data := buf.Bytes()
+	points := make([]Point, 100)
+	c := CapFromCenterAngle(center, s1.Angle(0.005))
+	for i := range points {
+		points[i] = samplePointFromCap(c)
+	}
+
+	encoded, err := NewEncodedShapeIndex(data, shapes)
+	if err != nil {
+		b.Fatal(err)
+	}
+	decoded := NewShapeIndex()
+	if err := decoded.Decode(bytes.NewReader(data), shapes); err != nil {
+		b.Fatal(err)
+	}
+	for _, bm := range []struct {
+		name  string
+		index *ShapeIndex
+	}{
+		{"Encoded", encoded},
+		{"Decoded", decoded},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			query := NewClosestEdgeQuery(bm.index, NewClosestEdgeQueryOptions().MaxResults(1))
+			for i := 0; i < b.N; i++ {
+				query.FindEdges(NewMinDistanceToPointTarget(points[i%len(points)]))
+			}
+		})
+	}
+}
+
+func TestShapeIndexDecode(t *testing.T) {
+	center := randomPoint()
+	shapes := encodedShapeIndexTestShapes(center)
+	for _, shapes := range [][]Shape{shapes[:1], shapes} {
+		index := NewShapeIndex()
+		for _, shape := range shapes {
+			index.Add(shape)
+		}
+		var buf bytes.Buffer
+		if err := index.Encode(&buf); err != nil {
+			t.Fatalf("index.Encode() returned error: %v", err)
+		}
+		data := append([]byte(nil), buf.Bytes()...)
+
+		// Decoding replaces the existing contents of an index, and stops at
+		// the end of the encoding.
+		decoded := NewShapeIndex()
+		decoded.Add(&PointVector{center})
+		buf.WriteString("rest")
+		if err := decoded.Decode(&buf, shapes); err != nil {
+			t.Fatalf("Decode() returned error: %v", err)
+		}
+		if got := buf.String(); got != "rest" {
+			t.Errorf("Decode() left %q unread, want %q", got, "rest")
+		}
+		if got, want := decoded.Len(), len(shapes); got != want {
+			t.Errorf("decoded.Len() = %d, want %d", got, want)
+		}
+		if !decoded.IsFresh() {
+			t.Errorf("decoded index should be fresh")
+		}
+		checkShapeIndexCellsEqual(t, decoded, index)
+
+		var buf2 bytes.Buffer
+		if err := decoded.Encode(&buf2); err != nil {
+			t.Fatalf("decoded.Encode() returned error: %v", err)
+		}
+		if !bytes.Equal(buf2.Bytes(), data) {
+			t.Errorf("re-encoding the decoded index gave different bytes")
+		}
+	}
+}
+
+func TestEncodeIndexCellCompact(t *testing.T) {
+	clipped := func(shapeID int32, containsCenter bool, edges ...int) *clippedShape {
+		c := newClippedShape(shapeID, len(edges))
+		c.containsCenter = containsCenter
+		copy(c.edges, edges)
+		return c
+	}
+	var contiguous []int
+	for i := 0; i < 18; i++ {
+		contiguous = append(contiguous, i)
+	}
+	tests := []struct {
+		desc        string
+		numShapeIDs int
+		shapes      []*clippedShape
+		want        []byte
+	}{
+		{"one shape, contiguous edges", 1, []*clippedShape{clipped(0, true, 3, 4, 5)}, []byte{0xc6, 0x01}},
+		{"one shape, one edge", 1, []*clippedShape{clipped(0, false, 7)}, []byte{0x39}},
+		{"one shape, no edges", 1, []*clippedShape{clipped(0, true)}, []byte{0x07}},
+		{"one shape, separate edges", 1, []*clippedShape{clipped(0, false, 1, 3)}, []byte{0x13, 0x08, 0x01}},
+		{"one shape, long range", 1, []*clippedShape{clipped(0, false, contiguous...)}, []byte{0x93, 0x01, 0x57, 0x00}},
+		{"one clipped shape", 3, []*clippedShape{clipped(2, false, 4, 5)}, []byte{0x10, 0x21}},
+		{"two clipped shapes", 3, []*clippedShape{clipped(0, true), clipped(2, false, 0, 2)},
+			[]byte{0x13, 0x0f, 0x09, 0x02, 0x00, 0x01}},
+	}
+	for _, test := range tests {
+		cell := NewShapeIndexCell(0)
+		for _, c := range test.shapes {
+			cell.add(c)
+		}
+		var buf bytes.Buffer
+		encodeIndexCellCompact(&encoder{w: &buf}, cell, test.numShapeIDs)
+		if got := buf.Bytes(); !bytes.Equal(got, test.want) {
+			t.Errorf("%s: encodeIndexCellCompact() = %x, want %x", test.desc, got, test.want)
+		}
+
+		shapes := make([]Shape, test.numShapeIDs)
+		for i := range shapes {
+			line := make(Polyline, 21)
+			shapes[i] = &line
+		}
+		d := &decoder{r: &sliceReader{data: test.want}}
+		got := decodeIndexCellCompact(d, CellIDFromFace(0), shapes)
+		if d.err != nil {
+			t.Errorf("%s: decodeIndexCellCompact() returned error: %v", test.desc, d.err)
+		} else if !reflect.DeepEqual(got, cell) {
+			t.Errorf("%s: decodeIndexCellCompact() = %v, want %v", test.desc, got, cell)
+		}
+
+		// The edges must belong to the shapes.
+		for i := range shapes {
+			line := make(Polyline, 3)
+			shapes[i] = &line
+		}
+		d = &decoder{r: &sliceReader{data: test.want}}
+		if decodeIndexCellCompact(d, CellIDFromFace(0), shapes); d.err == nil && test.desc != "one shape, no edges" {
+			t.Errorf("%s: decodeIndexCellCompact() with too few edges = nil error, want error", test.desc)
+		}
+	}
+}
+
+// fuzzShapeIndex checks that decoding arbitrary data with decode, for both
+// an index of one shape and an index of several shapes, which use different
+// cell encodings, never panics. Any index that is decoded is queried, so that
+// the cells of an index that is decoded lazily are decoded too, and must then
+// be encodable unless DecodeErr reports an error.
+func fuzzShapeIndex(f *testing.F, decode func(data []byte, shapes []Shape) (*ShapeIndex, error)) {
+	center := PointFromLatLng(LatLngFromDegrees(10, 20))
+	shapes := encodedShapeIndexTestShapes(center)
+	shapeSets := [][]Shape{shapes[:1], shapes}
+	for _, shapes := range shapeSets {
+		index := NewShapeIndex()
+		for _, shape := range shapes {
+			index.Add(shape)
+		}
+		var buf bytes.Buffer
+		if err := index.Encode(&buf); err != nil {
+			f.Fatalf("Encode() failed: %v", err)
+		}
+		f.Add(buf.Bytes())
+	}
+	target := NewMinDistanceToPointTarget(center)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, shapes := range shapeSets {
+			index, err := decode(data, shapes)
+			if err != nil {
+				continue
+			}
+			NewContainsPointQuery(index, VertexModelSemiOpen).Contains(center)
+			NewClosestEdgeQuery(index, NewClosestEdgeQueryOptions().MaxResults(3)).FindEdges(target)
+			for it := index.Iterator(); !it.Done(); it.Next() {
+				it.IndexCell()
+			}
+			if err := index.DecodeErr(); err != nil {
+				continue
+			}
+			if err := index.Encode(io.Discard); err != nil {
+				t.Errorf("Encode of decoded %x failed: %v", data, err)
+			}
+		}
+	})
+}
+
+func FuzzShapeIndexDecode(f *testing.F) {
+	fuzzShapeIndex(f, func(data []byte, shapes []Shape) (*ShapeIndex, error) {
+		index := NewShapeIndex()
+		return index, index.Decode(bytes.NewReader(data), shapes)
+	})
+}
+
+func FuzzEncodedShapeIndex(f *testing.F) {
+	fuzzShapeIndex(f, NewEncodedShapeIndex)
+}
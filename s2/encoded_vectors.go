@@ -0,0 +1,255 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// This file implements the vector encodings of the C++ s2coding library,
+// which are used by its S2ShapeIndex encoding. Each element of an encoded
+// vector can be decoded on its own, without decoding the rest of the vector.
+
+// encodeUintWithLength writes the low length bytes of x in little-endian
+// order.
+func encodeUintWithLength(e *encoder, x uint64, length int) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], x)
+	e.writeBytes(buf[:length])
+}
+
+// decodeUintWithLength returns the value of the given little-endian bytes.
+func decodeUintWithLength(b []byte) uint64 {
+	var x uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		x = x<<8 | uint64(b[i])
+	}
+	return x
+}
+
+// encodeUintVector encodes v as a varint holding len(v)*8 | (length-1),
+// followed by each value in length bytes, where length is the smallest
+// number of bytes (at least one) that can represent all of the values.
+func encodeUintVector(e *encoder, v []uint64) {
+	oneBits := uint64(1)
+	for _, x := range v {
+		oneBits |= x
+	}
+	length := (bits.Len64(oneBits)-1)>>3 + 1
+	e.writeUvarint(uint64(len(v))*8 | uint64(length-1))
+
+	buf := make([]byte, 8*len(v)+8)
+	for i, x := range v {
+		binary.LittleEndian.PutUint64(buf[i*length:], x)
+	}
+	e.writeBytes(buf[:len(v)*length])
+}
+
+// uintVector is a vector of unsigned integers written by encodeUintVector.
+type uintVector struct {
+	data   []byte
+	size   int
+	length int // The number of bytes used for each element.
+}
+
+// decodeUintVector reads a vector written by encodeUintVector.
+func decodeUintVector(d *decoder) uintVector {
+	sizeLen := d.readUvarint()
+	if d.err != nil {
+		return uintVector{}
+	}
+	size, length := sizeLen>>3, int(sizeLen&7)+1
+	if size > uint64(math.MaxInt)/uint64(length) {
+		d.err = fmt.Errorf("encoded vector has too many elements (%d)", size)
+		return uintVector{}
+	}
+	data := d.readBytes(size * uint64(length))
+	if d.err != nil {
+		return uintVector{}
+	}
+	return uintVector{data: data, size: int(size), length: length}
+}
+
+// get returns the i-th element of the vector.
+func (v uintVector) get(i int) uint64 {
+	return decodeUintWithLength(v.data[i*v.length : (i+1)*v.length])
+}
+
+// encodeCellIDVector encodes the given cell IDs in the format of the C++
+// EncodeS2CellIdVector. Each ID is represented as base + delta<<shift, where
+// base holds the most significant bytes shared by the IDs and the deltas are
+// encoded with encodeUintVector.
+//
+// The format is a byte holding the length of base in bytes (0-7) in bits
+// 0-2 and a shift code in bits 3-7, then a byte holding the shift if it is
+// odd and at least 5, then the bytes of base, and then the deltas. Values of
+// the shift code below 29 represent the even shifts 0-56, 29 and 30 represent
+// the odd shifts 1 and 3, and 31 means the shift is in the following byte.
+// The shift is only odd when all the cells are at the same level, in which
+// case bit shift-1 of every ID is set and is added back to base when decoding.
+func encodeCellIDVector(e *encoder, ids []CellID) {
+	var vOr, vMax uint64
+	vAnd, vMin := ^uint64(0), ^uint64(0)
+	for _, id := range ids {
+		x := uint64(id)
+		vOr |= x
+		vAnd &= x
+		if x < vMin {
+			vMin = x
+		}
+		if x > vMax {
+			vMax = x
+		}
+	}
+
+	var base uint64
+	var baseLen, shift, maxDeltaMSB int
+	if vOr > 0 {
+		// Only even shifts are allowed unless all the IDs have the same low
+		// bit. Shifts above 56 are pointless since deltas use at least one
+		// byte each.
+		shift = minInt(56, bits.TrailingZeros64(vOr)&^1)
+		if vAnd&(1<<uint(shift)) != 0 {
+			shift++
+		}
+
+		// Choose the length of base that minimizes the size of the encoding.
+		bestBytes := ^uint64(0)
+		for n := 0; n < 8; n++ {
+			b := vMin &^ (^uint64(0) >> uint(8*n))
+			msb := maxInt(0, bits.Len64((vMax-b)>>uint(shift))-1)
+			size := uint64(n) + uint64(len(ids))*uint64(msb>>3+1)
+			if size < bestBytes {
+				base, baseLen, maxDeltaMSB, bestBytes = b, n, msb, size
+			}
+		}
+
+		// An odd shift takes an extra byte to encode, so use an even shift
+		// if the deltas still fit in the same number of bytes.
+		if shift&1 != 0 && maxDeltaMSB&7 != 7 {
+			shift--
+		}
+	}
+
+	shiftCode := shift >> 1
+	if shift&1 != 0 {
+		shiftCode = minInt(31, shiftCode+29)
+	}
+	e.writeUint8(uint8(shiftCode<<3 | baseLen))
+	if shiftCode == 31 {
+		e.writeUint8(uint8(shift >> 1))
+	}
+	encodeUintWithLength(e, base>>uint(64-8*maxInt(1, baseLen)), baseLen)
+
+	deltas := make([]uint64, len(ids))
+	for i, id := range ids {
+		deltas[i] = (uint64(id) - base) >> uint(shift)
+	}
+	encodeUintVector(e, deltas)
+}
+
+// cellIDVector is a vector of cell IDs written by encodeCellIDVector.
+type cellIDVector struct {
+	base   uint64
+	shift  uint
+	deltas uintVector
+}
+
+// decodeCellIDVector reads a vector written by encodeCellIDVector.
+func decodeCellIDVector(d *decoder) cellIDVector {
+	codeAndLen := d.readUint8()
+	shiftCode := int(codeAndLen >> 3)
+	if shiftCode == 31 {
+		shiftCode = 29 + int(d.readUint8())
+		if d.err == nil && shiftCode > 56 {
+			d.err = fmt.Errorf("invalid cell ID vector shift code %d", shiftCode)
+		}
+	}
+	baseLen := int(codeAndLen & 7)
+	base := decodeUintWithLength(d.readBytes(uint64(baseLen)))
+	if d.err != nil {
+		return cellIDVector{}
+	}
+	base <<= uint(64 - 8*maxInt(1, baseLen))
+
+	var shift int
+	if shiftCode >= 29 {
+		shift = 2*(shiftCode-29) + 1
+		base |= 1 << uint(shift-1)
+	} else {
+		shift = 2 * shiftCode
+	}
+	return cellIDVector{base: base, shift: uint(shift), deltas: decodeUintVector(d)}
+}
+
+// size returns the number of cell IDs in the vector.
+func (v cellIDVector) size() int { return v.deltas.size }
+
+// get returns the i-th cell ID of the vector.
+func (v cellIDVector) get(i int) CellID {
+	return CellID(v.deltas.get(i)<<v.shift + v.base)
+}
+
+// encodeStringVector encodes the given byte strings in the format of the
+// C++ StringVectorEncoder: the end offset of each string, encoded with
+// encodeUintVector, followed by the strings themselves.
+func encodeStringVector(e *encoder, strs [][]byte) {
+	ends := make([]uint64, len(strs))
+	var end uint64
+	for i, s := range strs {
+		end += uint64(len(s))
+		ends[i] = end
+	}
+	encodeUintVector(e, ends)
+	for _, s := range strs {
+		e.writeBytes(s)
+	}
+}
+
+// stringVector is a vector of byte strings written by encodeStringVector.
+type stringVector struct {
+	ends uintVector
+	data []byte
+}
+
+// decodeStringVector reads a vector written by encodeStringVector.
+func decodeStringVector(d *decoder) stringVector {
+	ends := decodeUintVector(d)
+	var n uint64
+	if ends.size > 0 {
+		n = ends.get(ends.size - 1)
+	}
+	return stringVector{ends: ends, data: d.readBytes(n)}
+}
+
+// size returns the number of strings in the vector.
+func (v stringVector) size() int { return v.ends.size }
+
+// get returns the i-th string of the vector. It returns an error if the
+// offsets of the string are invalid.
+func (v stringVector) get(i int) ([]byte, error) {
+	var start uint64
+	if i > 0 {
+		start = v.ends.get(i - 1)
+	}
+	end := v.ends.get(i)
+	if start > end || end > uint64(len(v.data)) {
+		return nil, fmt.Errorf("encoded string %d has invalid offsets [%d, %d)", i, start, end)
+	}
+	return v.data[start:end], nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeUintVector(t *testing.T) {
+	// The expected sizes are those of the C++ EncodeUintVector<uint64>.
+	tests := []struct {
+		v        []uint64
+		wantSize int
+	}{
+		{nil, 1},
+		{[]uint64{0}, 2},
+		{[]uint64{0, 0, 0}, 4},
+		{[]uint64{^uint64(0)}, 9},
+		{[]uint64{0, 255, 1, 254}, 5},
+		{[]uint64{0, 255, 256, 254}, 9},
+		{[]uint64{0xffffff, 0x0102, 0, 0x050403}, 13},
+		{[]uint64{^uint64(0), 0, 0x0102030405060708}, 25},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		encodeUintVector(&encoder{w: &buf}, test.v)
+		if got := buf.Len(); got != test.wantSize {
+			t.Errorf("encodeUintVector(%v) wrote %d bytes, want %d", test.v, got, test.wantSize)
+		}
+
+		// Decode both in place and from a stream.
+		for _, d := range []*decoder{
+			{r: &sliceReader{data: buf.Bytes()}},
+			{r: bytes.NewReader(buf.Bytes())},
+		} {
+			got := decodeUintVector(d)
+			if d.err != nil {
+				t.Errorf("decodeUintVector(%v) returned error: %v", test.v, d.err)
+				continue
+			}
+			if got.size != len(test.v) {
+				t.Errorf("decodeUintVector(%v) has size %d, want %d", test.v, got.size, len(test.v))
+				continue
+			}
+			for i, want := range test.v {
+				if x := got.get(i); x != want {
+					t.Errorf("decodeUintVector(%v).get(%d) = %d, want %d", test.v, i, x, want)
+				}
+			}
+		}
+	}
+}
+
+func TestEncodeCellIDVector(t *testing.T) {
+	var faces []CellID
+	for f := 0; f < NumFaces; f++ {
+		faces = append(faces, CellIDFromFace(f))
+	}
+	// The expected sizes are those of the C++ EncodeS2CellIdVector.
+	tests := []struct {
+		desc     string
+		ids      []CellID
+		wantSize int
+	}{
+		{"empty", nil, 2},
+		{"none", []CellID{0}, 3},
+		{"none none", []CellID{0, 0}, 4},
+		{"sentinel", []CellID{CellID(^uint64(0))}, 10},
+		{"faces", faces, 8},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		encodeCellIDVector(&encoder{w: &buf}, test.ids)
+		if got := buf.Len(); got != test.wantSize {
+			t.Errorf("%s: encodeCellIDVector() wrote %d bytes, want %d", test.desc, got, test.wantSize)
+		}
+		checkCellIDVectorRoundTrip(t, test.desc, test.ids)
+	}
+
+	// Cells at the same level use an odd shift, and cells at different
+	// levels an even one.
+	for i := 0; i < 50; i++ {
+		level := randomUniformInt(MaxLevel + 1)
+		var sameLevel, mixed []CellID
+		for j := 0; j < 20; j++ {
+			sameLevel = append(sameLevel, randomCellIDForLevel(level))
+			mixed = append(mixed, randomCellID())
+		}
+		checkCellIDVectorRoundTrip(t, "same level", sameLevel)
+		checkCellIDVectorRoundTrip(t, "mixed levels", mixed)
+
+		// Nearby cells share their most significant bytes.
+		id := randomCellIDForLevel(level)
+		var nearby []CellID
+		for j := 0; j < 20 && id != id.ChildEndAtLevel(level); j++ {
+			nearby = append(nearby, id)
+			id = id.Next()
+		}
+		checkCellIDVectorRoundTrip(t, "nearby", nearby)
+	}
+}
+
+func checkCellIDVectorRoundTrip(t *testing.T, desc string, ids []CellID) {
+	t.Helper()
+	var buf bytes.Buffer
+	encodeCellIDVector(&encoder{w: &buf}, ids)
+	d := &decoder{r: &sliceReader{data: buf.Bytes()}}
+	v := decodeCellIDVector(d)
+	if d.err != nil {
+		t.Errorf("%s: decodeCellIDVector() returned error: %v", desc, d.err)
+		return
+	}
+	got := make([]CellID, v.size())
+	for i := range got {
+		got[i] = v.get(i)
+	}
+	if len(got) != len(ids) || (len(ids) > 0 && !reflect.DeepEqual(got, ids)) {
+		t.Errorf("%s: decodeCellIDVector(encodeCellIDVector(%v)) = %v", desc, ids, got)
+	}
+}
+
+func TestEncodeStringVector(t *testing.T) {
+	// The expected sizes are those of the C++ StringVectorEncoder.
+	tests := []struct {
+		strs     []string
+		wantSize int
+	}{
+		{nil, 1},
+		{[]string{""}, 2},
+		{[]string{"", "", ""}, 4},
+		{[]string{"apples"}, 8},
+		{[]string{"fuji", "mutsu"}, 12},
+		{[]string{strings.Repeat("x", 1000), strings.Repeat("y", 10000)}, 11005},
+	}
+	for _, test := range tests {
+		strs := make([][]byte, len(test.strs))
+		for i, s := range test.strs {
+			strs[i] = []byte(s)
+		}
+		var buf bytes.Buffer
+		encodeStringVector(&encoder{w: &buf}, strs)
+		if got := buf.Len(); got != test.wantSize {
+			t.Errorf("encodeStringVector(%q) wrote %d bytes, want %d", test.strs, got, test.wantSize)
+		}
+
+		d := &decoder{r: &sliceReader{data: buf.Bytes()}}
+		v := decodeStringVector(d)
+		if d.err != nil {
+			t.Errorf("decodeStringVector(%q) returned error: %v", test.strs, d.err)
+			continue
+		}
+		if v.size() != len(test.strs) {
+			t.Errorf("decodeStringVector(%q) has size %d, want %d", test.strs, v.size(), len(test.strs))
+			continue
+		}
+		for i, want := range test.strs {
+			if got, err := v.get(i); err != nil || string(got) != want {
+				t.Errorf("decodeStringVector(%q).get(%d) = %q, %v, want %q", test.strs, i, got, err, want)
+			}
+		}
+	}
+}
+
+func TestDecodeEncodedVectorErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		data   []byte
+		decode func(d *decoder)
+	}{
+		{"uint vector with no data", nil, func(d *decoder) { decodeUintVector(d) }},
+		{"truncated uint vector", []byte{2<<3 | 1, 0, 0, 0}, func(d *decoder) { decodeUintVector(d) }},
+		{"huge uint vector", []byte{0xf8, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, func(d *decoder) { decodeUintVector(d) }},
+		{"bad cell ID shift", []byte{31 << 3, 28, 0}, func(d *decoder) { decodeCellIDVector(d) }},
+		{"truncated cell ID base", []byte{3}, func(d *decoder) { decodeCellIDVector(d) }},
+		{"truncated strings", []byte{1 << 3, 5, 'a'}, func(d *decoder) { decodeStringVector(d) }},
+	}
+	for _, test := range tests {
+		for _, d := range []*decoder{
+			{r: &sliceReader{data: test.data}},
+			{r: bytes.NewReader(test.data)},
+		} {
+			if test.decode(d); d.err == nil {
+				t.Errorf("%s: decoding returned nil error, want error", test.desc)
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// ChordAngleErrorFromAngle converts a bound on the angular error of a
+// distance near dist into a bound on the error of its squared chord length,
+// which is the form expected by s1.ChordAngle's Expanded method and returned
+// by functions such as MinUpdateDistanceMaxError.
+func ChordAngleErrorFromAngle(dist s1.ChordAngle, err s1.Angle) float64 {
+	e := math.Abs(float64(err))
+	if dist < 0 || dist.IsInfinity() {
+		return 2 * e
+	}
+	// The squared chord length of an angle x is 4*sin²(x/2), whose derivative
+	// is 2*sin(x). Since sin is 1-Lipschitz, its magnitude within e of dist
+	// is at most 2*min(1, sin(dist)+e).
+	return 2 * math.Min(1, dist.Sin()+e) * e
+}
+
+// ExpandedByAngleErrors returns dist expanded by the sum of the given
+// angular error bounds. Errors from successive computations accumulate in
+// the worst case, so this can be used to combine several of the error
+// constants in this package (for example IntersectionError and
+// FaceClipErrorRadians) into a single conservative distance threshold. If the
+// total is negative, the distance is shrunk instead, as with
+// s1.ChordAngle's Expanded.
+func ExpandedByAngleErrors(dist s1.ChordAngle, errs ...s1.Angle) s1.ChordAngle {
+	var total s1.Angle
+	for _, e := range errs {
+		total += e
+	}
+	e := ChordAngleErrorFromAngle(dist, total)
+	if total < 0 {
+		e = -e
+	}
+	return dist.Expanded(e)
+}
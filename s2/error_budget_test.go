@@ -0,0 +1,65 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestChordAngleErrorFromAngle(t *testing.T) {
+	for iter := 0; iter < 1000; iter++ {
+		angle := s1.Angle(randomFloat64() * math.Pi)
+		err := s1.Angle(math.Pow(1e-12, randomFloat64()))
+		dist := s1.ChordAngleFromAngle(angle)
+		e := ChordAngleErrorFromAngle(dist, err)
+
+		// Check against the distances at the ends of the error interval,
+		// allowing for the rounding error of ChordAngleFromAngle.
+		slop := dist.MaxPointError()
+		hi := s1.ChordAngleFromAngle(s1.Angle(math.Min(math.Pi, float64(angle+err))))
+		if got := dist.Expanded(e + slop); got < hi {
+			t.Errorf("%v expanded by %v = %v, want >= %v", angle, err, got, hi)
+		}
+		lo := s1.ChordAngleFromAngle(s1.Angle(math.Max(0, float64(angle-err))))
+		if got := dist.Expanded(-e - slop); got > lo {
+			t.Errorf("%v shrunk by %v = %v, want <= %v", angle, err, got, lo)
+		}
+	}
+
+	if got, want := ChordAngleErrorFromAngle(s1.InfChordAngle(), 1e-10), 2e-10; got != want {
+		t.Errorf("ChordAngleErrorFromAngle(Inf, 1e-10) = %v, want %v", got, want)
+	}
+}
+
+func TestExpandedByAngleErrors(t *testing.T) {
+	dist := s1.ChordAngleFromAngle(s1.Degree)
+	if got := ExpandedByAngleErrors(dist); got != dist {
+		t.Errorf("ExpandedByAngleErrors(%v) = %v, want unchanged", dist, got)
+	}
+
+	want := dist.Expanded(ChordAngleErrorFromAngle(dist, IntersectionError+FaceClipErrorRadians))
+	if got := ExpandedByAngleErrors(dist, IntersectionError, FaceClipErrorRadians); got != want {
+		t.Errorf("ExpandedByAngleErrors(%v, IntersectionError, FaceClipErrorRadians) = %v, want %v", dist, got, want)
+	}
+	if got := ExpandedByAngleErrors(dist, -IntersectionMergeRadius); got >= dist {
+		t.Errorf("ExpandedByAngleErrors(%v, %v) = %v, want < %v", dist, -IntersectionMergeRadius, got, dist)
+	}
+	if got := ExpandedByAngleErrors(s1.InfChordAngle(), IntersectionError); got != s1.InfChordAngle() {
+		t.Errorf("ExpandedByAngleErrors(Inf, %v) = %v, want Inf", IntersectionError, got)
+	}
+}
@@ -296,3 +296,47 @@ func ExampleEdgeQuery_FindEdges_findFurthestEdges() {
 	// Polyline 0, Edge 33 is 26.115 degrees from Point (-0.425124, -0.667311, 0.611527)
 
 }
+
+func ExamplePolyline_Interpolate() {
+	route := s2.PolylineFromLatLngs([]s2.LatLng{
+		s2.LatLngFromDegrees(0, 0),
+		s2.LatLngFromDegrees(0, 1),
+		s2.LatLngFromDegrees(0, 3),
+	})
+
+	// Find the point a quarter of the way along the route, and the index of
+	// the vertex that follows it.
+	p, next := route.Interpolate(0.25)
+	fmt.Printf("position %v, next vertex %d\n", s2.LatLngFromPoint(p), next)
+
+	// Recover the progress along the route from the point and next vertex.
+	fmt.Printf("progress %.2f\n", route.Uninterpolate(p, next))
+
+	// Output:
+	// position [0.0000000, 0.7500000], next vertex 1
+	// progress 0.25
+}
+
+func ExamplePolyline_Project() {
+	route := s2.PolylineFromLatLngs([]s2.LatLng{
+		s2.LatLngFromDegrees(0, 0),
+		s2.LatLngFromDegrees(0, 1),
+		s2.LatLngFromDegrees(1, 1),
+	})
+
+	for _, ll := range []s2.LatLng{
+		s2.LatLngFromDegrees(0.1, 0.5),
+		s2.LatLngFromDegrees(0.5, 1.2),
+	} {
+		p := s2.PointFromLatLng(ll)
+		// Snap the position onto the route, and find which side of the
+		// route the position is on.
+		closest, next := route.Project(p)
+		fmt.Printf("%v: on route at %v before vertex %d, on right: %v\n",
+			ll, s2.LatLngFromPoint(closest), next, route.IsOnRight(p))
+	}
+
+	// Output:
+	// [0.1000000, 0.5000000]: on route at [0.0000000, 0.5000000] before vertex 1, on right: false
+	// [0.5000000, 1.2000000]: on route at [0.5000030, 1.0000000] before vertex 2, on right: true
+}
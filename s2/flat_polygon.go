@@ -0,0 +1,276 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/golang/geo/r1"
+	"github.com/golang/geo/r3"
+	"github.com/golang/geo/s1"
+)
+
+// The flat polygon layout is a single little-endian buffer in which every
+// field is at a fixed or computable offset, so that a polygon can be used
+// directly from a memory-mapped file without decoding:
+//
+//	offset  size         field
+//	0       4            magic "S2FP"
+//	4       4            version (1)
+//	8       4            number of loops L
+//	12      4            number of vertices V
+//	16      4            flags (bit 0: the polygon contains OriginPoint)
+//	20      4            reserved (0)
+//	24      32           bound: lat lo, lat hi, lng lo, lng hi (float64 radians)
+//	56      16*L         loops: first vertex, number of vertices, depth,
+//	                     reserved (uint32 each)
+//	56+16*L 24*V         vertices: x, y, z (float64)
+//
+// Each loop is stored as a chain of its Shape edges, i.e. holes are stored
+// in reverse order so that the interior is always on the left, and the full
+// loop is stored with no vertices. All fields are 4- or 8-byte aligned
+// relative to the start of the buffer.
+const (
+	flatPolygonVersion      = 1
+	flatPolygonHeaderSize   = 56
+	flatPolygonLoopSize     = 16
+	flatPolygonVertexSize   = 24
+	flatPolygonOriginInside = 1
+)
+
+var flatPolygonMagic = [4]byte{'S', '2', 'F', 'P'}
+
+// FlatPolygon is a read-only polygon backed by a buffer in the flat polygon
+// layout, such as a slice of a memory-mapped file. Its methods read the
+// buffer directly, so creating one takes O(number of loops) time for
+// validation and no allocations proportional to the number of vertices.
+//
+// FlatPolygon implements Shape, so it can be added to a ShapeIndex and used
+// with the queries. Only the polygon itself is stored in the flat layout; a
+// ShapeIndex containing it is still built in memory when first queried. The
+// buffer must not be modified while the FlatPolygon is in use.
+type FlatPolygon struct {
+	data        []byte
+	numLoops    int
+	numVertices int
+}
+
+// AppendFlatPolygon appends the flat layout of the given polygon to dst and
+// returns the extended buffer.
+func AppendFlatPolygon(dst []byte, p *Polygon) []byte {
+	le := binary.LittleEndian
+	var header [flatPolygonHeaderSize]byte
+	copy(header[0:4], flatPolygonMagic[:])
+	le.PutUint32(header[4:], flatPolygonVersion)
+	numVertices := 0
+	for i := 0; i < p.NumChains(); i++ {
+		numVertices += p.Chain(i).Length
+	}
+	le.PutUint32(header[8:], uint32(p.NumLoops()))
+	le.PutUint32(header[12:], uint32(numVertices))
+	if p.ReferencePoint().Contained {
+		le.PutUint32(header[16:], flatPolygonOriginInside)
+	}
+	bound := p.RectBound()
+	le.PutUint64(header[24:], math.Float64bits(bound.Lat.Lo))
+	le.PutUint64(header[32:], math.Float64bits(bound.Lat.Hi))
+	le.PutUint64(header[40:], math.Float64bits(bound.Lng.Lo))
+	le.PutUint64(header[48:], math.Float64bits(bound.Lng.Hi))
+	dst = append(dst, header[:]...)
+
+	var buf [flatPolygonVertexSize]byte
+	start := 0
+	for i := 0; i < p.NumChains(); i++ {
+		length := p.Chain(i).Length
+		le.PutUint32(buf[0:], uint32(start))
+		le.PutUint32(buf[4:], uint32(length))
+		le.PutUint32(buf[8:], uint32(p.Loop(i).depth))
+		le.PutUint32(buf[12:], 0)
+		dst = append(dst, buf[:flatPolygonLoopSize]...)
+		start += length
+	}
+	for i := 0; i < p.NumChains(); i++ {
+		for j := 0; j < p.Chain(i).Length; j++ {
+			v := p.ChainEdge(i, j).V0
+			le.PutUint64(buf[0:], math.Float64bits(v.X))
+			le.PutUint64(buf[8:], math.Float64bits(v.Y))
+			le.PutUint64(buf[16:], math.Float64bits(v.Z))
+			dst = append(dst, buf[:]...)
+		}
+	}
+	return dst
+}
+
+// FlatPolygonFromBytes returns a FlatPolygon backed by the given buffer,
+// which must start with a polygon in the flat layout. Bytes beyond the end
+// of the polygon are ignored; FlatPolygon.Size reports where it ends.
+func FlatPolygonFromBytes(data []byte) (*FlatPolygon, error) {
+	if len(data) < flatPolygonHeaderSize {
+		return nil, fmt.Errorf("s2: flat polygon too short: %d bytes", len(data))
+	}
+	if [4]byte{data[0], data[1], data[2], data[3]} != flatPolygonMagic {
+		return nil, fmt.Errorf("s2: not a flat polygon")
+	}
+	le := binary.LittleEndian
+	if v := le.Uint32(data[4:]); v != flatPolygonVersion {
+		return nil, fmt.Errorf("s2: unsupported flat polygon version %d", v)
+	}
+	p := &FlatPolygon{
+		data:        data,
+		numLoops:    int(le.Uint32(data[8:])),
+		numVertices: int(le.Uint32(data[12:])),
+	}
+	if size := uint64(flatPolygonHeaderSize) + uint64(p.numLoops)*flatPolygonLoopSize +
+		uint64(p.numVertices)*flatPolygonVertexSize; uint64(len(data)) < size {
+		return nil, fmt.Errorf("s2: flat polygon truncated: %d bytes, want %d", len(data), size)
+	}
+	next := 0
+	for i := 0; i < p.numLoops; i++ {
+		c := p.Chain(i)
+		if c.Start != next || c.Length < 0 || c.Length > p.numVertices-next {
+			return nil, fmt.Errorf("s2: flat polygon loop %d has invalid vertex range", i)
+		}
+		next += c.Length
+	}
+	if next != p.numVertices {
+		return nil, fmt.Errorf("s2: flat polygon loops have %d vertices, want %d", next, p.numVertices)
+	}
+	return p, nil
+}
+
+// Size returns the number of bytes used by the polygon in its buffer.
+func (p *FlatPolygon) Size() int {
+	return flatPolygonHeaderSize + p.numLoops*flatPolygonLoopSize + p.numVertices*flatPolygonVertexSize
+}
+
+// NumLoops returns the number of loops in the polygon.
+func (p *FlatPolygon) NumLoops() int { return p.numLoops }
+
+// RectBound returns the bounding rectangle stored with the polygon.
+func (p *FlatPolygon) RectBound() Rect {
+	return Rect{
+		Lat: r1.Interval{Lo: p.float64(24), Hi: p.float64(32)},
+		Lng: s1.Interval{Lo: p.float64(40), Hi: p.float64(48)},
+	}
+}
+
+// Polygon decodes the flat polygon into a Polygon.
+func (p *FlatPolygon) Polygon() *Polygon {
+	if p.numLoops == 0 {
+		return &Polygon{}
+	}
+	loops := make([]*Loop, 0, p.numLoops)
+	for i := 0; i < p.numLoops; i++ {
+		c := p.Chain(i)
+		vertices := make([]Point, c.Length)
+		for j := range vertices {
+			vertices[j] = p.vertex(c.Start + j)
+		}
+		loops = append(loops, loopFromChain(vertices, p.loopDepth(i)&1 != 0))
+	}
+	return PolygonFromLoops(loops)
+}
+
+// loopFromChain returns the polygon loop for the given Shape chain of a
+// polygon loop. A chain with no vertices is the full loop, and holes are
+// reversed since their chains are oriented with the polygon interior on
+// the left. The vertices are reused by the loop.
+func loopFromChain(vertices []Point, hole bool) *Loop {
+	if len(vertices) == 0 {
+		return FullLoop()
+	}
+	if hole {
+		for i, j := 0, len(vertices)-1; i < j; i, j = i+1, j-1 {
+			vertices[i], vertices[j] = vertices[j], vertices[i]
+		}
+	}
+	return LoopFromPoints(vertices)
+}
+
+func (p *FlatPolygon) float64(offset int) float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(p.data[offset:]))
+}
+
+// loopDepth returns the nesting depth of the given loop.
+func (p *FlatPolygon) loopDepth(i int) int {
+	return int(binary.LittleEndian.Uint32(p.data[flatPolygonHeaderSize+i*flatPolygonLoopSize+8:]))
+}
+
+// vertex returns the i-th vertex over all loops.
+func (p *FlatPolygon) vertex(i int) Point {
+	off := flatPolygonHeaderSize + p.numLoops*flatPolygonLoopSize + i*flatPolygonVertexSize
+	return Point{r3.Vector{X: p.float64(off), Y: p.float64(off + 8), Z: p.float64(off + 16)}}
+}
+
+// NumEdges returns the number of edges in the polygon.
+func (p *FlatPolygon) NumEdges() int { return p.numVertices }
+
+// Edge returns the endpoints of the given edge.
+func (p *FlatPolygon) Edge(e int) Edge {
+	pos := p.ChainPosition(e)
+	return p.ChainEdge(pos.ChainID, pos.Offset)
+}
+
+// ReferencePoint returns the reference point for the polygon.
+func (p *FlatPolygon) ReferencePoint() ReferencePoint {
+	return OriginReferencePoint(binary.LittleEndian.Uint32(p.data[16:])&flatPolygonOriginInside != 0)
+}
+
+// NumChains returns the number of loops in the polygon.
+func (p *FlatPolygon) NumChains() int { return p.numLoops }
+
+// Chain returns the edge chain of the given loop.
+func (p *FlatPolygon) Chain(chainID int) Chain {
+	off := flatPolygonHeaderSize + chainID*flatPolygonLoopSize
+	le := binary.LittleEndian
+	return Chain{int(le.Uint32(p.data[off:])), int(le.Uint32(p.data[off+4:]))}
+}
+
+// ChainEdge returns the j-th edge of the i-th loop.
+func (p *FlatPolygon) ChainEdge(i, j int) Edge {
+	c := p.Chain(i)
+	k := j + 1
+	if k == c.Length {
+		k = 0
+	}
+	return Edge{p.vertex(c.Start + j), p.vertex(c.Start + k)}
+}
+
+// ChainPosition returns the loop and offset within it of the given edge.
+func (p *FlatPolygon) ChainPosition(edgeID int) ChainPosition {
+	// Find the first loop that ends after the edge. This skips any full
+	// loops, which have no edges.
+	i := sort.Search(p.numLoops, func(i int) bool {
+		c := p.Chain(i)
+		return c.Start+c.Length > edgeID
+	})
+	return ChainPosition{i, edgeID - p.Chain(i).Start}
+}
+
+// Dimension returns the dimension of the polygon, which is 2.
+func (p *FlatPolygon) Dimension() int { return 2 }
+
+// IsEmpty reports whether the polygon has no loops.
+func (p *FlatPolygon) IsEmpty() bool { return defaultShapeIsEmpty(p) }
+
+// IsFull reports whether the polygon is the full polygon.
+func (p *FlatPolygon) IsFull() bool { return defaultShapeIsFull(p) }
+
+func (p *FlatPolygon) typeTag() typeTag { return typeTagNone }
+
+func (p *FlatPolygon) privateInterface() {}
@@ -0,0 +1,147 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFlatPolygonRoundTrip(t *testing.T) {
+	polygons := []*Polygon{
+		makePolygon("", true),
+		FullPolygon(),
+		makePolygon("0:0, 0:10, 10:10, 10:0", true),
+		makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 8:2, 8:8, 2:8", true),
+		makePolygon("0:0, 0:10, 10:10, 10:0; 20:20, 20:25, 25:25, 25:20; 2:2, 8:2, 8:8, 2:8", true),
+	}
+	for _, want := range polygons {
+		// Encode after a prefix to check that offsets are relative.
+		data := AppendFlatPolygon([]byte("prefix"), want)[len("prefix"):]
+		data = append(data, "suffix"...)
+		p, err := FlatPolygonFromBytes(data)
+		if err != nil {
+			t.Errorf("FlatPolygonFromBytes(%v) returned error: %v", want, err)
+			continue
+		}
+		if got := string(data[p.Size():]); got != "suffix" {
+			t.Errorf("data after Size() = %q, want %q", got, "suffix")
+		}
+		if p.NumLoops() != want.NumLoops() {
+			t.Errorf("NumLoops() = %d, want %d", p.NumLoops(), want.NumLoops())
+		}
+		if p.RectBound() != want.RectBound() {
+			t.Errorf("RectBound() = %v, want %v", p.RectBound(), want.RectBound())
+		}
+		if p.IsEmpty() != want.IsEmpty() || p.IsFull() != want.IsFull() {
+			t.Errorf("IsEmpty(), IsFull() = %v, %v, want %v, %v", p.IsEmpty(), p.IsFull(), want.IsEmpty(), want.IsFull())
+		}
+		if p.ReferencePoint() != want.ReferencePoint() {
+			t.Errorf("ReferencePoint() = %v, want %v", p.ReferencePoint(), want.ReferencePoint())
+		}
+		if p.NumEdges() != want.NumEdges() || p.NumChains() != want.NumChains() {
+			t.Errorf("NumEdges(), NumChains() = %d, %d, want %d, %d", p.NumEdges(), p.NumChains(), want.NumEdges(), want.NumChains())
+			continue
+		}
+		for e := 0; e < want.NumEdges(); e++ {
+			if got, want := p.Edge(e), want.Edge(e); got != want {
+				t.Errorf("Edge(%d) = %v, want %v", e, got, want)
+			}
+			if got, want := p.ChainPosition(e), want.ChainPosition(e); got != want {
+				t.Errorf("ChainPosition(%d) = %v, want %v", e, got, want)
+			}
+		}
+		for i := 0; i < want.NumChains(); i++ {
+			if got, want := p.Chain(i), want.Chain(i); got != want {
+				t.Errorf("Chain(%d) = %v, want %v", i, got, want)
+			}
+		}
+		if got := p.Polygon(); !DefaultTolerance.Polygons(got, want) {
+			t.Errorf("Polygon() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFlatPolygonContainsPoint(t *testing.T) {
+	want := makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 8:2, 8:8, 2:8", true)
+	p, err := FlatPolygonFromBytes(AppendFlatPolygon(nil, want))
+	if err != nil {
+		t.Fatalf("FlatPolygonFromBytes returned error: %v", err)
+	}
+	index := NewShapeIndex()
+	index.Add(p)
+	query := NewContainsPointQuery(index, VertexModelSemiOpen)
+	for _, s := range []string{"1:1", "5:5", "9:5", "0:0", "2:2", "11:11", "-1:5"} {
+		pt := parsePoint(s)
+		if got, want := query.Contains(pt), want.ContainsPoint(pt); got != want {
+			t.Errorf("Contains(%s) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestFlatPolygonFromBytesErrors(t *testing.T) {
+	data := AppendFlatPolygon(nil, makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 8:2, 8:8, 2:8", true))
+	corrupt := func(f func(b []byte)) []byte {
+		b := append([]byte(nil), data...)
+		f(b)
+		return b
+	}
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"short", data[:10], "too short"},
+		{"magic", corrupt(func(b []byte) { b[0] = 'X' }), "not a flat polygon"},
+		{"version", corrupt(func(b []byte) { b[4] = 9 }), "version"},
+		{"truncated", data[:len(data)-1], "truncated"},
+		{"loop start", corrupt(func(b []byte) { b[flatPolygonHeaderSize] = 1 }), "invalid vertex range"},
+		{"loop length", corrupt(func(b []byte) { b[flatPolygonHeaderSize+4] = 3 }), "vertex range"},
+	}
+	for _, test := range tests {
+		_, err := FlatPolygonFromBytes(test.data)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: FlatPolygonFromBytes error = %v, want containing %q", test.name, err, test.want)
+		}
+	}
+}
+
+func FuzzFlatPolygon(f *testing.F) {
+	for _, p := range []*Polygon{
+		makePolygon("", true),
+		FullPolygon(),
+		makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 8:2, 8:8, 2:8", true),
+	} {
+		f.Add(AppendFlatPolygon(nil, p))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p, err := FlatPolygonFromBytes(data)
+		if err != nil {
+			return
+		}
+		if p.Size() > len(data) {
+			t.Errorf("FlatPolygonFromBytes(%x).Size() = %d, want at most %d", data, p.Size(), len(data))
+		}
+		for e := 0; e < p.NumEdges(); e++ {
+			p.Edge(e)
+			p.ChainPosition(e)
+		}
+		p.RectBound()
+		p.ReferencePoint()
+		index := NewShapeIndex()
+		index.Add(p)
+		NewContainsPointQuery(index, VertexModelSemiOpen).Contains(OriginPoint())
+	})
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/golang/geo/s1"
+)
+
+// Float32VertexError is the maximum angle between a point and the point
+// reconstructed from its float32 coordinates by Float32Point. Rounding each
+// coordinate of a unit vector to float32 moves it by at most 2**-24 times
+// its length, and normalizing the result adds a negligible error.
+const Float32VertexError = s1.Angle(1.5 / (1 << 24))
+
+// Float32Point returns the point that the float32 storage types use to
+// represent p, which is within Float32VertexError of p.
+func Float32Point(p Point) Point {
+	return Point{r3.Vector{X: float64(float32(p.X)), Y: float64(float32(p.Y)), Z: float64(float32(p.Z))}.Normalize()}
+}
+
+// float32Points stores points as consecutive float32 x, y, z coordinates.
+type float32Points []float32
+
+func newFloat32Points(points []Point) float32Points {
+	v := make(float32Points, 0, 3*len(points))
+	for _, p := range points {
+		v = append(v, float32(p.X), float32(p.Y), float32(p.Z))
+	}
+	return v
+}
+
+func (v float32Points) len() int { return len(v) / 3 }
+
+// point returns the i-th point, reconstructed in the same way as by
+// Float32Point.
+func (v float32Points) point(i int) Point {
+	return Point{r3.Vector{X: float64(v[3*i]), Y: float64(v[3*i+1]), Z: float64(v[3*i+2])}.Normalize()}
+}
+
+// Float32Polyline is a polyline that stores its vertices as float32
+// coordinates, using half the memory of a Polyline. It implements Shape in
+// the same way as Polyline.
+//
+// See Float32Polygon for the accuracy of the reduced precision storage.
+type Float32Polyline struct {
+	vertices float32Points
+}
+
+// Float32PolylineFromPolyline returns the given polyline with its vertices
+// rounded to float32 coordinates.
+func Float32PolylineFromPolyline(p *Polyline) *Float32Polyline {
+	return &Float32Polyline{newFloat32Points(*p)}
+}
+
+// Polyline returns the polyline as a Polyline with the reconstructed vertices.
+func (p *Float32Polyline) Polyline() *Polyline {
+	line := make(Polyline, p.vertices.len())
+	for i := range line {
+		line[i] = p.vertices.point(i)
+	}
+	return &line
+}
+
+// NumEdges returns the number of edges in this shape.
+func (p *Float32Polyline) NumEdges() int { return maxInt(0, p.vertices.len()-1) }
+
+// Edge returns endpoints for the given edge index.
+func (p *Float32Polyline) Edge(i int) Edge {
+	return Edge{p.vertices.point(i), p.vertices.point(i + 1)}
+}
+
+// ReferencePoint returns the default reference point with negative
+// containment because polylines are not closed.
+func (p *Float32Polyline) ReferencePoint() ReferencePoint { return OriginReferencePoint(false) }
+
+// NumChains reports the number of contiguous edge chains in this polyline.
+func (p *Float32Polyline) NumChains() int { return minInt(1, p.NumEdges()) }
+
+// Chain returns the i-th edge Chain in the Shape.
+func (p *Float32Polyline) Chain(chainID int) Chain { return Chain{0, p.NumEdges()} }
+
+// ChainEdge returns the j-th edge of the i-th edge Chain.
+func (p *Float32Polyline) ChainEdge(chainID, offset int) Edge { return p.Edge(offset) }
+
+// ChainPosition returns a pair (i, j) such that edgeID is the j-th edge
+// of the i-th edge Chain.
+func (p *Float32Polyline) ChainPosition(edgeID int) ChainPosition {
+	return ChainPosition{0, edgeID}
+}
+
+// Dimension returns the dimension of the geometry represented by this polyline.
+func (p *Float32Polyline) Dimension() int { return 1 }
+
+// IsEmpty reports whether this shape contains no points.
+func (p *Float32Polyline) IsEmpty() bool { return defaultShapeIsEmpty(p) }
+
+// IsFull reports whether this shape contains all points on the sphere.
+func (p *Float32Polyline) IsFull() bool { return defaultShapeIsFull(p) }
+
+func (p *Float32Polyline) typeTag() typeTag { return typeTagNone }
+
+func (p *Float32Polyline) privateInterface() {}
+
+// Float32Polygon is a polygon that stores its vertices as float32
+// coordinates, using half the memory of a Polygon. It implements Shape in
+// the same way as Polygon, so it can be indexed and queried like any other
+// shape.
+//
+// Every vertex is within Float32VertexError of the original vertex, so the
+// polygon is within that distance of the original polygon. All computations
+// use the float64 points reconstructed from the stored coordinates, which
+// are always the same for the same stored values. Since the predicates fall
+// back to exact arithmetic when needed, the results are consistent with
+// each other for the stored geometry, even where they differ from the
+// results for the original polygon.
+//
+// Rounding can make a valid polygon invalid if its edges or vertices are
+// closer together than about twice Float32VertexError, for example by
+// making two nearby edges cross. This can be checked with
+// Polygon().Validate() when the input is not known to be well separated.
+type Float32Polygon struct {
+	vertices float32Points
+	polygonChains
+}
+
+// Float32PolygonFromPolygon returns the given polygon with its vertices
+// rounded to float32 coordinates.
+func Float32PolygonFromPolygon(p *Polygon) *Float32Polygon {
+	chains, vertices := newPolygonChains(p)
+	f := &Float32Polygon{newFloat32Points(vertices), chains}
+	// Rounding may move the boundary across OriginPoint, so the reference
+	// point is computed for the rounded loops.
+	f.containsOrigin = f.Polygon().ReferencePoint().Contained
+	return f
+}
+
+// Polygon returns the polygon as a Polygon with the reconstructed vertices.
+func (p *Float32Polygon) Polygon() *Polygon { return p.polygon(p.vertices.point) }
+
+// NumEdges returns the number of edges in the polygon.
+func (p *Float32Polygon) NumEdges() int { return p.vertices.len() }
+
+// Edge returns endpoints for the given edge index.
+func (p *Float32Polygon) Edge(e int) Edge {
+	pos := p.ChainPosition(e)
+	return p.ChainEdge(pos.ChainID, pos.Offset)
+}
+
+// ChainEdge returns the j-th edge of the i-th edge Chain (loop).
+func (p *Float32Polygon) ChainEdge(i, j int) Edge { return p.chainEdge(i, j, p.vertices.point) }
+
+// IsEmpty reports whether this polygon has no loops.
+func (p *Float32Polygon) IsEmpty() bool { return defaultShapeIsEmpty(p) }
+
+// IsFull reports whether this is the full polygon.
+func (p *Float32Polygon) IsFull() bool { return defaultShapeIsFull(p) }
+
+func (p *Float32Polygon) typeTag() typeTag { return typeTagNone }
+
+func (p *Float32Polygon) privateInterface() {}
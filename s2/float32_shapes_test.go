@@ -0,0 +1,87 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFloat32Point(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		p := PointFromCoords(r.NormFloat64(), r.NormFloat64(), r.NormFloat64())
+		q := Float32Point(p)
+		if !q.IsUnit() {
+			t.Errorf("Float32Point(%v) = %v is not unit length", p, q)
+		}
+		if d := p.Distance(q); d > Float32VertexError {
+			t.Errorf("Float32Point(%v) is %v from the point, want <= %v", p, d, Float32VertexError)
+		}
+	}
+}
+
+func TestFloat32Polyline(t *testing.T) {
+	for _, s := range []string{"", "0:0", "0:0, 1:1", "0:0, 0:10, 10:10, 10:0"} {
+		line := makePolyline(s)
+		p := Float32PolylineFromPolyline(line)
+		rounded := make(Polyline, len(*line))
+		for i, v := range *line {
+			rounded[i] = Float32Point(v)
+		}
+		checkShapesEqual(t, s, p, &rounded)
+		if got := p.Polyline(); len(*got) != len(rounded) || (len(rounded) > 0 && !got.Equal(&rounded)) {
+			t.Errorf("Float32PolylineFromPolyline(%s).Polyline() = %v, want %v", s, got, rounded)
+		}
+	}
+}
+
+func TestFloat32Polygon(t *testing.T) {
+	polygons := []*Polygon{
+		makePolygon("", true),
+		FullPolygon(),
+		makePolygon("0:0, 0:10, 10:10, 10:0", true),
+		makePolygon("0:0, 0:10, 10:10, 10:0; 20:20, 20:25, 25:25, 25:20; 2:2, 8:2, 8:8, 2:8", true),
+	}
+	for _, want := range polygons {
+		p := Float32PolygonFromPolygon(want)
+		if p.NumLoops() != want.NumLoops() || p.NumEdges() != want.NumEdges() {
+			t.Errorf("NumLoops(), NumEdges() = %d, %d, want %d, %d", p.NumLoops(), p.NumEdges(), want.NumLoops(), want.NumEdges())
+			continue
+		}
+		got := p.Polygon()
+		if err := got.Validate(); err != nil {
+			t.Errorf("Float32PolygonFromPolygon(%v).Polygon() is invalid: %v", want, err)
+		}
+		if !(Tolerance{MaxError: Float32VertexError}).Polygons(got, want) {
+			t.Errorf("Float32PolygonFromPolygon(%v).Polygon() = %v, want within %v", want, got, Float32VertexError)
+		}
+		checkShapesEqual(t, "Float32Polygon", p, got)
+	}
+
+	// Containment is consistent with the rounded polygon.
+	want := makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 8:2, 8:8, 2:8", true)
+	p := Float32PolygonFromPolygon(want)
+	index := NewShapeIndex()
+	index.Add(p)
+	query := NewContainsPointQuery(index, VertexModelSemiOpen)
+	rounded := p.Polygon()
+	for _, s := range []string{"1:1", "5:5", "9:5", "0:0", "2:2", "11:11", "-1:5"} {
+		pt := parsePoint(s)
+		if got, want := query.Contains(pt), rounded.ContainsPoint(pt); got != want {
+			t.Errorf("Contains(%s) = %v, want %v", s, got, want)
+		}
+	}
+}
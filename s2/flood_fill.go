@@ -0,0 +1,78 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "sort"
+
+// FloodFillCells returns the cells at the given level that are reachable from
+// the cell containing seed without crossing any of the given boundary cells,
+// moving only between cells that share an edge. This enumerates the interior
+// of a region from its boundary without testing each cell for containment,
+// which makes it suitable for extracting gridded interiors of very large
+// regions. A suitable boundary can be obtained from the cells returned by
+// RasterizeShape at the same level.
+//
+// Boundary cells may be at any level. Cells coarser than the given level
+// block all of their descendants, and finer cells block their ancestor at
+// the given level. Since the fill only moves between cells that share an
+// edge, a closed chain of boundary cells in which consecutive cells share at
+// least a vertex is enough to contain it.
+// The returned cells do not include any boundary cells, and are sorted in
+// increasing order. If the seed cell is a boundary cell, the result is empty.
+//
+// If more than maxCells cells are reached, the fill stops and false is
+// returned along with the cells found so far. This usually means that the
+// boundary is not closed, so the fill has leaked outside of it. If maxCells
+// is not positive, there is no limit.
+//
+// The level must be in the range [0, MaxLevel].
+func FloodFillCells(boundary []CellID, seed Point, level, maxCells int) (CellUnion, bool) {
+	blocking := make(CellUnion, 0, len(boundary))
+	for _, id := range boundary {
+		if id.IsValid() && id.Level() > level {
+			id = id.Parent(level)
+		}
+		blocking = append(blocking, id)
+	}
+	blocked := NewCellUnionContainsQuery(blocking)
+
+	start := cellIDFromPoint(seed).Parent(level)
+	if blocked.ContainsCellID(start) {
+		return nil, true
+	}
+
+	visited := map[CellID]bool{start: true}
+	result := CellUnion{start}
+	complete := true
+	// The result slice doubles as the queue of cells whose neighbors have not
+	// been explored yet.
+	for next := 0; next < len(result); next++ {
+		if maxCells > 0 && len(result) > maxCells {
+			result = result[:maxCells]
+			complete = false
+			break
+		}
+		for _, n := range result[next].EdgeNeighbors() {
+			if visited[n] || blocked.ContainsCellID(n) {
+				continue
+			}
+			visited[n] = true
+			result = append(result, n)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result, complete
+}
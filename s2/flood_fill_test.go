@@ -0,0 +1,108 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestFloodFillCellsLoopInterior(t *testing.T) {
+	const level = 10
+	loop := RegularLoop(PointFromLatLng(LatLngFromDegrees(30, 40)), s1.Degree, 7)
+
+	var boundary []CellID
+	for _, c := range RasterizeShape(loop, level) {
+		boundary = append(boundary, c.ID)
+	}
+	boundarySet := make(map[CellID]bool)
+	for _, id := range boundary {
+		boundarySet[id] = true
+	}
+
+	got, ok := FloodFillCells(boundary, loop.Centroid(), level, 0)
+	if !ok {
+		t.Fatalf("FloodFillCells(...) did not complete")
+	}
+	gotSet := make(map[CellID]bool)
+	for i, id := range got {
+		if i > 0 && got[i-1] >= id {
+			t.Errorf("FloodFillCells result not sorted at %d: %v, %v", i, got[i-1], id)
+		}
+		gotSet[id] = true
+		if boundarySet[id] {
+			t.Errorf("FloodFillCells returned boundary cell %v", id)
+		}
+	}
+
+	// Every cell at the level covering the loop's bound that is not on the
+	// boundary is filled if and only if its center is inside the loop.
+	rc := &RegionCoverer{MinLevel: level, MaxLevel: level, MaxCells: 1 << 20}
+	want := 0
+	for _, id := range rc.Covering(loop.CapBound()) {
+		if boundarySet[id] {
+			continue
+		}
+		inside := loop.ContainsPoint(id.Point())
+		if inside {
+			want++
+		}
+		if gotSet[id] != inside {
+			t.Errorf("cell %v filled = %v, want %v", id, gotSet[id], inside)
+		}
+	}
+	if len(got) != want || want == 0 {
+		t.Errorf("len(FloodFillCells(...)) = %d, want %d > 0", len(got), want)
+	}
+}
+
+func TestFloodFillCellsCoarseAndFineBoundary(t *testing.T) {
+	const level = 6
+	center := CellIDFromFace(2).ChildBeginAtLevel(level).Advance(1000)
+
+	// Surround the center cell with its neighbors, given as a mix of finer
+	// and coarser cells.
+	var boundary []CellID
+	for i, n := range center.AllNeighbors(level) {
+		if i%2 == 0 {
+			n = n.ChildBeginAtLevel(level + 3)
+		}
+		boundary = append(boundary, n)
+	}
+	got, ok := FloodFillCells(boundary, center.Point(), level, 0)
+	if !ok || len(got) != 1 || got[0] != center {
+		t.Errorf("FloodFillCells(%v, %v) = %v, %v, want [%v], true", boundary, center, got, ok, center)
+	}
+
+	// A coarse boundary cell containing the seed blocks it.
+	got, ok = FloodFillCells([]CellID{center.Parent(2)}, center.Point(), level, 0)
+	if !ok || len(got) != 0 {
+		t.Errorf("FloodFillCells(seed in boundary) = %v, %v, want [], true", got, ok)
+	}
+}
+
+func TestFloodFillCellsLeak(t *testing.T) {
+	// An open boundary lets the fill leak, which stops at maxCells.
+	const level, maxCells = 8, 1000
+	id := CellIDFromFace(0).ChildBeginAtLevel(level).Advance(500)
+	got, ok := FloodFillCells([]CellID{id}, id.Next().Point(), level, maxCells)
+	if ok {
+		t.Errorf("FloodFillCells with an open boundary completed, want leak")
+	}
+	if len(got) != maxCells {
+		t.Errorf("len(FloodFillCells(...)) = %d, want %d", len(got), maxCells)
+	}
+}
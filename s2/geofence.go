@@ -0,0 +1,164 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/geo/s1"
+)
+
+// GeofenceEventKind is the kind of a GeofenceEvent.
+type GeofenceEventKind int
+
+// The kinds of geofence events.
+const (
+	// GeofenceEnter is reported when a point moves into a fence.
+	GeofenceEnter GeofenceEventKind = iota
+	// GeofenceExit is reported when a point moves out of a fence.
+	GeofenceExit
+	// GeofenceInside is reported for each fence that a point remains inside.
+	GeofenceInside
+)
+
+func (k GeofenceEventKind) String() string {
+	switch k {
+	case GeofenceEnter:
+		return "Enter"
+	case GeofenceExit:
+		return "Exit"
+	case GeofenceInside:
+		return "Inside"
+	}
+	return fmt.Sprintf("GeofenceEventKind(%d)", int(k))
+}
+
+// GeofenceEvent is a change, or lack of change, in whether a tracked point
+// is inside one fence.
+type GeofenceEvent struct {
+	Kind    GeofenceEventKind
+	FenceID int32
+	Time    time.Time
+}
+
+// Geofence is a set of polygonal fences, against which streams of moving
+// points are evaluated with a GeofenceTracker.
+//
+// To avoid reporting a flurry of enter and exit events when a point moves
+// along a fence boundary or its position is noisy, a fence has hysteresis:
+// a point that is within the hysteresis distance of the boundary of a fence
+// keeps its previous state, so it must move at least that far into a fence
+// to enter it, and at least that far out of it to exit.
+//
+// Fences must all be added before the first tracker is created. Trackers
+// can then be used concurrently, one per goroutine.
+type Geofence struct {
+	index      *ShapeIndex
+	hysteresis s1.ChordAngle
+}
+
+// NewGeofence returns an empty Geofence with the given hysteresis distance.
+func NewGeofence(hysteresis s1.Angle) *Geofence {
+	return &Geofence{
+		index:      NewShapeIndex(),
+		hysteresis: s1.ChordAngleFromAngle(hysteresis),
+	}
+}
+
+// Add adds the polygon as a fence and returns its id, which identifies it
+// in events.
+func (g *Geofence) Add(fence *Polygon) int32 {
+	return g.index.Add(fence)
+}
+
+// NewTracker returns a tracker for one stream of points.
+func (g *Geofence) NewTracker() *GeofenceTracker {
+	g.index.Build()
+	opts := NewClosestEdgeQueryOptions().IncludeInteriors(false).DistanceLimit(g.hysteresis)
+	return &GeofenceTracker{
+		fence:    g,
+		contains: NewContainsPointQuery(g.index, VertexModelSemiOpen),
+		edges:    NewClosestEdgeQuery(g.index, opts),
+		inside:   make(map[int32]bool),
+	}
+}
+
+// GeofenceTracker tracks which fences of a Geofence contain one moving point.
+// It is not safe for concurrent use.
+type GeofenceTracker struct {
+	fence    *Geofence
+	contains *ContainsPointQuery
+	edges    *EdgeQuery
+	inside   map[int32]bool
+	last     time.Time
+	started  bool
+}
+
+// Update moves the tracked point to p at time t, and returns the events for
+// the fences that it entered, exited, or remains inside, ordered by fence id.
+// The first update only reports Enter events, for the fences containing the
+// point. Timestamps must not decrease; an update that goes back in time
+// returns an error and leaves the tracker unchanged.
+func (t *GeofenceTracker) Update(p Point, ts time.Time) ([]GeofenceEvent, error) {
+	if t.started && ts.Before(t.last) {
+		return nil, fmt.Errorf("s2: geofence update at %v is before the previous update at %v", ts, t.last)
+	}
+
+	now := make(map[int32]bool)
+	for _, id := range t.contains.containingShapeIDs(p) {
+		now[id] = true
+	}
+	if t.started && t.fence.hysteresis > 0 {
+		// Points near the boundary of a fence keep their previous state.
+		for _, r := range t.edges.FindEdges(NewMinDistanceToPointTarget(p)) {
+			if id := r.ShapeID(); t.inside[id] {
+				now[id] = true
+			} else {
+				delete(now, id)
+			}
+		}
+	}
+
+	var events []GeofenceEvent
+	for id := range now {
+		kind := GeofenceEnter
+		if t.inside[id] {
+			kind = GeofenceInside
+		}
+		events = append(events, GeofenceEvent{kind, id, ts})
+	}
+	for id := range t.inside {
+		if !now[id] {
+			events = append(events, GeofenceEvent{GeofenceExit, id, ts})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].FenceID < events[j].FenceID })
+
+	t.inside, t.last, t.started = now, ts, true
+	return events, nil
+}
+
+// Inside returns the ids of the fences that currently contain the tracked
+// point, in increasing order.
+func (t *GeofenceTracker) Inside() []int32 {
+	ids := make([]int32, 0, len(t.inside))
+	for id := range t.inside {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestGeofenceTracker(t *testing.T) {
+	g := NewGeofence(s1.Degree / 10)
+	a := g.Add(makePolygon("0:0, 0:2, 2:2, 2:0", true))
+	b := g.Add(makePolygon("1:1, 1:3, 3:3, 3:1", true))
+	tracker := g.NewTracker()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	type event struct {
+		kind GeofenceEventKind
+		id   int32
+	}
+	tests := []struct {
+		point string
+		want  []event
+	}{
+		// Starting inside a.
+		{"0.5:0.5", []event{{GeofenceEnter, a}}},
+		{"0.6:0.6", []event{{GeofenceInside, a}}},
+		// Just inside b, but within the hysteresis distance of its
+		// boundary, so b is not entered yet.
+		{"1.05:1.05", []event{{GeofenceInside, a}}},
+		{"1.5:1.5", []event{{GeofenceInside, a}, {GeofenceEnter, b}}},
+		// Just outside a, but still within the hysteresis distance.
+		{"2.05:2.05", []event{{GeofenceInside, a}, {GeofenceInside, b}}},
+		{"2.5:2.5", []event{{GeofenceExit, a}, {GeofenceInside, b}}},
+		{"5:5", []event{{GeofenceExit, b}}},
+		{"5:6", nil},
+	}
+	for i, test := range tests {
+		ts := start.Add(time.Duration(i) * time.Minute)
+		events, err := tracker.Update(parsePoint(test.point), ts)
+		if err != nil {
+			t.Fatalf("Update(%s) returned error %v", test.point, err)
+		}
+		var got []event
+		for _, e := range events {
+			if !e.Time.Equal(ts) {
+				t.Errorf("Update(%s) event time = %v, want %v", test.point, e.Time, ts)
+			}
+			got = append(got, event{e.Kind, e.FenceID})
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Update(%s) = %v, want %v", test.point, got, test.want)
+		}
+	}
+
+	if _, err := tracker.Update(parsePoint("1.5:1.5"), start); err == nil {
+		t.Errorf("Update with an earlier timestamp should fail")
+	}
+	if got := tracker.Inside(); len(got) != 0 {
+		t.Errorf("Inside() after a failed update = %v, want none", got)
+	}
+
+	// A new tracker starting near a boundary takes the containment as is.
+	tracker = g.NewTracker()
+	if events, _ := tracker.Update(parsePoint("1.05:1.05"), start); len(events) != 2 {
+		t.Errorf("first Update near a boundary = %v, want entering both fences", events)
+	}
+	if got, want := tracker.Inside(), []int32{a, b}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Inside() = %v, want %v", got, want)
+	}
+}
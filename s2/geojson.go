@@ -0,0 +1,247 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// GeoJSONOptions controls how coordinates are snapped when geometry is
+// written as GeoJSON. Snapping makes the output smaller and stable across
+// runs, but can change the topology of a polygon, for example by making two
+// nearby loops cross, so the snapped geometry is validated again and an
+// error is returned if it is no longer valid.
+//
+// The zero value writes the coordinates at full precision.
+type GeoJSONOptions struct {
+	// Decimals, if positive, is the number of decimal places of a degree
+	// that coordinates are rounded to. For example, 6 decimals is about
+	// 11cm at the equator.
+	Decimals int
+
+	// CellLevel, if positive, snaps each vertex to the center of the cell
+	// at that level containing it, before any rounding to Decimals.
+	CellLevel int
+}
+
+// snap returns the snapped position of the point, and its GeoJSON
+// coordinates as a longitude and a latitude in degrees.
+func (o GeoJSONOptions) snap(p Point) (Point, []float64) {
+	if o.CellLevel > 0 {
+		p = cellIDFromPoint(p).Parent(minInt(o.CellLevel, MaxLevel)).Point()
+	}
+	ll := LatLngFromPoint(p)
+	lat, lng := ll.Lat.Degrees(), ll.Lng.Degrees()
+	if o.Decimals > 0 {
+		scale := math.Pow(10, float64(o.Decimals))
+		lat, lng = math.Round(lat*scale)/scale, math.Round(lng*scale)/scale
+		p = PointFromLatLng(LatLngFromDegrees(lat, lng))
+	}
+	return p, []float64{lng, lat}
+}
+
+// snapVertices snaps the vertices, dropping those that become equal to the
+// previous one. If closed is true, the last vertex is also compared with
+// the first.
+func (o GeoJSONOptions) snapVertices(vertices []Point, closed bool) ([]Point, [][]float64) {
+	var points []Point
+	var coords [][]float64
+	for _, v := range vertices {
+		p, c := o.snap(v)
+		if len(points) > 0 && points[len(points)-1] == p {
+			continue
+		}
+		points = append(points, p)
+		coords = append(coords, c)
+	}
+	if closed && len(points) > 1 && points[0] == points[len(points)-1] {
+		points, coords = points[:len(points)-1], coords[:len(coords)-1]
+	}
+	return points, coords
+}
+
+// marshalGeoJSON returns the GeoJSON geometry object with the given type
+// and coordinates, which are a []float64 position or nested slices of them.
+// The output is the same as that of encoding/json, which is not used since
+// it relies on reflection that is not fully supported by TinyGo.
+func marshalGeoJSON(typ string, coords interface{}) []byte {
+	b := append([]byte(`{"type":`), strconv.Quote(typ)...)
+	b = append(b, `,"coordinates":`...)
+	b = appendGeoJSONCoordinates(b, coords)
+	return append(b, '}')
+}
+
+// appendGeoJSONCoordinates appends the given coordinates as a JSON array.
+func appendGeoJSONCoordinates(b []byte, coords interface{}) []byte {
+	b = append(b, '[')
+	switch c := coords.(type) {
+	case []float64:
+		for i, f := range c {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendGeoJSONFloat(b, f)
+		}
+	case [][]float64:
+		for i, e := range c {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendGeoJSONCoordinates(b, e)
+		}
+	case [][][]float64:
+		for i, e := range c {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendGeoJSONCoordinates(b, e)
+		}
+	case [][][][]float64:
+		for i, e := range c {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendGeoJSONCoordinates(b, e)
+		}
+	default:
+		panic(fmt.Sprintf("s2: unexpected GeoJSON coordinates type %T", coords))
+	}
+	return append(b, ']')
+}
+
+// appendGeoJSONFloat appends f formatted as by encoding/json: the shortest
+// representation that round trips, in exponent form only for very small or
+// large magnitudes.
+func appendGeoJSONFloat(b []byte, f float64) []byte {
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	b = strconv.AppendFloat(b, f, format, -1, 64)
+	if n := len(b); format == 'e' && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+		// Clean up e-09 to e-9.
+		b[n-2] = b[n-1]
+		b = b[:n-1]
+	}
+	return b
+}
+
+// PolylineToGeoJSON returns the polyline as a GeoJSON LineString, with its
+// coordinates snapped as given by the options. It returns an error if
+// snapping collapses the polyline to a single point.
+func PolylineToGeoJSON(p *Polyline, opts GeoJSONOptions) ([]byte, error) {
+	points, coords := opts.snapVertices(*p, false)
+	if len(*p) > 0 && len(points) < 2 {
+		return nil, fmt.Errorf("s2: snapping collapsed the polyline to a point")
+	}
+	snapped := Polyline(points)
+	if err := snapped.Validate(); err != nil {
+		return nil, fmt.Errorf("s2: snapped polyline is invalid: %v", err)
+	}
+	if coords == nil {
+		coords = [][]float64{}
+	}
+	return marshalGeoJSON("LineString", coords), nil
+}
+
+// PolygonToGeoJSON returns the polygon as a GeoJSON Polygon, or as a
+// MultiPolygon if it has more than one shell, with its coordinates snapped
+// as given by the options. As GeoJSON requires, shells are written counter-
+// clockwise and holes clockwise, and each ring repeats its first vertex at
+// the end.
+//
+// It returns an error if the polygon is full, which GeoJSON cannot
+// represent, or if snapping changes the topology of the polygon: a loop
+// collapses, loops or edges cross, or a loop changes its nesting depth.
+func PolygonToGeoJSON(p *Polygon, opts GeoJSONOptions) ([]byte, error) {
+	if p.IsFull() {
+		return nil, fmt.Errorf("s2: the full polygon cannot be represented in GeoJSON")
+	}
+
+	loops := make([]*Loop, len(p.loops))
+	rings := make([][][]float64, len(p.loops))
+	for i, l := range p.loops {
+		points, coords := opts.snapVertices(l.vertices, true)
+		if len(points) < 3 {
+			return nil, fmt.Errorf("s2: snapping collapsed loop %d", i)
+		}
+		coords = append(coords, coords[0])
+		if l.IsHole() {
+			for a, b := 0, len(coords)-1; a < b; a, b = a+1, b-1 {
+				coords[a], coords[b] = coords[b], coords[a]
+			}
+		}
+		loops[i], rings[i] = LoopFromPoints(points), coords
+	}
+	if err := validateSnappedPolygon(p, loops); err != nil {
+		return nil, fmt.Errorf("s2: snapping changed the topology: %v", err)
+	}
+
+	// Each shell and the holes directly inside it form one GeoJSON polygon.
+	// Since the loops are in pre-order, the holes of a shell are the loops
+	// of the next depth that follow it.
+	var polygons [][][][]float64
+	for i, l := range p.loops {
+		if l.IsHole() {
+			polygons[len(polygons)-1] = append(polygons[len(polygons)-1], rings[i])
+		} else {
+			polygons = append(polygons, [][][]float64{rings[i]})
+		}
+	}
+	switch len(polygons) {
+	case 0:
+		return marshalGeoJSON("Polygon", [][][]float64{}), nil
+	case 1:
+		return marshalGeoJSON("Polygon", polygons[0]), nil
+	}
+	return marshalGeoJSON("MultiPolygon", polygons), nil
+}
+
+// validateSnappedPolygon reports whether the snapped loops, which correspond
+// to the loops of the original polygon, still form a valid polygon with
+// the same nesting.
+func validateSnappedPolygon(orig *Polygon, loops []*Loop) error {
+	if len(loops) == 0 {
+		return nil
+	}
+	for i, l := range loops {
+		if err := l.findValidationErrorNoIndex(); err != nil {
+			return fmt.Errorf("loop %d: %v", i, err)
+		}
+		seen := make(map[Point]bool)
+		for _, v := range l.vertices {
+			if seen[v] {
+				return fmt.Errorf("loop %d: duplicate vertex %v", i, v)
+			}
+			seen[v] = true
+		}
+	}
+	snapped := PolygonFromLoops(loops)
+	for i, l := range loops {
+		if l.depth != orig.loops[i].depth {
+			return fmt.Errorf("loop %d changed nesting depth from %d to %d", i, orig.loops[i].depth, l.depth)
+		}
+	}
+	query := NewCrossingEdgeQuery(snapped.index)
+	for e := 0; e < snapped.NumEdges(); e++ {
+		edge := snapped.Edge(e)
+		if len(query.Crossings(edge.V0, edge.V1, snapped, CrossingTypeInterior)) > 0 {
+			return fmt.Errorf("edge %d crosses another edge", e)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPolygonToGeoJSON(t *testing.T) {
+	tests := []struct {
+		polygon *Polygon
+		opts    GeoJSONOptions
+		want    string
+	}{
+		{&Polygon{}, GeoJSONOptions{}, `{"type":"Polygon","coordinates":[]}`},
+		{
+			makePolygon("0:0, 0:1.0000001, 1:1", true),
+			GeoJSONOptions{Decimals: 3},
+			`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`,
+		},
+		{
+			// The hole is written clockwise.
+			makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 2:4, 4:4, 4:2", true),
+			GeoJSONOptions{Decimals: 6},
+			`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[2,4],[4,4],[4,2],[2,2]]]}`,
+		},
+		{
+			makePolygon("0:0, 0:1, 1:1; 5:5, 5:6, 6:6", true),
+			GeoJSONOptions{Decimals: 6},
+			`{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]],[[[5,5],[6,5],[6,6],[5,5]]]]}`,
+		},
+	}
+	for _, test := range tests {
+		got, err := PolygonToGeoJSON(test.polygon, test.opts)
+		if err != nil {
+			t.Errorf("PolygonToGeoJSON(%v, %+v) returned error %v", test.polygon, test.opts, err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("PolygonToGeoJSON(%v, %+v) = %s, want %s", test.polygon, test.opts, got, test.want)
+		}
+	}
+}
+
+func TestPolygonToGeoJSONTopology(t *testing.T) {
+	tests := []struct {
+		name    string
+		polygon *Polygon
+		opts    GeoJSONOptions
+	}{
+		{"collapsed loop", makePolygon("0:0, 0:0.001, 0.001:0.001", true), GeoJSONOptions{Decimals: 1}},
+		{"hole touching shell", makePolygon("0:0, 0:10, 10:10, 10:0; 0.01:1, 0.01:2, 1:2", true), GeoJSONOptions{Decimals: 1}},
+		{"collapsed to a cell", makePolygon("0:0, 0:0.0001, 0.0001:0.0001", true), GeoJSONOptions{CellLevel: 10}},
+		{"full", FullPolygon(), GeoJSONOptions{}},
+	}
+	for _, test := range tests {
+		if got, err := PolygonToGeoJSON(test.polygon, test.opts); err == nil {
+			t.Errorf("%s: PolygonToGeoJSON = %s, want error", test.name, got)
+		}
+	}
+
+	// Snapping to a fine cell level keeps a large polygon valid, and the
+	// output parses as GeoJSON.
+	p := makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 2:4, 4:4, 4:2", true)
+	b, err := PolygonToGeoJSON(p, GeoJSONOptions{CellLevel: 20, Decimals: 7})
+	if err != nil {
+		t.Fatalf("PolygonToGeoJSON returned error %v", err)
+	}
+	var g struct {
+		Type        string
+		Coordinates [][][2]float64
+	}
+	if err := json.Unmarshal(b, &g); err != nil || g.Type != "Polygon" || len(g.Coordinates) != 2 || len(g.Coordinates[0]) != 5 {
+		t.Errorf("PolygonToGeoJSON = %s, which does not parse as a polygon with a hole: %v", b, err)
+	}
+}
+
+func TestPolylineToGeoJSON(t *testing.T) {
+	got, err := PolylineToGeoJSON(makePolyline("0:0, 0:0.00001, 1:1, 2:1"), GeoJSONOptions{Decimals: 2})
+	if want := `{"type":"LineString","coordinates":[[0,0],[1,1],[1,2]]}`; err != nil || string(got) != want {
+		t.Errorf("PolylineToGeoJSON = %s, %v, want %s", got, err, want)
+	}
+	if got, err := PolylineToGeoJSON(makePolyline("0:0, 0:0.001"), GeoJSONOptions{Decimals: 1}); err == nil {
+		t.Errorf("PolylineToGeoJSON of a collapsed polyline = %s, want error", got)
+	}
+}
+
+func TestMarshalGeoJSONMatchesEncodingJSON(t *testing.T) {
+	floats := []float64{0, 1, -1, 0.5, 1e-6, 9.99e-7, -1.234e-9, 123.456789012345, 1e20, 1e21, -180, 89.999999999}
+	coords := []interface{}{
+		[]float64{},
+		floats,
+		[][]float64{floats[:2], floats[2:4]},
+		[][][]float64{{floats[4:6]}, {}},
+		[][][][]float64{{{floats[6:8], floats[8:10]}}, {{floats[10:]}}},
+	}
+	for _, c := range coords {
+		want, err := json.Marshal(struct {
+			Type        string      `json:"type"`
+			Coordinates interface{} `json:"coordinates"`
+		}{"Polygon", c})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := marshalGeoJSON("Polygon", c); string(got) != string(want) {
+			t.Errorf("marshalGeoJSON(%v) = %s, want %s", c, got, want)
+		}
+	}
+}
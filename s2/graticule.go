@@ -0,0 +1,138 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// This file contains functions that generate the lines of a graticule,
+// the grid of parallels and meridians drawn on maps. The lines are at the
+// multiples of a given spacing, measured from the equator and the prime
+// meridian, so that the lines of different regions line up.
+//
+// Parallels are not geodesics, so they are approximated by polylines whose
+// edges span at most a given longitude. Meridians are geodesics, but they
+// are divided the same way so that the lines can be clipped to a region
+// by testing their vertices.
+
+// GraticuleParallels returns the parallels (lines of constant latitude) at
+// the multiples of spacing that intersect the given region, clipped to the
+// region. Each parallel is divided into edges that span at most maxEdge of
+// longitude. The clipping is done at the vertices, so a line may extend
+// past the boundary of the region by up to one edge; use a smaller maxEdge
+// for a more accurate clipping. The poles, where a parallel is a single
+// point, are omitted.
+func GraticuleParallels(region Region, spacing, maxEdge s1.Angle) []Polyline {
+	if spacing <= 0 || maxEdge <= 0 {
+		return nil
+	}
+	rect := region.RectBound()
+	if rect.IsEmpty() {
+		return nil
+	}
+	lngLo, lngHi := graticuleLngRange(rect)
+	var lines []Polyline
+	for _, lat := range graticuleMultiples(rect.Lat.Lo, rect.Lat.Hi, spacing) {
+		if math.Abs(float64(lat)) >= math.Pi/2 {
+			continue
+		}
+		lines = appendClippedGraticuleLine(lines, region, lngLo, lngHi, maxEdge, func(lng s1.Angle) LatLng {
+			return LatLng{lat, lng}
+		})
+	}
+	return lines
+}
+
+// GraticuleMeridians returns the meridians (lines of constant longitude) at
+// the multiples of spacing that intersect the given region, clipped to the
+// region. Each meridian is divided into edges that span at most maxEdge of
+// latitude, and is clipped as described for GraticuleParallels. When the
+// region spans all longitudes, the antimeridian is returned once, at -180
+// degrees.
+func GraticuleMeridians(region Region, spacing, maxEdge s1.Angle) []Polyline {
+	if spacing <= 0 || maxEdge <= 0 {
+		return nil
+	}
+	rect := region.RectBound()
+	if rect.IsEmpty() {
+		return nil
+	}
+	lngLo, lngHi := graticuleLngRange(rect)
+	latLo, latHi := s1.Angle(rect.Lat.Lo), s1.Angle(rect.Lat.Hi)
+	var lines []Polyline
+	for _, lng := range graticuleMultiples(float64(lngLo), float64(lngHi), spacing) {
+		if rect.Lng.IsFull() && lng == lngHi {
+			// The same meridian as the one at lngLo.
+			continue
+		}
+		lng := lng.Normalized()
+		lines = appendClippedGraticuleLine(lines, region, latLo, latHi, maxEdge, func(lat s1.Angle) LatLng {
+			return LatLng{lat, lng}
+		})
+	}
+	return lines
+}
+
+// graticuleLngRange returns the longitude range of the rectangle as a pair
+// lo <= hi, where hi may exceed π if the range crosses the antimeridian.
+func graticuleLngRange(rect Rect) (lo, hi s1.Angle) {
+	lo, hi = s1.Angle(rect.Lng.Lo), s1.Angle(rect.Lng.Hi)
+	if rect.Lng.IsInverted() {
+		hi += 2 * math.Pi
+	}
+	return lo, hi
+}
+
+// graticuleMultiples returns the multiples of spacing in [lo, hi].
+func graticuleMultiples(lo, hi float64, spacing s1.Angle) []s1.Angle {
+	var values []s1.Angle
+	for k := math.Ceil(lo / float64(spacing)); k*float64(spacing) <= hi; k++ {
+		values = append(values, s1.Angle(k)*spacing)
+	}
+	return values
+}
+
+// appendClippedGraticuleLine samples the line given by the function f over
+// the range [lo, hi] of its parameter, and appends each maximal run of at
+// least two samples contained by the region to lines.
+func appendClippedGraticuleLine(lines []Polyline, region Region, lo, hi, maxEdge s1.Angle, f func(s1.Angle) LatLng) []Polyline {
+	n := maxInt(1, int(math.Ceil(float64((hi-lo)/maxEdge))))
+	var run Polyline
+	flush := func() {
+		if len(run) >= 2 {
+			lines = append(lines, run)
+		}
+		run = nil
+	}
+	for i := 0; i <= n; i++ {
+		t := lo + (hi-lo)*s1.Angle(i)/s1.Angle(n)
+		if i == n {
+			t = hi
+		}
+		p := PointFromLatLng(f(t))
+		if !region.ContainsPoint(p) {
+			flush()
+			continue
+		}
+		if len(run) == 0 || run[len(run)-1] != p {
+			run = append(run, p)
+		}
+	}
+	flush()
+	return lines
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestGraticuleFullSphere(t *testing.T) {
+	parallels := GraticuleParallels(FullRect(), 30*s1.Degree, s1.Degree)
+	if got, want := len(parallels), 5; got != want {
+		t.Fatalf("len(GraticuleParallels(FullRect(), 30°)) = %d, want %d", got, want)
+	}
+	for i, line := range parallels {
+		wantLat := float64(i-2) * 30
+		for _, p := range line {
+			if lat := LatLngFromPoint(p).Lat.Degrees(); math.Abs(lat-wantLat) > 1e-9 {
+				t.Errorf("parallel %d has a vertex at latitude %v, want %v", i, lat, wantLat)
+				break
+			}
+		}
+		// Each parallel goes all the way around, so it is closed.
+		if len(line) != 361 || !line[0].ApproxEqual(line[len(line)-1]) {
+			t.Errorf("parallel %d has %d vertices from %v to %v, want a closed line of 361", i, len(line), line[0], line[len(line)-1])
+		}
+	}
+
+	meridians := GraticuleMeridians(FullRect(), 30*s1.Degree, s1.Degree)
+	if got, want := len(meridians), 12; got != want {
+		t.Fatalf("len(GraticuleMeridians(FullRect(), 30°)) = %d, want %d", got, want)
+	}
+	for i, line := range meridians {
+		if got, want := LatLngFromPoint(line[len(line)/2]).Lng.Degrees(), float64(i)*30-180; math.Abs(got-want) > 1e-9 && math.Abs(got+want) > 1e-9 {
+			t.Errorf("meridian %d is at longitude %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestGraticuleClipping(t *testing.T) {
+	// A rectangle crossing the antimeridian.
+	rect := rectFromDegrees(-5, 165, 25, -165)
+	parallels := GraticuleParallels(rect, 10*s1.Degree, s1.Degree)
+	if got, want := len(parallels), 3; got != want {
+		t.Errorf("len(parallels) = %d, want %d", got, want)
+	}
+	meridians := GraticuleMeridians(rect, 10*s1.Degree, s1.Degree)
+	if got, want := len(meridians), 3; got != want {
+		t.Errorf("len(meridians) = %d, want %d", got, want)
+	}
+	for _, line := range append(parallels, meridians...) {
+		for _, p := range line {
+			if !rect.ContainsPoint(p) {
+				t.Errorf("vertex %v is outside %v", p, rect)
+			}
+		}
+	}
+
+	// A cap centered on the equator is clipped along its boundary, so each
+	// line is split into at most one run.
+	c := CapFromCenterAngle(PointFromLatLng(LatLngFromDegrees(0, 0)), 10*s1.Degree)
+	parallels = GraticuleParallels(c, 5*s1.Degree, s1.Degree/10)
+	if got, want := len(parallels), 3; got != want {
+		t.Errorf("len(parallels) = %d, want %d", got, want)
+	}
+	for _, line := range append(parallels, GraticuleMeridians(c, 5*s1.Degree, s1.Degree/10)...) {
+		for _, p := range line {
+			if !c.ContainsPoint(p) {
+				t.Errorf("vertex %v is outside %v", p, c)
+			}
+		}
+		if line.Length() < 10*s1.Degree {
+			t.Errorf("line %v is shorter than expected", line)
+		}
+	}
+
+	if got := GraticuleParallels(EmptyRect(), s1.Degree, s1.Degree); got != nil {
+		t.Errorf("GraticuleParallels(EmptyRect()) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// This file contains hash functions for the basic types, for use in hash
+// tables and for sharding. The hash values are well distributed even for
+// nearby values such as consecutive CellIDs, and are guaranteed to remain
+// the same across releases and platforms, so they can be used to assign
+// values to shards consistently.
+//
+// Values that compare equal with == have equal hashes. In particular the
+// coordinates 0 and -0 hash the same, and so do all NaNs.
+
+// hashSeed is the initial state of the hash functions.
+const hashSeed = 0x9e3779b97f4a7c15
+
+// mix64 is the finalizer of the SplitMix64 generator, which is a bijection
+// that makes every bit of the output depend on every bit of the input.
+func mix64(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// hashCombine returns the hash of h followed by x.
+func hashCombine(h, x uint64) uint64 {
+	return mix64(h ^ (x + hashSeed + (h << 6) + (h >> 2)))
+}
+
+// hashFloat64Bits returns the bits of x, with 0 and -0 and all NaNs mapped
+// to a single representation.
+func hashFloat64Bits(x float64) uint64 {
+	switch {
+	case x == 0:
+		return 0
+	case math.IsNaN(x):
+		return 0x7ff8000000000001
+	}
+	return math.Float64bits(x)
+}
+
+// Hash returns a hash of the CellID.
+func (ci CellID) Hash() uint64 {
+	return mix64(uint64(ci) + hashSeed)
+}
+
+// Hash returns a hash of the point's coordinates.
+func (p Point) Hash() uint64 {
+	h := hashCombine(hashSeed, hashFloat64Bits(p.X))
+	h = hashCombine(h, hashFloat64Bits(p.Y))
+	return hashCombine(h, hashFloat64Bits(p.Z))
+}
+
+// Hash returns a hash of the latitude and longitude.
+func (ll LatLng) Hash() uint64 {
+	h := hashCombine(hashSeed, hashFloat64Bits(float64(ll.Lat)))
+	return hashCombine(h, hashFloat64Bits(float64(ll.Lng)))
+}
+
+// ApproxEqualWithin reports whether the two points are separated by at most
+// the given angle.
+func (p Point) ApproxEqualWithin(other Point, tolerance s1.Angle) bool {
+	return p.approxEqual(other, tolerance)
+}
+
+// ApproxEqualWithin reports whether the latitudes and the longitudes of the
+// two LatLngs each differ by at most the given angle. Longitudes that differ
+// by a multiple of 360 degrees are not considered equal; normalize the
+// LatLngs first if necessary.
+func (ll LatLng) ApproxEqualWithin(other LatLng, tolerance s1.Angle) bool {
+	return math.Abs(float64(ll.Lat-other.Lat)) <= float64(tolerance) &&
+		math.Abs(float64(ll.Lng-other.Lng)) <= float64(tolerance)
+}
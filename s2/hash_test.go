@@ -0,0 +1,96 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/golang/geo/s1"
+)
+
+func TestHashStable(t *testing.T) {
+	// These values must not change, since they may be used for sharding.
+	tests := []struct {
+		got, want uint64
+	}{
+		{CellIDFromFace(3).Hash(), 0xba74399249216370},
+		{Point{r3.Vector{X: 1, Y: 0, Z: 0}}.Hash(), 0x60b0e34effe128fe},
+		{LatLngFromDegrees(10, 20).Hash(), 0x641acee22e9e7df4},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("hash = %#x, want %#x", test.got, test.want)
+		}
+	}
+	if got, want := mix64(1), uint64(0x5692161d100b05e5); got != want {
+		t.Errorf("mix64(1) = %#x, want %#x", got, want)
+	}
+}
+
+func TestHashEqualValues(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	if a, b := (Point{r3.Vector{X: 0, Y: 1, Z: 0}}), (Point{r3.Vector{X: negZero, Y: 1, Z: negZero}}); a != b || a.Hash() != b.Hash() {
+		t.Errorf("points %v and %v: equal = %v, hashes %#x and %#x", a, b, a == b, a.Hash(), b.Hash())
+	}
+	nan1, nan2 := math.NaN(), math.Float64frombits(0x7ff8000000000123)
+	if a, b := LatLngFromDegrees(nan1, 0), (LatLng{s1.Angle(nan2), 0}); a.Hash() != b.Hash() {
+		t.Errorf("NaN LatLngs hash differently: %#x and %#x", a.Hash(), b.Hash())
+	}
+	for i := 0; i < 100; i++ {
+		p := randomPoint()
+		q := Point{p.Vector}
+		if p.Hash() != q.Hash() {
+			t.Errorf("%v.Hash() = %#x, copy hashes to %#x", p, p.Hash(), q.Hash())
+		}
+		if ll := LatLngFromPoint(p); ll.Hash() != LatLngFromPoint(q).Hash() {
+			t.Errorf("%v.Hash() is not deterministic", ll)
+		}
+	}
+	if a, b := parsePoint("1:2"), parsePoint("1:2.000001"); a.Hash() == b.Hash() {
+		t.Errorf("nearby points %v and %v have the same hash", a, b)
+	}
+}
+
+func TestHashDistribution(t *testing.T) {
+	// Consecutive cells, which differ only in a few low bits, should spread
+	// evenly over a small number of shards.
+	const numShards = 16
+	const numCells = 1 << 14
+	var counts [numShards]int
+	id := CellIDFromFace(3).ChildBeginAtLevel(12)
+	for i := 0; i < numCells; i++ {
+		counts[id.Hash()%numShards]++
+		id = id.Next()
+	}
+	for shard, n := range counts {
+		if want := numCells / numShards; n < want*9/10 || n > want*11/10 {
+			t.Errorf("shard %d has %d cells, want about %d", shard, n, want)
+		}
+	}
+}
+
+func TestApproxEqualWithin(t *testing.T) {
+	p := parsePoint("10:10")
+	q := parsePoint("10:10.001")
+	if !p.ApproxEqualWithin(q, s1.Degree/500) || p.ApproxEqualWithin(q, s1.Degree/2000) {
+		t.Errorf("%v.ApproxEqualWithin(%v) does not respect the tolerance", p, q)
+	}
+	a, b := LatLngFromDegrees(10, 10), LatLngFromDegrees(10.001, 9.999)
+	if !a.ApproxEqualWithin(b, s1.Degree/500) || a.ApproxEqualWithin(b, s1.Degree/2000) {
+		t.Errorf("%v.ApproxEqualWithin(%v) does not respect the tolerance", a, b)
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"sort"
+
+	"github.com/golang/geo/s1"
+)
+
+// HausdorffDistanceQueryOptions holds the options for a HausdorffDistanceQuery.
+type HausdorffDistanceQueryOptions struct {
+	includeInteriors bool
+}
+
+// NewHausdorffDistanceQueryOptions returns the default options, which
+// include polygon interiors.
+func NewHausdorffDistanceQueryOptions() *HausdorffDistanceQueryOptions {
+	return &HausdorffDistanceQueryOptions{includeInteriors: true}
+}
+
+// IncludeInteriors specifies whether polygon interiors in the source index
+// are considered when computing distances. If true, points of the target
+// that lie inside a source polygon are at distance zero from the source.
+// Otherwise only the edges and points of the source are used.
+func (o *HausdorffDistanceQueryOptions) IncludeInteriors(x bool) *HausdorffDistanceQueryOptions {
+	o.includeInteriors = x
+	return o
+}
+
+// hausdorffMaxDepth limits the number of times an edge of the target is
+// subdivided when searching for the point furthest from the source.
+const hausdorffMaxDepth = 20
+
+// DirectedHausdorffResult is the result of a directed Hausdorff distance
+// query: the distance, and the point of the target at which it is attained.
+type DirectedHausdorffResult struct {
+	distance    s1.ChordAngle
+	targetPoint Point
+}
+
+// Distance returns the directed Hausdorff distance.
+func (r DirectedHausdorffResult) Distance() s1.ChordAngle { return r.distance }
+
+// TargetPoint returns the point of the target that is furthest from the
+// source.
+func (r DirectedHausdorffResult) TargetPoint() Point { return r.targetPoint }
+
+// HausdorffResult is the result of an undirected Hausdorff distance query,
+// which consists of the directed results in both directions.
+type HausdorffResult struct {
+	// TargetToSource is the directed result from the first index to the second.
+	TargetToSource DirectedHausdorffResult
+	// SourceToTarget is the directed result from the second index to the first.
+	SourceToTarget DirectedHausdorffResult
+}
+
+// Distance returns the undirected Hausdorff distance, which is the larger of
+// the two directed distances.
+func (r HausdorffResult) Distance() s1.ChordAngle {
+	if r.TargetToSource.distance > r.SourceToTarget.distance {
+		return r.TargetToSource.distance
+	}
+	return r.SourceToTarget.distance
+}
+
+// HausdorffDistanceQuery computes the Hausdorff distance between the geometry
+// of two ShapeIndexes. The directed Hausdorff distance from a target to a
+// source is the maximum, over all points of the target, of the distance from
+// the point to the closest point of the source. The (undirected) Hausdorff
+// distance is the larger of the two directed distances, and is a measure of
+// how similar two geometries are.
+//
+// The target is represented by its points and edges; the interiors of target
+// polygons are not considered. Along each target edge the furthest point is
+// found among the endpoints and the points where the closest source feature
+// changes, so the result is exact up to the accuracy of the distance
+// computations, except that subdivision of each target edge stops after a
+// fixed depth, which may underestimate the distance for edges near a very
+// large number of source features.
+//
+// Example:
+//
+//	query := s2.NewHausdorffDistanceQuery(nil)
+//	if result, ok := query.Result(index1, index2); ok {
+//		fmt.Println(result.Distance())
+//	}
+type HausdorffDistanceQuery struct {
+	opts *HausdorffDistanceQueryOptions
+}
+
+// NewHausdorffDistanceQuery returns a query with the given options. If opts
+// is nil, the default options are used.
+func NewHausdorffDistanceQuery(opts *HausdorffDistanceQueryOptions) *HausdorffDistanceQuery {
+	if opts == nil {
+		opts = NewHausdorffDistanceQueryOptions()
+	}
+	return &HausdorffDistanceQuery{opts: opts}
+}
+
+// DirectedResult returns the directed Hausdorff distance from the target to
+// the source, and the target point where it is attained. It returns false if
+// either index has no geometry.
+func (q *HausdorffDistanceQuery) DirectedResult(target, source *ShapeIndex) (DirectedHausdorffResult, bool) {
+	h := &hausdorffSearch{
+		closest:  NewClosestEdgeQuery(source, NewClosestEdgeQueryOptions().IncludeInteriors(q.opts.includeInteriors)),
+		boundary: NewClosestEdgeQuery(source, NewClosestEdgeQueryOptions().IncludeInteriors(false)),
+		result:   DirectedHausdorffResult{distance: s1.NegativeChordAngle},
+	}
+	if q.opts.includeInteriors {
+		h.crossings = NewCrossingEdgeQuery(source)
+		h.contains = NewContainsPointQuery(source, VertexModelSemiOpen)
+	}
+
+	for id := int32(0); id < target.nextID; id++ {
+		shape := target.Shape(id)
+		if shape == nil {
+			continue
+		}
+		for e := 0; e < shape.NumEdges(); e++ {
+			edge := shape.Edge(e)
+			if !h.updateVertex(edge.V0) {
+				return DirectedHausdorffResult{}, false
+			}
+			if edge.V0 != edge.V1 {
+				h.updateEdge(edge.V0, edge.V1)
+			}
+		}
+	}
+
+	if h.result.distance < 0 {
+		return DirectedHausdorffResult{}, false
+	}
+	return h.result, true
+}
+
+// DirectedDistance returns the directed Hausdorff distance from the target to
+// the source, or s1.InfChordAngle if either index has no geometry.
+func (q *HausdorffDistanceQuery) DirectedDistance(target, source *ShapeIndex) s1.ChordAngle {
+	result, ok := q.DirectedResult(target, source)
+	if !ok {
+		return s1.InfChordAngle()
+	}
+	return result.distance
+}
+
+// Result returns the directed Hausdorff results in both directions between
+// the two indexes. It returns false if either index has no geometry.
+func (q *HausdorffDistanceQuery) Result(index1, index2 *ShapeIndex) (HausdorffResult, bool) {
+	r1, ok := q.DirectedResult(index1, index2)
+	if !ok {
+		return HausdorffResult{}, false
+	}
+	r2, ok := q.DirectedResult(index2, index1)
+	if !ok {
+		return HausdorffResult{}, false
+	}
+	return HausdorffResult{TargetToSource: r1, SourceToTarget: r2}, true
+}
+
+// Distance returns the undirected Hausdorff distance between the two
+// indexes, or s1.InfChordAngle if either index has no geometry.
+func (q *HausdorffDistanceQuery) Distance(index1, index2 *ShapeIndex) s1.ChordAngle {
+	result, ok := q.Result(index1, index2)
+	if !ok {
+		return s1.InfChordAngle()
+	}
+	return result.Distance()
+}
+
+// hausdorffClosest is the closest source edge or point to a target point.
+type hausdorffClosest struct {
+	result EdgeQueryResult
+	point  Point // The closest point of the source.
+}
+
+// hausdorffSearch holds the state of a directed Hausdorff distance search.
+type hausdorffSearch struct {
+	closest  *EdgeQuery // Finds the closest source edge or interior.
+	boundary *EdgeQuery // Finds the closest source edge, excluding interiors.
+
+	// If interiors are included, crossings and contains are used to find
+	// the parts of the target edges that are inside source polygons.
+	crossings *CrossingEdgeQuery
+	contains  *ContainsPointQuery
+
+	result DirectedHausdorffResult
+}
+
+// update records p as the furthest target point if its distance d from the
+// source is larger than that of the points seen so far.
+func (h *hausdorffSearch) update(p Point, d s1.ChordAngle) {
+	if d > h.result.distance {
+		h.result = DirectedHausdorffResult{distance: d, targetPoint: p}
+	}
+}
+
+// updateVertex updates the result with the distance from the target vertex
+// p to the source. It returns false if the source has no geometry.
+func (h *hausdorffSearch) updateVertex(p Point) bool {
+	results := h.closest.FindEdges(NewMinDistanceToPointTarget(p))
+	if len(results) == 0 {
+		return false
+	}
+	h.update(p, results[0].Distance())
+	return true
+}
+
+// updateEdge updates the result with the distances from the points of the
+// target edge (a, b) to the source. If interiors are included, the edge is
+// split where it crosses the boundaries of source polygons, and the pieces
+// inside a polygon are skipped since all their points are at distance zero.
+// Outside of the polygons, the distance to the source is the distance to its
+// edges and points.
+func (h *hausdorffSearch) updateEdge(a, b Point) {
+	if h.crossings == nil {
+		h.searchEdge(a, b, h.closestBoundary(a), h.closestBoundary(b), 0)
+		return
+	}
+
+	fractions := []float64{0, 1}
+	for shape, edges := range h.crossings.CrossingsEdgeMap(a, b, CrossingTypeAll) {
+		if shape.Dimension() != 2 {
+			continue
+		}
+		for _, e := range edges {
+			edge := shape.Edge(e)
+			x := Intersection(a, b, edge.V0, edge.V1)
+			fractions = append(fractions, DistanceFraction(x, a, b))
+		}
+	}
+	sort.Float64s(fractions)
+
+	p0 := a
+	for i := 1; i < len(fractions); i++ {
+		if fractions[i] == fractions[i-1] {
+			continue
+		}
+		p1 := Interpolate(fractions[i], a, b)
+		if !h.contains.Contains(Interpolate(0.5, p0, p1)) {
+			h.searchEdge(p0, p1, h.closestBoundary(p0), h.closestBoundary(p1), 0)
+		}
+		p0 = p1
+	}
+}
+
+// closestBoundary finds the closest source edge or point to p, which must
+// not be inside a source polygon, and updates the result with its distance.
+func (h *hausdorffSearch) closestBoundary(p Point) hausdorffClosest {
+	results := h.boundary.FindEdges(NewMinDistanceToPointTarget(p))
+	if len(results) == 0 {
+		// The source consists only of polygons without edges.
+		return hausdorffClosest{result: newEdgeQueryResult(NewMinDistanceToPointTarget(p)), point: p}
+	}
+	r := results[0]
+	h.update(p, r.Distance())
+	return hausdorffClosest{r, h.boundary.Project(p, r)}
+}
+
+// searchEdge finds the point of the target edge (a, b) furthest from the
+// source, given the closest source edges or points to a and b. The distance
+// to a single source edge along a target edge is largest at one of its
+// endpoints, so the edge only needs to be subdivided where the closest source
+// edge changes, which is where the edge crosses the bisector of the closest
+// points of the two source edges.
+func (h *hausdorffSearch) searchEdge(a, b Point, ca, cb hausdorffClosest, depth int) {
+	if depth >= hausdorffMaxDepth || ca.result.IsEmpty() || cb.result.IsEmpty() {
+		return
+	}
+	if ca.result.shapeEdgeID() == cb.result.shapeEdgeID() || ca.point == cb.point {
+		return
+	}
+	// Distances change no faster than the position along the edge, so no
+	// point of the edge is further than this from the source.
+	bound := (ca.result.Distance().Angle() + cb.result.Distance().Angle() + a.Distance(b)) / 2
+	if s1.ChordAngleFromAngle(bound) <= h.result.distance {
+		return
+	}
+
+	// The points equidistant from the two closest points lie on the great
+	// circle whose normal is their difference.
+	n := ca.point.Sub(cb.point.Vector)
+	ab := a.PointCross(b)
+	m := Point{ab.Cross(n).Normalize()}
+	if m.Dot(a.Add(b.Vector)) < 0 {
+		m = Point{m.Mul(-1)}
+	}
+	if !m.IsUnit() || m == a || m == b || !Sign(a, m, ab) || !Sign(m, b, ab) {
+		return
+	}
+	cm := h.closestBoundary(m)
+	h.searchEdge(a, m, ca, cm, depth+1)
+	h.searchEdge(m, b, cm, cb, depth+1)
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+// bruteForceDirectedHausdorff returns the largest distance from the source of
+// the target vertices and of numSamples points along each target edge.
+func bruteForceDirectedHausdorff(target, source *ShapeIndex, includeInteriors bool, numSamples int) s1.ChordAngle {
+	query := NewClosestEdgeQuery(source, NewClosestEdgeQueryOptions().IncludeInteriors(includeInteriors))
+	max := s1.NegativeChordAngle
+	for id := int32(0); id < target.nextID; id++ {
+		shape := target.Shape(id)
+		for e := 0; e < shape.NumEdges(); e++ {
+			edge := shape.Edge(e)
+			for i := 0; i <= numSamples; i++ {
+				p := Interpolate(float64(i)/float64(numSamples), edge.V0, edge.V1)
+				if d := query.Distance(NewMinDistanceToPointTarget(p)); d > max {
+					max = d
+				}
+			}
+		}
+	}
+	return max
+}
+
+func TestHausdorffDistanceQueryKnownDistances(t *testing.T) {
+	tests := []struct {
+		target, source string
+		want           s1.Angle // The directed distance from target to source.
+	}{
+		{"0:0 # #", "0:1 # #", s1.Degree},
+		{"# 0:0, 0:10 #", "0:0 | 0:10 # #", 5 * s1.Degree},
+		{"# 0:0, 0:10 #", "0:0 | 0:4 | 0:10 # #", 3 * s1.Degree},
+		{"# 0:0, 0:10 #", "# 0:0, 0:10 #", 0},
+		{"# 0:0, 0:10 #", "# 0:0, 0:10, 0:20 #", 0},
+		{"# 0:0, 0:10, 0:20 #", "# 0:0, 0:10 #", 10 * s1.Degree},
+		{"# 1:1, 2:2 #", "# # 0:0, 0:3, 3:3, 3:0", 0},
+	}
+	query := NewHausdorffDistanceQuery(nil)
+	for _, test := range tests {
+		target := makeShapeIndex(test.target)
+		source := makeShapeIndex(test.source)
+		result, ok := query.DirectedResult(target, source)
+		if !ok {
+			t.Errorf("DirectedResult(%q, %q) = false, want true", test.target, test.source)
+			continue
+		}
+		if got := result.Distance().Angle(); !float64Near(got.Degrees(), test.want.Degrees(), 1e-9) {
+			t.Errorf("DirectedResult(%q, %q).Distance() = %v, want %v", test.target, test.source, got, test.want)
+		}
+		// The distance is attained at the target point.
+		d := NewClosestEdgeQuery(source, nil).Distance(NewMinDistanceToPointTarget(result.TargetPoint()))
+		if !float64Near(float64(d), float64(result.Distance()), 1e-15) {
+			t.Errorf("distance from the target point %v to %q = %v, want %v", result.TargetPoint(), test.source, d, result.Distance())
+		}
+	}
+}
+
+func TestHausdorffDistanceQueryIncludeInteriors(t *testing.T) {
+	target := makeShapeIndex("# 1:1, 2:2 #")
+	source := makeShapeIndex("# # 0:0, 0:3, 3:3, 3:0")
+
+	if got := NewHausdorffDistanceQuery(nil).DirectedDistance(target, source); got != 0 {
+		t.Errorf("DirectedDistance with interiors = %v, want 0", got)
+	}
+	got := NewHausdorffDistanceQuery(NewHausdorffDistanceQueryOptions().IncludeInteriors(false)).DirectedDistance(target, source)
+	want := bruteForceDirectedHausdorff(target, source, false, 100)
+	if got < want || got.Angle()-want.Angle() > 0.01*s1.Degree {
+		t.Errorf("DirectedDistance without interiors = %v, want %v", got.Angle(), want.Angle())
+	}
+
+	// A target edge that leaves the polygon and comes back.
+	target = makeShapeIndex("# 0.5:-1, 0.5:4 #")
+	source = makeShapeIndex("# # 0:0, 0:1, 1:1, 1:2, 0:2, 0:3, 3:3, 3:0")
+	got = NewHausdorffDistanceQuery(nil).DirectedDistance(target, source)
+	want = bruteForceDirectedHausdorff(target, source, true, 1000)
+	if got < want || got.Angle()-want.Angle() > 1e-3*s1.Degree {
+		t.Errorf("DirectedDistance of an edge leaving the polygon = %v, want %v", got.Angle(), want.Angle())
+	}
+}
+
+func TestHausdorffDistanceQueryEmpty(t *testing.T) {
+	query := NewHausdorffDistanceQuery(nil)
+	empty := NewShapeIndex()
+	index := makeShapeIndex("0:0 # #")
+
+	if _, ok := query.DirectedResult(empty, index); ok {
+		t.Errorf("DirectedResult(empty, index) = true, want false")
+	}
+	if _, ok := query.DirectedResult(index, empty); ok {
+		t.Errorf("DirectedResult(index, empty) = true, want false")
+	}
+	if got := query.Distance(index, empty); got != s1.InfChordAngle() {
+		t.Errorf("Distance(index, empty) = %v, want %v", got, s1.InfChordAngle())
+	}
+}
+
+func TestHausdorffDistanceQueryRandom(t *testing.T) {
+	query := NewHausdorffDistanceQuery(nil)
+	for i := 0; i < 20; i++ {
+		center := randomPoint()
+		a := NewShapeIndex()
+		a.Add(RegularLoop(center, kmToAngle(100), 7))
+		b := NewShapeIndex()
+		b.Add(&Polyline{
+			center,
+			Point{center.Add(randomPoint().Mul(0.02)).Normalize()},
+			Point{center.Add(randomPoint().Mul(0.02)).Normalize()},
+		})
+
+		result, ok := query.Result(a, b)
+		if !ok {
+			t.Fatalf("Result() = false, want true")
+		}
+		for _, test := range []struct {
+			target, source *ShapeIndex
+			got            s1.ChordAngle
+		}{
+			{a, b, result.TargetToSource.Distance()},
+			{b, a, result.SourceToTarget.Distance()},
+		} {
+			// The sampled points are at most half the sample spacing from
+			// the furthest point of the target.
+			want := bruteForceDirectedHausdorff(test.target, test.source, true, 200)
+			if test.got.Angle() < want.Angle()-1e-12 || test.got.Angle()-want.Angle() > kmToAngle(1) {
+				t.Errorf("%d. directed distance = %v, brute force %v", i, test.got.Angle(), want.Angle())
+			}
+		}
+		if got, want := result.Distance(), s1.ChordAngle(math.Max(float64(result.TargetToSource.Distance()), float64(result.SourceToTarget.Distance()))); got != want {
+			t.Errorf("Result().Distance() = %v, want %v", got, want)
+		}
+		if got, want := query.Distance(b, a), result.Distance(); got != want {
+			t.Errorf("Distance(b, a) = %v, want Distance(a, b) = %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+
+package s2
+
+// This file contains range-over-func iterators for traversals, which
+// require Go 1.23. Where a method returning a slice already exists, the
+// iterator form has the same name with a Seq suffix, in the style of
+// strings.Split and strings.SplitSeq.
+
+import "iter"
+
+// CellsAtLevel returns an iterator over the cells at the given level that
+// intersect this cell union, in increasing order. Cells of the union that
+// are larger than the level are expanded into their descendants at that
+// level, and cells that are smaller are replaced by their ancestor, without
+// materializing the (possibly very large) result. The union must be
+// normalized.
+func (cu *CellUnion) CellsAtLevel(level int) iter.Seq[CellID] {
+	return func(yield func(CellID) bool) {
+		var last CellID
+		for _, id := range *cu {
+			if id.Level() >= level {
+				if parent := id.Parent(level); parent != last {
+					last = parent
+					if !yield(parent) {
+						return
+					}
+				}
+				continue
+			}
+			end := id.ChildEndAtLevel(level)
+			for c := id.ChildBeginAtLevel(level); c != end; c = c.Next() {
+				if !yield(c) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// VerticesSeq returns an iterator over the vertices of the loop and their
+// indices.
+func (l *Loop) VerticesSeq() iter.Seq2[int, Point] {
+	return func(yield func(int, Point) bool) {
+		for i, v := range l.vertices {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// LoopsSeq returns an iterator over the loops of the polygon and their
+// indices, in the same order as Loops.
+func (p *Polygon) LoopsSeq() iter.Seq2[int, *Loop] {
+	return func(yield func(int, *Loop) bool) {
+		for i, l := range p.loops {
+			if !yield(i, l) {
+				return
+			}
+		}
+	}
+}
+
+// Shapes returns an iterator over the shapes in the index in increasing
+// order of shape ID. Shapes that have been removed are skipped.
+func (s *ShapeIndex) Shapes() iter.Seq2[int32, Shape] {
+	return func(yield func(int32, Shape) bool) {
+		for id := int32(0); id < s.nextID; id++ {
+			shape := s.Shape(id)
+			if shape == nil {
+				continue
+			}
+			if !yield(id, shape) {
+				return
+			}
+		}
+	}
+}
+
+// Edges returns an iterator over all the edges of all the shapes in the
+// index, in increasing order of shape ID and then edge ID.
+func (s *ShapeIndex) Edges() iter.Seq2[ShapeEdgeID, Edge] {
+	return func(yield func(ShapeEdgeID, Edge) bool) {
+		for id, shape := range s.Shapes() {
+			for e := 0; e < shape.NumEdges(); e++ {
+				if !yield(ShapeEdgeID{id, int32(e)}, shape.Edge(e)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Cells returns an iterator over the cells of the index and their contents,
+// in increasing order of CellID. The index is built first if necessary.
+func (s *ShapeIndex) Cells() iter.Seq2[CellID, *ShapeIndexCell] {
+	return func(yield func(CellID, *ShapeIndexCell) bool) {
+		for it := s.Iterator(); !it.Done(); it.Next() {
+			if !yield(it.CellID(), it.IndexCell()) {
+				return
+			}
+		}
+	}
+}
+
+// ContainingShapesSeq returns an iterator over the shapes that contain the
+// given point. Each shape is visited at most once. Unlike ContainingShapes,
+// the shapes are found one at a time as the iteration proceeds.
+func (q *ContainsPointQuery) ContainingShapesSeq(p Point) iter.Seq[Shape] {
+	return func(yield func(Shape) bool) {
+		q.VisitContainingShapes(p, func(shape Shape) bool {
+			return yield(shape)
+		})
+	}
+}
+
+// FindEdgesSeq returns an iterator over the results of FindEdges. The
+// results are computed when the iteration begins.
+func (e *EdgeQuery) FindEdgesSeq(target distanceTarget) iter.Seq[EdgeQueryResult] {
+	return func(yield func(EdgeQueryResult) bool) {
+		for _, r := range e.FindEdges(target) {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// RegionCellsAtLevel returns an iterator over the cells at the given level
+// that intersect the region, in increasing order. Like RegionCellIterator,
+// it does not materialize the covering.
+func RegionCellsAtLevel(region Region, level int) iter.Seq[CellID] {
+	return func(yield func(CellID) bool) {
+		for it := NewRegionCellIterator(region, level); !it.Done(); it.Next() {
+			if !yield(it.CellID()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+
+package s2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCellUnionCellsAtLevel(t *testing.T) {
+	face := CellIDFromFace(1)
+	x := face.ChildBeginAtLevel(2).Next().Next().ChildBeginAtLevel(5)
+	cu := CellUnion{face.ChildBeginAtLevel(2), x, x.Next()}
+	cu.Normalize()
+
+	var got []CellID
+	for id := range cu.CellsAtLevel(3) {
+		got = append(got, id)
+	}
+	children := face.ChildBeginAtLevel(2).Children()
+	want := append([]CellID(nil), children[:]...)
+	want = append(want, x.Parent(3))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CellsAtLevel(3) = %v, want %v", got, want)
+	}
+
+	// The expansion matches the bitset conversion.
+	for i := 0; i < 10; i++ {
+		var cu CellUnion
+		for j := 0; j < 5; j++ {
+			cu = append(cu, randomCellIDForLevel(randomUniformInt(10)))
+		}
+		cu.Normalize()
+		var got []CellIndexRange
+		for id := range cu.CellsAtLevel(7) {
+			got = append(got, CellIndexRange{id.LevelIndex(), id.LevelIndex() + 1})
+		}
+		if s := CellBitsetFromRanges(7, got); !s.Equal(CellBitsetFromCellUnion(cu, 7)) {
+			t.Errorf("CellsAtLevel(%v) = %v, want %v", cu, s.Ranges(), CellBitsetFromCellUnion(cu, 7).Ranges())
+		}
+		if len(got) != int(CellBitsetFromCellUnion(cu, 7).Cardinality()) {
+			t.Errorf("CellsAtLevel(%v) returned %d cells, want no duplicates", cu, len(got))
+		}
+	}
+
+	// Stopping early.
+	n := 0
+	for range cu.CellsAtLevel(10) {
+		if n++; n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("iteration did not stop early")
+	}
+}
+
+func TestLoopAndPolygonSeq(t *testing.T) {
+	p := makePolygon("0:0, 0:10, 10:0; 1:1, 1:2, 2:1", true)
+	for i, l := range p.LoopsSeq() {
+		if l != p.Loop(i) {
+			t.Errorf("LoopsSeq()[%d] = %v, want %v", i, l, p.Loop(i))
+		}
+		n := 0
+		for j, v := range l.VerticesSeq() {
+			if v != l.Vertex(j) {
+				t.Errorf("VerticesSeq()[%d] = %v, want %v", j, v, l.Vertex(j))
+			}
+			n++
+		}
+		if n != l.NumVertices() {
+			t.Errorf("VerticesSeq() returned %d vertices, want %d", n, l.NumVertices())
+		}
+	}
+}
+
+func TestShapeIndexSeq(t *testing.T) {
+	index := NewShapeIndex()
+	a := makePolyline("0:0, 0:1, 1:1")
+	b := makePolyline("5:5, 5:6")
+	c := makePolygon("10:10, 10:12, 12:10", true)
+	index.Add(a)
+	index.Add(b)
+	index.Add(c)
+
+	var ids []int32
+	for id, shape := range index.Shapes() {
+		ids = append(ids, id)
+		if shape != index.Shape(id) {
+			t.Errorf("Shapes()[%d] = %v, want %v", id, shape, index.Shape(id))
+		}
+	}
+	if want := []int32{0, 1, 2}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Shapes() ids = %v, want %v", ids, want)
+	}
+
+	numEdges := 0
+	for id, edge := range index.Edges() {
+		if want := index.Shape(id.ShapeID).Edge(int(id.EdgeID)); edge != want {
+			t.Errorf("Edges()[%v] = %v, want %v", id, edge, want)
+		}
+		numEdges++
+	}
+	if numEdges != index.NumEdges() {
+		t.Errorf("Edges() returned %d edges, want %d", numEdges, index.NumEdges())
+	}
+
+	var cells CellUnion
+	for id, cell := range index.Cells() {
+		if cell == nil {
+			t.Errorf("Cells() returned a nil cell for %v", id)
+		}
+		cells = append(cells, id)
+	}
+	if len(cells) == 0 || !cells.IsValid() {
+		t.Errorf("Cells() = %v, want a non-empty sorted list of disjoint cells", cells)
+	}
+
+	q := NewContainsPointQuery(index, VertexModelSemiOpen)
+	var shapes []Shape
+	for shape := range q.ContainingShapesSeq(PointFromLatLng(LatLngFromDegrees(10.5, 10.5))) {
+		shapes = append(shapes, shape)
+	}
+	if len(shapes) != 1 || shapes[0] != Shape(c) {
+		t.Errorf("ContainingShapesSeq() = %v, want [%v]", shapes, c)
+	}
+
+	query := NewClosestEdgeQuery(index, NewClosestEdgeQueryOptions().MaxResults(2))
+	target := NewMinDistanceToPointTarget(PointFromLatLng(LatLngFromDegrees(0, 0.5)))
+	var results []EdgeQueryResult
+	for r := range query.FindEdgesSeq(target) {
+		results = append(results, r)
+	}
+	if want := query.FindEdges(target); !reflect.DeepEqual(results, want) {
+		t.Errorf("FindEdgesSeq() = %v, want %v", results, want)
+	}
+
+	// Removed shapes are skipped.
+	index.Remove(b)
+	ids = nil
+	for id := range index.Shapes() {
+		ids = append(ids, id)
+	}
+	if want := []int32{0, 2}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Shapes() ids after Remove = %v, want %v", ids, want)
+	}
+}
+
+func TestRegionCellsAtLevel(t *testing.T) {
+	region := CapFromCenterAngle(PointFromLatLng(LatLngFromDegrees(3, 4)), 0.1)
+	var got []CellID
+	for id := range RegionCellsAtLevel(region, 6) {
+		got = append(got, id)
+	}
+	var want []CellID
+	for it := NewRegionCellIterator(region, 6); !it.Done(); it.Next() {
+		want = append(want, it.CellID())
+	}
+	if len(want) == 0 || !reflect.DeepEqual(got, want) {
+		t.Errorf("RegionCellsAtLevel = %v, want %v", got, want)
+	}
+}
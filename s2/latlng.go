@@ -37,6 +37,17 @@ func LatLngFromDegrees(lat, lng float64) LatLng {
 	return LatLng{s1.Angle(lat) * s1.Degree, s1.Angle(lng) * s1.Degree}
 }
 
+// LatLngFromDegreesOrError is like LatLngFromDegrees, but returns an error
+// if the coordinates are out of range (see IsValid) or not finite, instead of
+// returning a LatLng that maps to an unexpected point.
+func LatLngFromDegreesOrError(lat, lng float64) (LatLng, error) {
+	ll := LatLngFromDegrees(lat, lng)
+	if !ll.IsValid() {
+		return LatLng{}, fmt.Errorf("s2: invalid coordinates (%v, %v): latitude must be within [-90, 90] and longitude within [-180, 180] degrees", lat, lng)
+	}
+	return ll, nil
+}
+
 // IsValid returns true iff the LatLng is normalized, with Lat ∈ [-π/2,π/2] and Lng ∈ [-π,π].
 func (ll LatLng) IsValid() bool {
 	return math.Abs(ll.Lat.Radians()) <= math.Pi/2 && math.Abs(ll.Lng.Radians()) <= math.Pi
@@ -80,7 +91,7 @@ func longitude(p Point) s1.Angle {
 }
 
 // PointFromLatLng returns an Point for the given LatLng.
-// The maximum error in the result is 1.5 * dblEpsilon. (This does not
+// The maximum error in the result is 1.5 * DblEpsilon. (This does not
 // include the error of converting degrees, E5, E6, or E7 into radians.)
 func PointFromLatLng(ll LatLng) Point {
 	phi := ll.Lat.Radians()
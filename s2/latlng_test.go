@@ -168,3 +168,21 @@ func TestLatLngApproxEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestLatLngFromDegreesOrError(t *testing.T) {
+	for _, c := range [][2]float64{{0, 0}, {90, 180}, {-90, -180}, {45.5, -122.25}} {
+		got, err := LatLngFromDegreesOrError(c[0], c[1])
+		if err != nil {
+			t.Errorf("LatLngFromDegreesOrError(%v, %v) returned error: %v", c[0], c[1], err)
+			continue
+		}
+		if want := LatLngFromDegrees(c[0], c[1]); got != want {
+			t.Errorf("LatLngFromDegreesOrError(%v, %v) = %v, want %v", c[0], c[1], got, want)
+		}
+	}
+	for _, c := range [][2]float64{{90.001, 0}, {0, -180.5}, {math.NaN(), 0}, {0, math.Inf(1)}} {
+		if got, err := LatLngFromDegreesOrError(c[0], c[1]); err == nil {
+			t.Errorf("LatLngFromDegreesOrError(%v, %v) = %v, want error", c[0], c[1], got)
+		}
+	}
+}
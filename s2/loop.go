@@ -68,7 +68,10 @@ type Loop struct {
 	index *ShapeIndex
 }
 
-// LoopFromPoints constructs a loop from the given points.
+// LoopFromPoints constructs a loop from the given points. A single point
+// denotes the special empty or full loop, depending on whether it lies in
+// the northern or southern hemisphere, and is replaced by the canonical
+// vertex for that loop (see IsEmptyOrFullVertex).
 func LoopFromPoints(pts []Point) *Loop {
 	l := &Loop{
 		vertices: pts,
@@ -79,6 +82,23 @@ func LoopFromPoints(pts []Point) *Loop {
 	return l
 }
 
+// LoopFromPointsOrError is like LoopFromPoints, but returns an error if the
+// points do not form a valid loop as checked by Validate, for example if
+// there are fewer than 3 of them (other than for the empty and full loops)
+// or they contain duplicates. Use it when the points come from external
+// data, so that problems are reported when the data is read rather than as
+// wrong query results.
+func LoopFromPointsOrError(pts []Point) (*Loop, error) {
+	if len(pts) == 0 {
+		return nil, fmt.Errorf("s2: invalid loop: no vertices")
+	}
+	l := LoopFromPoints(pts)
+	if err := l.Validate(); err != nil {
+		return nil, fmt.Errorf("s2: invalid loop: %v", err)
+	}
+	return l, nil
+}
+
 // LoopFromCell constructs a loop corresponding to the given cell.
 //
 // Note that the loop and cell *do not* contain exactly the same set of
@@ -110,6 +130,23 @@ var (
 	fullLoopPoint  = Point{r3.Vector{X: 0, Y: 0, Z: -1}}
 )
 
+// IsEmptyOrFullVertex reports whether p is the vertex of the canonical empty
+// or full loop. Any single-vertex loop is interpreted as empty or full, but
+// only these two vertices are produced by the constructors and decoders, so
+// that loops compare equal regardless of where their vertex came from.
+func IsEmptyOrFullVertex(p Point) bool {
+	return p == emptyLoopPoint || p == fullLoopPoint
+}
+
+// canonicalEmptyOrFullVertex returns the canonical vertex of the empty loop
+// if full is false, or of the full loop otherwise.
+func canonicalEmptyOrFullVertex(full bool) Point {
+	if full {
+		return fullLoopPoint
+	}
+	return emptyLoopPoint
+}
+
 // EmptyLoop returns a special "empty" loop.
 func EmptyLoop() *Loop {
 	return LoopFromPoints([]Point{emptyLoopPoint})
@@ -133,6 +170,11 @@ func (l *Loop) initOriginAndBound() {
 		// This is the special empty or full loop, so the origin depends on if
 		// the vertex is in the southern hemisphere or not.
 		l.originInside = l.vertices[0].Z < 0
+		if v := canonicalEmptyOrFullVertex(l.originInside); l.vertices[0] != v {
+			// Replace the vertices rather than overwriting them, since the
+			// slice may belong to the caller of LoopFromPoints.
+			l.vertices = []Point{v}
+		}
 	} else {
 		// The brute force point containment algorithm works by counting edge
 		// crossings starting at a fixed reference point (chosen as OriginPoint()
@@ -222,10 +264,7 @@ func (l *Loop) Validate() error {
 	}
 
 	// Check for intersections between non-adjacent edges (including at vertices)
-	// TODO(roberts): Once shapeutil gets findAnyCrossing uncomment this.
-	// return findAnyCrossing(l.index)
-
-	return nil
+	return findSelfIntersection(l.index)
 }
 
 // findValidationErrorNoIndex reports whether this is not a valid loop, but
@@ -555,6 +594,43 @@ func (l *Loop) CapBound() Cap {
 	return l.bound.CapBound()
 }
 
+// SubregionBound returns a bounding rectangle that contains the RectBound of
+// every loop contained by this loop, which may be slightly larger than this
+// loop's own RectBound due to rounding errors. See ExpandForSubregions.
+func (l *Loop) SubregionBound() Rect {
+	return l.subregionBound
+}
+
+// CheckBoundsConsistency reports an error if the cached bounds of the loop
+// are inconsistent with its vertices: the RectBound must contain every vertex
+// and every pole contained by the loop, and the SubregionBound must contain
+// the RectBound. It is meant for diagnosing loops built or modified outside
+// of the usual constructors.
+func (l *Loop) CheckBoundsConsistency() error {
+	if !l.subregionBound.Contains(l.bound) {
+		return fmt.Errorf("subregion bound %v does not contain bound %v", l.subregionBound, l.bound)
+	}
+	if l.isEmptyOrFull() {
+		if l.IsEmpty() != l.bound.IsEmpty() || l.IsFull() != l.bound.IsFull() {
+			return fmt.Errorf("bound %v does not match empty or full loop", l.bound)
+		}
+		return nil
+	}
+	for i, v := range l.vertices {
+		if !l.bound.ContainsPoint(v) {
+			return fmt.Errorf("bound %v does not contain vertex %d", l.bound, i)
+		}
+	}
+	// ContainsPoint may use the bound itself, so count crossings directly.
+	if l.bruteForceContainsPoint(Point{r3.Vector{0, 0, 1}}) && l.bound.Lat.Hi != math.Pi/2 {
+		return fmt.Errorf("bound %v does not contain the north pole", l.bound)
+	}
+	if l.bruteForceContainsPoint(Point{r3.Vector{0, 0, -1}}) && l.bound.Lat.Lo != -math.Pi/2 {
+		return fmt.Errorf("bound %v does not contain the south pole", l.bound)
+	}
+	return nil
+}
+
 // Vertex returns the vertex for the given index. For convenience, the vertex indices
 // wrap automatically for methods that do index math such as Edge.
 // i.e., Vertex(NumEdges() + n) is the same as Vertex(n).
@@ -628,6 +704,17 @@ func (l *Loop) ContainsPoint(p Point) bool {
 	return l.iteratorContainsPoint(it, p)
 }
 
+// ContainsPointFast is like ContainsPoint, but always rejects points outside
+// the loop's bounding rectangle before looking at its edges or index. This is
+// faster when many of the points tested are far from the loop. To test many
+// points against the same loop, use a LoopContainsPointQuery instead.
+func (l *Loop) ContainsPointFast(p Point) bool {
+	if !l.bound.ContainsPoint(p) {
+		return false
+	}
+	return l.ContainsPoint(p)
+}
+
 // ContainsCell reports whether the given Cell is contained by this Loop.
 func (l *Loop) ContainsCell(target Cell) bool {
 	it := l.index.Iterator()
@@ -704,11 +791,11 @@ func (l *Loop) boundaryApproxIntersects(it *ShapeIndexIterator, target Cell) boo
 	}
 
 	// Otherwise check whether any of the edges intersect target.
-	maxError := (faceClipErrorUVCoord + intersectsRectErrorUVDist)
+	maxError := (FaceClipErrorUVCoord + IntersectsRectErrorUVDist)
 	bound := target.BoundUV().ExpandedByMargin(maxError)
 	for _, ai := range aClipped.edges {
 		v0, v1, ok := ClipToPaddedFace(l.Vertex(ai), l.Vertex(ai+1), target.Face(), maxError)
-		if ok && edgeIntersectsRect(v0, v1, bound) {
+		if ok && EdgeIntersectsRect(v0, v1, bound) {
 			return true
 		}
 	}
@@ -756,22 +843,7 @@ func RegularLoopForFrame(frame matrix3x3, radius s1.Angle, numVertices int) *Loo
 // chosen such that (first, ..., first+n*dir) are in the range [0, 2*n-1] as
 // expected by the Vertex method.
 func (l *Loop) CanonicalFirstVertex() (firstIdx, direction int) {
-	firstIdx = 0
-	n := len(l.vertices)
-	for i := 1; i < n; i++ {
-		if l.Vertex(i).Cmp(l.Vertex(firstIdx).Vector) == -1 {
-			firstIdx = i
-		}
-	}
-
-	// 0 <= firstIdx <= n-1, so (firstIdx+n*dir) <= 2*n-1.
-	if l.Vertex(firstIdx+1).Cmp(l.Vertex(firstIdx+n-1).Vector) == -1 {
-		return firstIdx, 1
-	}
-
-	// n <= firstIdx <= 2*n-1, so (firstIdx+n*dir) >= 0.
-	firstIdx += n
-	return firstIdx, -1
+	return canonicalFirstVertex(l.vertices)
 }
 
 // TurningAngle returns the sum of the turning angles at each vertex. The return
@@ -792,54 +864,13 @@ func (l *Loop) TurningAngle() float64 {
 		return 2 * math.Pi
 	}
 
-	// Don't crash even if the loop is not well-defined.
-	if len(l.vertices) < 3 {
-		return 0
-	}
-
-	// To ensure that we get the same result when the vertex order is rotated,
-	// and that the result is negated when the vertex order is reversed, we need
-	// to add up the individual turn angles in a consistent order. (In general,
-	// adding up a set of numbers in a different order can change the sum due to
-	// rounding errors.)
-	//
-	// Furthermore, if we just accumulate an ordinary sum then the worst-case
-	// error is quadratic in the number of vertices. (This can happen with
-	// spiral shapes, where the partial sum of the turning angles can be linear
-	// in the number of vertices.) To avoid this we use the Kahan summation
-	// algorithm (http://en.wikipedia.org/wiki/Kahan_summation_algorithm).
-	n := len(l.vertices)
-	i, dir := l.CanonicalFirstVertex()
-	sum := TurnAngle(l.Vertex((i+n-dir)%n), l.Vertex(i), l.Vertex((i+dir)%n))
-
-	compensation := s1.Angle(0)
-	for n-1 > 0 {
-		i += dir
-		angle := TurnAngle(l.Vertex(i-dir), l.Vertex(i), l.Vertex(i+dir))
-		oldSum := sum
-		angle += compensation
-		sum += angle
-		compensation = (oldSum - sum) + angle
-		n--
-	}
-
-	const maxCurvature = 2*math.Pi - 4*dblEpsilon
-
-	return math.Max(-maxCurvature, math.Min(maxCurvature, float64(dir)*float64(sum+compensation)))
+	return loopCurvature(l.vertices)
 }
 
 // turningAngleMaxError return the maximum error in TurningAngle. The value is not
 // constant; it depends on the loop.
 func (l *Loop) turningAngleMaxError() float64 {
-	// The maximum error can be bounded as follows:
-	//   3.00 * dblEpsilon    for RobustCrossProd(b, a)
-	//   3.00 * dblEpsilon    for RobustCrossProd(c, b)
-	//   3.25 * dblEpsilon    for Angle()
-	//   2.00 * dblEpsilon    for each addition in the Kahan summation
-	//   ------------------
-	//  11.25 * dblEpsilon
-	maxErrorPerVertex := 11.25 * dblEpsilon
-	return maxErrorPerVertex * float64(len(l.vertices))
+	return loopCurvatureMaxError(l.vertices)
 }
 
 // IsHole reports whether this loop represents a hole in its containing polygon.
@@ -879,6 +910,20 @@ func (l *Loop) Normalize() {
 	}
 }
 
+// clone returns a deep copy of the loop, including its depth.
+func (l *Loop) clone() *Loop {
+	c := &Loop{
+		vertices:       append([]Point(nil), l.vertices...),
+		originInside:   l.originInside,
+		depth:          l.depth,
+		bound:          l.bound,
+		subregionBound: l.subregionBound,
+		index:          NewShapeIndex(),
+	}
+	c.index.Add(c)
+	return c
+}
+
 // Invert reverses the order of the loop vertices, effectively complementing the
 // region represented by the loop. For example, the loop ABCD (with edges
 // AB, BC, CD, DA) becomes the loop DCBA (with edges DC, CB, BA, AD).
@@ -1243,7 +1288,12 @@ func (l *Loop) Centroid() Point {
 	return l.surfaceIntegralPoint(TrueCentroid)
 }
 
-// Encode encodes the Loop.
+// Encode encodes the Loop using the same lossless format as the C++ S2Loop:
+// a version byte (currently 1), the vertex count, each vertex as three
+// float64s, the originInside flag, the loop depth, and the bound. The empty
+// and full loops are encoded with their single special vertex, so they
+// decode to EmptyLoop and FullLoop respectively. Unlike polygons, loops have
+// no standalone compressed encoding.
 func (l Loop) Encode(w io.Writer) error {
 	e := &encoder{w: w}
 	l.encode(e)
@@ -1266,7 +1316,9 @@ func (l Loop) encode(e *encoder) {
 	l.bound.encode(e)
 }
 
-// Decode decodes a loop.
+// Decode decodes a loop encoded by Encode, replacing the contents of l. An
+// error is returned if the encoding has an unsupported version or is
+// truncated.
 func (l *Loop) Decode(r io.Reader) error {
 	*l = Loop{}
 	d := &decoder{r: asByteReader(r)}
@@ -1294,15 +1346,17 @@ func (l *Loop) decode(d *decoder) {
 		}
 		return
 	}
-	l.vertices = make([]Point, nvertices)
-	for i := range l.vertices {
-		l.vertices[i].X = d.readFloat64()
-		l.vertices[i].Y = d.readFloat64()
-		l.vertices[i].Z = d.readFloat64()
-	}
+	l.vertices = d.readPoints(uint64(nvertices))
 	l.index = NewShapeIndex()
 	l.originInside = d.readBool()
 	l.depth = int(d.readUint32())
+	if d.err != nil {
+		return
+	}
+	if nvertices == 1 {
+		// Other encoders may use any vertex for the empty and full loops.
+		l.vertices[0] = canonicalEmptyOrFullVertex(l.originInside)
+	}
 	l.bound.decode(d)
 	l.subregionBound = ExpandForSubregions(l.bound)
 
@@ -1375,8 +1429,7 @@ func (l *Loop) decodeCompressed(d *decoder, snapLevel int) {
 		d.err = fmt.Errorf("too many vertices (%d; max is %d)", nvertices, maxEncodedVertices)
 		return
 	}
-	l.vertices = make([]Point, nvertices)
-	decodePointsCompressed(d, snapLevel, l.vertices)
+	l.vertices = decodePointsCompressed(d, snapLevel, int(nvertices))
 	properties := d.readUvarint()
 
 	// Make sure values are valid before using.
@@ -1386,6 +1439,10 @@ func (l *Loop) decodeCompressed(d *decoder, snapLevel int) {
 
 	l.index = NewShapeIndex()
 	l.originInside = (properties & originInside) != 0
+	if nvertices == 1 {
+		// The canonical vertex does not survive snapping.
+		l.vertices[0] = canonicalEmptyOrFullVertex(l.originInside)
+	}
 
 	l.depth = int(d.readUvarint())
 
@@ -1834,10 +1891,43 @@ func (l *Loop) containsNonCrossingBoundary(other *Loop, reverseOther bool) bool
 		other.Vertex(1), reverseOther)
 }
 
+// Distance returns the distance from the given point to the loop interior. If
+// the loop is empty, it returns an infinite angle. The result is zero if the
+// point is inside the loop.
+func (l *Loop) Distance(x Point) s1.Angle {
+	// ContainsPoint is slightly more efficient than the generic version used
+	// by the EdgeQuery.
+	if l.ContainsPoint(x) {
+		return 0
+	}
+	return l.DistanceToBoundary(x)
+}
+
+// DistanceToBoundary returns the distance from the given point to the loop
+// boundary. If the loop is empty or full, it returns an infinite angle, since
+// such loops have no boundary.
+func (l *Loop) DistanceToBoundary(x Point) s1.Angle {
+	q := NewClosestEdgeQuery(l.index, NewClosestEdgeQueryOptions().IncludeInteriors(false))
+	return q.Distance(NewMinDistanceToPointTarget(x)).Angle()
+}
+
+// Project returns the closest point to x in the loop interior. If x is inside
+// the loop, x itself is returned. If the loop is empty, x is also returned
+// since there is no closest point.
+func (l *Loop) Project(x Point) Point {
+	if l.ContainsPoint(x) {
+		return x
+	}
+	return l.ProjectToBoundary(x)
+}
+
+// ProjectToBoundary returns the closest point to x on the loop boundary. If
+// the loop is empty or full, x is returned since there is no boundary.
+func (l *Loop) ProjectToBoundary(x Point) Point {
+	q := NewClosestEdgeQuery(l.index, NewClosestEdgeQueryOptions().IncludeInteriors(false))
+	return q.Project(x, q.findEdge(NewMinDistanceToPointTarget(x), q.opts))
+}
+
 // TODO(roberts): Differences from the C++ version:
-// DistanceToPoint
-// DistanceToBoundary
-// Project
-// ProjectToBoundary
 // BoundaryApproxEqual
 // BoundaryNear
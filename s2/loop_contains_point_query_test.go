@@ -0,0 +1,93 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestLoopContainsPointQuery(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	loops := []*Loop{
+		EmptyLoop(),
+		FullLoop(),
+		makeLoop("0:0, 0:10, 10:10, 10:0"),
+		makeLoop("0:0, 10:0, 10:10, 0:10"),
+	}
+	for _, n := range []int{4, 32, 33, 1000} {
+		center := PointFromLatLng(LatLngFromDegrees(20, 30))
+		loops = append(loops, RegularLoop(center, 5*s1.Degree, n))
+	}
+	for _, l := range loops {
+		q := NewLoopContainsPointQuery(l)
+		var points []Point
+		// Test the vertices, points near the loop, and points anywhere.
+		points = append(points, l.Vertices()...)
+		for i := 0; i < 500; i++ {
+			ll := LatLngFromDegrees(-10+40*rng.Float64(), -10+50*rng.Float64())
+			points = append(points, PointFromLatLng(ll))
+			points = append(points, PointFromCoords(rng.Float64()-0.5, rng.Float64()-0.5, rng.Float64()-0.5))
+		}
+		got := q.ContainsPoints(nil, points)
+		for i, p := range points {
+			want := l.ContainsPoint(p)
+			if got[i] != want {
+				t.Errorf("loop with %d vertices: ContainsPoints(%v)[%d] = %v, want %v", l.NumVertices(), p, i, got[i], want)
+			}
+			if q.Contains(p) != want {
+				t.Errorf("loop with %d vertices: Contains(%v) = %v, want %v", l.NumVertices(), p, !want, want)
+			}
+			if l.ContainsPointFast(p) != want {
+				t.Errorf("loop with %d vertices: ContainsPointFast(%v) = %v, want %v", l.NumVertices(), p, !want, want)
+			}
+		}
+	}
+}
+
+func BenchmarkLoopContainsPointQuery(b *testing.B) {
+	// Classify points spread over a region much larger than the loop, so
+	// that most of them are rejected by the bounds.
+	rng := rand.New(rand.NewSource(1))
+	points := make([]Point, 1024)
+	for i := range points {
+		points[i] = PointFromLatLng(LatLngFromDegrees(-20+40*rng.Float64(), -20+40*rng.Float64()))
+	}
+	for _, n := range []int{16, 1024, 65536} {
+		loop := RegularLoop(PointFromLatLng(LatLngFromDegrees(0, 0)), 5*s1.Degree, n)
+		// Build the index outside of the timed loops.
+		loop.ContainsPoint(points[0])
+
+		b.Run(fmt.Sprintf("ContainsPoint/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				loop.ContainsPoint(points[i%len(points)])
+			}
+		})
+		b.Run(fmt.Sprintf("ContainsPointFast/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				loop.ContainsPointFast(points[i%len(points)])
+			}
+		})
+		b.Run(fmt.Sprintf("Query/%d", n), func(b *testing.B) {
+			q := NewLoopContainsPointQuery(loop)
+			for i := 0; i < b.N; i++ {
+				q.Contains(points[i%len(points)])
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+// This file defines various measures for loops on the sphere. These are
+// low-level methods that work directly with slices of Points, so they can
+// be used on rings of vertices before a Loop is constructed from them.
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// RingOrientation reports whether the ring of vertices is CounterClockwise,
+// i.e. whether the region to its left is the smaller of the two regions it
+// bounds. This is the orientation that LoopFromPoints expects for the
+// boundary of a region that covers at most half of the sphere, so importers
+// can use it to decide whether to reverse a ring before constructing a Loop.
+//
+// The ring may repeat its first vertex at the end, as in GeoJSON and WKT,
+// and consecutive duplicate vertices are ignored. Indeterminate is returned
+// if the ring has fewer than 3 distinct vertices, or if it is so close to a
+// great circle that the two regions have the same area to within the error
+// of the computation.
+func RingOrientation(vertices []Point) Direction {
+	ring := make([]Point, 0, len(vertices))
+	for _, v := range vertices {
+		if len(ring) == 0 || v != ring[len(ring)-1] {
+			ring = append(ring, v)
+		}
+	}
+	for len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+	if len(ring) < 3 {
+		return Indeterminate
+	}
+
+	curvature := loopCurvature(ring)
+	maxError := loopCurvatureMaxError(ring)
+	switch {
+	case curvature > maxError:
+		return CounterClockwise
+	case curvature < -maxError:
+		return Clockwise
+	}
+	return Indeterminate
+}
+
+// canonicalFirstVertex returns a first index and a direction (either +1 or
+// -1) such that the vertex sequence (first, first+dir, ..., first+(n-1)*dir)
+// does not change when the loop vertex order is rotated or inverted. Indexes
+// are taken modulo the number of vertices, and are in the range [0, 2*n-1].
+func canonicalFirstVertex(vertices []Point) (firstIdx, direction int) {
+	n := len(vertices)
+	vertex := func(i int) Point { return vertices[i%n] }
+	for i := 1; i < n; i++ {
+		if vertex(i).Cmp(vertex(firstIdx).Vector) == -1 {
+			firstIdx = i
+		}
+	}
+
+	// 0 <= firstIdx <= n-1, so (firstIdx+n*dir) <= 2*n-1.
+	if vertex(firstIdx+1).Cmp(vertex(firstIdx+n-1).Vector) == -1 {
+		return firstIdx, 1
+	}
+
+	// n <= firstIdx <= 2*n-1, so (firstIdx+n*dir) >= 0.
+	firstIdx += n
+	return firstIdx, -1
+}
+
+// loopCurvature returns the sum of the turning angles at each vertex of the
+// loop, which is positive if the loop is counter-clockwise, negative if it
+// is clockwise, and zero if it is a great circle. See Loop.TurningAngle,
+// which also handles the empty and full loops. It returns 0 for loops with
+// fewer than 3 vertices.
+func loopCurvature(vertices []Point) float64 {
+	// Don't crash even if the loop is not well-defined.
+	if len(vertices) < 3 {
+		return 0
+	}
+
+	// To ensure that we get the same result when the vertex order is rotated,
+	// and that the result is negated when the vertex order is reversed, we need
+	// to add up the individual turn angles in a consistent order. (In general,
+	// adding up a set of numbers in a different order can change the sum due to
+	// rounding errors.)
+	//
+	// Furthermore, if we just accumulate an ordinary sum then the worst-case
+	// error is quadratic in the number of vertices. (This can happen with
+	// spiral shapes, where the partial sum of the turning angles can be linear
+	// in the number of vertices.) To avoid this we use the Kahan summation
+	// algorithm (http://en.wikipedia.org/wiki/Kahan_summation_algorithm).
+	n := len(vertices)
+	vertex := func(i int) Point { return vertices[i%len(vertices)] }
+	i, dir := canonicalFirstVertex(vertices)
+	sum := TurnAngle(vertex((i+n-dir)%n), vertex(i), vertex((i+dir)%n))
+
+	compensation := s1.Angle(0)
+	for n-1 > 0 {
+		i += dir
+		angle := TurnAngle(vertex(i-dir), vertex(i), vertex(i+dir))
+		oldSum := sum
+		angle += compensation
+		sum += angle
+		compensation = (oldSum - sum) + angle
+		n--
+	}
+
+	const maxCurvature = 2*math.Pi - 4*DblEpsilon
+
+	return math.Max(-maxCurvature, math.Min(maxCurvature, float64(dir)*float64(sum+compensation)))
+}
+
+// loopCurvatureMaxError returns the maximum error in loopCurvature for the
+// given loop. The value is not constant; it depends on the number of
+// vertices.
+func loopCurvatureMaxError(vertices []Point) float64 {
+	// The maximum error can be bounded as follows:
+	//   3.00 * DblEpsilon    for RobustCrossProd(b, a)
+	//   3.00 * DblEpsilon    for RobustCrossProd(c, b)
+	//   3.25 * DblEpsilon    for Angle()
+	//   2.00 * DblEpsilon    for each addition in the Kahan summation
+	//   ------------------
+	//  11.25 * DblEpsilon
+	maxErrorPerVertex := 11.25 * DblEpsilon
+	return float64(maxErrorPerVertex * float64(len(vertices)))
+}
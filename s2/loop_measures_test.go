@@ -0,0 +1,69 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestRingOrientation(t *testing.T) {
+	tests := []struct {
+		desc string
+		ring string
+		want Direction
+	}{
+		{"empty", "", Indeterminate},
+		{"two vertices", "0:0, 0:1", Indeterminate},
+		{"two vertices closed", "0:0, 0:1, 0:0", Indeterminate},
+		{"ccw triangle", "0:0, 0:1, 1:0", CounterClockwise},
+		{"cw triangle", "0:0, 1:0, 0:1", Clockwise},
+		{"ccw square closed", "0:0, 0:1, 1:1, 1:0, 0:0", CounterClockwise},
+		{"cw square closed", "0:0, 1:0, 1:1, 0:1, 0:0", Clockwise},
+		{"duplicate vertices", "0:0, 0:0, 0:1, 0:1, 1:0, 0:0, 0:0", CounterClockwise},
+		{"equator", "0:0, 0:90, 0:180, 0:-90", Indeterminate},
+		{"equator closed", "0:0, 0:90, 0:180, 0:-90, 0:0", Indeterminate},
+		{"tiny ccw triangle", "0:0, 0:1e-12, 1e-12:0", CounterClockwise},
+	}
+	for _, test := range tests {
+		if got := RingOrientation(parsePoints(test.ring)); got != test.want {
+			t.Errorf("%s: RingOrientation(%q) = %v, want %v", test.desc, test.ring, got, test.want)
+		}
+	}
+}
+
+func TestRingOrientationConsistentWithLoop(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		center := PointFromLatLng(LatLngFromDegrees(-90+180*rng.Float64(), -180+360*rng.Float64()))
+		loop := RegularLoop(center, s1.Angle(1e-3+rng.Float64()), 3+rng.Intn(20))
+		vertices := loop.Vertices()
+		if got := RingOrientation(vertices); got != CounterClockwise {
+			t.Errorf("RingOrientation(%v) = %v, want %v", vertices, got, CounterClockwise)
+		}
+		reversed := make([]Point, len(vertices))
+		for j, v := range vertices {
+			reversed[len(vertices)-1-j] = v
+		}
+		if got := RingOrientation(reversed); got != Clockwise {
+			t.Errorf("RingOrientation(%v) = %v, want %v", reversed, got, Clockwise)
+		}
+		if got, want := loopCurvature(vertices), loop.TurningAngle(); got != want {
+			t.Errorf("loopCurvature(%v) = %v, want %v", vertices, got, want)
+		}
+	}
+}
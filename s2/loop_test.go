@@ -15,6 +15,7 @@
 package s2
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"testing"
@@ -216,6 +217,67 @@ func TestLoopFullLoop(t *testing.T) {
 	}
 }
 
+func TestLoopEmptyOrFullNormalization(t *testing.T) {
+	north := PointFromCoords(1, 2, 3)
+	south := PointFromCoords(1, 2, -3)
+	if IsEmptyOrFullVertex(north) || !IsEmptyOrFullVertex(EmptyLoop().Vertex(0)) ||
+		!IsEmptyOrFullVertex(FullLoop().Vertex(0)) {
+		t.Errorf("IsEmptyOrFullVertex does not recognize exactly the canonical vertices")
+	}
+
+	tests := []struct {
+		vertex Point
+		want   *Loop
+	}{
+		{north, EmptyLoop()},
+		{south, FullLoop()},
+	}
+	for _, test := range tests {
+		pts := []Point{test.vertex}
+		l := LoopFromPoints(pts)
+		if !l.Equal(test.want) || l.IsEmpty() != test.want.IsEmpty() {
+			t.Errorf("LoopFromPoints(%v) = %v, want %v", test.vertex, l, test.want)
+		}
+		if pts[0] != test.vertex {
+			t.Errorf("LoopFromPoints(%v) modified its argument to %v", test.vertex, pts[0])
+		}
+
+		// Loops decoded from other encoders are normalized as well.
+		raw := &Loop{
+			vertices:     []Point{test.vertex},
+			originInside: test.want.IsFull(),
+			bound:        test.want.RectBound(),
+		}
+		var buf bytes.Buffer
+		if err := raw.Encode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		var got Loop
+		if err := got.Decode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(test.want) || got.IsEmpty() != test.want.IsEmpty() {
+			t.Errorf("Decode of loop with vertex %v = %v, want %v", test.vertex, &got, test.want)
+		}
+
+		// The compressed encoding snaps the vertex to a cell center.
+		e := &encoder{w: &buf}
+		test.want.encodeCompressed(e, MaxLevel, test.want.xyzFaceSiTiVertices())
+		if e.err != nil {
+			t.Fatal(e.err)
+		}
+		d := &decoder{r: &buf}
+		got = Loop{}
+		got.decodeCompressed(d, MaxLevel)
+		if d.err != nil {
+			t.Fatal(d.err)
+		}
+		if !got.Equal(test.want) || got.IsEmpty() != test.want.IsEmpty() {
+			t.Errorf("compressed round trip of %v = %v", test.want, &got)
+		}
+	}
+}
+
 func TestLoopBasic(t *testing.T) {
 	shape := Shape(makeLoop("0:0, 0:1, 1:0"))
 
@@ -321,7 +383,7 @@ func TestLoopRectBound(t *testing.T) {
 	arctic80Inv.Invert()
 	// The highest latitude of each edge is attained at its midpoint.
 	mid := Point{arctic80Inv.vertices[0].Vector.Add(arctic80Inv.vertices[1].Vector).Mul(.5)}
-	if got, want := arctic80Inv.RectBound().Lat.Hi, float64(LatLngFromPoint(mid).Lat); !float64Near(got, want, 10*dblEpsilon) {
+	if got, want := arctic80Inv.RectBound().Lat.Hi, float64(LatLngFromPoint(mid).Lat); !float64Near(got, want, 10*DblEpsilon) {
 		t.Errorf("arctic 80 inverse loop's RectBound should have a latutude hi of %v, got %v", got, want)
 	}
 }
@@ -1735,17 +1797,14 @@ func TestLoopValidateDetectsInvalidLoops(t *testing.T) {
 			msg:    "loop has degenerate third edge",
 			points: parsePoints("20:20, 20:21, 20:20"),
 		},
-		// TODO(roberts): Uncomment these cases when FindAnyCrossings is in.
-		/*
-			{
-				msg:    "loop has duplicate points",
-				points: parsePoints("20:20, 21:21, 21:20, 20:20, 20:21"),
-			},
-			{
-				msg:    "loop has crossing edges",
-				points: parsePoints("20:20, 21:21, 21:20.5, 21:20, 20:21"),
-			},
-		*/
+		{
+			msg:    "loop has duplicate points",
+			points: parsePoints("20:20, 21:21, 21:20, 20:20, 20:21"),
+		},
+		{
+			msg:    "loop has crossing edges",
+			points: parsePoints("20:20, 21:21, 21:20.5, 21:20, 20:21"),
+		},
 		{
 			// Ensure points are not normalized.
 			msg: "loop with non-normalized vertices",
@@ -1817,3 +1876,104 @@ func BenchmarkLoopContainsPoint(b *testing.B) {
 		vertices *= 2
 	}
 }
+
+func TestLoopFromPointsOrError(t *testing.T) {
+	for _, s := range []string{"0:0, 0:1, 1:0", "0:0, 0:10, 10:10, 10:0"} {
+		if _, err := LoopFromPointsOrError(parsePoints(s)); err != nil {
+			t.Errorf("LoopFromPointsOrError(%s) returned error: %v", s, err)
+		}
+	}
+	for _, l := range []*Loop{EmptyLoop(), FullLoop()} {
+		if _, err := LoopFromPointsOrError(l.Vertices()); err != nil {
+			t.Errorf("LoopFromPointsOrError(%v) returned error: %v", l.Vertices(), err)
+		}
+	}
+	for _, pts := range [][]Point{
+		nil,
+		parsePoints("0:0, 0:1"),
+		parsePoints("0:0, 0:1, 0:1, 1:0"),
+		{PointFromCoords(1, 0, 0), PointFromCoords(0, 1, 0), {r3.Vector{X: 0, Y: 0, Z: 2}}},
+	} {
+		if l, err := LoopFromPointsOrError(pts); err == nil {
+			t.Errorf("LoopFromPointsOrError(%v) = %v, want error", pts, l)
+		}
+	}
+}
+
+func TestLoopCheckBoundsConsistency(t *testing.T) {
+	for i, l := range allLoops {
+		if err := l.CheckBoundsConsistency(); err != nil {
+			t.Errorf("allLoops[%d].CheckBoundsConsistency() = %v, want nil", i, err)
+		}
+		if !l.SubregionBound().Contains(l.RectBound()) {
+			t.Errorf("allLoops[%d].SubregionBound() = %v, want to contain %v", i, l.SubregionBound(), l.RectBound())
+		}
+	}
+
+	shrunk := *makeLoop("0:0, 0:10, 10:10, 10:0")
+	shrunk.bound = rectFromDegrees(1, 1, 9, 9)
+	notExpanded := *makeLoop("0:0, 0:10, 10:10, 10:0")
+	notExpanded.subregionBound = rectFromDegrees(1, 1, 9, 9)
+	noPole := *makeLoop("80:0, 80:120, 80:-120")
+	noPole.bound = rectFromDegrees(80, -180, 85, 180)
+	noPole.subregionBound = ExpandForSubregions(noPole.bound)
+	for _, l := range []*Loop{&shrunk, &notExpanded, &noPole} {
+		if err := l.CheckBoundsConsistency(); err == nil {
+			t.Errorf("%v.CheckBoundsConsistency() = nil, want error", l)
+		}
+	}
+}
+
+func TestLoopDistanceMethods(t *testing.T) {
+	// EdgeQuery is already tested, so just do a bit of sanity checking.
+	square := makeLoop("-1:-1, -1:1, 1:1, 1:-1")
+	tests := []struct {
+		desc      string
+		loop      *Loop
+		x         Point
+		boundaryX Point
+	}{
+		// The empty and full loops don't have boundaries.
+		{"empty", EmptyLoop(), PointFromCoords(0, 1, 0), PointFromCoords(0, 1, 0)},
+		{"full", FullLoop(), PointFromCoords(0, 1, 0), PointFromCoords(0, 1, 0)},
+		// Note that because lines of latitude are curved on the sphere, it is
+		// not straightforward to project points onto any edge except along
+		// the equator, which is the only line of latitude that is also a
+		// geodesic.
+		{"vertex", square, parsePoint("1:-1"), square.Vertex(3)},
+		{"on edge", square, parsePoint("0.5:1"), parsePoint("0.5:1")},
+		{"inside", square, parsePoint("0:0.5"), parsePoint("0:1")},
+		{"outside, closest to edge", square, parsePoint("0:-2"), parsePoint("0:-1")},
+		{"outside, closest to vertex", square, parsePoint("3:4"), parsePoint("1:1")},
+	}
+	// This error is not guaranteed by the implementation but is okay for tests.
+	const maxError = 1e-15
+
+	for _, test := range tests {
+		if got := test.loop.ProjectToBoundary(test.x); got.Distance(test.boundaryX) > maxError {
+			t.Errorf("%s: ProjectToBoundary(%v) = %v, want %v", test.desc, test.x, got, test.boundaryX)
+		}
+		if test.loop.isEmptyOrFull() {
+			if got := test.loop.DistanceToBoundary(test.x); got != s1.InfAngle() {
+				t.Errorf("%s: DistanceToBoundary(%v) = %v, want %v", test.desc, test.x, got, s1.InfAngle())
+			}
+		} else if got, want := test.loop.DistanceToBoundary(test.x), test.x.Distance(test.boundaryX); !float64Near(float64(got), float64(want), maxError) {
+			t.Errorf("%s: DistanceToBoundary(%v) = %v, want %v", test.desc, test.x, got, want)
+		}
+		if test.loop.ContainsPoint(test.x) {
+			if got := test.loop.Distance(test.x); got != 0 {
+				t.Errorf("%s: Distance(%v) = %v, want 0", test.desc, test.x, got)
+			}
+			if got := test.loop.Project(test.x); got != test.x {
+				t.Errorf("%s: Project(%v) = %v, want %v", test.desc, test.x, got, test.x)
+			}
+		} else {
+			if got, want := test.loop.Distance(test.x), test.loop.DistanceToBoundary(test.x); got != want {
+				t.Errorf("%s: Distance(%v) = %v, want %v", test.desc, test.x, got, want)
+			}
+			if got, want := test.loop.Project(test.x), test.loop.ProjectToBoundary(test.x); got != want {
+				t.Errorf("%s: Project(%v) = %v, want %v", test.desc, test.x, got, want)
+			}
+		}
+	}
+}
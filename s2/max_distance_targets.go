@@ -84,7 +84,7 @@ func (m *MaxDistanceToPointTarget) visitContainingShapes(index *ShapeIndex, v sh
 	// the antipode of the target point. These are the polygons whose
 	// distance to the target is maxDistance.zero()
 	q := NewContainsPointQuery(index, VertexModelSemiOpen)
-	return q.visitContainingShapes(Point{m.point.Mul(-1)}, func(shape Shape) bool {
+	return q.VisitContainingShapes(Point{m.point.Mul(-1)}, func(shape Shape) bool {
 		return v(shape, m.point)
 	})
 }
@@ -257,7 +257,13 @@ func (m *MaxDistanceToShapeIndexTarget) visitContainingShapes(index *ShapeIndex,
 	//
 	// TODO(roberts): Do this by merge-joining the two ShapeIndexes and share
 	// the code with BooleanOperation.
-	for _, shape := range m.index.shapes {
+	// Shapes are visited in order of ID so that the results do not depend
+	// on map iteration order.
+	for id := int32(0); id < m.index.nextID; id++ {
+		shape := m.index.Shape(id)
+		if shape == nil {
+			continue
+		}
 		numChains := shape.NumChains()
 		// Shapes that don't have any edges require a special case (below).
 		testedPoint := false
@@ -90,7 +90,7 @@ func (m *MinDistanceToPointTarget) visitContainingShapes(index *ShapeIndex, v sh
 	// the antipode of the target point. These are the polygons whose
 	// distance to the target is maxDistance.zero()
 	q := NewContainsPointQuery(index, VertexModelSemiOpen)
-	return q.visitContainingShapes(m.point, func(shape Shape) bool {
+	return q.VisitContainingShapes(m.point, func(shape Shape) bool {
 		return v(shape, m.point)
 	})
 }
@@ -268,8 +268,7 @@ func NewMinDistanceToShapeIndexTarget(index *ShapeIndex) *MinDistanceToShapeInde
 }
 
 func (m *MinDistanceToShapeIndexTarget) capBound() Cap {
-	c := m.index.Region().CapBound()
-	return CapFromCenterAngle(Point{c.Center().Mul(-1)}, c.Radius())
+	return m.index.Region().CapBound()
 }
 
 func (m *MinDistanceToShapeIndexTarget) updateDistanceToPoint(p Point, dist distance) (distance, bool) {
@@ -303,17 +302,23 @@ func (m *MinDistanceToShapeIndexTarget) updateDistanceToCell(cell Cell, dist dis
 }
 
 // For target types consisting of multiple connected components (such as this one),
-// this method should return the polygons containing the antipodal reflection of
-// *any* connected component. (It is sufficient to test containment of one vertex per
-// connected component, since this allows us to also return any polygon whose
-// boundary has distance.zero() to the target.)
+// this method should return the polygons containing *any* connected component.
+// (It is sufficient to test containment of one vertex per connected component,
+// since this allows us to also return any polygon whose boundary has
+// distance.zero() to the target.)
 func (m *MinDistanceToShapeIndexTarget) visitContainingShapes(index *ShapeIndex, v shapePointVisitorFunc) bool {
 	// It is sufficient to find the set of chain starts in the target index
 	// (i.e., one vertex per connected component of edges) that are contained by
 	// the query index, except for one special case to handle full polygons.
 	//
 	// TODO(roberts): Do this by merge-joining the two ShapeIndexes.
-	for _, shape := range m.index.shapes {
+	// Shapes are visited in order of ID so that the results do not depend
+	// on map iteration order.
+	for id := int32(0); id < m.index.nextID; id++ {
+		shape := m.index.Shape(id)
+		if shape == nil {
+			continue
+		}
 		numChains := shape.NumChains()
 		// Shapes that don't have any edges require a special case (below).
 		testedPoint := false
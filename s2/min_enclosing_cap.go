@@ -0,0 +1,163 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math/rand"
+
+	"github.com/golang/geo/s1"
+)
+
+// SmallestEnclosingCap returns the smallest cap that contains all of the given
+// points. This is usually much tighter than the cap obtained from the
+// RectBound of the points, which is important when the cap is used to prune
+// queries.
+//
+// This uses Welzl's algorithm adapted to the sphere, which runs in expected
+// linear time. The algorithm is only well defined when all of the points lie
+// within some hemisphere; otherwise the result is the smaller of the cap
+// found by the algorithm (expanded to contain every point) and the cap bound
+// of the points' lat/lng rectangle. In all cases the returned cap is
+// guaranteed to contain every input point.
+//
+// The input slice is not modified.
+func SmallestEnclosingCap(points []Point) Cap {
+	if len(points) == 0 {
+		return EmptyCap()
+	}
+
+	// Welzl's algorithm has expected linear time only when the points are
+	// visited in random order. A fixed seed is used so that the results are
+	// reproducible.
+	pts := make([]Point, len(points))
+	copy(pts, points)
+	rnd := rand.New(rand.NewSource(1))
+	rnd.Shuffle(len(pts), func(i, j int) { pts[i], pts[j] = pts[j], pts[i] })
+
+	c := CapFromPoint(pts[0])
+	for i := 1; i < len(pts); i++ {
+		if c.ContainsPoint(pts[i]) {
+			continue
+		}
+		c = CapFromPoint(pts[i])
+		for j := 0; j < i; j++ {
+			if c.ContainsPoint(pts[j]) {
+				continue
+			}
+			c = capFromTwoPoints(pts[i], pts[j])
+			for k := 0; k < j; k++ {
+				if c.ContainsPoint(pts[k]) {
+					continue
+				}
+				c = capFromThreePoints(pts[i], pts[j], pts[k])
+			}
+		}
+	}
+
+	// Numerical errors in the boundary constructions above (or input points
+	// that do not lie in a common hemisphere) may leave a few points just
+	// outside the cap, so we expand it as necessary.
+	bound := EmptyRect()
+	for _, p := range points {
+		c = c.AddPoint(p)
+		bound = bound.AddPoint(LatLngFromPoint(p))
+	}
+
+	if rectCap := bound.CapBound(); rectCap.radius < c.radius {
+		return rectCap
+	}
+	return c
+}
+
+// SmallestEnclosingCapForShapes returns the smallest cap that contains all of
+// the given shapes. Point and polyline shapes are bounded by their vertices.
+// For polygonal shapes, the interior is accounted for as well: if a polygon
+// contains the point antipodal to the center of the cap bounding its
+// vertices (i.e. the polygon covers more than that cap), a full cap is
+// returned.
+func SmallestEnclosingCapForShapes(shapes ...Shape) Cap {
+	var vertices []Point
+	bounder := NewRectBounder()
+	for _, shape := range shapes {
+		if shape.IsFull() {
+			return FullCap()
+		}
+		for e := 0; e < shape.NumEdges(); e++ {
+			edge := shape.Edge(e)
+			vertices = append(vertices, edge.V0, edge.V1)
+			bounder.AddPoint(edge.V0)
+			bounder.AddPoint(edge.V1)
+		}
+	}
+
+	c := SmallestEnclosingCap(vertices)
+	if c.IsEmpty() {
+		return c
+	}
+
+	// A cap of radius less than 90 degrees is convex, so it contains every
+	// edge whose endpoints it contains. Larger caps are not, so we fall back
+	// to the cap around the bound of the edges.
+	if c.radius >= s1.RightChordAngle {
+		c = bounder.RectBound().CapBound()
+	}
+
+	antipode := Point{c.center.Mul(-1)}
+	for _, shape := range shapes {
+		if shape.Dimension() == 2 && containsBruteForce(shape, antipode) {
+			return FullCap()
+		}
+	}
+	return c
+}
+
+// capFromTwoPoints returns the smallest cap containing the two given points,
+// i.e. the cap whose diameter is the edge between them.
+func capFromTwoPoints(a, b Point) Cap {
+	center := a.Add(b.Vector)
+	if center.Norm2() < DblEpsilon {
+		// The points are (nearly) antipodal, so any point on the great
+		// circle equidistant from them is a valid center.
+		center = a.PointCross(b).Vector
+	}
+	c := CapFromPoint(Point{center.Normalize()})
+	return c.AddPoint(a).AddPoint(b)
+}
+
+// capFromThreePoints returns the smallest cap that has all three of the
+// given points on its boundary. If the points are (nearly) collinear, the
+// largest of the caps spanning each pair of them is returned instead.
+func capFromThreePoints(a, b, c Point) Cap {
+	n := b.Sub(a.Vector).Cross(c.Sub(a.Vector))
+	if n.Norm2() < DblEpsilon*DblEpsilon {
+		return largestCapOf(capFromTwoPoints(a, b), capFromTwoPoints(b, c), capFromTwoPoints(a, c))
+	}
+	// Of the two circumcenters, choose the one on the same side as the points.
+	if n.Dot(a.Vector) < 0 {
+		n = n.Mul(-1)
+	}
+	return CapFromPoint(Point{n.Normalize()}).AddPoint(a).AddPoint(b).AddPoint(c)
+}
+
+// largestCapOf returns the cap with the largest radius.
+func largestCapOf(first Cap, others ...Cap) Cap {
+	best := first
+	for _, c := range others {
+		if c.radius > best.radius {
+			best = c
+		}
+	}
+	return best
+}
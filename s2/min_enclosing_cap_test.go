@@ -0,0 +1,154 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestSmallestEnclosingCapEmptyAndSinglePoint(t *testing.T) {
+	if got := SmallestEnclosingCap(nil); !got.IsEmpty() {
+		t.Errorf("SmallestEnclosingCap(nil) = %v, want empty cap", got)
+	}
+
+	p := PointFromCoords(1, 2, 3)
+	got := SmallestEnclosingCap([]Point{p, p})
+	if !got.ContainsPoint(p) || got.Radius() > 1e-15 {
+		t.Errorf("SmallestEnclosingCap(%v, %v) = %v, want a cap of zero radius at the point", p, p, got)
+	}
+}
+
+func TestSmallestEnclosingCapKnownValues(t *testing.T) {
+	tests := []struct {
+		have   string
+		center Point
+		radius s1.Angle
+	}{
+		{
+			have:   "0:-10, 0:10",
+			center: PointFromLatLng(LatLngFromDegrees(0, 0)),
+			radius: 10 * s1.Degree,
+		},
+		{
+			// The third point lies inside the cap spanned by the first two.
+			have:   "0:-10, 0:10, 1:1",
+			center: PointFromLatLng(LatLngFromDegrees(0, 0)),
+			radius: 10 * s1.Degree,
+		},
+		{
+			have:   "0:-10, 0:10, 5:0, -5:0, 0:0",
+			center: PointFromLatLng(LatLngFromDegrees(0, 0)),
+			radius: 10 * s1.Degree,
+		},
+		{
+			// A regular triangle around the north pole.
+			have:   "80:0, 80:120, 80:-120",
+			center: PointFromCoords(0, 0, 1),
+			radius: 10 * s1.Degree,
+		},
+	}
+
+	for _, test := range tests {
+		got := SmallestEnclosingCap(parsePoints(test.have))
+		if !got.Center().approxEqual(test.center, 1e-13) {
+			t.Errorf("SmallestEnclosingCap(%s).Center() = %v, want %v", test.have, got.Center(), test.center)
+		}
+		if !float64Near(got.Radius().Radians(), test.radius.Radians(), 1e-13) {
+			t.Errorf("SmallestEnclosingCap(%s).Radius() = %v, want %v", test.have, got.Radius(), test.radius)
+		}
+	}
+}
+
+func TestSmallestEnclosingCapRandomPoints(t *testing.T) {
+	for iter := 0; iter < 100; iter++ {
+		bound := randomCap(1e-10, 1)
+		n := 1 + randomUniformInt(50)
+		points := make([]Point, n)
+		rect := EmptyRect()
+		for i := range points {
+			points[i] = samplePointFromCap(bound)
+			rect = rect.AddPoint(LatLngFromPoint(points[i]))
+		}
+
+		got := SmallestEnclosingCap(points)
+		for _, p := range points {
+			if !got.ContainsPoint(p) {
+				t.Fatalf("SmallestEnclosingCap(%v) = %v, does not contain %v", points, got, p)
+			}
+		}
+		if got.radius > bound.radius.Expanded(1e-14) {
+			t.Errorf("SmallestEnclosingCap(%v) = %v, larger than the sampled cap %v", points, got, bound)
+		}
+		if got.radius > rect.CapBound().radius {
+			t.Errorf("SmallestEnclosingCap(%v) = %v, larger than the rect cap bound %v", points, got, rect.CapBound())
+		}
+	}
+}
+
+func TestSmallestEnclosingCapNotInHemisphere(t *testing.T) {
+	points := []Point{
+		PointFromCoords(1, 0, 0),
+		PointFromCoords(-1, 0, 0),
+		PointFromCoords(0, 1, 0),
+		PointFromCoords(0, -1, 0),
+		PointFromCoords(0, 0, 1),
+		PointFromCoords(0, 0, -1),
+	}
+	got := SmallestEnclosingCap(points)
+	for _, p := range points {
+		if !got.ContainsPoint(p) {
+			t.Errorf("SmallestEnclosingCap(%v) = %v, does not contain %v", points, got, p)
+		}
+	}
+}
+
+func TestSmallestEnclosingCapForShapes(t *testing.T) {
+	polyline := makePolyline("0:0, 0:10, 0:20")
+	got := SmallestEnclosingCapForShapes(polyline)
+	want := CapFromCenterAngle(PointFromLatLng(LatLngFromDegrees(0, 10)), 10*s1.Degree)
+	if !got.ApproxEqual(want) {
+		t.Errorf("SmallestEnclosingCapForShapes(%v) = %v, want %v", polyline, got, want)
+	}
+
+	loop := makeLoop("-1:-1, -1:1, 1:1, 1:-1")
+	got = SmallestEnclosingCapForShapes(loop)
+	if !got.ContainsPoint(PointFromLatLng(LatLngFromDegrees(0, 0))) || got.Radius() > 1.5*s1.Degree {
+		t.Errorf("SmallestEnclosingCapForShapes(%v) = %v, want a small cap around the origin", loop, got)
+	}
+
+	// The complement of the loop has the same vertices, but covers almost
+	// the whole sphere.
+	loop.Invert()
+	if got := SmallestEnclosingCapForShapes(loop); !got.IsFull() {
+		t.Errorf("SmallestEnclosingCapForShapes(%v) = %v, want full cap", loop, got)
+	}
+
+	if got := SmallestEnclosingCapForShapes(FullLoop()); !got.IsFull() {
+		t.Errorf("SmallestEnclosingCapForShapes(FullLoop()) = %v, want full cap", got)
+	}
+	if got := SmallestEnclosingCapForShapes(EmptyLoop()); !got.IsEmpty() {
+		t.Errorf("SmallestEnclosingCapForShapes(EmptyLoop()) = %v, want empty cap", got)
+	}
+
+	points := &PointVector{PointFromCoords(1, 0, 0), PointFromCoords(0, 1, 0)}
+	got = SmallestEnclosingCapForShapes(points, polyline)
+	for _, p := range append(*points, *polyline...) {
+		if !got.ContainsPoint(p) {
+			t.Errorf("SmallestEnclosingCapForShapes(%v, %v) = %v, does not contain %v", points, polyline, got, p)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Shape interface enforcement
+var _ Shape = (*MultiPolyline)(nil)
+
+// MultiPolyline is a Shape representing a collection of polylines, such as
+// the parts of a multi-linestring. Each polyline is a separate chain of the
+// shape, so chain i consists of the edges of polyline i, and the whole
+// collection occupies a single entry in a ShapeIndex.
+//
+// Like LaxPolyline, adjacent vertices may be identical or antipodal, and a
+// polyline with fewer than two vertices has no edges. Such polylines are
+// kept as empty chains so that chain IDs continue to match polyline indices.
+type MultiPolyline struct {
+	vertices []Point
+
+	// vertexStarts[i] is the index in vertices of the first vertex of
+	// polyline i, and edgeStarts[i] is the ID of its first edge. Both have
+	// one extra trailing element holding the total count.
+	vertexStarts []int
+	edgeStarts   []int
+}
+
+// MultiPolylineFromPoints constructs a MultiPolyline with one polyline for
+// each of the given vertex sequences.
+func MultiPolylineFromPoints(lines [][]Point) *MultiPolyline {
+	m := &MultiPolyline{
+		vertexStarts: make([]int, len(lines)+1),
+		edgeStarts:   make([]int, len(lines)+1),
+	}
+	numVertices, numEdges := 0, 0
+	for i, line := range lines {
+		m.vertexStarts[i] = numVertices
+		m.edgeStarts[i] = numEdges
+		numVertices += len(line)
+		numEdges += maxInt(0, len(line)-1)
+	}
+	m.vertexStarts[len(lines)] = numVertices
+	m.edgeStarts[len(lines)] = numEdges
+
+	m.vertices = make([]Point, 0, numVertices)
+	for _, line := range lines {
+		m.vertices = append(m.vertices, line...)
+	}
+	return m
+}
+
+// MultiPolylineFromPolylines constructs a MultiPolyline from the given
+// polylines.
+func MultiPolylineFromPolylines(lines []Polyline) *MultiPolyline {
+	points := make([][]Point, len(lines))
+	for i, line := range lines {
+		points[i] = line
+	}
+	return MultiPolylineFromPoints(points)
+}
+
+// NumPolylines returns the number of polylines, which is also the number of
+// chains.
+func (m *MultiPolyline) NumPolylines() int { return len(m.vertexStarts) - 1 }
+
+// Polyline returns the vertices of the i-th polyline. The returned slice
+// shares storage with the shape and must not be modified.
+func (m *MultiPolyline) Polyline(i int) Polyline {
+	return Polyline(m.vertices[m.vertexStarts[i]:m.vertexStarts[i+1]:m.vertexStarts[i+1]])
+}
+
+// chainForEdge returns the polyline containing edge e.
+func (m *MultiPolyline) chainForEdge(e int) int {
+	// This is the first polyline whose edges end after e, which skips over
+	// any polylines that have no edges.
+	return sort.Search(m.NumPolylines(), func(i int) bool { return m.edgeStarts[i+1] > e })
+}
+
+func (m *MultiPolyline) NumEdges() int                  { return m.edgeStarts[len(m.edgeStarts)-1] }
+func (m *MultiPolyline) ReferencePoint() ReferencePoint { return OriginReferencePoint(false) }
+func (m *MultiPolyline) NumChains() int                 { return m.NumPolylines() }
+func (m *MultiPolyline) Dimension() int                 { return 1 }
+func (m *MultiPolyline) IsEmpty() bool                  { return defaultShapeIsEmpty(m) }
+func (m *MultiPolyline) IsFull() bool                   { return defaultShapeIsFull(m) }
+func (m *MultiPolyline) typeTag() typeTag               { return typeTagMultiPolyline }
+func (m *MultiPolyline) privateInterface()              {}
+
+func (m *MultiPolyline) Edge(e int) Edge {
+	i := m.chainForEdge(e)
+	v := m.vertexStarts[i] + e - m.edgeStarts[i]
+	return Edge{m.vertices[v], m.vertices[v+1]}
+}
+
+func (m *MultiPolyline) Chain(i int) Chain {
+	return Chain{m.edgeStarts[i], m.edgeStarts[i+1] - m.edgeStarts[i]}
+}
+
+func (m *MultiPolyline) ChainEdge(i, j int) Edge {
+	v := m.vertexStarts[i] + j
+	return Edge{m.vertices[v], m.vertices[v+1]}
+}
+
+func (m *MultiPolyline) ChainPosition(e int) ChainPosition {
+	i := m.chainForEdge(e)
+	return ChainPosition{i, e - m.edgeStarts[i]}
+}
+
+// Encode encodes the MultiPolyline as a version byte, the number of
+// polylines, and for each polyline its vertex count and vertices.
+func (m *MultiPolyline) Encode(w io.Writer) error {
+	e := &encoder{w: w}
+	m.encode(e)
+	return e.err
+}
+
+func (m *MultiPolyline) encode(e *encoder) {
+	e.writeInt8(encodingVersion)
+	e.writeUint32(uint32(m.NumPolylines()))
+	for i := 0; i < m.NumPolylines(); i++ {
+		line := m.Polyline(i)
+		e.writeUint32(uint32(len(line)))
+		for _, v := range line {
+			e.writeFloat64(v.X)
+			e.writeFloat64(v.Y)
+			e.writeFloat64(v.Z)
+		}
+	}
+}
+
+// Decode decodes a MultiPolyline encoded by Encode, replacing the contents
+// of m.
+func (m *MultiPolyline) Decode(r io.Reader) error {
+	d := &decoder{r: asByteReader(r)}
+	m.decode(d)
+	return d.err
+}
+
+func (m *MultiPolyline) decode(d *decoder) {
+	version := d.readInt8()
+	if d.err != nil {
+		return
+	}
+	if version != encodingVersion {
+		d.err = fmt.Errorf("can't decode version %d; my version: %d", version, encodingVersion)
+		return
+	}
+	nlines := d.readUint32()
+	if d.err != nil {
+		return
+	}
+	if nlines > maxEncodedVertices {
+		d.err = fmt.Errorf("too many polylines (%d; max is %d)", nlines, maxEncodedVertices)
+		return
+	}
+	lines := make([][]Point, 0, minUint64(uint64(nlines), maxDecodePrealloc))
+	total := uint64(0)
+	for i := uint32(0); i < nlines; i++ {
+		nvertices := d.readUint32()
+		if d.err != nil {
+			return
+		}
+		if total += uint64(nvertices); total > maxEncodedVertices {
+			d.err = fmt.Errorf("too many vertices (%d; max is %d)", total, maxEncodedVertices)
+			return
+		}
+		lines = append(lines, d.readPoints(uint64(nvertices)))
+	}
+	if d.err != nil {
+		return
+	}
+	*m = *MultiPolylineFromPoints(lines)
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestMultiPolylineEmpty(t *testing.T) {
+	shape := MultiPolylineFromPoints(nil)
+
+	if got, want := shape.NumEdges(), 0; got != want {
+		t.Errorf("shape.NumEdges() = %v, want %v", got, want)
+	}
+	if got, want := shape.NumChains(), 0; got != want {
+		t.Errorf("shape.NumChains() = %v, want %v", got, want)
+	}
+	if got, want := shape.Dimension(), 1; got != want {
+		t.Errorf("shape.Dimension() = %v, want %v", got, want)
+	}
+	if !shape.IsEmpty() {
+		t.Errorf("shape.IsEmpty() = false, want true")
+	}
+	if shape.IsFull() {
+		t.Errorf("shape.IsFull() = true, want false")
+	}
+}
+
+func TestMultiPolylineChains(t *testing.T) {
+	lines := [][]Point{
+		parsePoints("0:0, 0:1, 1:1"),
+		parsePoints("5:5"),
+		nil,
+		parsePoints("2:2, 2:3"),
+		parsePoints("3:3, 3:4, 4:4, 4:5"),
+	}
+	shape := MultiPolylineFromPoints(lines)
+
+	if got, want := shape.NumEdges(), 6; got != want {
+		t.Errorf("shape.NumEdges() = %v, want %v", got, want)
+	}
+	if got, want := shape.NumChains(), len(lines); got != want {
+		t.Errorf("shape.NumChains() = %v, want %v", got, want)
+	}
+
+	e := 0
+	for i, line := range lines {
+		if got := shape.Polyline(i); len(got) != len(line) {
+			t.Errorf("shape.Polyline(%d) = %v, want %v", i, got, line)
+		}
+		chain := shape.Chain(i)
+		if chain.Start != e || chain.Length != maxInt(0, len(line)-1) {
+			t.Errorf("shape.Chain(%d) = %v, want {%d %d}", i, chain, e, maxInt(0, len(line)-1))
+		}
+		for j := 0; j < chain.Length; j++ {
+			want := Edge{line[j], line[j+1]}
+			if got := shape.ChainEdge(i, j); got != want {
+				t.Errorf("shape.ChainEdge(%d, %d) = %v, want %v", i, j, got, want)
+			}
+			if got := shape.Edge(e); got != want {
+				t.Errorf("shape.Edge(%d) = %v, want %v", e, got, want)
+			}
+			if got, want := shape.ChainPosition(e), (ChainPosition{i, j}); got != want {
+				t.Errorf("shape.ChainPosition(%d) = %v, want %v", e, got, want)
+			}
+			e++
+		}
+	}
+}
+
+func TestMultiPolylineIndex(t *testing.T) {
+	shape := MultiPolylineFromPolylines([]Polyline{
+		*makePolyline("0:0, 0:1"),
+		*makePolyline("10:10, 10:11"),
+	})
+	index := NewShapeIndex()
+	index.Add(shape)
+
+	query := NewClosestEdgeQuery(index, NewClosestEdgeQueryOptions().MaxResults(1))
+	results := query.FindEdges(NewMinDistanceToPointTarget(parsePoint("10:10.5")))
+	if len(results) != 1 {
+		t.Fatalf("FindEdges returned %d results, want 1", len(results))
+	}
+	if got := shape.ChainPosition(int(results[0].EdgeID())).ChainID; got != 1 {
+		t.Errorf("closest edge is in polyline %d, want 1", got)
+	}
+}
+
+func TestMultiPolylineEncodeDecode(t *testing.T) {
+	for _, lines := range [][][]Point{
+		nil,
+		{parsePoints("0:0, 0:1, 1:1"), nil, parsePoints("5:5, 6:6")},
+	} {
+		shape := MultiPolylineFromPoints(lines)
+		var buf bytes.Buffer
+		if err := shape.Encode(&buf); err != nil {
+			t.Fatalf("Encode(%v): %v", lines, err)
+		}
+		encoded := append([]byte(nil), buf.Bytes()...)
+
+		got := new(MultiPolyline)
+		if err := got.Decode(&buf); err != nil {
+			t.Fatalf("Decode(Encode(%v)): %v", lines, err)
+		}
+		if !reflect.DeepEqual(got, shape) {
+			t.Errorf("Decode(Encode(%v)) = %v, want %v", lines, got, shape)
+		}
+
+		for n := 0; n < len(encoded); n++ {
+			if err := new(MultiPolyline).Decode(bytes.NewReader(encoded[:n])); err == nil {
+				t.Errorf("Decode of %d of %d bytes succeeded, want error", n, len(encoded))
+			}
+		}
+	}
+}
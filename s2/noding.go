@@ -0,0 +1,256 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/geo/s1"
+)
+
+// NodePolylines splits the edges of the polylines at all of their mutual
+// intersections, including the self-intersections of each polyline, and
+// returns the resulting segments. This noding is the first step in building
+// a planar subdivision from linework, since afterwards edges only meet at
+// their endpoints.
+//
+// Crossing edges are split at their intersection point as computed by
+// Intersection, which is the same for both edges, so the segments of the
+// two edges share an endpoint exactly. A vertex of one polyline that lies
+// within snapRadius of an edge of another also splits that edge, which
+// handles T-junctions. Any new node within snapRadius of an existing
+// vertex or of an earlier node is snapped to it, which avoids creating tiny
+// segments and ensures that all the edges passing near a point are split
+// at the same node; note that this can move a node off the edge by up to
+// snapRadius.
+//
+// The segments are returned in the order of the polylines and their edges,
+// each edge's segments in order from its first vertex. Degenerate edges are
+// dropped.
+func NodePolylines(lines []*Polyline, snapRadius s1.Angle) []Edge {
+	var segments []Edge
+	for _, s := range nodePolylines(lines, snapRadius) {
+		segments = append(segments, s...)
+	}
+	return segments
+}
+
+// SplitCrossingEdges splits the given edges at all of their crossings and
+// returns the resulting edges, in the order of the input edges. This is the
+// "split crossing edges" option of the C++ S2Builder, and lets almost planar
+// input, such as boundaries from several sources that cross or nearly meet,
+// be merged into a valid arrangement in which edges meet only at shared
+// vertices.
+//
+// The intersectionTolerance is the maximum distance that an edge may move
+// so that it passes through a split point; values smaller than the error of
+// Intersection are increased to it. Crossings, and vertices that lie near
+// other edges, within twice this tolerance of each other or of an input
+// vertex are merged into a single vertex. This radius is the
+// IntersectionMergeRadius of the C++ library for the smallest tolerance: the
+// two computed intersection points for the same crossing may have errors in
+// opposite directions. See NodePolylines for the details of the splitting.
+func SplitCrossingEdges(edges []Edge, intersectionTolerance s1.Angle) []Edge {
+	if intersectionTolerance < IntersectionError {
+		intersectionTolerance = IntersectionError
+	}
+	lines := make([]*Polyline, len(edges))
+	for i, e := range edges {
+		lines[i] = &Polyline{e.V0, e.V1}
+	}
+	return NodePolylines(lines, 2*intersectionTolerance)
+}
+
+// nodePolylines is like NodePolylines, but returns the segments of each
+// polyline separately.
+func nodePolylines(lines []*Polyline, snapRadius s1.Angle) [][]Edge {
+	index := NewShapeIndex()
+	for _, l := range lines {
+		index.Add(l)
+	}
+	limit := s1.ChordAngleFromAngle(snapRadius)
+	query := NewClosestEdgeQuery(index, NewClosestEdgeQueryOptions().DistanceLimit(limit.Successor()))
+	sites := newNodeSites(snapRadius)
+	for _, l := range lines {
+		for _, v := range *l {
+			sites.add(v)
+		}
+	}
+
+	segments := make([][]Edge, len(lines))
+	for i, l := range lines {
+		for e := 0; e < l.NumEdges(); e++ {
+			edge := l.Edge(e)
+			if edge.V0 == edge.V1 {
+				continue
+			}
+			var nodes []Point
+			for _, r := range query.FindEdges(NewMinDistanceToEdgeTarget(edge)) {
+				if int(r.ShapeID()) == i && int(r.EdgeID()) == e {
+					continue
+				}
+				other := index.Shape(r.ShapeID()).Edge(int(r.EdgeID()))
+				nodes = appendNodes(nodes, edge, other, snapRadius)
+			}
+			for j, n := range nodes {
+				nodes[j] = sites.snap(n)
+			}
+			segments[i] = appendNodedSegments(segments[i], edge, nodes)
+		}
+	}
+	return segments
+}
+
+// maxNodingRounds is the number of times nodePolylinesPlanar re-nodes the
+// segments before giving up.
+const maxNodingRounds = 8
+
+// nodePolylinesPlanar is like nodePolylines, but nodes the segments again
+// until none of them are split, since moving the nodes to nearby sites can
+// create new crossings. Before each round, vertices within twice snapRadius
+// of an earlier vertex are merged into it, which keeps two vertices that are
+// only just farther apart than snapRadius from splitting each other's
+// segments forever. Afterwards no two segments cross, no two vertices are
+// within twice snapRadius of each other, and no vertex is within snapRadius
+// of the interior of a segment. It returns an error if this does not happen
+// within a few rounds.
+func nodePolylinesPlanar(lines []*Polyline, snapRadius s1.Angle) ([][]Edge, error) {
+	sites := newNodeSites(2 * snapRadius)
+	var segmentLines []*Polyline
+	var owner []int
+	for i, l := range lines {
+		for e := 0; e < l.NumEdges(); e++ {
+			edge := l.Edge(e)
+			segmentLines = append(segmentLines, &Polyline{edge.V0, edge.V1})
+			owner = append(owner, i)
+		}
+	}
+	for round := 0; round < maxNodingRounds; round++ {
+		changed := false
+		for _, l := range segmentLines {
+			for j, v := range *l {
+				if (*l)[j] = sites.snap(v); (*l)[j] != v {
+					changed = true
+				}
+			}
+		}
+		segments := make([][]Edge, len(lines))
+		var nextLines []*Polyline
+		var nextOwner []int
+		for j, segs := range nodePolylines(segmentLines, snapRadius) {
+			changed = changed || len(segs) > 1
+			segments[owner[j]] = append(segments[owner[j]], segs...)
+			for _, s := range segs {
+				nextLines = append(nextLines, &Polyline{s.V0, s.V1})
+				nextOwner = append(nextOwner, owner[j])
+			}
+		}
+		if !changed {
+			return segments, nil
+		}
+		segmentLines, owner = nextLines, nextOwner
+	}
+	return nil, fmt.Errorf("s2: edges could not be noded in %d rounds", maxNodingRounds)
+}
+
+// appendNodes appends the points where the other edge splits the edge.
+func appendNodes(nodes []Point, edge, other Edge, snapRadius s1.Angle) []Point {
+	// snap returns the closest of the endpoints of the two edges that is
+	// within snapRadius of p, or p itself.
+	snap := func(p Point) Point {
+		best, bestDist := p, snapRadius
+		for _, v := range []Point{edge.V0, edge.V1, other.V0, other.V1} {
+			if d := p.Distance(v); d <= bestDist {
+				best, bestDist = v, d
+			}
+		}
+		return best
+	}
+	if CrossingSign(edge.V0, edge.V1, other.V0, other.V1) == Cross {
+		nodes = append(nodes, snap(Intersection(edge.V0, edge.V1, other.V0, other.V1)))
+	}
+	for _, v := range []Point{other.V0, other.V1} {
+		if DistanceFromSegment(v, edge.V0, edge.V1) <= snapRadius {
+			nodes = append(nodes, snap(v))
+		}
+	}
+	return nodes
+}
+
+// appendNodedSegments appends the segments of the edge split at the given
+// nodes.
+func appendNodedSegments(segments []Edge, edge Edge, nodes []Point) []Edge {
+	sort.Slice(nodes, func(i, j int) bool {
+		return edge.V0.Distance(nodes[i]) < edge.V0.Distance(nodes[j])
+	})
+	prev := edge.V0
+	for _, n := range nodes {
+		if n == prev || n == edge.V1 {
+			continue
+		}
+		segments = append(segments, Edge{prev, n})
+		prev = n
+	}
+	return append(segments, Edge{prev, edge.V1})
+}
+
+// nodeSites is the set of vertices and nodes created so far while noding,
+// bucketed by cells large enough that every site within the snap radius of
+// a point is in the cell containing the point or one of its neighbors.
+type nodeSites struct {
+	snapRadius s1.Angle
+	level      int
+	cells      map[CellID][]Point
+}
+
+func newNodeSites(snapRadius s1.Angle) *nodeSites {
+	return &nodeSites{
+		snapRadius: snapRadius,
+		level:      MinWidthMetric.MaxLevel(snapRadius.Radians()),
+		cells:      make(map[CellID][]Point),
+	}
+}
+
+// add adds p as a site.
+func (s *nodeSites) add(p Point) {
+	id := cellIDFromPoint(p).Parent(s.level)
+	s.cells[id] = append(s.cells[id], p)
+}
+
+// closest returns the closest site within the snap radius of p, and whether
+// there is one.
+func (s *nodeSites) closest(p Point) (Point, bool) {
+	id := cellIDFromPoint(p).Parent(s.level)
+	best, bestDist, found := p, s.snapRadius, false
+	for _, c := range append(id.AllNeighbors(s.level), id) {
+		for _, site := range s.cells[c] {
+			if d := p.Distance(site); d <= bestDist {
+				best, bestDist, found = site, d, true
+			}
+		}
+	}
+	return best, found
+}
+
+// snap returns the closest site within the snap radius of p. If there is
+// none, p is added as a new site and returned.
+func (s *nodeSites) snap(p Point) Point {
+	site, ok := s.closest(p)
+	if !ok {
+		s.add(p)
+	}
+	return site
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/golang/geo/s1"
+)
+
+func TestNodePolylines(t *testing.T) {
+	const snapRadius = s1.Degree / 1000
+	tests := []struct {
+		name  string
+		lines []string
+		want  int
+	}{
+		{"disjoint", []string{"0:0, 0:1", "1:0, 1:1"}, 2},
+		{"cross", []string{"0:-1, 0:1", "-1:0, 1:0"}, 4},
+		{"T-junction", []string{"0:-1, 0:1", "0:0, 1:0"}, 3},
+		{"shared endpoint", []string{"0:0, 0:1", "0:1, 1:1"}, 2},
+		{"self-crossing", []string{"0:0, 1:1, 1:0, 0:1"}, 5},
+		{"two crossings", []string{"0:0, 0:3", "-1:1, 1:1, 1:2, -1:2"}, 8},
+		// The second line crosses the first very close to its vertex, so
+		// the crossing is snapped to the vertex instead of making a tiny
+		// segment.
+		{"snapped", []string{"0:-1, 0:0, 0:1", "-1:0.0000001, 1:0.0000001"}, 4},
+		{"degenerate", []string{"0:0, 0:0, 0:1"}, 1},
+	}
+	for _, test := range tests {
+		var lines []*Polyline
+		for _, s := range test.lines {
+			lines = append(lines, makePolyline(s))
+		}
+		segments := NodePolylines(lines, snapRadius)
+		if len(segments) != test.want {
+			t.Errorf("%s: NodePolylines returned %d segments %v, want %d", test.name, len(segments), segments, test.want)
+			continue
+		}
+
+		// After noding, no two segments cross in their interiors, and the
+		// total length is unchanged except for snapping.
+		var before, after s1.Angle
+		for _, l := range lines {
+			before += l.Length()
+		}
+		for i, a := range segments {
+			after += a.V0.Distance(a.V1)
+			for _, b := range segments[i+1:] {
+				if CrossingSign(a.V0, a.V1, b.V0, b.V1) == Cross {
+					t.Errorf("%s: segments %v and %v cross", test.name, a, b)
+				}
+			}
+		}
+		if d := after - before; d.Abs() > 4*snapRadius {
+			t.Errorf("%s: noding changed the length by %v", test.name, d)
+		}
+	}
+
+	// Segments of crossing edges share the intersection point exactly.
+	segments := NodePolylines([]*Polyline{makePolyline("-1:-1.3, 2:1"), makePolyline("1:-2, -1:1.7")}, 0)
+	if len(segments) != 4 || segments[0].V1 != segments[2].V1 || segments[1].V0 != segments[0].V1 {
+		t.Errorf("NodePolylines of crossing edges = %v, want segments meeting at one point", segments)
+	}
+}
+
+func TestSplitCrossingEdges(t *testing.T) {
+	// Three edges from different sources that should all cross at one point,
+	// but whose computed pairwise crossings differ slightly. After splitting,
+	// every new vertex must be shared by all the edges passing through it.
+	a := Edge{parsePoint("0:0"), parsePoint("2:2")}
+	b := Edge{parsePoint("0:2"), parsePoint("2:0")}
+	x := Intersection(a.V0, a.V1, b.V0, b.V1)
+	tangent := x.Cross(r3.Vector{X: 0, Y: 0.3, Z: 1}).Normalize()
+	c := Edge{
+		Point{x.Sub(tangent.Mul(0.02)).Normalize()},
+		Point{x.Add(tangent.Mul(0.02)).Add(r3.Vector{X: 0, Y: 0, Z: 1e-15}).Normalize()},
+	}
+	edges := []Edge{a, b, c}
+	split := SplitCrossingEdges(edges, 0)
+
+	degree := make(map[Point]int)
+	for i, a := range split {
+		degree[a.V0]++
+		degree[a.V1]++
+		for _, b := range split[i+1:] {
+			if CrossingSign(a.V0, a.V1, b.V0, b.V1) == Cross {
+				t.Errorf("edges %v and %v cross", a, b)
+			}
+		}
+	}
+	var nodes []Point
+	for v, d := range degree {
+		switch d {
+		case 1:
+			// An input vertex.
+		case 6:
+			nodes = append(nodes, v)
+		default:
+			t.Errorf("vertex %v has degree %d, want 1 or 6", v, d)
+		}
+	}
+	if len(nodes) != 1 {
+		t.Errorf("SplitCrossingEdges(%v) = %v, want one node shared by all edges", edges, split)
+	}
+
+	// A larger tolerance also merges a crossing with a nearby vertex.
+	edges = []Edge{
+		{parsePoint("0:0"), parsePoint("0:2")},
+		{parsePoint("-1:1.001"), parsePoint("1:1.001")},
+		{parsePoint("0:1"), parsePoint("1:0.5")},
+	}
+	split = SplitCrossingEdges(edges, 0.01*s1.Degree)
+	if len(split) != 5 {
+		t.Errorf("SplitCrossingEdges(%v) = %v, want 5 edges", edges, split)
+	}
+	for _, e := range split {
+		if e.V0 == e.V1 {
+			t.Errorf("SplitCrossingEdges returned degenerate edge %v", e)
+		}
+	}
+}
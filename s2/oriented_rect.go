@@ -0,0 +1,222 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/golang/geo/r2"
+)
+
+// maxOrientedRectRadius is the largest angular radius of input geometry for
+// which an OrientedRect is computed. The gnomonic projection used by
+// OrientedRect becomes very distorted as points approach 90 degrees from the
+// center of the projection.
+const maxOrientedRectRadius = 80 * math.Pi / 180
+
+// OrientedRect represents a rectangle in the gnomonic (central) projection of
+// the sphere onto the plane tangent to Center. The sides of the rectangle are
+// parallel to XAxis and YAxis, which together with Center form a right-handed
+// orthonormal frame. Bound gives the extents of the rectangle along those
+// axes in projected coordinates.
+//
+// Because the gnomonic projection maps geodesics to straight lines, each side
+// of the rectangle is a geodesic on the sphere and the rectangle corresponds
+// exactly to a convex spherical quadrilateral. Unlike a lat/lng Rect, the
+// frame may be rotated to fit elongated features that run diagonally.
+type OrientedRect struct {
+	Center       Point
+	XAxis, YAxis Point
+	Bound        r2.Rect
+}
+
+// Project returns the coordinates of the given point in the projected plane
+// of this rectangle. It returns false if the point is in the hemisphere
+// opposite the center, in which case it has no projection.
+func (o OrientedRect) Project(p Point) (r2.Point, bool) {
+	z := p.Dot(o.Center.Vector)
+	if z <= 0 {
+		return r2.Point{}, false
+	}
+	return r2.Point{X: p.Dot(o.XAxis.Vector) / z, Y: p.Dot(o.YAxis.Vector) / z}, true
+}
+
+// Unproject returns the point on the sphere corresponding to the given
+// coordinates in the projected plane of this rectangle.
+func (o OrientedRect) Unproject(q r2.Point) Point {
+	v := o.Center.Add(o.XAxis.Mul(q.X)).Add(o.YAxis.Mul(q.Y))
+	return Point{v.Normalize()}
+}
+
+// ContainsPoint reports whether the rectangle contains the given point.
+func (o OrientedRect) ContainsPoint(p Point) bool {
+	q, ok := o.Project(p)
+	return ok && o.Bound.ContainsPoint(q)
+}
+
+// Vertices returns the four corners of the rectangle in counterclockwise
+// order, starting with the corner at the lower bounds of both axes.
+func (o OrientedRect) Vertices() [4]Point {
+	var vertices [4]Point
+	for i, q := range o.Bound.Vertices() {
+		vertices[i] = o.Unproject(q)
+	}
+	return vertices
+}
+
+// Loop returns the rectangle as a Loop. The rectangle must have positive
+// extents along both axes for the loop to be valid.
+func (o OrientedRect) Loop() *Loop {
+	vertices := o.Vertices()
+	return LoopFromPoints(vertices[:])
+}
+
+func (o OrientedRect) String() string {
+	return fmt.Sprintf("[Center=%v, XAxis=%v, Bound=%v]", o.Center, o.XAxis, o.Bound)
+}
+
+// MinAreaOrientedRect returns the rectangle of minimal projected area that
+// contains all of the given points, allowing the frame of the rectangle to
+// be rotated to any orientation about the center of the points' smallest
+// enclosing cap.
+//
+// It returns false if there are no points, or if the points do not fit
+// within a cap of radius 80 degrees; such inputs cannot be usefully bounded
+// by a rectangle in a single gnomonic projection.
+//
+// The area is minimized in the projected plane rather than on the sphere,
+// which differs by a negligible amount for features of modest size.
+func MinAreaOrientedRect(points []Point) (OrientedRect, bool) {
+	c := SmallestEnclosingCap(points)
+	if c.IsEmpty() || c.Radius().Radians() > maxOrientedRectRadius {
+		return OrientedRect{}, false
+	}
+
+	frame := getFrame(c.Center())
+	o := OrientedRect{
+		Center: c.Center(),
+		XAxis:  frame.col(0),
+		YAxis:  frame.col(1),
+	}
+
+	projected := make([]r2.Point, len(points))
+	for i, p := range points {
+		projected[i], _ = o.Project(p)
+	}
+	hull := r2ConvexHull(projected)
+
+	// The minimum area enclosing rectangle has a side collinear with one of
+	// the edges of the convex hull, so we try each edge direction in turn.
+	// A hull with a single vertex yields the default axes.
+	bestDir := r2.Point{X: 1, Y: 0}
+	o.Bound = r2.RectFromPoints(hull...)
+	bestArea := math.Inf(1)
+	for i := range hull {
+		d := hull[(i+1)%len(hull)].Sub(hull[i])
+		if d.Norm() == 0 {
+			continue
+		}
+		u := d.Normalize()
+		v := u.Ortho()
+		bound := r2.EmptyRect()
+		for _, q := range hull {
+			bound = bound.AddPoint(r2.Point{X: q.Dot(u), Y: q.Dot(v)})
+		}
+		if area := bound.Size().X * bound.Size().Y; area < bestArea {
+			bestArea = area
+			bestDir = u
+			o.Bound = bound
+		}
+	}
+
+	// Rotate the axes to match the best direction. The bound was computed
+	// in exactly these coordinates, so projecting through the new axes
+	// reproduces it.
+	x, y := o.XAxis, o.YAxis
+	u, v := bestDir, bestDir.Ortho()
+	o.XAxis = Point{x.Mul(u.X).Add(y.Mul(u.Y))}
+	o.YAxis = Point{x.Mul(v.X).Add(y.Mul(v.Y))}
+	return o, true
+}
+
+// MinAreaOrientedRectForShape returns the minimal area OrientedRect that
+// contains all the edges of the given shape. Since the sides of the
+// rectangle are geodesics, bounding the edge endpoints is sufficient. For
+// polygonal shapes, it returns false if the interior of the shape is not
+// bounded by its edges (e.g., a loop around a small hole).
+func MinAreaOrientedRectForShape(shape Shape) (OrientedRect, bool) {
+	var points []Point
+	for e := 0; e < shape.NumEdges(); e++ {
+		edge := shape.Edge(e)
+		points = append(points, edge.V0, edge.V1)
+	}
+	o, ok := MinAreaOrientedRect(points)
+	if !ok {
+		return o, false
+	}
+	if shape.Dimension() == 2 && containsBruteForce(shape, Point{o.Center.Mul(-1)}) {
+		return OrientedRect{}, false
+	}
+	return o, true
+}
+
+// r2ConvexHull returns the convex hull of the given points in counterclockwise
+// order, using Andrew's monotone chain algorithm. Collinear points on the
+// boundary of the hull are omitted.
+func r2ConvexHull(points []r2.Point) []r2.Point {
+	pts := make([]r2.Point, len(points))
+	copy(pts, points)
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].X != pts[j].X {
+			return pts[i].X < pts[j].X
+		}
+		return pts[i].Y < pts[j].Y
+	})
+	if len(pts) < 3 {
+		if len(pts) == 2 && pts[0] == pts[1] {
+			return pts[:1]
+		}
+		return pts
+	}
+
+	hull := make([]r2.Point, 0, 2*len(pts))
+	turnsLeft := func(a, b, c r2.Point) bool {
+		return b.Sub(a).Cross(c.Sub(a)) > 0
+	}
+	// Lower hull.
+	for _, p := range pts {
+		for len(hull) >= 2 && !turnsLeft(hull[len(hull)-2], hull[len(hull)-1], p) {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	// Upper hull.
+	lower := len(hull) + 1
+	for i := len(pts) - 2; i >= 0; i-- {
+		p := pts[i]
+		for len(hull) >= lower && !turnsLeft(hull[len(hull)-2], hull[len(hull)-1], p) {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	// The last point is the same as the first one.
+	hull = hull[:len(hull)-1]
+	if len(hull) == 0 {
+		return pts[:1]
+	}
+	return hull
+}
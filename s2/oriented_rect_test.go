@@ -0,0 +1,138 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r2"
+)
+
+func TestOrientedRectProjectUnproject(t *testing.T) {
+	frame := getFrame(PointFromCoords(1, 1, 1))
+	o := OrientedRect{
+		Center: frame.col(2),
+		XAxis:  frame.col(0),
+		YAxis:  frame.col(1),
+		Bound:  r2.RectFromPoints(r2.Point{X: -0.1, Y: -0.2}, r2.Point{X: 0.1, Y: 0.2}),
+	}
+
+	for _, q := range []r2.Point{{X: 0, Y: 0}, {X: 0.05, Y: -0.1}, {X: -3, Y: 7}} {
+		got, ok := o.Project(o.Unproject(q))
+		if !ok || !r2PointsApproxEqual(got, q, 1e-14) {
+			t.Errorf("Project(Unproject(%v)) = %v, %v, want %v, true", q, got, ok, q)
+		}
+	}
+
+	if _, ok := o.Project(Point{o.Center.Mul(-1)}); ok {
+		t.Errorf("Project(%v) = _, true, want false for the antipode of the center", Point{o.Center.Mul(-1)})
+	}
+	if !o.ContainsPoint(o.Center) {
+		t.Errorf("%v.ContainsPoint(%v) = false, want true", o, o.Center)
+	}
+	if o.ContainsPoint(o.Unproject(r2.Point{X: 0.2, Y: 0})) {
+		t.Errorf("%v.ContainsPoint(%v) = true, want false", o, o.Unproject(r2.Point{X: 0.2, Y: 0}))
+	}
+
+	loop := o.Loop()
+	if err := loop.Validate(); err != nil {
+		t.Errorf("%v.Loop() is not valid: %v", o, err)
+	}
+	if !loop.ContainsPoint(o.Center) {
+		t.Errorf("%v.Loop() does not contain the center", o)
+	}
+}
+
+func TestMinAreaOrientedRectDiagonalFeature(t *testing.T) {
+	// A thin polyline running diagonally in lat/lng space.
+	polyline := makePolyline("0:0, 1:1.01, 2:1.99, 3:3, 4:4.01, 5:5")
+	o, ok := MinAreaOrientedRectForShape(polyline)
+	if !ok {
+		t.Fatalf("MinAreaOrientedRectForShape(%v) = _, false, want true", polyline)
+	}
+	for _, p := range *polyline {
+		if !o.Bound.ExpandedByMargin(1e-14).ContainsPoint(mustProject(t, o, p)) {
+			t.Errorf("%v does not contain %v", o, p)
+		}
+	}
+
+	// The rectangle should be much smaller than the lat/lng bound.
+	rect := polyline.RectBound()
+	latLngArea := rect.Area()
+	orientedArea := o.Loop().Area()
+	if orientedArea > 0.1*latLngArea {
+		t.Errorf("oriented rect area = %v, want much less than the lat/lng rect area %v", orientedArea, latLngArea)
+	}
+
+	// The long axis should be roughly along the polyline.
+	size := o.Bound.Size()
+	if math.Max(size.X, size.Y) < 10*math.Min(size.X, size.Y) {
+		t.Errorf("%v has size %v, want an elongated rectangle", o, size)
+	}
+}
+
+func TestMinAreaOrientedRectRandom(t *testing.T) {
+	for iter := 0; iter < 50; iter++ {
+		c := randomCap(1e-6, 0.1)
+		points := make([]Point, 1+randomUniformInt(30))
+		for i := range points {
+			points[i] = samplePointFromCap(c)
+		}
+		o, ok := MinAreaOrientedRect(points)
+		if !ok {
+			t.Fatalf("MinAreaOrientedRect(%v) = _, false, want true", points)
+		}
+		if got := (Point{o.XAxis.Cross(o.YAxis.Vector)}); !pointsApproxEqual(got, o.Center, 1e-14) {
+			t.Errorf("axes of %v are not a right-handed frame", o)
+		}
+		for _, p := range points {
+			if !o.Bound.ExpandedByMargin(1e-13).ContainsPoint(mustProject(t, o, p)) {
+				t.Errorf("%v does not contain %v", o, p)
+			}
+		}
+	}
+}
+
+func TestMinAreaOrientedRectDegenerate(t *testing.T) {
+	if _, ok := MinAreaOrientedRect(nil); ok {
+		t.Errorf("MinAreaOrientedRect(nil) = _, true, want false")
+	}
+
+	p := PointFromLatLng(LatLngFromDegrees(10, 20))
+	o, ok := MinAreaOrientedRect([]Point{p, p})
+	if !ok || !o.ContainsPoint(p) {
+		t.Errorf("MinAreaOrientedRect(%v, %v) = %v, %v, want a rect containing the point", p, p, o, ok)
+	}
+
+	far := PointFromLatLng(LatLngFromDegrees(-10, -160))
+	if _, ok := MinAreaOrientedRect([]Point{p, far}); ok {
+		t.Errorf("MinAreaOrientedRect(%v, %v) = _, true, want false for points too far apart", p, far)
+	}
+
+	loop := makeLoop("-1:-1, -1:1, 1:1, 1:-1")
+	loop.Invert()
+	if _, ok := MinAreaOrientedRectForShape(loop); ok {
+		t.Errorf("MinAreaOrientedRectForShape(%v) = _, true, want false for an inverted loop", loop)
+	}
+}
+
+func mustProject(t *testing.T, o OrientedRect, p Point) r2.Point {
+	q, ok := o.Project(p)
+	if !ok {
+		t.Fatalf("%v.Project(%v) = _, false, want true", o, p)
+	}
+	return q
+}
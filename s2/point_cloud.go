@@ -0,0 +1,77 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "fmt"
+
+// Shape interface enforcement
+var _ Shape = (*PointCloud[struct{}])(nil)
+
+// PointCloud is a Shape representing a set of points, each of which may
+// carry a payload of type T. Like PointVector, each point is a degenerate
+// edge in a chain of its own, so edge i, chain i and point i all refer to
+// the same point. This means that the edge ID of a query result against a
+// ShapeIndex can be used directly to look up the matching point and its
+// payload, even when the index also contains other shapes.
+type PointCloud[T any] struct {
+	points   []Point
+	payloads []T
+}
+
+// PointCloudFromPoints constructs a PointCloud from the given points and
+// payloads. The payloads may be nil, in which case the points have no
+// payloads; otherwise there must be exactly one payload per point.
+func PointCloudFromPoints[T any](points []Point, payloads []T) *PointCloud[T] {
+	if payloads != nil && len(payloads) != len(points) {
+		panic(fmt.Sprintf("s2: PointCloud has %d points but %d payloads", len(points), len(payloads)))
+	}
+	p := &PointCloud[T]{points: append([]Point(nil), points...)}
+	if payloads != nil {
+		p.payloads = append([]T(nil), payloads...)
+	}
+	return p
+}
+
+// NumPoints returns the number of points.
+func (p *PointCloud[T]) NumPoints() int { return len(p.points) }
+
+// Point returns the i-th point, which is also the only vertex of edge i.
+func (p *PointCloud[T]) Point(i int) Point { return p.points[i] }
+
+// HasPayloads reports whether the points have payloads.
+func (p *PointCloud[T]) HasPayloads() bool { return p.payloads != nil }
+
+// Payload returns the payload of the i-th point, and false if the points
+// have no payloads.
+func (p *PointCloud[T]) Payload(i int) (T, bool) {
+	if p.payloads == nil {
+		var zero T
+		return zero, false
+	}
+	return p.payloads[i], true
+}
+
+func (p *PointCloud[T]) NumEdges() int                     { return len(p.points) }
+func (p *PointCloud[T]) Edge(i int) Edge                   { return Edge{p.points[i], p.points[i]} }
+func (p *PointCloud[T]) ReferencePoint() ReferencePoint    { return OriginReferencePoint(false) }
+func (p *PointCloud[T]) NumChains() int                    { return len(p.points) }
+func (p *PointCloud[T]) Chain(i int) Chain                 { return Chain{i, 1} }
+func (p *PointCloud[T]) ChainEdge(i, j int) Edge           { return Edge{p.points[i], p.points[i]} }
+func (p *PointCloud[T]) ChainPosition(e int) ChainPosition { return ChainPosition{e, 0} }
+func (p *PointCloud[T]) Dimension() int                    { return 0 }
+func (p *PointCloud[T]) IsEmpty() bool                     { return defaultShapeIsEmpty(p) }
+func (p *PointCloud[T]) IsFull() bool                      { return defaultShapeIsFull(p) }
+func (p *PointCloud[T]) typeTag() typeTag                  { return typeTagNone }
+func (p *PointCloud[T]) privateInterface()                 {}
@@ -0,0 +1,98 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "testing"
+
+func TestPointCloudBasics(t *testing.T) {
+	points := parsePoints("0:0, 1:1, 2:2")
+	shape := PointCloudFromPoints(points, []string{"a", "b", "c"})
+
+	if got, want := shape.NumEdges(), 3; got != want {
+		t.Errorf("shape.NumEdges() = %v, want %v", got, want)
+	}
+	if got, want := shape.NumChains(), 3; got != want {
+		t.Errorf("shape.NumChains() = %v, want %v", got, want)
+	}
+	if got, want := shape.Dimension(), 0; got != want {
+		t.Errorf("shape.Dimension() = %v, want %v", got, want)
+	}
+	if shape.IsEmpty() {
+		t.Errorf("shape.IsEmpty() = true, want false")
+	}
+	if shape.IsFull() {
+		t.Errorf("shape.IsFull() = true, want false")
+	}
+	for i, p := range points {
+		if got, want := shape.Chain(i), (Chain{i, 1}); got != want {
+			t.Errorf("shape.Chain(%d) = %v, want %v", i, got, want)
+		}
+		if got, want := shape.Edge(i), (Edge{p, p}); got != want {
+			t.Errorf("shape.Edge(%d) = %v, want %v", i, got, want)
+		}
+		if got, want := shape.ChainPosition(i), (ChainPosition{i, 0}); got != want {
+			t.Errorf("shape.ChainPosition(%d) = %v, want %v", i, got, want)
+		}
+	}
+
+	// The shape keeps its own copy of the input.
+	points[0] = parsePoint("5:5")
+	if shape.Point(0) == points[0] {
+		t.Errorf("PointCloud shares storage with its input")
+	}
+
+	empty := PointCloudFromPoints[int](nil, nil)
+	if !empty.IsEmpty() || empty.HasPayloads() {
+		t.Errorf("empty PointCloud: IsEmpty() = %v, HasPayloads() = %v", empty.IsEmpty(), empty.HasPayloads())
+	}
+	noPayloads := PointCloudFromPoints[int](points, nil)
+	if _, ok := noPayloads.Payload(1); ok {
+		t.Errorf("Payload(1) of a PointCloud without payloads reported ok")
+	}
+}
+
+func TestPointCloudMismatchedPayloads(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("PointCloudFromPoints with mismatched payloads did not panic")
+		}
+	}()
+	PointCloudFromPoints(parsePoints("0:0, 1:1"), []int{1})
+}
+
+func TestPointCloudIndexPayloads(t *testing.T) {
+	index := NewShapeIndex()
+	index.Add(makePolygon("10:10, 10:12, 12:10", true))
+	cities := PointCloudFromPoints(parsePoints("0:0, 0:5, 5:0"), []string{"alpha", "bravo", "charlie"})
+	citiesID := index.Add(cities)
+
+	query := NewClosestEdgeQuery(index, NewClosestEdgeQueryOptions().MaxResults(2))
+	results := query.FindEdges(NewMinDistanceToPointTarget(parsePoint("0:4")))
+	if len(results) != 2 {
+		t.Fatalf("FindEdges returned %d results, want 2", len(results))
+	}
+	var got []string
+	for _, r := range results {
+		if r.ShapeID() != citiesID {
+			t.Errorf("result %v is not from the point cloud", r)
+			continue
+		}
+		name, _ := cities.Payload(int(r.EdgeID()))
+		got = append(got, name)
+	}
+	if len(got) != 2 || got[0] != "bravo" || got[1] != "alpha" {
+		t.Errorf("closest payloads = %v, want [bravo alpha]", got)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "sort"
+
+// PointIndex maintains an index of points sorted by leaf CellID. Each point
+// carries a payload of type T, which can be used to map results back to
+// client data structures; use struct{} if no payload is needed. The same
+// point may be added more than once, with the same or different payloads.
+//
+// The index is sorted lazily, the first time it is iterated or queried after
+// points have been added. Points that share a leaf cell are kept in the order
+// they were added.
+//
+// This type is not safe for concurrent use, not even by several queries,
+// unless Build has been called since the last change.
+//
+// Use ClosestPointQuery to find the points nearest to a target geometry.
+type PointIndex[T any] struct {
+	entries []pointIndexEntry[T]
+	sorted  bool
+}
+
+// pointIndexEntry is a point in a PointIndex along with its payload and the
+// leaf cell that it is sorted by.
+type pointIndexEntry[T any] struct {
+	id    CellID
+	point Point
+	data  T
+}
+
+// NewPointIndex returns an empty PointIndex.
+func NewPointIndex[T any]() *PointIndex[T] {
+	return &PointIndex[T]{sorted: true}
+}
+
+// Add adds the given point and payload to the index.
+func (p *PointIndex[T]) Add(point Point, data T) {
+	p.entries = append(p.entries, pointIndexEntry[T]{cellIDFromPoint(point), point, data})
+	p.sorted = false
+}
+
+// NumPoints returns the number of points in the index.
+func (p *PointIndex[T]) NumPoints() int { return len(p.entries) }
+
+// Reset removes all the points from the index.
+func (p *PointIndex[T]) Reset() {
+	p.entries = nil
+	p.sorted = true
+}
+
+// Build sorts any points added since the index was last sorted. It is called
+// automatically when needed, but calling it explicitly allows the index to be
+// shared by concurrent queries.
+func (p *PointIndex[T]) Build() {
+	if p.sorted {
+		return
+	}
+	sort.SliceStable(p.entries, func(i, j int) bool { return p.entries[i].id < p.entries[j].id })
+	p.sorted = true
+}
+
+// cellRange returns the range [lo, hi) of the positions of the entries
+// contained by the given cell.
+func (p *PointIndex[T]) cellRange(id CellID) (lo, hi int) {
+	lo = p.seek(id.RangeMin())
+	hi = lo + sort.Search(len(p.entries)-lo, func(i int) bool { return p.entries[lo+i].id > id.RangeMax() })
+	return lo, hi
+}
+
+// seek returns the position of the first entry whose leaf cell is at or
+// after the given one.
+func (p *PointIndex[T]) seek(id CellID) int {
+	return sort.Search(len(p.entries), func(i int) bool { return p.entries[i].id >= id })
+}
+
+// Iterator returns an iterator positioned at the first point in the index,
+// in leaf CellID order.
+func (p *PointIndex[T]) Iterator() *PointIndexIterator[T] {
+	p.Build()
+	return &PointIndexIterator[T]{index: p}
+}
+
+// PointIndexIterator iterates over the points of a PointIndex in leaf CellID
+// order. Adding points to the index invalidates its iterators.
+type PointIndexIterator[T any] struct {
+	index *PointIndex[T]
+	pos   int
+}
+
+// Begin positions the iterator at the first point in the index.
+func (it *PointIndexIterator[T]) Begin() { it.pos = 0 }
+
+// Next advances the iterator to the next point.
+func (it *PointIndexIterator[T]) Next() { it.pos++ }
+
+// Done reports whether the iterator is positioned past the last point.
+func (it *PointIndexIterator[T]) Done() bool { return it.pos >= len(it.index.entries) }
+
+// Seek positions the iterator at the first point whose leaf cell is at or
+// after the given cell's RangeMin, so that the points contained by the cell
+// follow until a point with CellID after its RangeMax.
+func (it *PointIndexIterator[T]) Seek(target CellID) { it.pos = it.index.seek(target.RangeMin()) }
+
+// CellID returns the leaf cell containing the current point.
+func (it *PointIndexIterator[T]) CellID() CellID { return it.index.entries[it.pos].id }
+
+// Point returns the current point.
+func (it *PointIndexIterator[T]) Point() Point { return it.index.entries[it.pos].point }
+
+// Data returns the payload of the current point.
+func (it *PointIndexIterator[T]) Data() T { return it.index.entries[it.pos].data }
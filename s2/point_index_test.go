@@ -0,0 +1,106 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPointIndexIterator(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	index := NewPointIndex[int]()
+	if it := index.Iterator(); !it.Done() {
+		t.Errorf("iterator of an empty index is not done")
+	}
+
+	var points []Point
+	for i := 0; i < 100; i++ {
+		p := randomPointFromRand(rng)
+		points = append(points, p)
+		index.Add(p, i)
+	}
+	// Add a duplicate of the first point with another payload.
+	index.Add(points[0], 100)
+	if got, want := index.NumPoints(), 101; got != want {
+		t.Fatalf("NumPoints() = %d, want %d", got, want)
+	}
+
+	seen := make(map[int]bool)
+	prev := CellID(0)
+	var dupPayloads []int
+	for it := index.Iterator(); !it.Done(); it.Next() {
+		if it.CellID() < prev {
+			t.Errorf("CellID() = %v after %v, want increasing order", it.CellID(), prev)
+		}
+		prev = it.CellID()
+		if it.CellID() != cellIDFromPoint(it.Point()) {
+			t.Errorf("CellID() = %v, want the leaf cell of %v", it.CellID(), it.Point())
+		}
+		if it.Data() < 100 && it.Point() != points[it.Data()] {
+			t.Errorf("Point() = %v, want %v for payload %d", it.Point(), points[it.Data()], it.Data())
+		}
+		if it.Point() == points[0] {
+			dupPayloads = append(dupPayloads, it.Data())
+		}
+		seen[it.Data()] = true
+	}
+	if len(seen) != 101 {
+		t.Errorf("iteration visited %d payloads, want 101", len(seen))
+	}
+	// Points in the same leaf cell are kept in the order they were added.
+	if len(dupPayloads) != 2 || dupPayloads[0] != 0 || dupPayloads[1] != 100 {
+		t.Errorf("payloads of the duplicated point = %v, want [0 100]", dupPayloads)
+	}
+
+	// Seek to a cell visits exactly the points it contains.
+	for level := 0; level <= 4; level++ {
+		id := cellIDFromPoint(points[7]).Parent(level)
+		want := 0
+		for _, p := range points {
+			if id.Contains(cellIDFromPoint(p)) {
+				want++
+			}
+		}
+		if id.Contains(cellIDFromPoint(points[0])) {
+			want++
+		}
+		got := 0
+		it := index.Iterator()
+		for it.Seek(id); !it.Done() && it.CellID() <= id.RangeMax(); it.Next() {
+			got++
+		}
+		if got != want {
+			t.Errorf("Seek(%v) visited %d points, want %d", id, got, want)
+		}
+	}
+
+	index.Reset()
+	if index.NumPoints() != 0 || !index.Iterator().Done() {
+		t.Errorf("index is not empty after Reset")
+	}
+}
+
+// randomPointFromRand returns a point chosen uniformly at random from the
+// sphere using the given source.
+func randomPointFromRand(rng *rand.Rand) Point {
+	// Sample the unit ball and project onto the sphere.
+	for {
+		x, y, z := rng.Float64()*2-1, rng.Float64()*2-1, rng.Float64()*2-1
+		if n := x*x + y*y + z*z; n > 0 && n <= 1 {
+			return PointFromCoords(x, y, z)
+		}
+	}
+}
@@ -63,7 +63,7 @@ func PointArea(a, b, c Point) float64 {
 	sa := b.stableAngle(c)
 	sb := c.stableAngle(a)
 	sc := a.stableAngle(b)
-	s := 0.5 * (sa + sb + sc)
+	s := s1.Angle(0.5 * (sa + sb + sc))
 	if s >= 3e-4 {
 		// Consider whether Girard's formula might be more accurate.
 		dmin := s - maxAngle(sa, sb, sc)
@@ -77,6 +77,13 @@ func PointArea(a, b, c Point) float64 {
 	}
 
 	// Use l'Huilier's formula.
+	return lhuilierArea(sa, sb, sc)
+}
+
+// lhuilierArea returns the area of the triangle with the given side lengths
+// using l'Huilier's formula.
+func lhuilierArea(sa, sb, sc s1.Angle) float64 {
+	s := s1.Angle(0.5 * (sa + sb + sc))
 	return 4 * math.Atan(math.Sqrt(math.Max(0.0,
 		math.Tan(float64(0.5*s))*math.Tan(0.5*float64(s-sa))*
 			math.Tan(0.5*float64(s-sb))*math.Tan(0.5*float64(s-sc)))))
@@ -215,14 +215,20 @@ func (fi *facesIterator) next() (ok bool) {
 	return true
 }
 
-func decodePointsCompressed(d *decoder, level int, target []Point) {
-	faces := decodeFaces(len(target), d)
+func decodePointsCompressed(d *decoder, level, n int) []Point {
+	faces := decodeFaces(n, d)
+	if d.err != nil {
+		return nil
+	}
 
 	piCoder := newNthDerivativeCoder(derivativeEncodingOrder)
 	qiCoder := newNthDerivativeCoder(derivativeEncodingOrder)
 
+	// A single face run can claim any number of points, so the slice grows
+	// as points are read rather than being allocated up front.
+	target := make([]Point, 0, minInt(n, maxDecodePrealloc))
 	iter := facesIterator{faces: faces}
-	for i := range target {
+	for i := 0; i < n && d.err == nil; i++ {
 		decodeFn := decodePointCompressed
 		if i == 0 {
 			decodeFn = decodeFirstPointFixedLength
@@ -230,32 +236,33 @@ func decodePointsCompressed(d *decoder, level int, target []Point) {
 		pi, qi := decodeFn(d, level, piCoder, qiCoder)
 		if ok := iter.next(); !ok && d.err == nil {
 			d.err = fmt.Errorf("ran out of faces at target %d", i)
-			return
+			return nil
 		}
-		target[i] = Point{facePiQitoXYZ(iter.curFace, pi, qi, level)}
+		target = append(target, Point{facePiQitoXYZ(iter.curFace, pi, qi, level)})
 	}
 
-	numOffCenter := int(d.readUvarint())
+	numOffCenter := d.readUvarint()
 	if d.err != nil {
-		return
+		return nil
 	}
-	if numOffCenter > len(target) {
+	if numOffCenter > uint64(len(target)) {
 		d.err = fmt.Errorf("numOffCenter = %d, should be at most len(target) = %d", numOffCenter, len(target))
-		return
+		return nil
 	}
-	for i := 0; i < numOffCenter; i++ {
-		idx := int(d.readUvarint())
+	for i := uint64(0); i < numOffCenter; i++ {
+		idx := d.readUvarint()
 		if d.err != nil {
-			return
+			return nil
 		}
-		if idx >= len(target) {
+		if idx >= uint64(len(target)) {
 			d.err = fmt.Errorf("off center index = %d, should be < len(target) = %d", idx, len(target))
-			return
+			return nil
 		}
 		target[idx].X = d.readFloat64()
 		target[idx].Y = d.readFloat64()
 		target[idx].Z = d.readFloat64()
 	}
+	return target
 }
 
 func decodeFirstPointFixedLength(d *decoder, level int, piCoder, qiCoder *nthDerivativeCoder) (pi, qi uint32) {
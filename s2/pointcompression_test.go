@@ -78,8 +78,7 @@ NextTest:
 		}
 
 		d := &decoder{r: &buf}
-		got := make([]Point, len(tt.pts))
-		decodePointsCompressed(d, tt.level, got)
+		got := decodePointsCompressed(d, tt.level, len(tt.pts))
 		if d.err != nil {
 			t.Errorf("decodePointsCompressed (%s): %v", tt.label, d.err)
 		}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"io"
 	"math"
+
+	"github.com/golang/geo/s1"
 )
 
 // Polygon represents a sequence of zero or more loops; recall that the
@@ -57,6 +59,10 @@ type Polygon struct {
 	// hasHoles tracks if this polygon has at least one hole.
 	hasHoles bool
 
+	// hasInconsistentLoopOrientations is set by PolygonFromOrientedLoops if
+	// the given loops did not have consistent shell/hole orientations.
+	hasInconsistentLoopOrientations bool
+
 	// numVertices keeps the running total of all of the vertices of the contained loops.
 	numVertices int
 
@@ -100,6 +106,16 @@ func PolygonFromLoops(loops []*Loop) *Polygon {
 	return p
 }
 
+// PolygonFromLoopsOrError is like PolygonFromLoops, but returns an error if
+// the loops do not form a valid polygon as checked by Validate.
+func PolygonFromLoopsOrError(loops []*Loop) (*Polygon, error) {
+	p := PolygonFromLoops(loops)
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("s2: invalid polygon: %v", err)
+	}
+	return p, nil
+}
+
 // PolygonFromOrientedLoops returns a Polygon from the given set of loops,
 // like PolygonFromLoops. It expects loops to be oriented such that the polygon
 // interior is on the left-hand side of all loops. This implies that shells
@@ -180,10 +196,21 @@ func PolygonFromOrientedLoops(loops []*Loop) *Polygon {
 		}
 	}
 
+	// Verify that the original loops had consistent shell/hole orientations.
+	// Each original loop L should have been inverted if and only if it now
+	// represents a hole. There is no point in saving which loop was at fault,
+	// because in general there is no way to determine which ones are incorrect.
+	for _, l := range p.loops {
+		if (containedOrigin[l] != l.ContainsOrigin()) != l.IsHole() {
+			p.hasInconsistentLoopOrientations = true
+		}
+	}
+
 	return p
 }
 
-// Invert inverts the polygon (replaces it by its complement).
+// Invert inverts the polygon (replaces it by its complement). Its loops are
+// inverted in place; use Complement to leave the polygon unchanged.
 func (p *Polygon) Invert() {
 	// Inverting any one loop will invert the polygon.  The best loop to invert
 	// is the one whose area is largest, since this yields the smallest area
@@ -251,6 +278,21 @@ func (p *Polygon) Invert() {
 	p.initLoopProperties()
 }
 
+// Complement returns a new polygon that contains exactly the points not
+// contained by this polygon, leaving this polygon unchanged. Unlike inverting
+// each loop, this keeps the loop depths consistent, so that the holes of
+// the complement are the shells of this polygon and vice versa. The
+// complement of the empty polygon is the full polygon, and vice versa.
+func (p *Polygon) Complement() *Polygon {
+	c := &Polygon{loops: make([]*Loop, 0, len(p.loops))}
+	for _, l := range p.loops {
+		c.loops = append(c.loops, l.clone())
+	}
+	c.initLoopProperties()
+	c.Invert()
+	return c
+}
+
 // Defines a total ordering on Loops that does not depend on the cyclic
 // order of loop vertices. This function is used to choose which loop to
 // invert in the case where several loops have exactly the same area.
@@ -276,6 +318,167 @@ func PolygonFromCell(cell Cell) *Polygon {
 	return PolygonFromLoops([]*Loop{LoopFromCell(cell)})
 }
 
+// PolygonFromRect returns a polygon approximating the given rectangle. Sides
+// along meridians are exact, since meridians are great circles. Each side
+// along a parallel of latitude other than the equator is approximated by
+// maxVerticesPerEdge edges spanning equal ranges of longitude, and these bulge
+// towards the nearer pole. An edge spanning d radians of longitude at latitude
+// lat deviates from the parallel by at most atan(tan(lat)/cos(d/2)) - lat, at
+// its midpoint. More edges are used if needed so that no edge spans more than
+// 90 degrees.
+//
+// The empty and full rectangles give the empty and full polygons. Rectangles
+// with zero area give the empty polygon.
+func PolygonFromRect(r Rect, maxVerticesPerEdge int) *Polygon {
+	if r.IsFull() {
+		return FullPolygon()
+	}
+	if r.IsEmpty() || r.Lat.Lo == r.Lat.Hi || r.Lng.Lo == r.Lng.Hi {
+		return PolygonFromLoops(nil)
+	}
+
+	// numEdges returns the number of edges used for a side spanning the given
+	// angle when maxEdges are wanted.
+	numEdges := func(span float64, maxEdges int) int {
+		return maxInt(maxEdges, int(math.Ceil(span/(math.Pi/2))), 1)
+	}
+	var vertices []Point
+	add := func(lat, lng float64) {
+		vertices = append(vertices, PointFromLatLng(LatLng{s1.Angle(lat), s1.Angle(lng)}))
+	}
+	// parallel adds the vertices of the side at the given latitude, going
+	// east or west from one end of the longitude range. The far end is added
+	// too unless the side is a closed ring. A side at a pole adds only the
+	// pole.
+	lngLength := r.Lng.Length()
+	parallel := func(lat float64, east, ring bool) {
+		if math.Abs(lat) == math.Pi/2 {
+			vertices = append(vertices, PointFromCoords(0, 0, math.Copysign(1, lat)))
+			return
+		}
+		maxEdges := maxVerticesPerEdge
+		if lat == 0 {
+			maxEdges = 1
+		}
+		n := numEdges(lngLength, maxEdges)
+		last := n
+		if ring {
+			last = n - 1
+		}
+		for i := 0; i <= last; i++ {
+			d := lngLength * float64(i) / float64(n)
+			if east {
+				add(lat, r.Lng.Lo+d)
+			} else {
+				add(lat, r.Lng.Hi-d)
+			}
+		}
+	}
+
+	if r.Lng.IsFull() {
+		// The boundary consists of one or two rings along parallels, oriented
+		// so that the rectangle is on their left.
+		var loops []*Loop
+		if r.Lat.Lo != -math.Pi/2 {
+			parallel(r.Lat.Lo, true, true)
+			loops = append(loops, LoopFromPoints(vertices))
+		}
+		if r.Lat.Hi != math.Pi/2 {
+			vertices = nil
+			parallel(r.Lat.Hi, false, true)
+			loops = append(loops, LoopFromPoints(vertices))
+		}
+		return PolygonFromOrientedLoops(loops)
+	}
+
+	// Go counter-clockwise around the rectangle, adding the interior vertices
+	// of the meridian sides between the parallel sides.
+	latLength := r.Lat.Length()
+	m := numEdges(latLength, 1)
+	parallel(r.Lat.Lo, true, false)
+	for i := 1; i < m; i++ {
+		add(r.Lat.Lo+latLength*float64(i)/float64(m), r.Lng.Hi)
+	}
+	parallel(r.Lat.Hi, false, false)
+	for i := 1; i < m; i++ {
+		add(r.Lat.Hi-latLength*float64(i)/float64(m), r.Lng.Lo)
+	}
+	return PolygonFromLoops([]*Loop{LoopFromPoints(vertices)})
+}
+
+// PolygonFromCap returns a polygon approximating the given cap by a regular
+// loop of numVertices vertices (at least 3) on the boundary of the cap. For a
+// cap of radius r smaller than a hemisphere, the polygon is contained by the
+// cap and the midpoint of each edge is atan(tan(r)*cos(pi/numVertices)) from
+// the center rather than r. For caps larger than a hemisphere the edges bulge
+// outside the cap instead, and hemispheres are represented exactly.
+//
+// The empty and full caps give the empty and full polygons. A cap of zero
+// radius gives the empty polygon.
+func PolygonFromCap(c Cap, numVertices int) *Polygon {
+	if c.IsFull() {
+		return FullPolygon()
+	}
+	if c.IsEmpty() || c.Height() == 0 {
+		return PolygonFromLoops(nil)
+	}
+	return PolygonFromLoops([]*Loop{RegularLoop(c.Center(), c.Radius(), maxInt(numVertices, 3))})
+}
+
+// PolygonFromClosedPolylines returns a polygon whose loops are the given
+// closed polylines, such as those returned by BoundaryPolylines. Each
+// polyline must end at its first vertex and have at least 3 distinct
+// vertices, and the polylines must be oriented so that the polygon interior
+// is on their left (i.e. shells and holes have opposite orientations). An
+// error is returned if any polyline is not closed or the result is not a
+// valid polygon. No polylines give the empty polygon.
+func PolygonFromClosedPolylines(lines []*Polyline) (*Polygon, error) {
+	loops := make([]*Loop, 0, len(lines))
+	for i, line := range lines {
+		n := len(*line)
+		if n < 4 || (*line)[0] != (*line)[n-1] {
+			return nil, fmt.Errorf("s2: polyline %d is not a closed polyline with at least 3 edges", i)
+		}
+		// Copy the vertices so that the loop does not share them with the
+		// polyline.
+		vertices := append([]Point(nil), (*line)[:n-1]...)
+		loop, err := LoopFromPointsOrError(vertices)
+		if err != nil {
+			return nil, fmt.Errorf("s2: polyline %d: %v", i, err)
+		}
+		loops = append(loops, loop)
+	}
+	if len(loops) == 0 {
+		return PolygonFromLoops(nil), nil
+	}
+	p := PolygonFromOrientedLoops(loops)
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("s2: invalid polygon: %v", err)
+	}
+	return p, nil
+}
+
+// BoundaryPolylines returns the loops of the polygon as closed polylines,
+// whose last vertex is a copy of the first. They are oriented so that the
+// polygon interior is on their left, so holes are clockwise. The empty and
+// full polygons have no boundary and return no polylines.
+func (p *Polygon) BoundaryPolylines() []*Polyline {
+	var lines []*Polyline
+	for _, l := range p.loops {
+		if l.isEmptyOrFull() {
+			continue
+		}
+		n := l.NumVertices()
+		line := make(Polyline, n+1)
+		for i := 0; i < n; i++ {
+			line[i] = l.OrientedVertex(i)
+		}
+		line[n] = line[0]
+		lines = append(lines, &line)
+	}
+	return lines
+}
+
 // initNested takes the set of loops in this polygon and performs the nesting
 // computations to set the proper nesting and parent/child relationships.
 func (p *Polygon) initNested() {
@@ -459,18 +662,18 @@ func (p *Polygon) Validate() error {
 		}
 	}
 
-	// TODO(roberts): Uncomment the remaining checks when they are completed.
-
 	// Check for loop self-intersections and loop pairs that cross
 	// (including duplicate edges and vertices).
-	// if findSelfIntersection(p.index) {
-	//	return fmt.Errorf("polygon has loop pairs that cross")
-	// }
+	if p.index != nil {
+		if err := findSelfIntersection(p.index); err != nil {
+			return err
+		}
+	}
 
-	// Check whether initOriented detected inconsistent loop orientations.
-	// if p.hasInconsistentLoopOrientations {
-	// 	return fmt.Errorf("inconsistent loop orientations detected")
-	// }
+	// Check whether PolygonFromOrientedLoops detected inconsistent loop orientations.
+	if p.hasInconsistentLoopOrientations {
+		return fmt.Errorf("inconsistent loop orientations detected")
+	}
 
 	// Finally, verify the loop nesting hierarchy.
 	return p.findLoopNestingError()
@@ -554,7 +757,7 @@ func (p *Polygon) Parent(k int) (index int, ok bool) {
 	// we don't know how many may be next to us before we get back to our parent loop.)
 	// Move up one position from us, and then begin traversing back through the set of loops
 	// until we find the one that is our parent or we get to the top of the polygon.
-	for k--; k >= 0 && p.loops[k].depth <= depth; k-- {
+	for k--; k >= 0 && p.loops[k].depth >= depth; k-- {
 	}
 	return k, true
 }
@@ -580,17 +783,136 @@ func (p *Polygon) LastDescendant(k int) int {
 	return k - 1
 }
 
+// Shells returns the indices of the shells of this polygon, i.e. the loops
+// with even depth, in loop order. Each shell is followed by its holes and any
+// shells nested inside them (see HolesOf).
+func (p *Polygon) Shells() []int {
+	var shells []int
+	for i, l := range p.loops {
+		if !l.IsHole() {
+			shells = append(shells, i)
+		}
+	}
+	return shells
+}
+
+// HolesOf returns the indices of the holes immediately inside shell k, i.e.
+// the children of loop k in the nesting hierarchy. Shells nested inside those
+// holes are not included. If loop k is a hole, HolesOf returns nil.
+func (p *Polygon) HolesOf(k int) []int {
+	if p.loops[k].IsHole() {
+		return nil
+	}
+	var holes []int
+	depth := p.loops[k].depth
+	for i, last := k+1, p.LastDescendant(k); i <= last; i++ {
+		if p.loops[i].depth == depth+1 {
+			holes = append(holes, i)
+		}
+	}
+	return holes
+}
+
+// AddShell adds the given loop to the polygon as a shell. The loop must be
+// valid, must not cross or contain any of the existing loops, and must lie
+// outside the polygon interior (either disjoint from every shell or inside
+// one of the holes). On error the polygon is left unchanged.
+//
+// Note that adding loops one at a time costs time proportional to the total
+// number of vertices for each loop added. To build a polygon with many loops
+// at once, use PolygonFromLoops instead.
+func (p *Polygon) AddShell(l *Loop) error {
+	return p.addNestedLoop(l, false)
+}
+
+// AddHole adds the given loop to the polygon as a hole. Like all the loops of
+// a polygon, the loop is oriented so that it encloses the hole, i.e. it is
+// counter-clockwise around the points removed from the polygon. The loop must
+// be valid, must not cross or contain any of the existing loops, and must lie
+// inside one of the shells. On error the polygon is left unchanged.
+func (p *Polygon) AddHole(l *Loop) error {
+	return p.addNestedLoop(l, true)
+}
+
+// addNestedLoop adds l to the polygon after checking that its position in the
+// nesting hierarchy makes it a hole if and only if hole is true.
+func (p *Polygon) addNestedLoop(l *Loop, hole bool) error {
+	if err := l.Validate(); err != nil {
+		return fmt.Errorf("s2: invalid loop: %v", err)
+	}
+	if l.isEmptyOrFull() {
+		return fmt.Errorf("s2: empty and full loops cannot be added to a polygon")
+	}
+	if p.IsFull() {
+		return fmt.Errorf("s2: loops cannot be added to the full polygon")
+	}
+
+	// The loop must nest cleanly with all the existing loops. Its depth is the
+	// number of existing loops that contain it.
+	depth := 0
+	for i, o := range p.loops {
+		switch {
+		case !l.Intersects(o):
+		case o.Contains(l):
+			depth++
+		case l.Contains(o):
+			return fmt.Errorf("s2: loop would contain loop %d", i)
+		default:
+			return fmt.Errorf("s2: loop crosses loop %d", i)
+		}
+	}
+	if isHole := depth&1 != 0; isHole != hole {
+		if hole {
+			return fmt.Errorf("s2: hole is not inside a shell")
+		}
+		return fmt.Errorf("s2: shell is inside the polygon interior")
+	}
+
+	// Copy the loops slice so that a slice passed to PolygonFromLoops is not
+	// modified.
+	p.loops = append(p.loops[:len(p.loops):len(p.loops)], l)
+	p.initNested()
+	return nil
+}
+
 // CapBound returns a bounding spherical cap.
 func (p *Polygon) CapBound() Cap { return p.bound.CapBound() }
 
 // RectBound returns a bounding latitude-longitude rectangle.
 func (p *Polygon) RectBound() Rect { return p.bound }
 
+// SubregionBound returns a bounding rectangle that contains the RectBound of
+// every polygon contained by this polygon. See ExpandForSubregions.
+func (p *Polygon) SubregionBound() Rect { return p.subregionBound }
+
+// CheckBoundsConsistency reports an error if the cached bounds of the polygon
+// or any of its loops are inconsistent. In addition to the checks made by
+// Loop.CheckBoundsConsistency, the polygon's RectBound must contain the bound
+// of every shell, and the SubregionBound of every loop must contain the bound
+// of its children.
+func (p *Polygon) CheckBoundsConsistency() error {
+	if !p.subregionBound.Contains(p.bound) {
+		return fmt.Errorf("subregion bound %v does not contain bound %v", p.subregionBound, p.bound)
+	}
+	for i, l := range p.loops {
+		if err := l.CheckBoundsConsistency(); err != nil {
+			return fmt.Errorf("loop %d: %v", i, err)
+		}
+		if !l.IsHole() && !p.bound.Contains(l.bound) {
+			return fmt.Errorf("bound %v does not contain bound %v of loop %d", p.bound, l.bound, i)
+		}
+		if parent, ok := p.Parent(i); ok && !p.loops[parent].subregionBound.Contains(l.bound) {
+			return fmt.Errorf("subregion bound of loop %d does not contain bound %v of loop %d", parent, l.bound, i)
+		}
+	}
+	return nil
+}
+
 // ContainsPoint reports whether the polygon contains the point.
 func (p *Polygon) ContainsPoint(point Point) bool {
 	// NOTE: A bounds check slows down this function by about 50%. It is
 	// worthwhile only when it might allow us to delay building the index.
-	if !p.index.IsFresh() && !p.bound.ContainsPoint(point) {
+	if (p.index == nil || !p.index.IsFresh()) && !p.bound.ContainsPoint(point) {
 		return false
 	}
 
@@ -687,12 +1009,12 @@ func (p *Polygon) boundaryApproxIntersects(it *ShapeIndexIterator, cell Cell) bo
 	}
 
 	// Otherwise check whether any of the edges intersect cell.
-	maxError := (faceClipErrorUVCoord + intersectsRectErrorUVDist)
+	maxError := (FaceClipErrorUVCoord + IntersectsRectErrorUVDist)
 	bound := cell.BoundUV().ExpandedByMargin(maxError)
 	for _, e := range aClipped.edges {
 		edge := p.index.Shape(0).Edge(e)
 		v0, v1, ok := ClipToPaddedFace(edge.V0, edge.V1, cell.Face(), maxError)
-		if ok && edgeIntersectsRect(v0, v1, bound) {
+		if ok && EdgeIntersectsRect(v0, v1, bound) {
 			return true
 		}
 	}
@@ -823,6 +1145,12 @@ func (p *Polygon) privateInterface() {}
 // Specifically, it reports whether all the points in the other polygon
 // are also in this polygon.
 func (p *Polygon) Contains(o *Polygon) bool {
+	// Every polygon contains the empty polygon. This is checked explicitly
+	// because the bound of a zero-value Polygon is not the empty rectangle.
+	if o.IsEmpty() {
+		return true
+	}
+
 	// If both polygons have one loop, use the more efficient Loop method.
 	// Note that Loop's Contains does its own bounding rectangle check.
 	if len(p.loops) == 1 && len(o.loops) == 1 {
@@ -896,6 +1224,87 @@ func (p *Polygon) Intersects(o *Polygon) bool {
 	return !p.excludesBoundary(o) || !o.excludesNonCrossingShells(p)
 }
 
+// IntersectionPoint returns a point in the intersection of this polygon and
+// the other one, and reports whether there is such a point (i.e. whether
+// Intersects returns true). This is much cheaper than computing the
+// intersection itself, and is useful for diagnostics and labeling.
+//
+// The point is a loop vertex of one polygon that is contained by the other
+// or, if there is none, a point where their boundaries cross or touch. In
+// either case it may lie on the boundary of a polygon, and so need not be
+// contained by it under the semi-open boundary model.
+func (p *Polygon) IntersectionPoint(o *Polygon) (Point, bool) {
+	if !p.Intersects(o) {
+		return Point{}, false
+	}
+	for _, l := range o.loops {
+		if v := l.Vertex(0); p.ContainsPoint(v) {
+			return v, true
+		}
+	}
+	for _, l := range p.loops {
+		if v := l.Vertex(0); o.ContainsPoint(v) {
+			return v, true
+		}
+	}
+
+	// Neither polygon contains a loop of the other, so their boundaries must
+	// cross or touch.
+	if o.index == nil {
+		return Point{}, false
+	}
+	query := NewCrossingEdgeQuery(o.index)
+	for _, l := range p.loops {
+		for i := 0; i < l.NumEdges(); i++ {
+			a, b := l.Vertex(i), l.Vertex(i+1)
+			edges := query.Crossings(a, b, o, CrossingTypeAll)
+			if len(edges) == 0 {
+				continue
+			}
+			e := o.Edge(edges[0])
+			if CrossingSign(a, b, e.V0, e.V1) == Cross {
+				return Intersection(a, b, e.V0, e.V1), true
+			}
+			// The edges share a vertex.
+			if a == e.V0 || a == e.V1 {
+				return a, true
+			}
+			return b, true
+		}
+	}
+	return Point{}, false
+}
+
+// Union returns a new polygon containing the points contained by either this
+// polygon or the other one. Vertices closer together than the error of
+// computing edge crossings are merged; use a BooleanOperation to snap the
+// result more coarsely. It returns an error if the result cannot be built,
+// see BooleanOperation.
+func (p *Polygon) Union(o *Polygon) (*Polygon, error) {
+	return p.booleanOperation(BooleanOperationUnion, o)
+}
+
+// Intersection returns a new polygon containing the points contained by both
+// this polygon and the other one. Vertices are merged as for Union.
+func (p *Polygon) Intersection(o *Polygon) (*Polygon, error) {
+	return p.booleanOperation(BooleanOperationIntersection, o)
+}
+
+// Difference returns a new polygon containing the points contained by this
+// polygon but not the other one. Vertices are merged as for Union.
+func (p *Polygon) Difference(o *Polygon) (*Polygon, error) {
+	return p.booleanOperation(BooleanOperationDifference, o)
+}
+
+// booleanOperation returns the result of the given operation on this polygon
+// and the other one.
+func (p *Polygon) booleanOperation(opType BooleanOperationType, o *Polygon) (*Polygon, error) {
+	a, b := NewShapeIndex(), NewShapeIndex()
+	a.Add(p)
+	b.Add(o)
+	return NewBooleanOperation(opType, nil).Build(a, b)
+}
+
 // compareBoundary returns +1 if this polygon contains the boundary of B, -1 if A
 // excludes the boundary of B, and 0 if the boundaries of A and B cross.
 func (p *Polygon) compareBoundary(o *Loop) int {
@@ -1042,6 +1451,23 @@ func (p *Polygon) Encode(w io.Writer) error {
 	return e.err
 }
 
+// EncodeWithIndex encodes the Polygon together with its ShapeIndex, which is
+// built first if necessary. Decoding the result with Decode restores the
+// index directly, which avoids the cost of rebuilding it on the first query
+// at the expense of a larger encoding.
+//
+// The encoding is a version marker, followed by the encoding written by
+// Encode and the index as written by ShapeIndex.Encode, which is the format
+// of the C++ MutableS2ShapeIndex. Only this package can decode the marker;
+// use Encode for data that must be read by other S2 libraries.
+func (p *Polygon) EncodeWithIndex(w io.Writer) error {
+	e := &encoder{w: w}
+	e.writeInt8(encodingIndexedVersion)
+	p.encode(e)
+	p.index.encode(e)
+	return e.err
+}
+
 // encode only supports lossless encoding and not compressed format.
 func (p *Polygon) encode(e *encoder) {
 	if p.numVertices == 0 {
@@ -1132,10 +1558,15 @@ func (p *Polygon) encodeCompressed(e *encoder, snapLevel int, vertices []xyzFace
 	// speed difference is inconsequential.
 }
 
-// Decode decodes the Polygon.
+// Decode decodes the Polygon. Encodings produced by EncodeWithIndex also
+// restore the polygon's index.
 func (p *Polygon) Decode(r io.Reader) error {
 	d := &decoder{r: asByteReader(r)}
 	version := int8(d.readUint8())
+	indexed := version == encodingIndexedVersion
+	if indexed {
+		version = int8(d.readUint8())
+	}
 	var dec func(*decoder)
 	switch version {
 	case encodingVersion:
@@ -1146,6 +1577,9 @@ func (p *Polygon) Decode(r io.Reader) error {
 		return fmt.Errorf("unsupported version %d", version)
 	}
 	dec(d)
+	if indexed && d.err == nil {
+		p.index.decode(d, []Shape{p})
+	}
 	return d.err
 }
 
@@ -1169,11 +1603,12 @@ func (p *Polygon) decode(d *decoder) {
 		d.err = fmt.Errorf("too many loops (%d; max is %d)", nloops, maxEncodedLoops)
 		return
 	}
-	p.loops = make([]*Loop, nloops)
-	for i := range p.loops {
-		p.loops[i] = new(Loop)
-		p.loops[i].decode(d)
-		p.numVertices += len(p.loops[i].vertices)
+	p.loops = make([]*Loop, 0, minUint64(uint64(nloops), maxDecodePrealloc))
+	for i := uint32(0); i < nloops && d.err == nil; i++ {
+		l := new(Loop)
+		l.decode(d)
+		p.loops = append(p.loops, l)
+		p.numVertices += len(l.vertices)
 	}
 
 	p.bound.decode(d)
@@ -1193,14 +1628,22 @@ func (p *Polygon) decodeCompressed(d *decoder) {
 	}
 	// Polygons with no loops are explicitly allowed here: a newly created
 	// polygon has zero loops and such polygons encode and decode properly.
-	nloops := int(d.readUvarint())
+	nloops := d.readUvarint()
+	if d.err != nil {
+		return
+	}
 	if nloops > maxEncodedLoops {
 		d.err = fmt.Errorf("too many loops (%d; max is %d)", nloops, maxEncodedLoops)
+		return
 	}
-	p.loops = make([]*Loop, nloops)
-	for i := range p.loops {
-		p.loops[i] = new(Loop)
-		p.loops[i].decodeCompressed(d, snapLevel)
+	p.loops = make([]*Loop, 0, minUint64(nloops, maxDecodePrealloc))
+	for i := uint64(0); i < nloops; i++ {
+		l := new(Loop)
+		l.decodeCompressed(d, snapLevel)
+		if d.err != nil {
+			return
+		}
+		p.loops = append(p.loops, l)
 	}
 	p.initLoopProperties()
 }
@@ -15,10 +15,14 @@
 package s2
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/golang/geo/r1"
 	"github.com/golang/geo/s1"
 )
 
@@ -211,6 +215,11 @@ func TestPolygonFull(t *testing.T) {
 	if !shape.ReferencePoint().Contained {
 		t.Errorf("shape.ReferencePoint().Contained = false, want true")
 	}
+	for _, p := range []Point{OriginPoint(), PointFromCoords(0, 0, 1), PointFromCoords(0, 0, -1)} {
+		if !shape.ContainsPoint(p) {
+			t.Errorf("shape.ContainsPoint(%v) = false, want true", p)
+		}
+	}
 }
 
 func TestPolygonInitLoopPropertiesGetsRightBounds(t *testing.T) {
@@ -296,6 +305,13 @@ func reverseLoopVertices(l *Loop) {
 	}
 }
 
+// reverseLoopPoints reverses the order of the given loop vertices.
+func reverseLoopPoints(vertices []Point) {
+	for i, j := 0, len(vertices)-1; i < j; i, j = i+1, j-1 {
+		vertices[i], vertices[j] = vertices[j], vertices[i]
+	}
+}
+
 // shuffleLoops randomizes the slice of loops using Fisher-Yates shuffling.
 func shuffleLoops(loops []*Loop) {
 	n := len(loops)
@@ -344,7 +360,10 @@ func generatePolygonConcentricTestLoops(numLoops, minVertices int) []*Loop {
 	return loops
 }
 
-func checkPolygonInvalid(t *testing.T, label string, loops []*Loop, initOriented bool, f modifyPolygonFunc) {
+// checkPolygonInvalid builds a polygon from the given loops, applies the
+// optional modification, and checks that Validate returns an error that
+// contains the given text.
+func checkPolygonInvalid(t *testing.T, want string, loops []*Loop, initOriented bool, f modifyPolygonFunc) {
 	shuffleLoops(loops)
 	var polygon *Polygon
 	if initOriented {
@@ -357,9 +376,29 @@ func checkPolygonInvalid(t *testing.T, label string, loops []*Loop, initOriented
 		f(polygon)
 	}
 
-	if err := polygon.Validate(); err == nil {
-		t.Errorf("%s: %v.Validate() = %v, want non-nil", label, polygon, err)
+	if err := polygon.Validate(); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("%v.Validate() = %v, want error containing %q", polygon, err, want)
+	}
+}
+
+// concentricTestLoopVertices returns the vertices of the loops generated by
+// generatePolygonConcentricTestLoops, so that they can be modified before the
+// loops are built.
+func concentricTestLoopVertices(numLoops, minVertices int) [][]Point {
+	var vertices [][]Point
+	for _, l := range generatePolygonConcentricTestLoops(numLoops, minVertices) {
+		vertices = append(vertices, append([]Point(nil), l.Vertices()...))
 	}
+	return vertices
+}
+
+// loopsFromVertices builds a loop from each of the given vertex slices.
+func loopsFromVertices(vertices [][]Point) []*Loop {
+	var loops []*Loop
+	for _, v := range vertices {
+		loops = append(loops, LoopFromPoints(v))
+	}
+	return loops
 }
 
 func TestPolygonUninitializedIsValid(t *testing.T) {
@@ -386,23 +425,151 @@ func TestPolygonIsValidLoopNestingInvalid(t *testing.T) {
 	}
 }
 
+func TestPolygonIsValidUnitLength(t *testing.T) {
+	for iter := 0; iter < 100; iter++ {
+		vertices := concentricTestLoopVertices(1+randomUniformInt(3), 3)
+		l := vertices[randomUniformInt(len(vertices))]
+		i := randomUniformInt(len(l))
+		l[i] = Point{l[i].Mul(2)}
+		checkPolygonInvalid(t, "unit length", loopsFromVertices(vertices), oneIn(2), nil)
+	}
+}
+
+func TestPolygonIsValidVertexCount(t *testing.T) {
+	for iter := 0; iter < 100; iter++ {
+		vertices := concentricTestLoopVertices(1+randomUniformInt(3), 3)
+		i := randomUniformInt(len(vertices))
+		// Loops with a single vertex are the special empty and full loops.
+		vertices[i] = vertices[i][:2]
+		checkPolygonInvalid(t, "at least 3 vertices", loopsFromVertices(vertices), false, nil)
+	}
+}
+
+func TestPolygonIsValidDuplicateVertex(t *testing.T) {
+	for iter := 0; iter < 100; iter++ {
+		vertices := concentricTestLoopVertices(1, 3)
+		l := vertices[0]
+		i := randomUniformInt(len(l))
+		j := randomUniformInt(len(l) - 1)
+		if j >= i {
+			j++
+		}
+		l[j] = l[i]
+		checkPolygonInvalid(t, "duplicate vertex", loopsFromVertices(vertices), oneIn(2), nil)
+	}
+}
+
+func TestPolygonIsValidSelfIntersection(t *testing.T) {
+	for iter := 0; iter < 100; iter++ {
+		// Use multiple loops so that we can test both holes and shells. We
+		// need at least 5 vertices so that the modified edges don't intersect
+		// any nested loops.
+		vertices := concentricTestLoopVertices(1+randomUniformInt(5), 5)
+		l := vertices[randomUniformInt(len(vertices))]
+		i := randomUniformInt(len(l))
+		j := (i + 1) % len(l)
+		l[i], l[j] = l[j], l[i]
+		checkPolygonInvalid(t, "crosses", loopsFromVertices(vertices), oneIn(2), nil)
+	}
+}
+
+func TestPolygonIsValidEmptyLoop(t *testing.T) {
+	for iter := 0; iter < 100; iter++ {
+		// A polygon with a single empty loop is the empty polygon, so there
+		// must be at least one other loop.
+		loops := generatePolygonConcentricTestLoops(1+randomUniformInt(4), 3)
+		loops = append(loops, EmptyLoop())
+		checkPolygonInvalid(t, "empty loop", loops, false, nil)
+	}
+}
+
+func TestPolygonIsValidFullLoop(t *testing.T) {
+	for iter := 0; iter < 100; iter++ {
+		// This is only an error if there is at least one other loop.
+		loops := generatePolygonConcentricTestLoops(1+randomUniformInt(5), 3)
+		loops = append(loops, FullLoop())
+		checkPolygonInvalid(t, "full loop", loops, false, nil)
+	}
+}
+
+func TestPolygonIsValidLoopsCrossing(t *testing.T) {
+	for iter := 0; iter < 100; iter++ {
+		vertices := concentricTestLoopVertices(2, 4)
+		// Both loops have the same number of vertices, and vertices at the
+		// same index position are collinear with the center point, so we can
+		// create a crossing by simply exchanging two vertices at the same
+		// index position.
+		n := len(vertices[0])
+		i := randomUniformInt(n)
+		vertices[0][i], vertices[1][i] = vertices[1][i], vertices[0][i]
+		if oneIn(2) {
+			// By copying the two adjacent vertices from one loop to the
+			// other, we can ensure that the crossings happen at vertices
+			// rather than edges.
+			vertices[0][(i+1)%n] = vertices[1][(i+1)%n]
+			vertices[0][(i+n-1)%n] = vertices[1][(i+n-1)%n]
+		}
+		checkPolygonInvalid(t, "crosses", loopsFromVertices(vertices), false, nil)
+	}
+}
+
+func TestPolygonIsValidDuplicateEdge(t *testing.T) {
+	for iter := 0; iter < 100; iter++ {
+		vertices := concentricTestLoopVertices(2, 4)
+		n := len(vertices[0])
+		if oneIn(2) {
+			// Create a duplicate edge with the same orientation in both loops.
+			i := randomUniformInt(n)
+			vertices[0][i] = vertices[1][i]
+			vertices[0][(i+1)%n] = vertices[1][(i+1)%n]
+		} else {
+			// Make the loops identical.
+			copy(vertices[0], vertices[1])
+		}
+		checkPolygonInvalid(t, "has duplicate", loopsFromVertices(vertices), false, nil)
+	}
+}
+
+func TestPolygonIsValidInconsistentOrientations(t *testing.T) {
+	for iter := 0; iter < 100; iter++ {
+		// All the loops are oriented counterclockwise, so the nested loops
+		// do not have the orientation of holes.
+		loops := generatePolygonConcentricTestLoops(2+randomUniformInt(5), 3)
+		checkPolygonInvalid(t, "inconsistent loop orientations", loops, true, nil)
+	}
+}
+
+func TestPolygonIsValidLoopDepthNegative(t *testing.T) {
+	for iter := 0; iter < 100; iter++ {
+		loops := generatePolygonConcentricTestLoops(1+randomUniformInt(4), 3)
+		checkPolygonInvalid(t, "invalid loop depth", loops, false, polygonSetInvalidLoopDepth)
+	}
+}
+
+func TestPolygonIsValidNestedLoops(t *testing.T) {
+	// Concentric loops are nested without touching, so oriented correctly
+	// they form a valid polygon.
+	for iter := 0; iter < 100; iter++ {
+		vertices := concentricTestLoopVertices(1+randomUniformInt(5), 3)
+		for i := 1; i < len(vertices); i += 2 {
+			reverseLoopPoints(vertices[i])
+		}
+		p := PolygonFromOrientedLoops(loopsFromVertices(vertices))
+		if err := p.Validate(); err != nil {
+			t.Errorf("%v.Validate() = %v, want nil", p, err)
+		}
+	}
+}
+
 // TODO(roberts): Implement remaining validity tests.
 // IsValidTests
-//   TestUnitLength
-//   TestVertexCount
-//   TestDuplicateVertex
-//   TestSelfIntersection
-//   TestEmptyLoop
-//   TestFullLoop
-//   TestLoopsCrossing
-//   TestDuplicateEdge
-//   TestInconsistentOrientations
-//   TestLoopDepthNegative
-//   TestLoopNestingInvalid
 //   TestFuzzTest
 
 func TestPolygonParent(t *testing.T) {
 	p1 := PolygonFromLoops([]*Loop{{}})
+	// A shell with two holes, the first of which contains an island. The
+	// loops are ordered as shell, hole, island, hole.
+	p2 := makePolygon("0:0, 0:10, 10:10, 10:0; 1:1, 1:5, 5:5, 5:1; 2:2, 2:3, 3:3, 3:2; 6:6, 6:8, 8:8, 8:6", true)
 	tests := []struct {
 		p    *Polygon
 		have int
@@ -411,9 +578,10 @@ func TestPolygonParent(t *testing.T) {
 	}{
 		{fullPolygon, 0, -1, false},
 		{p1, 0, -1, false},
-
-		// TODO: When multiple loops are supported, add more test cases to
-		// more fully show the parent levels.
+		{p2, 0, -1, false},
+		{p2, 1, 0, true},
+		{p2, 2, 1, true},
+		{p2, 3, 0, true},
 	}
 
 	for _, test := range tests {
@@ -447,6 +615,112 @@ func TestPolygonLastDescendant(t *testing.T) {
 	}
 }
 
+func TestPolygonShellsAndHoles(t *testing.T) {
+	// Two shells, the first with two holes, the first of which contains an
+	// island. The loops are ordered as shell, hole, island, hole, shell.
+	p := makePolygon("0:0, 0:10, 10:10, 10:0; 1:1, 1:5, 5:5, 5:1; 2:2, 2:3, 3:3, 3:2; 6:6, 6:8, 8:8, 8:6; 20:20, 20:21, 21:21, 21:20", true)
+
+	if got, want := p.Shells(), []int{0, 2, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Shells() = %v, want %v", got, want)
+	}
+	tests := []struct {
+		k    int
+		want []int
+	}{
+		{0, []int{1, 3}},
+		{1, nil},
+		{2, nil},
+		{3, nil},
+		{4, nil},
+	}
+	for _, test := range tests {
+		if got := p.HolesOf(test.k); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("HolesOf(%d) = %v, want %v", test.k, got, test.want)
+		}
+	}
+
+	if got := emptyPolygon.Shells(); got != nil {
+		t.Errorf("emptyPolygon.Shells() = %v, want nil", got)
+	}
+	if got, want := fullPolygon.Shells(), []int{0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("fullPolygon.Shells() = %v, want %v", got, want)
+	}
+}
+
+func TestPolygonAddShellAndHole(t *testing.T) {
+	p := &Polygon{}
+	steps := []struct {
+		loop string
+		hole bool
+	}{
+		{"0:0, 0:10, 10:10, 10:0", false},
+		{"6:6, 6:8, 8:8, 8:6", true},
+		{"20:20, 20:21, 21:21, 21:20", false},
+		{"1:1, 1:5, 5:5, 5:1", true},
+		{"2:2, 2:3, 3:3, 3:2", false},
+	}
+	for _, step := range steps {
+		var err error
+		if step.hole {
+			err = p.AddHole(makeLoop(step.loop))
+		} else {
+			err = p.AddShell(makeLoop(step.loop))
+		}
+		if err != nil {
+			t.Fatalf("adding %q (hole %v) failed: %v", step.loop, step.hole, err)
+		}
+		if err := p.Validate(); err != nil {
+			t.Fatalf("polygon invalid after adding %q: %v", step.loop, err)
+		}
+	}
+
+	want := makePolygon("0:0, 0:10, 10:10, 10:0; 1:1, 1:5, 5:5, 5:1; 2:2, 2:3, 3:3, 3:2; 6:6, 6:8, 8:8, 8:6; 20:20, 20:21, 21:21, 21:20", true)
+	checkPolygonsEqual(t, "AddShell/AddHole", p, want)
+	// Siblings are kept in the order they were added, so the hole containing
+	// the island comes second.
+	if got, want := p.HolesOf(0), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HolesOf(0) = %v, want %v", got, want)
+	}
+
+	errTests := []struct {
+		loop string
+		hole bool
+	}{
+		// A hole outside of every shell.
+		{"30:30, 30:31, 31:31, 31:30", true},
+		// A shell inside the polygon interior.
+		{"8.5:8.5, 8.5:9, 9:9, 9:8.5", false},
+		// A shell inside a hole that contains an island.
+		{"1.5:1.5, 1.5:4.5, 4.5:4.5, 4.5:1.5", false},
+		// A shell crossing an existing shell.
+		{"9:9, 9:12, 12:12, 12:9", false},
+		// A hole containing an existing hole.
+		{"5.5:5.5, 5.5:9, 9:9, 9:5.5", true},
+		// A shell containing the whole polygon.
+		{"-1:-1, -1:30, 30:30, 30:-1", false},
+	}
+	for _, test := range errTests {
+		numLoops := p.NumLoops()
+		var err error
+		if test.hole {
+			err = p.AddHole(makeLoop(test.loop))
+		} else {
+			err = p.AddShell(makeLoop(test.loop))
+		}
+		if err == nil {
+			t.Errorf("adding %q (hole %v) succeeded, want error", test.loop, test.hole)
+		}
+		if p.NumLoops() != numLoops {
+			t.Errorf("adding %q (hole %v) changed the number of loops from %d to %d", test.loop, test.hole, numLoops, p.NumLoops())
+		}
+	}
+
+	full := FullPolygon()
+	if err := full.AddHole(makeLoop("0:0, 0:1, 1:1, 1:0")); err == nil {
+		t.Errorf("FullPolygon().AddHole succeeded, want error")
+	}
+}
+
 func TestPolygonContainsPoint(t *testing.T) {
 	tests := []struct {
 		polygon string
@@ -472,6 +746,51 @@ func TestPolygonContainsPoint(t *testing.T) {
 	}
 }
 
+// checkPolygonsEqual reports an error if the two polygons do not contain the
+// same points. Their loops may differ where loops touch at a vertex, so the
+// polygons are compared by testing points on either side of every edge of
+// both of them.
+func checkPolygonsEqual(t *testing.T, desc string, got, want *Polygon) {
+	t.Helper()
+	if got.IsEmpty() != want.IsEmpty() || got.IsFull() != want.IsFull() {
+		t.Errorf("%s = %v, want %v", desc, got, want)
+		return
+	}
+	for _, p := range []*Polygon{got, want} {
+		for i := 0; i < p.NumEdges(); i++ {
+			e := p.Edge(i)
+			mid := Point{e.V0.Add(e.V1.Vector).Normalize()}
+			offset := e.V0.PointCross(e.V1).Normalize().Mul(1e-9)
+			for _, q := range []Point{{mid.Add(offset).Normalize()}, {mid.Sub(offset).Normalize()}} {
+				if got.ContainsPoint(q) != want.ContainsPoint(q) {
+					t.Errorf("%s = %v, want %v; they differ at %v", desc, got, want, q)
+					return
+				}
+			}
+		}
+	}
+}
+
+// polygonOp returns the result of the given operation on a and b computed
+// with the Polygon method, and fails the test if it returns an error.
+func polygonOp(t *testing.T, opType BooleanOperationType, a, b *Polygon) *Polygon {
+	t.Helper()
+	var result *Polygon
+	var err error
+	switch opType {
+	case BooleanOperationUnion:
+		result, err = a.Union(b)
+	case BooleanOperationIntersection:
+		result, err = a.Intersection(b)
+	case BooleanOperationDifference:
+		result, err = a.Difference(b)
+	}
+	if err != nil {
+		t.Fatalf("%v.%v(%v) returned error: %v", a, opType, b, err)
+	}
+	return result
+}
+
 // Given a pair of polygons where A contains B, check that various identities
 // involving union, intersection, and difference operations hold true.
 func testPolygonOneNestedPair(t *testing.T, a, b *Polygon) {
@@ -485,8 +804,11 @@ func testPolygonOneNestedPair(t *testing.T, a, b *Polygon) {
 		t.Errorf("%v.Intersects(%v) = %v, want %v", b, a, got, want)
 	}
 
-	// TODO(roberts): Add the remaining checks related to construction
-	// via union, intersection, and difference.
+	checkPolygonsEqual(t, "a.Union(b)", polygonOp(t, BooleanOperationUnion, a, b), a)
+	checkPolygonsEqual(t, "a.Intersection(b)", polygonOp(t, BooleanOperationIntersection, a, b), b)
+	if got := polygonOp(t, BooleanOperationDifference, b, a); !got.IsEmpty() {
+		t.Errorf("%v.Difference(%v) = %v, want empty", b, a, got)
+	}
 }
 
 // Given a pair of disjoint polygons A and B, check that various identities
@@ -506,8 +828,28 @@ func testPolygonOneDisjointPair(t *testing.T, a, b *Polygon) {
 		t.Errorf("%v.Contains(%v) = %v, want %v", b, a, got, want)
 	}
 
-	// TODO(roberts): Add the remaining checks related to construction
-	// via builder, union, intersection, and difference.
+	// The union of disjoint polygons has the loops of both of them. The
+	// Builder cannot tell whether a polygon without edges is empty or full,
+	// so in that case the union only needs to be one or the other.
+	union := polygonOp(t, BooleanOperationUnion, a, b)
+	builder := NewBuilder(BuilderOptions{})
+	builder.AddPolygon(a)
+	builder.AddPolygon(b)
+	f, err := builder.BuildPolygon()
+	switch {
+	case err != nil:
+		t.Errorf("building the loops of %v and %v returned error: %v", a, b, err)
+	case f.IsEmpty():
+		if !union.IsEmpty() && !union.IsFull() {
+			t.Errorf("%v.Union(%v) = %v, want empty or full", a, b, union)
+		}
+	default:
+		checkPolygonsEqual(t, "a.Union(b)", union, f)
+	}
+	if got := polygonOp(t, BooleanOperationIntersection, a, b); !got.IsEmpty() {
+		t.Errorf("%v.Intersection(%v) = %v, want empty", a, b, got)
+	}
+	checkPolygonsEqual(t, "a.Difference(b)", polygonOp(t, BooleanOperationDifference, a, b), a)
 }
 
 // Given polygons A and B whose union covers the sphere, check that various
@@ -519,8 +861,9 @@ func testPolygonOneCoveringPair(t *testing.T, a, b *Polygon) {
 	if got, want := b.Contains(a), b.IsFull(); got != want {
 		t.Errorf("%v.Contains(%v) = %v, want %v", a, b, got, want)
 	}
-	// TODO(roberts): Add the remaining checks related to construction via union
-
+	if got := polygonOp(t, BooleanOperationUnion, a, b); !got.IsFull() {
+		t.Errorf("%v.Union(%v) = %v, want full", a, b, got)
+	}
 }
 
 // Given polygons A and B such that both A and its complement intersect both B
@@ -537,66 +880,85 @@ func testPolygonOneOverlappingPair(t *testing.T, a, b *Polygon) {
 		t.Errorf("%v.Intersects(%v) = false, want true", a, b)
 	}
 
-	// TODO(roberts): Add the remaining checks related to construction
-	// via builder, union, intersection, and difference.
+	if got := polygonOp(t, BooleanOperationUnion, a, b); got.IsFull() {
+		t.Errorf("%v.Union(%v) = full, want not full", a, b)
+	}
+	if got := polygonOp(t, BooleanOperationIntersection, a, b); got.IsEmpty() {
+		t.Errorf("%v.Intersection(%v) = empty, want not empty", a, b)
+	}
+	if got := polygonOp(t, BooleanOperationDifference, b, a); got.IsEmpty() {
+		t.Errorf("%v.Difference(%v) = empty, want not empty", b, a)
+	}
 }
 
 // Given a pair of polygons where A contains B, test various identities
 // involving A, B, and their complements.
 func testPolygonNestedPair(t *testing.T, a, b *Polygon) {
-	// TODO(roberts): Uncomment once complement is completed
-	// a1 := InitToComplement(a)
-	// b1 := InitToComplement(b)
+	a1 := a.Complement()
+	b1 := b.Complement()
 
 	testPolygonOneNestedPair(t, a, b)
-	// testPolygonOneNestedPair(t, b1, a1)
-	// testPolygonOneDisjointPair(t, a1, b)
-	// testPolygonOneCoveringPair(t, a, b1)
+	testPolygonOneNestedPair(t, b1, a1)
+	testPolygonOneDisjointPair(t, a1, b)
+	testPolygonOneCoveringPair(t, a, b1)
 }
 
 // Given a pair of disjoint polygons A and B, test various identities
 // involving A, B, and their complements.
 func testPolygonDisjointPair(t *testing.T, a, b *Polygon) {
-	// TODO(roberts): Uncomment once complement is completed
-	// a1 := InitToComplement(a)
-	// b1 := InitToComplement(b)
+	a1 := a.Complement()
+	b1 := b.Complement()
 
 	testPolygonOneDisjointPair(t, a, b)
-	// testPolygonOneCoveringPair(t, a1, b1)
-	// testPolygonOneNestedPair(t, a1, b)
-	// testPolygonOneNestedPair(t, b1, a)
+	testPolygonOneCoveringPair(t, a1, b1)
+	testPolygonOneNestedPair(t, a1, b)
+	testPolygonOneNestedPair(t, b1, a)
 }
 
 // Given polygons A and B such that both A and its complement intersect both B
 // and its complement, test various identities involving these four polygons.
 func testPolygonOverlappingPair(t *testing.T, a, b *Polygon) {
-	// TODO(roberts): Uncomment once complement is completed
-	// a1 := InitToComplement(a);
-	// b1 := InitToComplement(b);
+	a1 := a.Complement()
+	b1 := b.Complement()
 
 	testPolygonOneOverlappingPair(t, a, b)
-	// testPolygonOneOverlappingPair(t, a1, b1);
-	// testPolygonOneOverlappingPair(t, a1, b);
-	// testPolygonOneOverlappingPair(t, a, b1);
+	testPolygonOneOverlappingPair(t, a1, b1)
+	testPolygonOneOverlappingPair(t, a1, b)
+	testPolygonOneOverlappingPair(t, a, b1)
 }
 
 // Test identities that should hold for any pair of polygons A, B and their
 // complements.
 func testPolygonComplements(t *testing.T, a, b *Polygon) {
-	// TODO(roberts): Uncomment once complement is completed
-	// a1 := InitToComplement(a);
-	// b1 := InitToComplement(b);
+	a1 := a.Complement()
+	b1 := b.Complement()
 
-	// testOneComplementPair(t, a, a1, b, b1)
-	// testOneComplementPair(t, a1, a, b, b1)
-	// testOneComplementPair(t, a, a1, b1, b)
-	// testOneComplementPair(t, a1, a, b1, b)
+	testPolygonOneComplementPair(t, a, a1, b, b1)
+	testPolygonOneComplementPair(t, a1, a, b, b1)
+	testPolygonOneComplementPair(t, a, a1, b1, b)
+	testPolygonOneComplementPair(t, a1, a, b1, b)
+}
 
-	// TODO(roberts): Add the checks related to construction via union, etc.
+// Given polygons A and B and their complements A1 and B1, check that
+// various identities involving union, intersection, and difference hold
+// true.
+func testPolygonOneComplementPair(t *testing.T, a, a1, b, b1 *Polygon) {
+	// Check De Morgan's laws, and that A - B = A ∩ B1.
+	checkPolygonsEqual(t, "a1.Union(b1)", polygonOp(t, BooleanOperationUnion, a1, b1), polygonOp(t, BooleanOperationIntersection, a, b).Complement())
+	checkPolygonsEqual(t, "a1.Intersection(b1)", polygonOp(t, BooleanOperationIntersection, a1, b1), polygonOp(t, BooleanOperationUnion, a, b).Complement())
+	checkPolygonsEqual(t, "a.Difference(b)", polygonOp(t, BooleanOperationDifference, a, b), polygonOp(t, BooleanOperationIntersection, a, b1))
 }
 
 func testPolygonDestructiveUnion(t *testing.T, a, b *Polygon) {
-	// TODO(roberts): Add the checks related to construction via union, etc.
+	// The union must not depend on the order of the operands, and must
+	// absorb both of them.
+	c := polygonOp(t, BooleanOperationUnion, a, b)
+	checkPolygonsEqual(t, "b.Union(a)", polygonOp(t, BooleanOperationUnion, b, a), c)
+	checkPolygonsEqual(t, "a.Union(b).Union(a)", polygonOp(t, BooleanOperationUnion, c, a), c)
+	checkPolygonsEqual(t, "a.Union(b).Union(b)", polygonOp(t, BooleanOperationUnion, c, b), c)
+	if err := c.Validate(); err != nil {
+		t.Errorf("%v.Union(%v) = %v is not valid: %v", a, b, c, err)
+	}
 }
 
 func TestPolygonRelations(t *testing.T) {
@@ -1156,6 +1518,51 @@ func TestPolygonInvert(t *testing.T) {
 	}
 }
 
+func TestPolygonComplement(t *testing.T) {
+	for _, p := range []*Polygon{
+		near0231Polygon, near023H1Polygon, far2H013Polygon, south20bH0acPolygon,
+		nf2N2F210S210abPolygon, cross1SideHolePolygon, overlap1CenterHolePolygon,
+	} {
+		numLoops := p.NumLoops()
+		c := p.Complement()
+		if p.NumLoops() != numLoops {
+			t.Errorf("%v.Complement() modified the polygon", p)
+		}
+		if err := c.Validate(); err != nil {
+			t.Errorf("%v.Complement() = %v is not valid: %v", p, c, err)
+		}
+		if c.NumLoops() != numLoops {
+			t.Errorf("%v.Complement() has %d loops, want %d", p, c.NumLoops(), numLoops)
+		}
+		for i := 0; i < c.NumLoops(); i++ {
+			if parent, ok := c.Parent(i); ok && c.Loop(parent).depth != c.Loop(i).depth-1 {
+				t.Errorf("%v.Complement() loop %d has depth %d, want %d", p, i, c.Loop(i).depth, c.Loop(parent).depth+1)
+			}
+		}
+		for i := 0; i < p.NumEdges(); i++ {
+			e := p.Edge(i)
+			mid := Point{e.V0.Add(e.V1.Vector).Normalize()}
+			offset := e.V0.PointCross(e.V1).Normalize().Mul(1e-9)
+			for _, q := range []Point{{mid.Add(offset).Normalize()}, {mid.Sub(offset).Normalize()}} {
+				if c.ContainsPoint(q) == p.ContainsPoint(q) {
+					t.Errorf("%v.Complement().ContainsPoint(%v) = %v, want %v", p, q, c.ContainsPoint(q), !p.ContainsPoint(q))
+				}
+			}
+		}
+		checkPolygonsEqual(t, "p.Complement().Complement()", c.Complement(), p)
+	}
+
+	if got := emptyPolygon.Complement(); !got.IsFull() {
+		t.Errorf("emptyPolygon.Complement() = %v, want full", got)
+	}
+	if got := fullPolygon.Complement(); !got.IsEmpty() {
+		t.Errorf("fullPolygon.Complement() = %v, want empty", got)
+	}
+	if !fullPolygon.IsFull() {
+		t.Errorf("fullPolygon.Complement() modified the full polygon")
+	}
+}
+
 // TODO(roberts): Remaining Tests
 // TestInit
 // TestMultipleInit
@@ -1205,3 +1612,331 @@ func TestPolygonInvert(t *testing.T) {
 //   TestNarrowGapRemoved
 //   TestCloselySpacedEdgeVerticesKept
 //   TestPolylineAssemblyBug
+
+func TestPolygonFromLoopsOrError(t *testing.T) {
+	if _, err := PolygonFromLoopsOrError([]*Loop{makeLoop("0:0, 0:10, 10:10, 10:0"), makeLoop("2:2, 2:8, 8:8, 8:2")}); err != nil {
+		t.Errorf("PolygonFromLoopsOrError(nested loops) returned error: %v", err)
+	}
+	if p, err := PolygonFromLoopsOrError([]*Loop{makeLoop("0:0, 0:10, 10:10, 10:0"), makeLoop("0:0, 0:1, 1:0")}); err == nil {
+		t.Errorf("PolygonFromLoopsOrError(loops sharing an edge) = %v, want error", p)
+	}
+}
+
+func TestPolygonCheckBoundsConsistency(t *testing.T) {
+	for _, p := range []*Polygon{
+		emptyPolygon, fullPolygon, near0231Polygon, near023H1Polygon, far2H013Polygon,
+		south20bH0acPolygon, nf2N2F210S210abPolygon, cross2CenterHolePolygon,
+	} {
+		if err := p.CheckBoundsConsistency(); err != nil {
+			t.Errorf("%v.CheckBoundsConsistency() = %v, want nil", p, err)
+		}
+		if !p.SubregionBound().Contains(p.RectBound()) {
+			t.Errorf("%v.SubregionBound() = %v, want to contain %v", p, p.SubregionBound(), p.RectBound())
+		}
+	}
+
+	shrunk := makePolygon("0:0, 0:10, 10:10, 10:0", true)
+	shrunk.bound = rectFromDegrees(1, 1, 9, 9)
+	badHole := makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 2:8, 8:8, 8:2", true)
+	badHole.loops[0].subregionBound = rectFromDegrees(3, 3, 7, 7)
+	for _, p := range []*Polygon{shrunk, badHole} {
+		if err := p.CheckBoundsConsistency(); err == nil {
+			t.Errorf("%v.CheckBoundsConsistency() = nil, want error", p)
+		}
+	}
+}
+
+func TestPolygonBoundaryPolylines(t *testing.T) {
+	for _, p := range []*Polygon{
+		near0231Polygon, near023H1Polygon, far2H013Polygon,
+		south20bH0acPolygon, nf2N2F210S210abPolygon, cross2CenterHolePolygon,
+	} {
+		lines := p.BoundaryPolylines()
+		if len(lines) != p.NumLoops() {
+			t.Errorf("%v.BoundaryPolylines() returned %d polylines, want %d", p, len(lines), p.NumLoops())
+			continue
+		}
+		for i, line := range lines {
+			if n := len(*line); (*line)[0] != (*line)[n-1] || n != p.Loop(i).NumVertices()+1 {
+				t.Errorf("%v.BoundaryPolylines()[%d] = %v, want a closed polyline", p, i, line)
+			}
+		}
+		got, err := PolygonFromClosedPolylines(lines)
+		if err != nil {
+			t.Errorf("PolygonFromClosedPolylines(%v.BoundaryPolylines()) returned error: %v", p, err)
+			continue
+		}
+		if got.NumLoops() != p.NumLoops() || !got.Contains(p) || !p.Contains(got) {
+			t.Errorf("PolygonFromClosedPolylines(%v.BoundaryPolylines()) = %v, want the same polygon", p, got)
+		}
+	}
+
+	for _, p := range []*Polygon{emptyPolygon, fullPolygon} {
+		if lines := p.BoundaryPolylines(); len(lines) != 0 {
+			t.Errorf("%v.BoundaryPolylines() = %v, want none", p, lines)
+		}
+	}
+	if got, err := PolygonFromClosedPolylines(nil); err != nil || !got.IsEmpty() {
+		t.Errorf("PolygonFromClosedPolylines(nil) = %v, %v, want empty polygon", got, err)
+	}
+}
+
+func TestPolygonFromClosedPolylinesErrors(t *testing.T) {
+	tests := []struct {
+		desc  string
+		lines []string
+	}{
+		{"open polyline", []string{"0:0, 0:1, 1:1, 1:0"}},
+		{"too few vertices", []string{"0:0, 0:1, 0:0"}},
+		{"duplicate vertices", []string{"0:0, 0:1, 0:1, 1:1, 0:0"}},
+		{"crossing loops", []string{"0:0, 0:2, 2:2, 2:0, 0:0", "1:1, 1:3, 3:3, 3:1, 1:1"}},
+	}
+	for _, test := range tests {
+		var lines []*Polyline
+		for _, s := range test.lines {
+			lines = append(lines, makePolyline(s))
+		}
+		if p, err := PolygonFromClosedPolylines(lines); err == nil {
+			t.Errorf("%s: PolygonFromClosedPolylines(%v) = %v, want error", test.desc, test.lines, p)
+		}
+	}
+}
+
+func TestPolygonFromRect(t *testing.T) {
+	if got := PolygonFromRect(EmptyRect(), 4); !got.IsEmpty() {
+		t.Errorf("PolygonFromRect(EmptyRect()) = %v, want empty", got)
+	}
+	if got := PolygonFromRect(FullRect(), 4); !got.IsFull() {
+		t.Errorf("PolygonFromRect(FullRect()) = %v, want full", got)
+	}
+	if got := PolygonFromRect(rectFromDegrees(10, 20, 10, 30), 4); !got.IsEmpty() {
+		t.Errorf("PolygonFromRect of a zero area rect = %v, want empty", got)
+	}
+
+	tests := []struct {
+		rect        Rect
+		maxVertices int
+	}{
+		{rectFromDegrees(10, 20, 30, 50), 1},
+		{rectFromDegrees(10, 20, 30, 50), 8},
+		{rectFromDegrees(-40, 20, 60, 50), 4},
+		// Crossing the 180 degree meridian.
+		{rectFromDegrees(-10, 170, 5, -160), 4},
+		// More than 180 degrees of longitude.
+		{rectFromDegrees(-20, -100, 20, 120), 1},
+		// On the equator.
+		{rectFromDegrees(0, 0, 45, 10), 2},
+		// Touching one or both poles.
+		{rectFromDegrees(60, 0, 90, 90), 4},
+		{rectFromDegrees(-90, -30, -70, 30), 4},
+		{rectFromDegrees(-90, 10, 90, 20), 4},
+		// Full longitude range.
+		{rectFromDegrees(-30, -180, 30, 180), 8},
+		{rectFromDegrees(70, -180, 90, 180), 8},
+		{rectFromDegrees(-90, -180, -10, 180), 2},
+	}
+	for _, test := range tests {
+		p := PolygonFromRect(test.rect, test.maxVertices)
+		if err := p.Validate(); err != nil {
+			t.Errorf("PolygonFromRect(%v, %d) is invalid: %v", test.rect, test.maxVertices, err)
+			continue
+		}
+
+		// Use the documented error bound for the sides along parallels.
+		var maxErr float64
+		for _, lat := range []float64{test.rect.Lat.Lo, test.rect.Lat.Hi} {
+			if lat == 0 || math.Abs(lat) == math.Pi/2 {
+				continue
+			}
+			n := maxInt(test.maxVertices, int(math.Ceil(test.rect.Lng.Length()/(math.Pi/2))))
+			d := test.rect.Lng.Length() / float64(n)
+			maxErr = math.Max(maxErr, math.Atan(math.Tan(math.Abs(lat))/math.Cos(d/2))-math.Abs(lat))
+		}
+		maxErr += 1e-13
+
+		inner := r1.Interval{Lo: test.rect.Lat.Lo + maxErr, Hi: test.rect.Lat.Hi - maxErr}
+		innerLng := test.rect.Lng.Expanded(-1e-13)
+		for i := 0; i < 1000; i++ {
+			pt := randomPoint()
+			ll := LatLngFromPoint(pt)
+			if inner.Contains(ll.Lat.Radians()) && innerLng.Contains(ll.Lng.Radians()) && !p.ContainsPoint(pt) {
+				t.Errorf("PolygonFromRect(%v, %d) does not contain %v", test.rect, test.maxVertices, ll)
+			}
+			if test.rect.DistanceToLatLng(ll).Radians() > maxErr && p.ContainsPoint(pt) {
+				t.Errorf("PolygonFromRect(%v, %d) contains %v", test.rect, test.maxVertices, ll)
+			}
+		}
+	}
+}
+
+func TestPolygonFromCap(t *testing.T) {
+	if got := PolygonFromCap(EmptyCap(), 10); !got.IsEmpty() {
+		t.Errorf("PolygonFromCap(EmptyCap()) = %v, want empty", got)
+	}
+	if got := PolygonFromCap(FullCap(), 10); !got.IsFull() {
+		t.Errorf("PolygonFromCap(FullCap()) = %v, want full", got)
+	}
+	if got := PolygonFromCap(CapFromPoint(PointFromCoords(1, 0, 0)), 10); !got.IsEmpty() {
+		t.Errorf("PolygonFromCap of a point cap = %v, want empty", got)
+	}
+
+	tests := []struct {
+		radius      s1.Angle
+		numVertices int
+	}{
+		{s1.Degree, 3},
+		{10 * s1.Degree, 2},
+		{10 * s1.Degree, 16},
+		{80 * s1.Degree, 32},
+		{120 * s1.Degree, 8},
+	}
+	for _, test := range tests {
+		c := CapFromCenterAngle(randomPoint(), test.radius)
+		p := PolygonFromCap(c, test.numVertices)
+		if err := p.Validate(); err != nil {
+			t.Errorf("PolygonFromCap(%v, %d) is invalid: %v", c, test.numVertices, err)
+			continue
+		}
+		if got, want := p.NumEdges(), maxInt(test.numVertices, 3); got != want {
+			t.Errorf("PolygonFromCap(%v, %d).NumEdges() = %d, want %d", c, test.numVertices, got, want)
+		}
+		if !p.ContainsPoint(c.Center()) {
+			t.Errorf("PolygonFromCap(%v, %d) does not contain the cap center", c, test.numVertices)
+		}
+
+		// The edge midpoints are at the documented distance from the center.
+		n := float64(maxInt(test.numVertices, 3))
+		r := test.radius.Radians()
+		mid := math.Atan(math.Tan(r) * math.Cos(math.Pi/n))
+		if mid < 0 {
+			mid += math.Pi
+		}
+		inner, outer := math.Min(r, mid)-1e-13, math.Max(r, mid)+1e-13
+		for i := 0; i < 1000; i++ {
+			pt := randomPoint()
+			d := c.Center().Distance(pt).Radians()
+			if d < inner && !p.ContainsPoint(pt) {
+				t.Errorf("PolygonFromCap(%v, %d) does not contain a point %v from the center", c, test.numVertices, d)
+			}
+			if d > outer && p.ContainsPoint(pt) {
+				t.Errorf("PolygonFromCap(%v, %d) contains a point %v from the center", c, test.numVertices, d)
+			}
+		}
+	}
+}
+
+// benchmarkPolygonOperation benchmarks the given operation on pairs of regular
+// polygons with 2^n vertices each. If contained is true, the second polygon
+// is the first one scaled by half around its center, otherwise it is the
+// first one shifted by half its radius, so that their boundaries cross.
+func benchmarkPolygonOperation(b *testing.B, contained bool, op func(a, b *Polygon)) {
+	const numPairs = 4
+	rng := rand.New(rand.NewSource(1))
+	radius := kmToAngle(10)
+
+	for n := 2; n <= 10; n += 2 {
+		numVertices := 1 << n
+		b.Run(fmt.Sprintf("%d-vertices", numVertices), func(b *testing.B) {
+			b.StopTimer()
+			var pairs [numPairs][2]*Polygon
+			for i := range pairs {
+				center := randomPointFromRand(rng)
+				pairs[i][0] = PolygonFromLoops([]*Loop{RegularLoop(center, radius, numVertices)})
+				if contained {
+					pairs[i][1] = PolygonFromLoops([]*Loop{RegularLoop(center, radius/2, numVertices)})
+				} else {
+					shifted := InterpolateAtDistance(radius/2, center, randomPointFromRand(rng))
+					pairs[i][1] = PolygonFromLoops([]*Loop{RegularLoop(shifted, radius, numVertices)})
+				}
+			}
+			b.StartTimer()
+			for i := 0; i < b.N; i++ {
+				pair := pairs[i%numPairs]
+				op(pair[0], pair[1])
+			}
+		})
+	}
+}
+
+func BenchmarkPolygonIntersection(b *testing.B) {
+	benchmarkPolygonOperation(b, false, func(x, y *Polygon) { x.Intersection(y) })
+}
+
+func BenchmarkPolygonUnion(b *testing.B) {
+	benchmarkPolygonOperation(b, false, func(x, y *Polygon) { x.Union(y) })
+}
+
+func BenchmarkPolygonIntersects(b *testing.B) {
+	benchmarkPolygonOperation(b, false, func(x, y *Polygon) { x.Intersects(y) })
+}
+
+func BenchmarkPolygonContains(b *testing.B) {
+	benchmarkPolygonOperation(b, true, func(x, y *Polygon) { x.Contains(y) })
+}
+
+// polygonNearPoint reports whether p contains x or x is within a small
+// distance of the boundary of p.
+func polygonNearPoint(p *Polygon, x Point) bool {
+	if p.ContainsPoint(x) {
+		return true
+	}
+	for _, l := range p.loops {
+		for i := 0; i < l.NumEdges(); i++ {
+			if DistanceFromSegment(x, l.Vertex(i), l.Vertex(i+1)) < 1e-14 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestPolygonIntersectionPoint(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"both empty", "empty", "empty", false},
+		{"empty and full", "empty", "full", false},
+		{"both full", "full", "full", true},
+		{"full", "full", "0:0, 0:1, 1:1, 1:0", true},
+		{"disjoint", "0:0, 0:1, 1:1, 1:0", "5:5, 5:6, 6:6, 6:5", false},
+		{"overlapping", "0:0, 0:2, 2:2, 2:0", "1:1, 1:3, 3:3, 3:1", true},
+		{"nested", "0:0, 0:10, 10:10, 10:0", "4:4, 4:5, 5:5, 5:4", true},
+		{"crossing without contained vertices", "0:-5, 0:5, 1:5, 1:-5", "-5:0, -5:1, 5:1, 5:0", true},
+		{"in hole", "0:0, 0:10, 10:10, 10:0; 2:2, 2:8, 8:8, 8:2", "4:4, 4:5, 5:5, 5:4", false},
+		{"overlapping hole", "0:0, 0:10, 10:10, 10:0; 2:2, 2:8, 8:8, 8:2", "1:4, 1:5, 5:5, 5:4", true},
+		{"holes", "0:0, 0:10, 10:10, 10:0; 2:2, 2:8, 8:8, 8:2",
+			"-1:-1, -1:11, 11:11, 11:-1; 1:1, 1:9, 9:9, 9:1", true},
+	}
+	for _, test := range tests {
+		a := makePolygon(test.a, true)
+		b := makePolygon(test.b, true)
+		for _, pair := range [][2]*Polygon{{a, b}, {b, a}} {
+			x, ok := pair[0].IntersectionPoint(pair[1])
+			if ok != test.want {
+				t.Errorf("%s: IntersectionPoint = _, %v, want %v", test.name, ok, test.want)
+				continue
+			}
+			if ok && (!polygonNearPoint(pair[0], x) || !polygonNearPoint(pair[1], x)) {
+				t.Errorf("%s: IntersectionPoint = %v, not in both polygons", test.name, LatLngFromPoint(x))
+			}
+		}
+	}
+}
+
+func TestPolygonIntersectionPointRandom(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		a := concentricLoopsPolygon(randomPoint(), 1+randomUniformInt(3), 3+randomUniformInt(10))
+		b := concentricLoopsPolygon(samplePointFromCap(CapFromCenterAngle(a.Loop(0).Vertex(0), 0.01)),
+			1+randomUniformInt(3), 3+randomUniformInt(10))
+		x, ok := a.IntersectionPoint(b)
+		if ok != a.Intersects(b) {
+			t.Errorf("%d: IntersectionPoint = _, %v, want %v", i, ok, !ok)
+			continue
+		}
+		if ok && (!polygonNearPoint(a, x) || !polygonNearPoint(b, x)) {
+			t.Errorf("%d: IntersectionPoint = %v, not in both polygons", i, LatLngFromPoint(x))
+		}
+	}
+}
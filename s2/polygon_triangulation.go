@@ -0,0 +1,235 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Triangulate returns a triangulation of the polygon interior as a
+// TriangleMesh. Holes are handled by joining each hole to its shell with a
+// pair of coincident bridge edges before triangulating, and vertices are
+// shared between adjacent triangles. The triangles have disjoint interiors
+// and their union is exactly the polygon.
+//
+// The triangulation uses ear clipping, whose running time is quadratic in
+// the number of vertices of each shell (including its holes). Every shell
+// together with its holes must be contained within a hemisphere. An error
+// is returned for polygons that cannot be triangulated, such as the full
+// polygon.
+func (p *Polygon) Triangulate() (*TriangleMesh, error) {
+	rings, err := polygonRings(p)
+	if err != nil {
+		return nil, err
+	}
+
+	mesh := &TriangleMesh{}
+	vertexIDs := make(map[Point]int)
+	for _, ring := range rings {
+		triangles, err := ring.triangulate()
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int, len(ring))
+		for i, v := range ring {
+			id, ok := vertexIDs[v]
+			if !ok {
+				id = len(mesh.Vertices)
+				vertexIDs[v] = id
+				mesh.Vertices = append(mesh.Vertices, v)
+			}
+			ids[i] = id
+		}
+		for _, t := range triangles {
+			mesh.Triangles = append(mesh.Triangles, [3]int{ids[t[0]], ids[t[1]], ids[t[2]]})
+		}
+	}
+	return mesh, nil
+}
+
+// polygonRing is a single weakly simple ring of vertices formed from a shell
+// of a polygon and all of its holes. The holes are spliced into the shell
+// using pairs of coincident "bridge" edges, so that the interior of the ring
+// is exactly the interior of the shell minus its holes. The ring is oriented
+// so that the interior is on the left.
+type polygonRing []Point
+
+// polygonRings returns one polygonRing for each shell of the polygon.
+func polygonRings(p *Polygon) ([]polygonRing, error) {
+	if p.IsFull() {
+		return nil, fmt.Errorf("the full polygon cannot be triangulated")
+	}
+
+	var rings []polygonRing
+	for k, shell := range p.loops {
+		if shell.IsHole() {
+			continue
+		}
+		ring := polygonRing(append([]Point(nil), shell.vertices...))
+
+		// Collect the holes that are immediate children of this shell.
+		var holes []polygonRing
+		for j := k + 1; j <= p.LastDescendant(k); j++ {
+			if hole := p.loops[j]; hole.depth == shell.depth+1 {
+				h := make(polygonRing, len(hole.vertices))
+				for i := range h {
+					h[i] = hole.OrientedVertex(i)
+				}
+				holes = append(holes, h)
+			}
+		}
+
+		for len(holes) > 0 {
+			var err error
+			if ring, err = ring.bridgeHole(holes[0], holes[1:]); err != nil {
+				return nil, fmt.Errorf("loop %d: %v", k, err)
+			}
+			holes = holes[1:]
+		}
+		rings = append(rings, ring)
+	}
+	return rings, nil
+}
+
+// bridgeHole returns a new ring in which the given hole has been joined to
+// this ring using a pair of bridge edges between mutually visible vertices.
+// The remaining holes that have not been bridged yet must be provided so
+// that the bridge does not cross them.
+func (r polygonRing) bridgeHole(hole polygonRing, others []polygonRing) (polygonRing, error) {
+	for hi, h := range hole {
+		// Try the vertices of the ring in order of increasing distance from h;
+		// the closest vertices are the most likely to be visible.
+		candidates := make([]int, len(r))
+		for i := range candidates {
+			candidates[i] = i
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return ChordAngleBetweenPoints(h, r[candidates[i]]) < ChordAngleBetweenPoints(h, r[candidates[j]])
+		})
+
+		for _, ri := range candidates {
+			o := r[ri]
+			if o == h || !r.wedgeContains(ri, h) || !hole.wedgeContains(hi, o) {
+				continue
+			}
+			if r.crossedBy(o, h) || hole.crossedBy(o, h) {
+				continue
+			}
+			crossed := false
+			for _, other := range others {
+				if other.crossedBy(o, h) {
+					crossed = true
+					break
+				}
+			}
+			if crossed {
+				continue
+			}
+
+			result := make(polygonRing, 0, len(r)+len(hole)+2)
+			result = append(result, r[:ri+1]...)
+			result = append(result, hole[hi:]...)
+			result = append(result, hole[:hi+1]...)
+			result = append(result, r[ri:]...)
+			return result, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find a bridge to a hole")
+}
+
+// wedgeContains reports whether the direction from vertex i towards the
+// point x lies within the interior angle of the ring at that vertex.
+func (r polygonRing) wedgeContains(i int, x Point) bool {
+	n := len(r)
+	prev, next := r[(i+n-1)%n], r[(i+1)%n]
+	return OrderedCCW(next, x, prev, r[i])
+}
+
+// crossedBy reports whether the edge AB crosses any edge of the ring at a
+// point other than a shared vertex.
+func (r polygonRing) crossedBy(a, b Point) bool {
+	crosser := NewChainEdgeCrosser(a, b, r[len(r)-1])
+	for _, v := range r {
+		if crosser.ChainCrossingSign(v) == Cross {
+			return true
+		}
+	}
+	return false
+}
+
+// triangulate triangulates the ring using ear clipping, and returns the
+// triangles as triples of indices into the ring. Every triangle is oriented
+// counterclockwise.
+//
+// The ear clipping algorithm requires that the interior of the ring is
+// contained within a hemisphere. An error is returned if the ring could not
+// be triangulated.
+func (r polygonRing) triangulate() ([][3]int, error) {
+	n := len(r)
+	if n < 3 {
+		return nil, fmt.Errorf("ring has %d vertices, at least 3 are required", n)
+	}
+
+	prev := make([]int, n)
+	next := make([]int, n)
+	for i := range r {
+		prev[i] = (i + n - 1) % n
+		next[i] = (i + 1) % n
+	}
+
+	triangles := make([][3]int, 0, n-2)
+	i := 0
+	for remaining, sinceLastEar := n, 0; remaining > 3; {
+		a, c := prev[i], next[i]
+		if r.isEar(a, i, c, next) {
+			triangles = append(triangles, [3]int{a, i, c})
+			next[a], prev[c] = c, a
+			remaining--
+			sinceLastEar = 0
+			// Step back so that the vertex before the ear is retested.
+			i = a
+			continue
+		}
+		i = c
+		if sinceLastEar++; sinceLastEar > remaining {
+			return nil, fmt.Errorf("could not find an ear among %d remaining vertices", remaining)
+		}
+	}
+	triangles = append(triangles, [3]int{prev[i], i, next[i]})
+	return triangles, nil
+}
+
+// isEar reports whether the vertex b is convex and the triangle ABC formed
+// with its neighbors contains no other remaining vertices of the ring.
+func (r polygonRing) isEar(a, b, c int, next []int) bool {
+	pa, pb, pc := r[a], r[b], r[c]
+	if RobustSign(pa, pb, pc) != CounterClockwise {
+		return false
+	}
+	for j := next[c]; j != a; j = next[j] {
+		x := r[j]
+		if x == pa || x == pb || x == pc {
+			continue
+		}
+		// Vertices on the boundary of the triangle are resolved consistently
+		// using symbolic perturbations.
+		if RobustSign(pa, pb, x) == CounterClockwise && RobustSign(pb, pc, x) == CounterClockwise &&
+			RobustSign(pc, pa, x) == CounterClockwise {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestPolygonTriangulate(t *testing.T) {
+	tests := []struct {
+		have          string
+		wantTriangles int
+	}{
+		{"0:0, 0:10, 10:10, 10:0", 2},
+		{"0:0, 0:10, 5:10, 5:5, 10:5, 10:0", 4},
+		// A square with a square hole: 8 vertices plus 2 bridge vertices.
+		{"0:0, 0:10, 10:10, 10:0; 2:2, 2:4, 4:4, 4:2", 8},
+		// Two disjoint shells.
+		{"0:0, 0:1, 1:1; 5:5, 5:6, 6:6, 6:5", 3},
+	}
+
+	for _, test := range tests {
+		p := makePolygon(test.have, true)
+		mesh, err := p.Triangulate()
+		if err != nil {
+			t.Fatalf("%s: Triangulate() failed: %v", test.have, err)
+		}
+		if got := mesh.NumTriangles(); got != test.wantTriangles {
+			t.Errorf("%s: NumTriangles() = %d, want %d", test.have, got, test.wantTriangles)
+		}
+		if got, want := mesh.Area(), p.Area(); !float64Near(got, want, 1e-14) {
+			t.Errorf("%s: Area() = %v, want %v", test.have, got, want)
+		}
+		if got, want := mesh.Centroid(), p.Centroid(); !pointsApproxEqual(got, want, 1e-14) {
+			t.Errorf("%s: Centroid() = %v, want %v", test.have, got, want)
+		}
+		for i := 0; i < mesh.NumTriangles(); i++ {
+			tri := mesh.Triangle(i)
+			if RobustSign(tri[0], tri[1], tri[2]) != CounterClockwise {
+				t.Errorf("%s: triangle %d (%v) is not counterclockwise", test.have, i, tri)
+			}
+		}
+	}
+}
+
+func TestPolygonTriangulateSharesVertices(t *testing.T) {
+	p := makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 2:4, 4:4, 4:2", true)
+	mesh, err := p.Triangulate()
+	if err != nil {
+		t.Fatalf("Triangulate() failed: %v", err)
+	}
+	// The bridge vertices are shared, so only the original vertices remain.
+	if got, want := len(mesh.Vertices), 8; got != want {
+		t.Errorf("len(mesh.Vertices) = %d, want %d", got, want)
+	}
+}
+
+func TestPolygonTriangulateRegularLoops(t *testing.T) {
+	center := PointFromLatLng(LatLngFromDegrees(-20, 100))
+	p := PolygonFromLoops([]*Loop{
+		RegularLoop(center, 20*s1.Degree, 100),
+		RegularLoop(center, 1*s1.Degree, 10),
+	})
+	mesh, err := p.Triangulate()
+	if err != nil {
+		t.Fatalf("Triangulate() failed: %v", err)
+	}
+	if got, want := mesh.NumTriangles(), 110; got != want {
+		t.Errorf("NumTriangles() = %d, want %d", got, want)
+	}
+	if got, want := mesh.Area(), p.Area(); !float64Near(got, want, 1e-13) {
+		t.Errorf("Area() = %v, want %v", got, want)
+	}
+}
+
+func TestPolygonTriangulateErrors(t *testing.T) {
+	mesh, err := (&Polygon{}).Triangulate()
+	if err != nil || !mesh.IsEmpty() {
+		t.Errorf("empty polygon Triangulate() = %v, %v, want an empty mesh", mesh, err)
+	}
+	if _, err := FullPolygon().Triangulate(); err == nil {
+		t.Errorf("FullPolygon().Triangulate() should have failed")
+	}
+
+	// A shell that is larger than a hemisphere has no ears.
+	p := makePolygon("0:0, 0:10, 10:10, 10:0", true)
+	p.Invert()
+	if _, err := p.Triangulate(); err == nil {
+		t.Errorf("%v.Triangulate() should have failed", p)
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"sort"
+
+	"github.com/golang/geo/s1"
+)
+
+// Polygonize returns the polygons formed by the faces of the arrangement
+// of the given polylines, in the manner of the JTS Polygonizer.
+//
+// The polylines are first noded with NodePolylines using the given snap
+// radius, so they do not need to be split at their intersections
+// beforehand. Dangling edges (those with an endpoint not shared by any other
+// edge) and cut edges (those whose removal disconnects the arrangement) are
+// discarded, since they do not bound any face.
+//
+// Each connected component of the remaining edges divides the sphere into
+// faces. The face with the largest area in each component is considered to be
+// the exterior of that component and is not returned; this assumes that every
+// component of the linework spans less than a hemisphere. A component that
+// lies inside a face of another component becomes a hole of the smallest
+// such face. The returned polygons have disjoint interiors.
+func Polygonize(lines []*Polyline, snapRadius s1.Angle) []*Polygon {
+	g := newPolygonizeGraph(NodePolylines(lines, snapRadius))
+	g.removeDangles()
+	for g.removeCutEdges() {
+		g.removeDangles()
+	}
+
+	// Classify the rings of every component as faces or as the exterior.
+	type face struct {
+		loops   [][]Point
+		polygon *Polygon
+		area    float64
+		comp    int
+	}
+	var faces []*face
+	exterior := make(map[int]*face)
+	for _, ring := range g.rings() {
+		f := &face{loops: splitRing(ring), comp: g.comp[ring[0]]}
+		f.polygon = polygonFromCycles(f.loops)
+		f.area = f.polygon.Area()
+		if e, ok := exterior[f.comp]; !ok || f.area > e.area {
+			if ok {
+				faces = append(faces, e)
+			}
+			exterior[f.comp] = f
+		} else {
+			faces = append(faces, f)
+		}
+	}
+
+	// Add the boundary of each component as a hole of the smallest face of
+	// another component that contains it. Components are disjoint after
+	// noding, so testing a single vertex is sufficient.
+	for comp := 0; comp < g.numComps; comp++ {
+		e, ok := exterior[comp]
+		if !ok {
+			continue
+		}
+		var parent *face
+		for _, f := range faces {
+			if f.comp != comp && (parent == nil || f.area < parent.area) &&
+				f.polygon.ContainsPoint(e.loops[0][0]) {
+				parent = f
+			}
+		}
+		if parent != nil {
+			parent.loops = append(parent.loops, e.loops...)
+		}
+	}
+
+	polygons := make([]*Polygon, 0, len(faces))
+	for _, f := range faces {
+		polygons = append(polygons, polygonFromCycles(f.loops))
+	}
+	return polygons
+}
+
+// polygonizeGraph is an undirected planar graph used by Polygonize. The
+// neighbors of every vertex are kept in counterclockwise order.
+type polygonizeGraph struct {
+	vertices []Point
+	adj      map[Point][]Point
+	comp     map[Point]int
+	numComps int
+}
+
+func newPolygonizeGraph(segments []Edge) *polygonizeGraph {
+	g := &polygonizeGraph{adj: make(map[Point][]Point)}
+	for _, s := range segments {
+		if s.V0 != s.V1 {
+			g.addEdge(s.V0, s.V1)
+			g.addEdge(s.V1, s.V0)
+		}
+	}
+	for _, v := range g.vertices {
+		sortCCW(v, g.adj[v])
+	}
+	return g
+}
+
+// addEdge adds the directed edge (a, b) unless it is already present.
+func (g *polygonizeGraph) addEdge(a, b Point) {
+	nbrs, ok := g.adj[a]
+	if !ok {
+		g.vertices = append(g.vertices, a)
+	}
+	for _, n := range nbrs {
+		if n == b {
+			return
+		}
+	}
+	g.adj[a] = append(nbrs, b)
+}
+
+// removeEdge removes the undirected edge between a and b.
+func (g *polygonizeGraph) removeEdge(a, b Point) {
+	remove := func(a, b Point) {
+		nbrs := g.adj[a]
+		for i, n := range nbrs {
+			if n == b {
+				g.adj[a] = append(nbrs[:i:i], nbrs[i+1:]...)
+				return
+			}
+		}
+	}
+	remove(a, b)
+	remove(b, a)
+}
+
+// removeDangles repeatedly removes edges incident to vertices of degree one.
+func (g *polygonizeGraph) removeDangles() {
+	var stack []Point
+	for _, v := range g.vertices {
+		if len(g.adj[v]) == 1 {
+			stack = append(stack, v)
+		}
+	}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if len(g.adj[v]) != 1 {
+			continue
+		}
+		n := g.adj[v][0]
+		g.removeEdge(v, n)
+		if len(g.adj[n]) == 1 {
+			stack = append(stack, n)
+		}
+	}
+}
+
+// next returns the vertex that follows the directed edge (a, b) on the
+// boundary of the face to its left, i.e. the neighbor of b that comes
+// immediately before a in counterclockwise order.
+func (g *polygonizeGraph) next(a, b Point) Point {
+	nbrs := g.adj[b]
+	for i, n := range nbrs {
+		if n == a {
+			return nbrs[(i+len(nbrs)-1)%len(nbrs)]
+		}
+	}
+	panic("s2: polygonize graph is missing a reverse edge")
+}
+
+// rings returns the boundary of every face of the graph as a sequence of
+// vertices, with the face on the left. It also labels the connected
+// components of the graph.
+func (g *polygonizeGraph) rings() [][]Point {
+	g.labelComponents()
+	visited := make(map[Edge]bool)
+	var rings [][]Point
+	for _, v := range g.vertices {
+		for _, n := range g.adj[v] {
+			if visited[Edge{v, n}] {
+				continue
+			}
+			var ring []Point
+			for a, b := v, n; !visited[Edge{a, b}]; a, b = b, g.next(a, b) {
+				visited[Edge{a, b}] = true
+				ring = append(ring, a)
+			}
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+// removeCutEdges removes every edge that has the same face on both sides,
+// and reports whether any edges were removed.
+func (g *polygonizeGraph) removeCutEdges() bool {
+	var cuts []Edge
+	for _, ring := range g.rings() {
+		seen := make(map[Edge]bool)
+		for i, a := range ring {
+			b := ring[(i+1)%len(ring)]
+			if seen[Edge{b, a}] {
+				cuts = append(cuts, Edge{a, b})
+			}
+			seen[Edge{a, b}] = true
+		}
+	}
+	for _, e := range cuts {
+		g.removeEdge(e.V0, e.V1)
+	}
+	return len(cuts) > 0
+}
+
+// labelComponents assigns a component number to every vertex that has at
+// least one incident edge.
+func (g *polygonizeGraph) labelComponents() {
+	g.comp = make(map[Point]int)
+	g.numComps = 0
+	for _, v := range g.vertices {
+		if _, ok := g.comp[v]; ok || len(g.adj[v]) == 0 {
+			continue
+		}
+		stack := []Point{v}
+		g.comp[v] = g.numComps
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, n := range g.adj[u] {
+				if _, ok := g.comp[n]; !ok {
+					g.comp[n] = g.numComps
+					stack = append(stack, n)
+				}
+			}
+		}
+		g.numComps++
+	}
+}
+
+// sortCCW sorts the given neighbors of v in counterclockwise order around v,
+// starting from the first one. The order is computed with exact predicates.
+func sortCCW(v Point, nbrs []Point) {
+	if len(nbrs) == 0 {
+		return
+	}
+	first := nbrs[0]
+	sort.Slice(nbrs, func(i, j int) bool {
+		return nbrs[i] != nbrs[j] && OrderedCCW(first, nbrs[i], nbrs[j], v)
+	})
+}
+
+// splitRing splits a ring that visits some vertices more than once into
+// simple cycles.
+func splitRing(ring []Point) [][]Point {
+	var cycles [][]Point
+	var stack []Point
+	pos := make(map[Point]int)
+	for _, v := range ring {
+		if i, ok := pos[v]; ok {
+			cycles = append(cycles, append([]Point(nil), stack[i:]...))
+			for _, u := range stack[i+1:] {
+				delete(pos, u)
+			}
+			stack = stack[:i+1]
+			continue
+		}
+		pos[v] = len(stack)
+		stack = append(stack, v)
+	}
+	return append(cycles, stack)
+}
+
+// polygonFromCycles returns the polygon whose interior is on the left of all
+// of the given cycles. The cycles are copied, since the loops may be inverted
+// in place.
+func polygonFromCycles(cycles [][]Point) *Polygon {
+	loops := make([]*Loop, 0, len(cycles))
+	for _, c := range cycles {
+		loops = append(loops, LoopFromPoints(append([]Point(nil), c...)))
+	}
+	return PolygonFromOrientedLoops(loops)
+}
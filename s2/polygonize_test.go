@@ -0,0 +1,122 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestPolygonize(t *testing.T) {
+	const snapRadius = s1.Degree / 1000
+	tests := []struct {
+		name  string
+		lines []string
+		// want is the number of loops of each returned polygon.
+		want []int
+		// area is the total area of the returned polygons, as the area of
+		// the given polygon.
+		area string
+	}{
+		{
+			name:  "empty",
+			lines: nil,
+		},
+		{
+			name:  "open polyline",
+			lines: []string{"0:0, 0:2, 2:2, 2:0"},
+		},
+		{
+			name:  "square",
+			lines: []string{"0:0, 0:2, 2:2, 2:0, 0:0"},
+			want:  []int{1},
+			area:  "0:0, 0:2, 2:2, 2:0",
+		},
+		{
+			name:  "square from separate edges",
+			lines: []string{"0:0, 0:2", "2:2, 0:2", "2:2, 2:0", "0:0, 2:0"},
+			want:  []int{1},
+			area:  "0:0, 0:2, 2:2, 2:0",
+		},
+		{
+			name:  "square with diagonal",
+			lines: []string{"0:0, 0:2, 2:2, 2:0, 0:0", "0:0, 2:2"},
+			want:  []int{1, 1},
+			area:  "0:0, 0:2, 2:2, 2:0",
+		},
+		{
+			name:  "square with dangles",
+			lines: []string{"0:0, 0:2, 2:2, 2:0, 0:0", "1:1, 1:2, 1:3", "5:5, 6:6"},
+			want:  []int{1},
+			area:  "0:0, 0:2, 2:2, 2:0",
+		},
+		{
+			name:  "squares joined by a cut edge",
+			lines: []string{"0:0, 0:1, 1:1, 1:0, 0:0", "1:1, 3:3", "3:3, 3:4, 4:4, 4:3, 3:3"},
+			want:  []int{1, 1},
+		},
+		{
+			name:  "tic-tac-toe",
+			lines: []string{"-1:1, 4:1", "-1:2, 4:2", "1:-1, 1:4", "2:-1, 2:4"},
+			want:  []int{1},
+		},
+		{
+			name:  "square with island",
+			lines: []string{"0:0, 0:3, 3:3, 3:0, 0:0", "1:1, 1:2, 2:2, 2:1, 1:1"},
+			want:  []int{2, 1},
+			area:  "0:0, 0:3, 3:3, 3:0",
+		},
+		{
+			name:  "square with pinched triangle",
+			lines: []string{"0:0, 0:3, 3:3, 3:0, 0:0", "0:0, 1:2, 2:1, 0:0"},
+			want:  []int{2, 1},
+			area:  "0:0, 0:3, 3:3, 3:0",
+		},
+	}
+	for _, test := range tests {
+		var lines []*Polyline
+		for _, s := range test.lines {
+			lines = append(lines, makePolyline(s))
+		}
+		polygons := Polygonize(lines, snapRadius)
+		if len(polygons) != len(test.want) {
+			t.Errorf("%s: Polygonize returned %d polygons, want %d", test.name, len(polygons), len(test.want))
+			continue
+		}
+		var area float64
+		for i, p := range polygons {
+			if err := p.Validate(); err != nil {
+				t.Errorf("%s: polygon %d is invalid: %v", test.name, i, err)
+			}
+			area += p.Area()
+		}
+		counts := make(map[int]int)
+		for i, n := range test.want {
+			counts[n]++
+			counts[polygons[i].NumLoops()]--
+		}
+		for n, c := range counts {
+			if c != 0 {
+				t.Errorf("%s: Polygonize returned %d more polygons with %d loops than expected", test.name, -c, n)
+			}
+		}
+		if test.area != "" {
+			if want := makePolygon(test.area, false).Area(); !float64Near(area, want, 1e-15) {
+				t.Errorf("%s: total area = %v, want %v", test.name, area, want)
+			}
+		}
+	}
+}
@@ -372,12 +372,12 @@ func (p Polyline) encode(e *encoder) {
 
 // Decode decodes the polyline.
 func (p *Polyline) Decode(r io.Reader) error {
-	d := decoder{r: asByteReader(r)}
+	d := &decoder{r: asByteReader(r)}
 	p.decode(d)
 	return d.err
 }
 
-func (p *Polyline) decode(d decoder) {
+func (p *Polyline) decode(d *decoder) {
 	version := d.readInt8()
 	if d.err != nil {
 		return
@@ -394,12 +394,7 @@ func (p *Polyline) decode(d decoder) {
 		d.err = fmt.Errorf("too many vertices (%d; max is %d)", nvertices, maxEncodedVertices)
 		return
 	}
-	*p = make([]Point, nvertices)
-	for i := range *p {
-		(*p)[i].X = d.readFloat64()
-		(*p)[i].Y = d.readFloat64()
-		(*p)[i].Z = d.readFloat64()
-	}
+	*p = d.readPoints(uint64(nvertices))
 }
 
 // Project returns a point on the polyline that is closest to the given point,
@@ -561,8 +556,8 @@ func (p *Polyline) Interpolate(fraction float64) (Point, int) {
 // beginning of the polyline over the length of the polyline. The return
 // value is always between 0 and 1 inclusive.
 //
-// The polyline should not be empty.  If it has fewer than 2 vertices, the
-// return value is zero.
+// The polyline should not be empty.  If it has fewer than 2 vertices, or if
+// all of its vertices are identical, the return value is zero.
 func (p *Polyline) Uninterpolate(point Point, nextVertex int) float64 {
 	if len(*p) < 2 {
 		return 0
@@ -576,13 +571,135 @@ func (p *Polyline) Uninterpolate(point Point, nextVertex int) float64 {
 	for i := nextVertex; i < len(*p); i++ {
 		sum += (*p)[i-1].Distance((*p)[i])
 	}
+	if sum == 0 {
+		return 0
+	}
 	// The ratio can be greater than 1.0 due to rounding errors or because the
 	// point is not exactly on the polyline.
 	return minFloat64(1.0, float64(lengthToPoint/sum))
 }
 
+// Densify returns a copy of the polyline with vertices inserted so that no
+// edge is longer than maxEdge. Each edge is divided into the smallest number
+// of equal parts that satisfies the limit, so the inserted vertices lie on
+// the original great circle arcs and the original vertices are kept. This is
+// useful before exporting to systems that interpolate linearly between
+// vertices in lat/lng space.
+//
+// If maxEdge is not positive, the copy is not densified.
+func (p *Polyline) Densify(maxEdge s1.Angle) *Polyline {
+	if len(*p) == 0 {
+		return &Polyline{}
+	}
+	out := Polyline{(*p)[0]}
+	for i := 1; i < len(*p); i++ {
+		a, b := (*p)[i-1], (*p)[i]
+		if maxEdge > 0 {
+			n := int(math.Ceil(float64(a.Distance(b) / maxEdge)))
+			for j := 1; j < n; j++ {
+				out = append(out, Interpolate(float64(j)/float64(n), a, b))
+			}
+		}
+		out = append(out, b)
+	}
+	return &out
+}
+
+// nextDistinctVertex returns the index of the first vertex after the given
+// index that is different from the vertex at that index, or len(p) if there
+// is no such vertex.
+func (p *Polyline) nextDistinctVertex(index int) int {
+	initial := (*p)[index]
+	for index++; index < len(*p) && (*p)[index] == initial; index++ {
+	}
+	return index
+}
+
+// polylineSearchState is a state in the depth-first search performed by
+// NearlyCovers. It represents a partial match of the covered polyline up to
+// vertex j, where the match ends on the covering polyline at vertex i if
+// iInProgress is false, or somewhere on the edge starting at vertex i if it
+// is true.
+type polylineSearchState struct {
+	i, j        int
+	iInProgress bool
+}
+
+// NearlyCovers reports whether every point of the polyline o is within
+// maxError of a contiguous portion of this polyline, with the matching points
+// visited in the same order along both polylines. The converse is not
+// required, so a long polyline may cover a short one that follows part of it.
+// This can be used to check whether one GPS trace follows another.
+//
+// Every polyline covers an empty polyline, and an empty polyline covers no
+// non-empty polyline. If o has only one distinct vertex, it is covered if
+// that vertex is within maxError of some edge of this polyline.
+func (p *Polyline) NearlyCovers(o *Polyline, maxError s1.Angle) bool {
+	// This algorithm uses a depth-first search to find a path through this
+	// polyline such that o stays within maxError of it. From each state we
+	// advance along whichever of the two polylines keeps the next pair of
+	// edges within maxError of each other.
+	if len(*o) == 0 {
+		return true
+	}
+	if len(*p) == 0 {
+		return false
+	}
+
+	var pending []polylineSearchState
+	done := make(map[polylineSearchState]bool)
+
+	// Find all possible starting states.
+	for i, nextI := 0, p.nextDistinctVertex(0); nextI < len(*p); {
+		nextNextI := p.nextDistinctVertex(nextI)
+		closest := Project((*o)[0], (*p)[i], (*p)[nextI])
+
+		// In order to avoid duplicate starting states, we exclude the end
+		// vertex of each edge *except* for the last non-degenerate edge.
+		if (nextNextI == len(*p) || closest != (*p)[nextI]) &&
+			closest.Distance((*o)[0]) <= maxError {
+			pending = append(pending, polylineSearchState{i, 0, true})
+		}
+		i, nextI = nextI, nextNextI
+	}
+
+	for len(pending) > 0 {
+		state := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if done[state] {
+			continue
+		}
+		done[state] = true
+
+		nextI := p.nextDistinctVertex(state.i)
+		nextJ := o.nextDistinctVertex(state.j)
+		if nextJ == len(*o) {
+			return true
+		}
+		if nextI == len(*p) {
+			continue
+		}
+
+		var iBegin, jBegin Point
+		if state.iInProgress {
+			jBegin = (*o)[state.j]
+			iBegin = Project(jBegin, (*p)[state.i], (*p)[nextI])
+		} else {
+			iBegin = (*p)[state.i]
+			jBegin = Project(iBegin, (*o)[state.j], (*o)[nextJ])
+		}
+
+		if IsEdgeBNearEdgeA(jBegin, (*o)[nextJ], iBegin, (*p)[nextI], maxError) {
+			pending = append(pending, polylineSearchState{nextI, state.j, false})
+		}
+		if IsEdgeBNearEdgeA(iBegin, (*p)[nextI], jBegin, (*o)[nextJ], maxError) {
+			pending = append(pending, polylineSearchState{state.i, nextJ, true})
+		}
+	}
+	return false
+}
+
 // TODO(roberts): Differences from C++.
-// NearlyCoversPolyline
 // InitToSnapped
 // InitToSimplified
 // SnapLevel
@@ -0,0 +1,424 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+)
+
+// This file implements dynamic timewarp alignment of polylines, and the
+// medoid and consensus polyline computations built on top of it.
+//
+// A vertex alignment between two polylines A and B is a sequence of pairs
+// (i, j) of vertex indices, one in each polyline, called a warp path. The
+// warp path starts at (0, 0) and ends at (len(A)-1, len(B)-1), and each pair
+// is followed by one of (i+1, j), (i, j+1) or (i+1, j+1). Informally, it
+// pairs up each vertex of A with one or more vertices of B and vice versa,
+// without ever going backwards along either polyline.
+//
+// The cost of an alignment is the sum over its pairs of the squared chord
+// distance between the paired vertices, and the optimal alignment is the one
+// with the lowest cost. This cost is a useful measure of the dissimilarity
+// of two polylines, for example to cluster similar vehicle trajectories.
+//
+// Computing the optimal alignment exactly takes O(len(A) * len(B)) time.
+// The approximate algorithm (based on FastDTW) computes an alignment of
+// half-resolution versions of the polylines recursively, and then refines it
+// within a window around the projected result. It runs in linear time, and
+// in practice is usually very close to optimal.
+
+// VertexPair is a pair of vertex indices, one in each of two polylines.
+type VertexPair struct {
+	A, B int
+}
+
+// VertexAlignment is an alignment between the vertices of two polylines,
+// along with its cost.
+type VertexAlignment struct {
+	// AlignmentCost is the sum of the squared chord distances between the
+	// vertices of each pair in the warp path.
+	AlignmentCost float64
+
+	// WarpPath is the sequence of aligned vertex pairs, from (0, 0) to the
+	// last vertex of each polyline.
+	WarpPath []VertexPair
+}
+
+// columnStride is the half-open range [start, end) of columns of a single row
+// of the dynamic programming table that lie within a search window.
+type columnStride struct {
+	start, end int
+}
+
+// inRange reports whether the given column is within this stride.
+func (c columnStride) inRange(col int) bool {
+	return c.start <= col && col < c.end
+}
+
+// alignmentWindow is the set of cells of the dynamic programming table that
+// are searched, represented as one column stride per row. The strides must be
+// monotonic: both the start and end of each stride must be no less than those
+// of the previous stride.
+type alignmentWindow struct {
+	strides    []columnStride
+	rows, cols int
+}
+
+// newAlignmentWindow returns a window with the given strides.
+func newAlignmentWindow(strides []columnStride) *alignmentWindow {
+	return &alignmentWindow{
+		strides: strides,
+		rows:    len(strides),
+		cols:    strides[len(strides)-1].end,
+	}
+}
+
+// alignmentWindowFromWarpPath returns the smallest window containing every
+// pair of the given warp path.
+func alignmentWindowFromWarpPath(path []VertexPair) *alignmentWindow {
+	last := path[len(path)-1]
+	strides := make([]columnStride, last.A+1)
+	for i := range strides {
+		strides[i] = columnStride{math.MaxInt32, -1}
+	}
+	for _, p := range path {
+		if p.B < strides[p.A].start {
+			strides[p.A].start = p.B
+		}
+		if p.B+1 > strides[p.A].end {
+			strides[p.A].end = p.B + 1
+		}
+	}
+	return newAlignmentWindow(strides)
+}
+
+// stride returns the column stride of the given row, or an empty stride if the
+// row is out of range.
+func (w *alignmentWindow) stride(row int) columnStride {
+	if row < 0 || row >= w.rows {
+		return columnStride{}
+	}
+	return w.strides[row]
+}
+
+// upsample returns a window scaled up to the given number of rows and
+// columns. Each cell of the new window maps to a cell of this window.
+func (w *alignmentWindow) upsample(newRows, newCols int) *alignmentWindow {
+	rowScale := float64(newRows) / float64(w.rows)
+	colScale := float64(newCols) / float64(w.cols)
+	strides := make([]columnStride, newRows)
+	for row := range strides {
+		from := w.strides[int((float64(row)+0.5)/rowScale)]
+		strides[row] = columnStride{
+			start: int(colScale*float64(from.start) + 0.5),
+			end:   int(colScale*float64(from.end) + 0.5),
+		}
+	}
+	return newAlignmentWindow(strides)
+}
+
+// dilate returns a window expanded by the given radius in each direction, so
+// that it contains every cell within that (Chebyshev) distance of a cell of
+// this window.
+func (w *alignmentWindow) dilate(radius int) *alignmentWindow {
+	strides := make([]columnStride, w.rows)
+	for row := range strides {
+		prev := maxInt(0, row-radius)
+		next := minInt(row+radius, w.rows-1)
+		strides[row] = columnStride{
+			start: maxInt(0, w.strides[prev].start-radius),
+			end:   minInt(w.strides[next].end+radius, w.cols),
+		}
+	}
+	return newAlignmentWindow(strides)
+}
+
+// alignmentCost returns the cost of pairing the vertices x and y.
+func alignmentCost(x, y Point) float64 {
+	return x.Sub(y.Vector).Norm2()
+}
+
+// dynamicTimewarp returns the optimal alignment of a and b among those whose
+// warp paths lie within the given window.
+func dynamicTimewarp(a, b *Polyline, w *alignmentWindow) VertexAlignment {
+	rows, cols := len(*a), len(*b)
+	costs := make([][]float64, rows)
+	for row := range costs {
+		costs[row] = make([]float64, cols)
+	}
+
+	// cost returns the cost of the given cell, or +Inf if it is outside the
+	// window.
+	cost := func(row, col int) float64 {
+		if row < 0 || col < 0 || !w.stride(row).inRange(col) {
+			return math.Inf(1)
+		}
+		return costs[row][col]
+	}
+
+	for row := 0; row < rows; row++ {
+		s := w.stride(row)
+		for col := s.start; col < s.end; col++ {
+			best := 0.0
+			if row > 0 || col > 0 {
+				best = math.Min(cost(row-1, col-1), math.Min(cost(row-1, col), cost(row, col-1)))
+			}
+			costs[row][col] = best + alignmentCost((*a)[row], (*b)[col])
+		}
+	}
+
+	// Trace the optimal path back from the last cell.
+	row, col := rows-1, cols-1
+	path := []VertexPair{{row, col}}
+	for row > 0 || col > 0 {
+		diag, up, left := cost(row-1, col-1), cost(row-1, col), cost(row, col-1)
+		switch {
+		case diag <= up && diag <= left:
+			row, col = row-1, col-1
+		case up <= left:
+			row--
+		default:
+			col--
+		}
+		path = append(path, VertexPair{row, col})
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return VertexAlignment{
+		AlignmentCost: costs[rows-1][cols-1],
+		WarpPath:      path,
+	}
+}
+
+// ExactVertexAlignment returns the optimal alignment between the vertices of
+// a and b. This takes O(len(a) * len(b)) time and space. Both polylines must
+// be non-empty.
+func ExactVertexAlignment(a, b *Polyline) VertexAlignment {
+	strides := make([]columnStride, len(*a))
+	for i := range strides {
+		strides[i] = columnStride{0, len(*b)}
+	}
+	return dynamicTimewarp(a, b, newAlignmentWindow(strides))
+}
+
+// ExactVertexAlignmentCost returns the cost of the optimal alignment between
+// the vertices of a and b, without computing the alignment itself. This
+// takes O(len(a) * len(b)) time but only O(len(b)) space. Both polylines must
+// be non-empty.
+func ExactVertexAlignmentCost(a, b *Polyline) float64 {
+	// costs holds the costs of the previous row, updated in place as the
+	// current row is computed.
+	costs := make([]float64, len(*b))
+	for i := range costs {
+		costs[i] = math.Inf(1)
+	}
+	leftDiagMinCost := 0.0
+	for row := 0; row < len(*a); row++ {
+		for col := 0; col < len(*b); col++ {
+			upCost := costs[col]
+			costs[col] = math.Min(leftDiagMinCost, upCost) + alignmentCost((*a)[row], (*b)[col])
+			leftDiagMinCost = math.Min(costs[col], upCost)
+		}
+		leftDiagMinCost = math.Inf(1)
+	}
+	return costs[len(costs)-1]
+}
+
+// halfResolution returns a polyline consisting of every other vertex of p,
+// starting with the first.
+func halfResolution(p *Polyline) *Polyline {
+	half := make(Polyline, 0, (len(*p)+1)/2)
+	for i := 0; i < len(*p); i += 2 {
+		half = append(half, (*p)[i])
+	}
+	return &half
+}
+
+// ApproxVertexAlignment returns an approximately optimal alignment between the
+// vertices of a and b, using a search radius of the fourth root of the
+// length of the longer polyline. See ApproxVertexAlignmentWithRadius.
+func ApproxVertexAlignment(a, b *Polyline) VertexAlignment {
+	maxLength := maxInt(len(*a), len(*b))
+	radius := int(math.Pow(float64(maxLength), 0.25))
+	return ApproxVertexAlignmentWithRadius(a, b, radius)
+}
+
+// ApproxVertexAlignmentWithRadius returns an approximately optimal alignment
+// between the vertices of a and b. It aligns half-resolution versions of the
+// polylines recursively, and then searches for the optimal alignment within
+// the given radius of the projection of that result. Larger radii give
+// results closer to optimal at the expense of speed. The running time is
+// O(radius * (len(a) + len(b))). A radius less than 1 is treated as 1. Both
+// polylines must be non-empty.
+func ApproxVertexAlignmentWithRadius(a, b *Polyline, radius int) VertexAlignment {
+	radius = maxInt(radius, 1)
+	// Once the polylines are small enough, the exact computation is cheaper.
+	if size := radius + 2; len(*a) <= size || len(*b) <= size {
+		return ExactVertexAlignment(a, b)
+	}
+
+	half := ApproxVertexAlignmentWithRadius(halfResolution(a), halfResolution(b), radius)
+	w := alignmentWindowFromWarpPath(half.WarpPath).upsample(len(*a), len(*b)).dilate(radius)
+	return dynamicTimewarp(a, b, w)
+}
+
+// vertexAlignment returns the exact or approximate vertex alignment of a and b.
+func vertexAlignment(a, b *Polyline, approx bool) VertexAlignment {
+	if approx {
+		return ApproxVertexAlignment(a, b)
+	}
+	return ExactVertexAlignment(a, b)
+}
+
+// vertexAlignmentCost returns the exact or approximate vertex alignment cost
+// of a and b.
+func vertexAlignmentCost(a, b *Polyline, approx bool) float64 {
+	if approx {
+		return ApproxVertexAlignment(a, b).AlignmentCost
+	}
+	return ExactVertexAlignmentCost(a, b)
+}
+
+// MedoidOptions holds the options for MedoidPolyline.
+type MedoidOptions struct {
+	approx bool
+}
+
+// NewMedoidOptions returns the default options, which use approximate
+// alignments.
+func NewMedoidOptions() *MedoidOptions {
+	return &MedoidOptions{approx: true}
+}
+
+// Approx specifies whether approximate alignments are used rather than exact
+// ones. Approximate alignments are much faster for long polylines.
+func (o *MedoidOptions) Approx(x bool) *MedoidOptions {
+	o.approx = x
+	return o
+}
+
+// MedoidPolyline returns the index of the medoid of the given polylines: the
+// polyline whose total alignment cost to all the others is smallest. If opts
+// is nil, the default options are used. It returns -1 if there are no
+// polylines. All the polylines must be non-empty.
+//
+// This computes an alignment between every pair of polylines, so it takes
+// O(n^2) alignments for n polylines.
+func MedoidPolyline(polylines []*Polyline, opts *MedoidOptions) int {
+	if opts == nil {
+		opts = NewMedoidOptions()
+	}
+	costs := make([]float64, len(polylines))
+	for i := range polylines {
+		for j := i + 1; j < len(polylines); j++ {
+			c := vertexAlignmentCost(polylines[i], polylines[j], opts.approx)
+			costs[i] += c
+			costs[j] += c
+		}
+	}
+
+	medoid := -1
+	for i, c := range costs {
+		if medoid < 0 || c < costs[medoid] {
+			medoid = i
+		}
+	}
+	return medoid
+}
+
+// ConsensusOptions holds the options for ConsensusPolyline.
+type ConsensusOptions struct {
+	approx       bool
+	seedMedoid   bool
+	iterationCap int
+}
+
+// NewConsensusOptions returns the default options, which use approximate
+// alignments, start from the first polyline, and run at most 5 iterations.
+func NewConsensusOptions() *ConsensusOptions {
+	return &ConsensusOptions{
+		approx:       true,
+		iterationCap: 5,
+	}
+}
+
+// Approx specifies whether approximate alignments are used rather than exact
+// ones. Approximate alignments are much faster for long polylines.
+func (o *ConsensusOptions) Approx(x bool) *ConsensusOptions {
+	o.approx = x
+	return o
+}
+
+// SeedMedoid specifies whether the consensus starts from the medoid of the
+// polylines rather than from the first polyline. This usually gives a better
+// result, but computing the medoid takes O(n^2) alignments for n polylines.
+func (o *ConsensusOptions) SeedMedoid(x bool) *ConsensusOptions {
+	o.seedMedoid = x
+	return o
+}
+
+// IterationCap sets the maximum number of refinement iterations.
+func (o *ConsensusOptions) IterationCap(n int) *ConsensusOptions {
+	o.iterationCap = n
+	return o
+}
+
+// ConsensusPolyline returns a polyline that represents the "average" of the
+// given polylines, in the sense of dynamic timewarp barycenter averaging
+// (DBA). Starting from a seed polyline, each iteration aligns every input
+// polyline to the current consensus, and moves each consensus vertex to the
+// normalized sum of the input vertices aligned to it. This repeats until the
+// consensus stops changing or the iteration cap is reached.
+//
+// The result has the same number of vertices as the seed polyline. If opts is
+// nil, the default options are used. It returns nil if there are no
+// polylines. All the polylines must be non-empty.
+func ConsensusPolyline(polylines []*Polyline, opts *ConsensusOptions) *Polyline {
+	if opts == nil {
+		opts = NewConsensusOptions()
+	}
+	if len(polylines) == 0 {
+		return nil
+	}
+
+	seed := 0
+	if opts.seedMedoid {
+		seed = MedoidPolyline(polylines, NewMedoidOptions().Approx(opts.approx))
+	}
+	consensus := make(Polyline, len(*polylines[seed]))
+	copy(consensus, *polylines[seed])
+
+	for iter := 0; iter < opts.iterationCap; iter++ {
+		sums := make([]Point, len(consensus))
+		for _, p := range polylines {
+			for _, pair := range vertexAlignment(&consensus, p, opts.approx).WarpPath {
+				sums[pair.A] = Point{sums[pair.A].Add((*p)[pair.B].Vector)}
+			}
+		}
+		next := make(Polyline, len(sums))
+		for i, s := range sums {
+			next[i] = Point{s.Normalize()}
+		}
+
+		converged := next.ApproxEqual(&consensus)
+		consensus = next
+		if converged {
+			break
+		}
+	}
+	return &consensus
+}
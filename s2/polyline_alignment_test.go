@@ -0,0 +1,208 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+// checkWarpPath verifies that the alignment's warp path is valid for a and b
+// and that its cost matches the path.
+func checkWarpPath(t *testing.T, a, b *Polyline, alignment VertexAlignment) {
+	t.Helper()
+	path := alignment.WarpPath
+	if len(path) == 0 || path[0] != (VertexPair{0, 0}) || path[len(path)-1] != (VertexPair{len(*a) - 1, len(*b) - 1}) {
+		t.Errorf("warp path %v does not run from (0, 0) to (%d, %d)", path, len(*a)-1, len(*b)-1)
+		return
+	}
+	var cost float64
+	for i, p := range path {
+		if i > 0 {
+			di, dj := p.A-path[i-1].A, p.B-path[i-1].B
+			if di < 0 || di > 1 || dj < 0 || dj > 1 || di+dj == 0 {
+				t.Errorf("warp path %v has an invalid step from %v to %v", path, path[i-1], p)
+			}
+		}
+		cost += alignmentCost((*a)[p.A], (*b)[p.B])
+	}
+	if !float64Near(cost, alignment.AlignmentCost, 1e-14) {
+		t.Errorf("alignment cost = %v, want %v from its warp path", alignment.AlignmentCost, cost)
+	}
+}
+
+func TestPolylineAlignmentWindowFromWarpPath(t *testing.T) {
+	path := []VertexPair{{0, 0}, {1, 1}, {2, 1}, {3, 2}, {3, 3}}
+	w := alignmentWindowFromWarpPath(path)
+	want := []columnStride{{0, 1}, {1, 2}, {1, 2}, {2, 4}}
+	if !reflect.DeepEqual(w.strides, want) || w.rows != 4 || w.cols != 4 {
+		t.Errorf("alignmentWindowFromWarpPath(%v) = %+v, want strides %v with 4 rows and columns", path, w, want)
+	}
+}
+
+func TestPolylineAlignmentWindowUpsample(t *testing.T) {
+	w := newAlignmentWindow([]columnStride{{0, 1}, {1, 2}, {2, 3}})
+	got := w.upsample(6, 6)
+	want := []columnStride{{0, 2}, {0, 2}, {2, 4}, {2, 4}, {4, 6}, {4, 6}}
+	if !reflect.DeepEqual(got.strides, want) {
+		t.Errorf("upsample(6, 6) = %v, want %v", got.strides, want)
+	}
+}
+
+func TestPolylineAlignmentWindowDilate(t *testing.T) {
+	w := newAlignmentWindow([]columnStride{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}})
+	got := w.dilate(1)
+	want := []columnStride{{0, 3}, {0, 4}, {0, 5}, {1, 5}, {2, 5}}
+	if !reflect.DeepEqual(got.strides, want) {
+		t.Errorf("dilate(1) = %v, want %v", got.strides, want)
+	}
+}
+
+func TestPolylineAlignmentExact(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want []VertexPair
+	}{
+		{"1:1", "2:2", []VertexPair{{0, 0}}},
+		{"0:0", "0:1, 0:2, 0:3", []VertexPair{{0, 0}, {0, 1}, {0, 2}}},
+		{"0:1, 0:2, 0:3", "0:0", []VertexPair{{0, 0}, {1, 0}, {2, 0}}},
+		{"1:0, 5:0, 6:0, 9:0", "2:0, 7:0, 8:0", []VertexPair{{0, 0}, {1, 1}, {2, 1}, {3, 2}}},
+		{"0:0, 1:0, 2:0", "0:0, 1:0, 2:0", []VertexPair{{0, 0}, {1, 1}, {2, 2}}},
+	}
+	for _, test := range tests {
+		a, b := makePolyline(test.a), makePolyline(test.b)
+		got := ExactVertexAlignment(a, b)
+		if !reflect.DeepEqual(got.WarpPath, test.want) {
+			t.Errorf("ExactVertexAlignment(%v, %v).WarpPath = %v, want %v", test.a, test.b, got.WarpPath, test.want)
+		}
+		checkWarpPath(t, a, b, got)
+		if cost := ExactVertexAlignmentCost(a, b); !float64Near(cost, got.AlignmentCost, 1e-15) {
+			t.Errorf("ExactVertexAlignmentCost(%v, %v) = %v, want %v", test.a, test.b, cost, got.AlignmentCost)
+		}
+	}
+}
+
+// perturbedPolyline returns a random walk of n vertices, or a noisy copy of
+// base if it is not nil.
+func perturbedPolyline(rng *rand.Rand, base *Polyline, n int, noise s1.Angle) *Polyline {
+	var p Polyline
+	if base == nil {
+		ll := LatLngFromDegrees(rng.Float64()*120-60, rng.Float64()*360-180)
+		for i := 0; i < n; i++ {
+			p = append(p, PointFromLatLng(ll))
+			ll.Lat += s1.Angle(rng.Float64()) * 1e-3
+			ll.Lng += s1.Angle(rng.Float64()*2-1) * 1e-3
+		}
+		return &p
+	}
+	for _, v := range *base {
+		ll := LatLngFromPoint(v)
+		ll.Lat += s1.Angle(rng.NormFloat64()) * noise
+		ll.Lng += s1.Angle(rng.NormFloat64()) * noise
+		p = append(p, PointFromLatLng(ll))
+	}
+	return &p
+}
+
+func TestPolylineAlignmentApprox(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 50; iter++ {
+		a := perturbedPolyline(rng, nil, 1+rng.Intn(200), 0)
+		b := perturbedPolyline(rng, nil, 1+rng.Intn(200), 0)
+		exact := ExactVertexAlignment(a, b)
+		checkWarpPath(t, a, b, exact)
+		if cost := ExactVertexAlignmentCost(a, b); !float64Near(cost, exact.AlignmentCost, 1e-14) {
+			t.Errorf("ExactVertexAlignmentCost = %v, want %v", cost, exact.AlignmentCost)
+		}
+
+		for _, radius := range []int{0, 1, 3, 10} {
+			approx := ApproxVertexAlignmentWithRadius(a, b, radius)
+			checkWarpPath(t, a, b, approx)
+			if approx.AlignmentCost < exact.AlignmentCost*(1-1e-12) {
+				t.Errorf("radius %d: approximate cost %v < exact cost %v", radius, approx.AlignmentCost, exact.AlignmentCost)
+			}
+		}
+		// A radius at least as large as the polylines is exact.
+		approx := ApproxVertexAlignmentWithRadius(a, b, 200)
+		if !float64Near(approx.AlignmentCost, exact.AlignmentCost, 1e-14) {
+			t.Errorf("radius 200: approximate cost %v, want %v", approx.AlignmentCost, exact.AlignmentCost)
+		}
+		checkWarpPath(t, a, b, ApproxVertexAlignment(a, b))
+	}
+}
+
+func TestPolylineAlignmentMedoid(t *testing.T) {
+	if got := MedoidPolyline(nil, nil); got != -1 {
+		t.Errorf("MedoidPolyline(nil) = %d, want -1", got)
+	}
+	polylines := []*Polyline{
+		makePolyline("0:0, 0:1, 0:2"),
+		makePolyline("5:0, 5:1, 5:2"),
+		makePolyline("1:0, 1:1, 1:2"),
+		makePolyline("2:0, 2:1, 2:2"),
+	}
+	if got := MedoidPolyline(polylines[:1], nil); got != 0 {
+		t.Errorf("MedoidPolyline(one polyline) = %d, want 0", got)
+	}
+	for _, approx := range []bool{true, false} {
+		if got := MedoidPolyline(polylines, NewMedoidOptions().Approx(approx)); got != 3 {
+			t.Errorf("MedoidPolyline(%v, approx %v) = %d, want 3", polylines, approx, got)
+		}
+	}
+}
+
+func TestPolylineAlignmentConsensus(t *testing.T) {
+	if got := ConsensusPolyline(nil, nil); got != nil {
+		t.Errorf("ConsensusPolyline(nil) = %v, want nil", got)
+	}
+
+	one := makePolyline("0:0, 0:1, 0:2")
+	if got := ConsensusPolyline([]*Polyline{one}, nil); !got.ApproxEqual(one) {
+		t.Errorf("ConsensusPolyline(%v) = %v, want %v", one, got, one)
+	}
+
+	// The consensus of two parallel lines is the line midway between them.
+	two := []*Polyline{makePolyline("0:1, 0:2, 0:3"), makePolyline("2:1, 2:2, 2:3")}
+	want := makePolyline("1:1, 1:2, 1:3")
+	for _, approx := range []bool{true, false} {
+		got := ConsensusPolyline(two, NewConsensusOptions().Approx(approx))
+		if !got.approxEqual(want, s1.Angle(1e-4)*s1.Degree) {
+			t.Errorf("ConsensusPolyline(%v, approx %v) = %v, want %v", two, approx, got, want)
+		}
+	}
+
+	// The consensus of noisy copies of a trajectory is close to it.
+	rng := rand.New(rand.NewSource(1))
+	base := perturbedPolyline(rng, nil, 100, 0)
+	var noisy []*Polyline
+	for i := 0; i < 10; i++ {
+		noisy = append(noisy, perturbedPolyline(rng, base, 0, 1e-5))
+	}
+	got := ConsensusPolyline(noisy, NewConsensusOptions().SeedMedoid(true).IterationCap(10))
+	if len(*got) != len(*base) {
+		t.Fatalf("len(ConsensusPolyline) = %d, want %d", len(*got), len(*base))
+	}
+	var maxDist s1.Angle
+	for i := range *got {
+		maxDist = s1.Angle(math.Max(float64(maxDist), float64((*got)[i].Distance((*base)[i]))))
+	}
+	if maxDist > 2e-5 {
+		t.Errorf("ConsensusPolyline of noisy copies is up to %v from the original, want <= 2e-5", maxDist)
+	}
+}
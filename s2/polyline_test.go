@@ -16,6 +16,7 @@ package s2
 
 import (
 	"math"
+	"math/rand"
 	"reflect"
 	"testing"
 
@@ -286,6 +287,46 @@ func TestPolylineSubsample(t *testing.T) {
 	}
 }
 
+func TestPolylineSubsampleStaysWithinTolerance(t *testing.T) {
+	// Simulate noisy GPS traces as random walks, and check that the
+	// subsampled polyline and the original are within the tolerance of each
+	// other in both directions.
+	rng := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 50; iter++ {
+		ll := LatLngFromDegrees(rng.Float64()*160-80, rng.Float64()*360-180)
+		heading := rng.Float64() * 2 * math.Pi
+		var line Polyline
+		for i := 0; i < 500; i++ {
+			line = append(line, PointFromLatLng(ll))
+			heading += rng.NormFloat64() * 0.3
+			step := 1e-4 * (1 + rng.Float64())
+			ll = LatLng{ll.Lat + s1.Angle(step*math.Sin(heading)), ll.Lng + s1.Angle(step*math.Cos(heading))}
+		}
+
+		tolerance := s1.Angle(1e-5 * (1 + 10*rng.Float64()))
+		indices := line.SubsampleVertices(tolerance)
+		if indices[0] != 0 || indices[len(indices)-1] != len(line)-1 {
+			t.Errorf("SubsampleVertices(%v) = %v, want first and last vertices preserved", tolerance, indices)
+		}
+		var simplified Polyline
+		for _, i := range indices {
+			simplified = append(simplified, line[i])
+		}
+		if len(simplified) >= len(line) {
+			t.Errorf("SubsampleVertices(%v) kept %d of %d vertices, want fewer", tolerance, len(simplified), len(line))
+		}
+
+		// Allow a little extra for numerical error in the covering test.
+		maxError := tolerance + 1e-13
+		if !simplified.NearlyCovers(&line, maxError) {
+			t.Errorf("simplified.NearlyCovers(line, %v) = false, want true", maxError)
+		}
+		if !line.NearlyCovers(&simplified, maxError) {
+			t.Errorf("line.NearlyCovers(simplified, %v) = false, want true", maxError)
+		}
+	}
+}
+
 func TestProject(t *testing.T) {
 	latlngs := []LatLng{
 		LatLngFromDegrees(0, 0),
@@ -623,23 +664,115 @@ func TestPolylineUninterpolate(t *testing.T) {
 	if got, want := line.Uninterpolate(PointFromCoords(0, 1, 0), len(line)), 1.0; !float64Eq(got, want) {
 		t.Errorf("line.Uninterpolate(%v, %d) = %v, want %v", PointFromCoords(0, 1, 0), len(line), got, want)
 	}
+
+	// A polyline whose vertices are all identical has zero length.
+	degenerate := Polyline{PointFromCoords(1, 0, 0), PointFromCoords(1, 0, 0)}
+	if got, want := degenerate.Uninterpolate(PointFromCoords(1, 0, 0), 1), 0.0; got != want {
+		t.Errorf("degenerate.Uninterpolate(%v, 1) = %v, want %v", PointFromCoords(1, 0, 0), got, want)
+	}
 }
 
 // TODO(roberts): Test differences from C++:
 // InitToSnapped
 // InitToSimplified
-//
-// PolylineCoveringTest
-//    PolylineOverlapsSelf
-//    PolylineDoesNotOverlapReverse
-//    PolylineOverlapsEquivalent
-//    ShortCoveredByLong
-//    PartialOverlapOnly
-//    ShortBacktracking
-//    LongBacktracking
-//    IsResilientToDuplicatePoints
-//    CanChooseBetweenTwoPotentialStartingPoints
-//    StraightAndWigglyPolylinesCoverEachOther
-//    MatchStartsAtLastVertex
-//    MatchStartsAtDuplicatedLastVertex
-//    EmptyPolylines
+
+func TestPolylineNearlyCovers(t *testing.T) {
+	tests := []struct {
+		desc     string
+		a, b     string
+		maxError float64 // degrees
+		bCoversA bool
+		aCoversB bool
+	}{
+		{"overlaps self", "1:1, 2:2, -1:10", "1:1, 2:2, -1:10", 1e-10, true, true},
+		{"does not overlap reverse", "1:1, 2:2, -1:10", "-1:10, 2:2, 1:1", 1e-10, false, false},
+		// These two polylines trace the exact same polyline, but the second
+		// one uses three points instead of two.
+		{"overlaps equivalent", "1:1, 2:1", "1:1, 1.5:1, 2:1", 1e-10, true, true},
+		// The second polyline is always within 0.001 degrees of the first
+		// polyline, but the first polyline is too long to be covered by the
+		// second.
+		{"short covered by long", "-5:1, 10:1, 10:5, 5:10", "9:1, 9.9995:1, 10.0005:5", 1e-3, false, true},
+		// These two polylines partially overlap each other, but neither is a
+		// subset of the other.
+		{"partial overlap only", "-5:1, 10:1", "0:1, 20:1", 1.0, false, false},
+		// Two lines that backtrack a bit (less than 1.5 degrees) on different
+		// edges. A simple greedy matching algorithm would fail on this example.
+		{"short backtracking", "0:0, 0:2, 0:1, 0:4, 0:5", "0:0, 0:2, 0:4, 0:3, 0:5", 1.5, true, true},
+		{"short backtracking small error", "0:0, 0:2, 0:1, 0:4, 0:5", "0:0, 0:2, 0:4, 0:3, 0:5", 0.5, false, false},
+		// Two arcs with opposite direction do not overlap if the shorter arc
+		// is longer than maxError, but do if the shorter arc is shorter than
+		// maxError.
+		{"long backtracking", "5:1, -5:1", "1:1, 3:1", 1.0, false, false},
+		{"long backtracking large error", "5:1, -5:1", "1:1, 3:1", 2.5, false, true},
+		{"resilient to duplicate points", "0:1, 0:2, 0:2, 0:3", "0:1, 0:1, 0:1, 0:3", 1e-10, true, true},
+		// In the first polyline, the edges from 0:11 to 0:0 and from 0:0 to
+		// 0:9 are both possible starting states for covering the second
+		// polyline, but only the latter leads to a match.
+		{"two potential starting points", "0:11, 0:0, 0:9, 0:20", "0:10, 0:15", 1.5, false, true},
+		{"straight and wiggly", "40:1, 20:1",
+			"39.9:0.9, 40:1.1, 30:1.15, 29:0.95, 28:1.1, 27:1.15, 26:1.05, 25:0.85, 24:1.1, 23:0.9, 20:0.99",
+			0.2, true, true},
+		// The matching segment starts at the last vertex of the first polyline.
+		{"match starts at last vertex", "0:0, 0:2", "0:2, 0:3", 1.5, false, true},
+		{"match starts at duplicated last vertex", "0:0, 0:2, 0:2, 0:2", "0:2, 0:3", 1.5, false, true},
+		// Anything covers an empty polyline, but an empty polyline covers
+		// nothing else.
+		{"empty", "0:1, 0:2", "", 0, false, true},
+		{"both empty", "", "", 0, true, true},
+	}
+	for _, test := range tests {
+		a := makePolyline(test.a)
+		b := makePolyline(test.b)
+		maxError := s1.Angle(test.maxError) * s1.Degree
+		if got := b.NearlyCovers(a, maxError); got != test.bCoversA {
+			t.Errorf("%s: %v.NearlyCovers(%v, %v) = %v, want %v", test.desc, test.b, test.a, maxError, got, test.bCoversA)
+		}
+		if got := a.NearlyCovers(b, maxError); got != test.aCoversB {
+			t.Errorf("%s: %v.NearlyCovers(%v, %v) = %v, want %v", test.desc, test.a, test.b, maxError, got, test.aCoversB)
+		}
+	}
+}
+
+func TestPolylineDensify(t *testing.T) {
+	tests := []struct {
+		line    string
+		maxEdge s1.Angle
+		want    int
+	}{
+		{"", s1.Degree, 0},
+		{"0:0", s1.Degree, 1},
+		{"0:0, 0:1", s1.Degree, 2},
+		{"0:0, 0:10", s1.Degree, 11},
+		{"0:0, 0:10, 0:10.5", 3 * s1.Degree, 6},
+		{"0:0, 0:10", 0, 2},
+	}
+	for _, test := range tests {
+		p := makePolyline(test.line)
+		got := p.Densify(test.maxEdge)
+		if len(*got) != test.want {
+			t.Errorf("%v.Densify(%v) has %d vertices, want %d", test.line, test.maxEdge, len(*got), test.want)
+			continue
+		}
+		if len(*p) == 0 {
+			continue
+		}
+		if (*got)[0] != (*p)[0] || (*got)[len(*got)-1] != (*p)[len(*p)-1] {
+			t.Errorf("%v.Densify(%v) = %v, does not keep the end points", test.line, test.maxEdge, got)
+		}
+		if diff := got.Length() - p.Length(); math.Abs(diff.Radians()) > 1e-14 {
+			t.Errorf("%v.Densify(%v) changed the length by %v", test.line, test.maxEdge, diff)
+		}
+		for i := 0; i < got.NumEdges(); i++ {
+			if e := got.Edge(i); test.maxEdge > 0 && e.V0.Distance(e.V1) > test.maxEdge+1e-15 {
+				t.Errorf("%v.Densify(%v) has edge %d of length %v", test.line, test.maxEdge, i, e.V0.Distance(e.V1))
+			}
+		}
+	}
+
+	// The inserted vertices are on the great circle, not the parallel.
+	got := makePolyline("45:0, 45:90").Densify(10 * s1.Degree)
+	if lat := LatLngFromPoint((*got)[len(*got)/2]).Lat.Degrees(); lat < 50 {
+		t.Errorf("midpoint of densified edge along 45 degrees has latitude %v, want the great circle's", lat)
+	}
+}
@@ -38,11 +38,15 @@ const (
 	// epsilon is a small number that represents a reasonable level of noise between two
 	// values that can be considered to be equal.
 	epsilon = 1e-15
-	// dblEpsilon is a smaller number for values that require more precision.
-	// This is the C++ DBL_EPSILON equivalent.
-	dblEpsilon = 2.220446049250313e-16
-	// dblError is the C++ value for S2 rounding_epsilon().
-	dblError = 1.110223024625156e-16
+	// DblEpsilon is a smaller number for values that require more precision.
+	// This is the C++ DBL_EPSILON equivalent, i.e. the difference between 1
+	// and the next larger float64. Most of the error bounds in this package
+	// are expressed as multiples of it.
+	DblEpsilon = 2.220446049250313e-16
+	// DblError is the C++ value for S2 rounding_epsilon(), the maximum
+	// relative error of a single correctly rounded float64 operation. It is
+	// half of DblEpsilon.
+	DblError = 1.110223024625156e-16
 
 	// maxDeterminantError is the maximum error in computing (AxB).C where all vectors
 	// are unit length. Using standard inequalities, it can be shown that
@@ -60,7 +64,7 @@ const (
 	// relative error (which does not affect the sign of the result), we get
 	//
 	//  fl((AxB).C) = (AxB).C + d where |d| <= (3 + 2/sqrt(3)) * e
-	maxDeterminantError = 1.8274 * dblEpsilon
+	maxDeterminantError = 1.8274 * DblEpsilon
 
 	// detErrorMultiplier is the factor to scale the magnitudes by when checking
 	// for the sign of set of points with certainty. Using a similar technique to
@@ -70,7 +74,7 @@ const (
 	//
 	// If the determinant magnitude is larger than this value then we know
 	// its sign with certainty.
-	detErrorMultiplier = 3.2321 * dblEpsilon
+	detErrorMultiplier = 3.2321 * DblEpsilon
 )
 
 // Direction is an indication of the ordering of a set of points.
@@ -232,6 +236,7 @@ func expensiveSign(a, b, c Point) Direction {
 	}
 
 	// Otherwise fall back to exact arithmetic and symbolic permutations.
+	reportRobustness(ExactArithmeticFallback, "Sign")
 	return exactSign(a, b, c, true)
 }
 
@@ -273,6 +278,7 @@ func exactSign(a, b, c Point, perturb bool) Direction {
 	if detSign == Indeterminate && perturb {
 		// Otherwise, we need to resort to symbolic perturbations to resolve the
 		// sign of the determinant.
+		reportRobustness(SymbolicPerturbation, "Sign")
 		detSign = symbolicallyPerturbedSign(xa, xb, xc, xbCrossXc)
 	}
 	return permSign * detSign
@@ -417,6 +423,41 @@ func symbolicallyPerturbedSign(a, b, c, bCrossC r3.PreciseVector) Direction {
 	return CounterClockwise // dc.Z * db.Y * da.X
 }
 
+// Collinear reports whether the three points lie exactly on a common great
+// circle, or equivalently whether they are coplanar with the origin. This
+// includes the cases where two of the points are equal or antipodal. The
+// test is exact: it falls back to high-precision arithmetic when the sign
+// of the determinant cannot be determined in floating point, and does not
+// use the symbolic perturbations of RobustSign.
+func Collinear(a, b, c Point) bool {
+	if triageSign(a, b, c) != Indeterminate {
+		return false
+	}
+	if stableSign(a, b, c) != Indeterminate {
+		return false
+	}
+	return exactSign(a, b, c, false) == Indeterminate
+}
+
+// NearlyCollinear reports whether B is within the given distance of the
+// great circle through A and C. This is useful for removing nearly
+// degenerate vertices from a chain before building a loop. If A and C are
+// equal or antipodal, every great circle through A also passes through C,
+// so the result is true. A tolerance of zero gives the exact test of
+// Collinear.
+func NearlyCollinear(a, b, c Point, tolerance s1.Angle) bool {
+	if tolerance <= 0 {
+		return Collinear(a, b, c)
+	}
+	// PointCross computes the normal accurately even when A and C are
+	// nearly equal. Its length is twice the sine of the angle AC.
+	n := a.PointCross(c)
+	if a == c || a == (Point{c.Mul(-1)}) || n.Norm2() == 0 {
+		return true
+	}
+	return math.Abs(b.Dot(n.Vector))/n.Norm() <= math.Sin(math.Min(tolerance.Radians(), math.Pi/2))
+}
+
 // CompareDistances returns -1, 0, or +1 according to whether AX < BX, A == B,
 // or AX > BX respectively. Distances are measured with respect to the positions
 // of X, A, and B as though they were reprojected to lie exactly on the surface of
@@ -461,10 +502,12 @@ func CompareDistances(x, a, b Point) int {
 		return sign
 	}
 
+	reportRobustness(ExactArithmeticFallback, "CompareDistances")
 	sign = exactCompareDistances(r3.PreciseVectorFromVector(x.Vector), r3.PreciseVectorFromVector(a.Vector), r3.PreciseVectorFromVector(b.Vector))
 	if sign != 0 {
 		return sign
 	}
+	reportRobustness(SymbolicPerturbation, "CompareDistances")
 	return symbolicCompareDistances(x, a, b)
 }
 
@@ -472,7 +515,7 @@ func CompareDistances(x, a, b Point) int {
 // maximum error amount in the result. This requires X and Y be normalized.
 func cosDistance(x, y Point) (cos, err float64) {
 	cos = x.Dot(y.Vector)
-	return cos, 9.5*dblError*math.Abs(cos) + 1.5*dblError
+	return cos, float64(9.5*DblError*math.Abs(cos)) + 1.5*DblError
 }
 
 // sin2Distance returns sin**2(XY), where XY is the angle between X and Y,
@@ -480,13 +523,13 @@ func cosDistance(x, y Point) (cos, err float64) {
 func sin2Distance(x, y Point) (sin2, err float64) {
 	// The (x-y).Cross(x+y) trick eliminates almost all of error due to x
 	// and y being not quite unit length. This method is extremely accurate
-	// for small distances; the *relative* error in the result is O(dblError) for
-	// distances as small as dblError.
+	// for small distances; the *relative* error in the result is O(DblError) for
+	// distances as small as DblError.
 	n := x.Sub(y.Vector).Cross(x.Add(y.Vector))
 	sin2 = 0.25 * n.Norm2()
-	err = ((21+4*math.Sqrt(3))*dblError*sin2 +
-		32*math.Sqrt(3)*dblError*dblError*math.Sqrt(sin2) +
-		768*dblError*dblError*dblError*dblError)
+	err = (float64((21+4*math.Sqrt(3))*DblError*sin2) +
+		float64(32*math.Sqrt(3)*DblError*DblError*math.Sqrt(sin2)) +
+		768*DblError*DblError*DblError*DblError)
 	return sin2, err
 }
 
@@ -608,6 +651,7 @@ func CompareDistance(x, y Point, r s1.ChordAngle) int {
 			return sign
 		}
 	}
+	reportRobustness(ExactArithmeticFallback, "CompareDistance")
 	return exactCompareDistance(r3.PreciseVectorFromVector(x.Vector), r3.PreciseVectorFromVector(y.Vector), big.NewFloat(float64(r)).SetPrec(big.MaxPrec))
 }
 
@@ -615,8 +659,8 @@ func CompareDistance(x, y Point, r s1.ChordAngle) int {
 // less than, equal to, or greater than r2 respectively using cos distance.
 func triageCompareCosDistance(x, y Point, r2 float64) int {
 	cosXY, cosXYError := cosDistance(x, y)
-	cosR := 1.0 - 0.5*r2
-	cosRError := 2.0 * dblError * cosR
+	cosR := 1.0 - float64(0.5*r2)
+	cosRError := float64(2.0 * DblError * cosR)
 	diff := cosXY - cosR
 	err := cosXYError + cosRError
 	if diff > err {
@@ -633,8 +677,8 @@ func triageCompareCosDistance(x, y Point, r2 float64) int {
 func triageCompareSin2Distance(x, y Point, r2 float64) int {
 	// Only valid for distance limits < 90 degrees.
 	sin2XY, sin2XYError := sin2Distance(x, y)
-	sin2R := r2 * (1.0 - 0.25*r2)
-	sin2RError := 3.0 * dblError * sin2R
+	sin2R := float64(r2 * (1.0 - float64(0.25*r2)))
+	sin2RError := float64(3.0 * DblError * sin2R)
 	diff := sin2XY - sin2R
 	err := sin2XYError + sin2RError
 	if diff > err {
@@ -669,23 +669,23 @@ func TestPredicatesCompareDistanceCoverage(t *testing.T) {
 		},
 		{
 			x:        PointFromCoords(1, 1e-40, 0),
-			y:        PointFromCoords(1+dblEpsilon, 1e-40, 0),
-			r:        s1.ChordAngleFromAngle(0.9 * dblEpsilon * 1e-40),
+			y:        PointFromCoords(1+DblEpsilon, 1e-40, 0),
+			r:        s1.ChordAngleFromAngle(0.9 * DblEpsilon * 1e-40),
 			distFunc: triageCompareSin2Distance,
 			wantSign: 1,
 			wantPrec: exactPrecision,
 		},
 		{
 			x:        PointFromCoords(1, 1e-40, 0),
-			y:        PointFromCoords(1+dblEpsilon, 1e-40, 0),
-			r:        s1.ChordAngleFromAngle(1.1 * dblEpsilon * 1e-40),
+			y:        PointFromCoords(1+DblEpsilon, 1e-40, 0),
+			r:        s1.ChordAngleFromAngle(1.1 * DblEpsilon * 1e-40),
 			distFunc: triageCompareSin2Distance,
 			wantSign: -1,
 			wantPrec: exactPrecision,
 		},
 		{
 			x:        PointFromCoords(1, 0, 0),
-			y:        PointFromCoords(1+dblEpsilon, 0, 0),
+			y:        PointFromCoords(1+DblEpsilon, 0, 0),
 			r:        s1.ChordAngle(0),
 			distFunc: triageCompareSin2Distance,
 			wantSign: 0,
@@ -710,7 +710,7 @@ func TestPredicatesCompareDistanceCoverage(t *testing.T) {
 		},
 		{
 			x:        PointFromCoords(1, 1, 0),
-			y:        PointFromCoords(1, -1-2*dblEpsilon, 0),
+			y:        PointFromCoords(1, -1-2*DblEpsilon, 0),
 			r:        s1.RightChordAngle,
 			distFunc: triageCompareCosDistance,
 			wantSign: 1,
@@ -718,7 +718,7 @@ func TestPredicatesCompareDistanceCoverage(t *testing.T) {
 		},
 		{
 			x:        PointFromCoords(1, 1, 0),
-			y:        PointFromCoords(1, -1-dblEpsilon, 0),
+			y:        PointFromCoords(1, -1-DblEpsilon, 0),
 			r:        s1.RightChordAngle,
 			distFunc: triageCompareCosDistance,
 			wantSign: 1,
@@ -965,3 +965,55 @@ func BenchmarkRobustSignNearCollinear(b *testing.B) {
 		RobustSign(poA, poB, poC)
 	}
 }
+
+func TestPredicatesCollinear(t *testing.T) {
+	a := parsePoint("10:20")
+	tests := []struct {
+		a, b, c Point
+		want    bool
+	}{
+		{parsePoint("0:0"), parsePoint("0:10"), parsePoint("0:-170"), true},
+		{parsePoint("10:0"), parsePoint("20:0"), parsePoint("-80:0"), true},
+		{a, a, parsePoint("1:2"), true},
+		{a, parsePoint("1:2"), Point{a.Mul(-1)}, true},
+		{parsePoint("0:0"), parsePoint("0:10"), parsePoint("1e-10:20"), false},
+		{parsePoint("10:20"), parsePoint("-30:40"), parsePoint("50:-60"), false},
+		// Nearly collinear points that are only distinguished exactly.
+		{Point{r3.Vector{X: 1, Y: 0, Z: 0}}, Point{r3.Vector{X: 0, Y: 1, Z: 0}}, Point{r3.Vector{X: 1, Y: 1, Z: 1e-300}.Normalize()}, false},
+	}
+	for _, test := range tests {
+		if got := Collinear(test.a, test.b, test.c); got != test.want {
+			t.Errorf("Collinear(%v, %v, %v) = %v, want %v", test.a, test.b, test.c, got, test.want)
+		}
+		if got := NearlyCollinear(test.a, test.b, test.c, 0); got != test.want {
+			t.Errorf("NearlyCollinear(%v, %v, %v, 0) = %v, want %v", test.a, test.b, test.c, got, test.want)
+		}
+	}
+}
+
+func TestPredicatesNearlyCollinear(t *testing.T) {
+	tests := []struct {
+		a, b, c   string
+		tolerance s1.Angle
+		want      bool
+	}{
+		{"0:0", "0.5:5", "0:10", s1.Degree, true},
+		{"0:0", "0.5:5", "0:10", s1.Degree / 4, false},
+		// B beyond the end of AC still measures the distance to the great
+		// circle, not the segment.
+		{"0:0", "0.5:50", "0:10", s1.Degree, true},
+		{"0:0", "45:45", "0:0", s1.Degree, true},
+		// Very close A and C, where B is off their line by a wide margin.
+		{"0:0", "1:5", "0:1e-9", s1.Degree / 2, false},
+		{"0:0", "1e-12:5", "0:1e-9", s1.Degree / 2, true},
+	}
+	for _, test := range tests {
+		a, b, c := parsePoint(test.a), parsePoint(test.b), parsePoint(test.c)
+		if got := NearlyCollinear(a, b, c, test.tolerance); got != test.want {
+			t.Errorf("NearlyCollinear(%s, %s, %s, %v) = %v, want %v", test.a, test.b, test.c, test.tolerance, got, test.want)
+		}
+	}
+	if a := parsePoint("0:0"); !NearlyCollinear(a, parsePoint("45:45"), Point{a.Mul(-1)}, s1.Degree) {
+		t.Errorf("NearlyCollinear with antipodal A and C = false, want true")
+	}
+}
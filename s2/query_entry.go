@@ -14,8 +14,6 @@
 
 package s2
 
-import "container/heap"
-
 // A queryQueueEntry stores CellIDs and distance from a target. It is used by the
 // different S2 Query types to efficiently build their internal priority queue
 // in the optimized algorithm implementations.
@@ -36,58 +34,32 @@ type queryQueueEntry struct {
 // queryQueue is used by the optimized algorithm to maintain a priority queue of
 // unprocessed CellIDs, sorted in increasing order of distance from the target.
 type queryQueue struct {
-	queue queryPQ
+	queue *binaryHeap[*queryQueueEntry]
 }
 
 // newQueryQueue returns a new initialized queryQueue.
 func newQueryQueue() *queryQueue {
-	q := &queryQueue{
-		queue: make(queryPQ, 0),
+	return &queryQueue{
+		queue: newBinaryHeap(func(a, b *queryQueueEntry) bool {
+			return a.distance.less(b.distance)
+		}),
 	}
-	heap.Init(&q.queue)
-	return q
 }
 
 // push adds the given entry to the top of this queue.
 func (q *queryQueue) push(e *queryQueueEntry) {
-	heap.Push(&q.queue, e)
+	q.queue.push(e)
 }
 
 // pop returns the top element of this queue.
 func (q *queryQueue) pop() *queryQueueEntry {
-	return heap.Pop(&q.queue).(*queryQueueEntry)
+	return q.queue.pop()
 }
 
 func (q *queryQueue) size() int {
-	return q.queue.Len()
+	return q.queue.len()
 }
 
 func (q *queryQueue) reset() {
-	q.queue = q.queue[:0]
-}
-
-// queryPQ is a priority queue that implements the heap interface.
-type queryPQ []*queryQueueEntry
-
-func (q queryPQ) Len() int { return len(q) }
-func (q queryPQ) Less(i, j int) bool {
-	return q[i].distance.less(q[j].distance)
-}
-
-// Swap swaps the two entries.
-func (q queryPQ) Swap(i, j int) {
-	q[i], q[j] = q[j], q[i]
-}
-
-// Push adds the given entry to the queue.
-func (q *queryPQ) Push(x any) {
-	item := x.(*queryQueueEntry)
-	*q = append(*q, item)
-}
-
-// Pop returns the top element of the queue.
-func (q *queryPQ) Pop() any {
-	item := (*q)[len(*q)-1]
-	*q = (*q)[:len(*q)-1]
-	return item
+	q.queue.reset()
 }
@@ -146,6 +146,13 @@ func (q *queryOptions) DistanceLimit(x s1.ChordAngle) *queryOptions {
 	return q
 }
 
+// Region specifies that results must intersect the given Region, or nil for
+// no region limits.
+func (q *queryOptions) Region(r Region) *queryOptions {
+	q.region = r
+	return q
+}
+
 // ClosestInclusiveDistanceLimit sets the distance limit such that results whose
 // distance is exactly equal to the limit are also returned.
 func (q *queryOptions) ClosestInclusiveDistanceLimit(limit s1.ChordAngle) *queryOptions {
@@ -169,7 +176,7 @@ func (q *queryOptions) FurthestInclusiveDistanceLimit(limit s1.ChordAngle) *quer
 // option to find a set of candidate edges that can then be filtered
 // further (e.g., using CompareDistance).
 func (q *queryOptions) ClosestConservativeDistanceLimit(limit s1.ChordAngle) *queryOptions {
-	q.distanceLimit = limit.Expanded(minUpdateDistanceMaxError(limit))
+	q.distanceLimit = limit.Expanded(MinUpdateDistanceMaxError(limit))
 	return q
 }
 
@@ -178,7 +185,7 @@ func (q *queryOptions) ClosestConservativeDistanceLimit(limit s1.ChordAngle) *qu
 // edges whose true distance is greater than or equal to limit will be returned
 // (along with some edges whose true distance is slightly less).
 func (q *queryOptions) FurthestConservativeDistanceLimit(limit s1.ChordAngle) *queryOptions {
-	q.distanceLimit = limit.Expanded(-minUpdateDistanceMaxError(limit))
+	q.distanceLimit = limit.Expanded(-MinUpdateDistanceMaxError(limit))
 	return q
 }
 
@@ -0,0 +1,165 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "sort"
+
+// This file contains the generic containers shared by the query types for
+// their priority queues and result sets.
+
+// binaryHeap is a priority queue of elements ordered by the given less
+// function, with the smallest element at the top. Unlike container/heap it
+// stores the elements directly, which avoids boxing each element in an
+// interface value.
+type binaryHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// newBinaryHeap returns an empty heap using the given ordering.
+func newBinaryHeap[T any](less func(a, b T) bool) *binaryHeap[T] {
+	return &binaryHeap[T]{less: less}
+}
+
+// len returns the number of elements in the heap.
+func (h *binaryHeap[T]) len() int { return len(h.items) }
+
+// top returns the smallest element without removing it. The heap must not
+// be empty.
+func (h *binaryHeap[T]) top() T { return h.items[0] }
+
+// reset removes all elements, retaining the allocated storage.
+func (h *binaryHeap[T]) reset() { h.items = h.items[:0] }
+
+// push adds the element to the heap.
+func (h *binaryHeap[T]) push(x T) {
+	h.items = append(h.items, x)
+	h.up(len(h.items) - 1)
+}
+
+// pop removes and returns the smallest element. The heap must not be empty.
+func (h *binaryHeap[T]) pop() T {
+	n := len(h.items) - 1
+	top := h.items[0]
+	h.items[0] = h.items[n]
+	var zero T
+	h.items[n] = zero
+	h.items = h.items[:n]
+	h.down(0)
+	return top
+}
+
+// replaceTop replaces the smallest element with x, which is more efficient
+// than a pop followed by a push. The heap must not be empty.
+func (h *binaryHeap[T]) replaceTop(x T) {
+	h.items[0] = x
+	h.down(0)
+}
+
+func (h *binaryHeap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			break
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+func (h *binaryHeap[T]) down(i int) {
+	n := len(h.items)
+	for {
+		smallest := i
+		if l := 2*i + 1; l < n && h.less(h.items[l], h.items[smallest]) {
+			smallest = l
+		}
+		if r := 2*i + 2; r < n && h.less(h.items[r], h.items[smallest]) {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		h.items[i], h.items[smallest] = h.items[smallest], h.items[i]
+		i = smallest
+	}
+}
+
+// topKResults is a result set that retains at most k distinct elements,
+// namely the k smallest ones according to the given less function. Elements
+// are identified by the given key function, and adding an element whose key
+// is already present has no effect.
+//
+// Internally the elements are kept in a heap with the largest element on
+// top, so that once the set is full the element to evict (and the bound
+// that further candidates must beat) is available in constant time.
+type topKResults[T any, K comparable] struct {
+	k       int
+	less    func(a, b T) bool
+	key     func(T) K
+	heap    *binaryHeap[T]
+	members map[K]struct{}
+}
+
+// newTopKResults returns an empty result set retaining at most k elements.
+func newTopKResults[T any, K comparable](k int, less func(a, b T) bool, key func(T) K) *topKResults[T, K] {
+	return &topKResults[T, K]{
+		k:       k,
+		less:    less,
+		key:     key,
+		heap:    newBinaryHeap(func(a, b T) bool { return less(b, a) }),
+		members: make(map[K]struct{}),
+	}
+}
+
+// len returns the number of elements in the set.
+func (t *topKResults[T, K]) len() int { return t.heap.len() }
+
+// full reports whether the set contains k elements.
+func (t *topKResults[T, K]) full() bool { return t.heap.len() >= t.k }
+
+// worst returns the largest element in the set. The set must not be empty.
+func (t *topKResults[T, K]) worst() T { return t.heap.top() }
+
+// add adds x to the set if it is not already present and either the set is
+// not full or x is smaller than the largest element, which is then evicted.
+// It reports whether x was added.
+func (t *topKResults[T, K]) add(x T) bool {
+	k := t.key(x)
+	if _, ok := t.members[k]; ok || t.k <= 0 {
+		return false
+	}
+	if !t.full() {
+		t.heap.push(x)
+		t.members[k] = struct{}{}
+		return true
+	}
+	if !t.less(x, t.worst()) {
+		return false
+	}
+	delete(t.members, t.key(t.worst()))
+	t.heap.replaceTop(x)
+	t.members[k] = struct{}{}
+	return true
+}
+
+// sorted returns the elements of the set in increasing order. The set is
+// unchanged.
+func (t *topKResults[T, K]) sorted() []T {
+	result := make([]T, len(t.heap.items))
+	copy(result, t.heap.items)
+	sort.Slice(result, func(i, j int) bool { return t.less(result[i], result[j]) })
+	return result
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestBinaryHeap(t *testing.T) {
+	h := newBinaryHeap(func(a, b int) bool { return a < b })
+	var want []int
+	for i := 0; i < 200; i++ {
+		x := randomUniformInt(50)
+		h.push(x)
+		want = append(want, x)
+	}
+	sort.Ints(want)
+
+	// Replace the top with a large value and then drain the heap.
+	h.replaceTop(1000)
+	want = append(want[1:], 1000)
+
+	var got []int
+	for h.len() > 0 {
+		got = append(got, h.pop())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("heap order = %v, want %v", got, want)
+	}
+
+	h.push(3)
+	h.reset()
+	if h.len() != 0 {
+		t.Errorf("len() after reset = %d, want 0", h.len())
+	}
+}
+
+func TestTopKResults(t *testing.T) {
+	type item struct {
+		id    int
+		value float64
+	}
+	less := func(a, b item) bool {
+		if a.value != b.value {
+			return a.value < b.value
+		}
+		return a.id < b.id
+	}
+	key := func(x item) int { return x.id }
+
+	for _, k := range []int{0, 1, 5, 50} {
+		results := newTopKResults(k, less, key)
+		var all []item
+		for i := 0; i < 100; i++ {
+			x := item{randomUniformInt(40), 0}
+			x.value = float64(x.id % 7)
+			// Duplicate keys are ignored.
+			results.add(x)
+			dup := false
+			for _, y := range all {
+				if y.id == x.id {
+					dup = true
+				}
+			}
+			if !dup {
+				all = append(all, x)
+			}
+			if results.len() > k {
+				t.Fatalf("k=%d: len() = %d", k, results.len())
+			}
+		}
+
+		sort.Slice(all, func(i, j int) bool { return less(all[i], all[j]) })
+		want := all
+		if len(want) > k {
+			want = want[:k]
+		}
+		got := results.sorted()
+		if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Errorf("k=%d: sorted() = %v, want %v", k, got, want)
+		}
+		if len(want) == k && k > 0 {
+			if !results.full() {
+				t.Errorf("k=%d: full() = false, want true", k)
+			}
+			if got := results.worst(); got != want[k-1] {
+				t.Errorf("k=%d: worst() = %v, want %v", k, got, want[k-1])
+			}
+		}
+	}
+}
+
+func TestEdgeQueryMaxResultsWithMaxError(t *testing.T) {
+	// With a bounded number of results and a positive max error, the query
+	// must still return MaxResults distinct edges when enough exist.
+	index := NewShapeIndex()
+	points := PointVector(parsePoints("0:0, 0:1, 0:2, 0:3, 0:4, 0:5, 0:6, 0:7, 0:8, 0:9"))
+	index.Add(&points)
+	for _, bruteForce := range []bool{false, true} {
+		opts := NewClosestEdgeQueryOptions().
+			MaxResults(4).
+			MaxError(s1.ChordAngleFromAngle(s1.Degree)).
+			UseBruteForce(bruteForce)
+		query := NewClosestEdgeQuery(index, opts)
+		target := NewMinDistanceToPointTarget(parsePoint("0:0"))
+		results := query.FindEdges(target)
+		if len(results) != 4 {
+			t.Fatalf("bruteForce=%v: FindEdges returned %d results, want 4", bruteForce, len(results))
+		}
+		seen := make(map[int32]bool)
+		for i, r := range results {
+			if seen[r.EdgeID()] {
+				t.Errorf("bruteForce=%v: edge %d returned twice", bruteForce, r.EdgeID())
+			}
+			seen[r.EdgeID()] = true
+			if i > 0 && r.Distance() < results[i-1].Distance() {
+				t.Errorf("bruteForce=%v: results not sorted: %v", bruteForce, results)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"sort"
+
+	"github.com/golang/geo/r2"
+)
+
+// RasterEdge is the portion of a shape edge that lies within a single cell.
+// The endpoints are (u,v)-coordinates on the face containing the cell, so
+// they lie within the cell's (u,v) bound.
+type RasterEdge struct {
+	EdgeID int
+	A, B   r2.Point
+}
+
+// RasterCell is a cell intersected by one or more shape edges, together with
+// the clipped portions of those edges.
+type RasterCell struct {
+	ID    CellID
+	Edges []RasterEdge
+}
+
+// RasterizeShape returns every cell at the given level that intersects an edge
+// of the shape, along with the portion of each such edge that lies within the
+// cell, expressed in the cell's (u,v) frame. This is the basic building block
+// for grid-based algorithms such as flood fills and distance transforms.
+//
+// The cells are returned in increasing CellID order, and the edges within each
+// cell in increasing edge ID order. Cell boundaries are closed, so an edge that
+// passes along a cell boundary is reported in the cells on both sides of it.
+// Cells that intersect the interior of a polygon but none of its edges are not
+// returned. Degenerate edges, such as those of point shapes, are reported in
+// the cells that contain them.
+//
+// The level must be in the range [0, MaxLevel].
+func RasterizeShape(shape Shape, level int) []RasterCell {
+	cells := make(map[CellID][]RasterEdge)
+	for e := 0; e < shape.NumEdges(); e++ {
+		edge := shape.Edge(e)
+		for _, segment := range FaceSegments(edge.V0, edge.V1) {
+			pcell := PaddedCellFromCellID(CellIDFromFace(segment.face), 0)
+			bound := r2.RectFromPoints(segment.a, segment.b)
+			rasterizeEdge(cells, pcell, level, e, segment.a, segment.b, bound)
+		}
+	}
+
+	result := make([]RasterCell, 0, len(cells))
+	for id, edges := range cells {
+		result = append(result, RasterCell{ID: id, Edges: edges})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// rasterizeEdge adds the portions of the edge AB that lie within the
+// descendants of pcell at the given level to cells. The bound must be a
+// tight bound of the portion of AB within pcell.
+func rasterizeEdge(cells map[CellID][]RasterEdge, pcell *PaddedCell, level, edgeID int, a, b r2.Point, bound r2.Rect) {
+	if pcell.Level() == level {
+		// Extract the clipped endpoints from the bound, in the same way
+		// as ClipEdge.
+		ai, aj := 0, 0
+		if a.X > b.X {
+			ai = 1
+		}
+		if a.Y > b.Y {
+			aj = 1
+		}
+		id := pcell.CellID()
+		cells[id] = append(cells[id], RasterEdge{
+			EdgeID: edgeID,
+			A:      bound.VertexIJ(ai, aj),
+			B:      bound.VertexIJ(1-ai, 1-aj),
+		})
+		return
+	}
+
+	for pos := 0; pos < 4; pos++ {
+		i, j := pcell.ChildIJ(pos)
+		child := PaddedCellFromParentIJ(pcell, i, j)
+		if childBound, ok := ClipEdgeBound(a, b, child.Bound(), bound); ok {
+			rasterizeEdge(cells, child, level, edgeID, a, b, childBound)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r2"
+	"github.com/golang/geo/s1"
+)
+
+func TestRasterizeShapeSingleCell(t *testing.T) {
+	id := CellIDFromFace(1).ChildBeginAtLevel(10)
+	a := id.ChildBeginAtLevel(20).Point()
+	b := id.ChildEndAtLevel(20).Prev().Point()
+	line := &Polyline{a, b}
+
+	cells := RasterizeShape(line, 10)
+	if len(cells) != 1 || cells[0].ID != id {
+		t.Fatalf("RasterizeShape(%v, 10) = %v, want only cell %v", line, cells, id)
+	}
+	if len(cells[0].Edges) != 1 {
+		t.Fatalf("RasterizeShape(%v, 10) edges = %v, want 1 edge", line, cells[0].Edges)
+	}
+	_, au, av := xyzToFaceUV(a.Vector)
+	_, bu, bv := xyzToFaceUV(b.Vector)
+	got := cells[0].Edges[0]
+	if got.EdgeID != 0 || got.A != (r2.Point{au, av}) || got.B != (r2.Point{bu, bv}) {
+		t.Errorf("RasterizeShape(%v, 10) edge = %v, want {0 %v %v}", line, got, r2.Point{au, av}, r2.Point{bu, bv})
+	}
+}
+
+func TestRasterizeShapeEmpty(t *testing.T) {
+	if got := RasterizeShape(&Polyline{}, 5); len(got) != 0 {
+		t.Errorf("RasterizeShape(empty, 5) = %v, want empty", got)
+	}
+}
+
+func TestRasterizeShapeMatchesEdges(t *testing.T) {
+	index := makeShapeIndex("0:0 | 45:45 # 10:-5, 10:20, -20:100 # 60:60, 60:62, 62:62, 62:60")
+	const level = 8
+	for _, shape := range index.shapes {
+		cells := RasterizeShape(shape, level)
+
+		edgesByCell := make(map[CellID]map[int]bool)
+		for i, c := range cells {
+			if i > 0 && cells[i-1].ID >= c.ID {
+				t.Errorf("cells not in increasing order: %v then %v", cells[i-1].ID, c.ID)
+			}
+			if c.ID.Level() != level {
+				t.Errorf("cell %v has level %d, want %d", c.ID, c.ID.Level(), level)
+			}
+			cell := CellFromCellID(c.ID)
+			bound := cell.BoundUV()
+			edgesByCell[c.ID] = make(map[int]bool)
+			for j, e := range c.Edges {
+				if j > 0 && c.Edges[j-1].EdgeID >= e.EdgeID {
+					t.Errorf("cell %v edges not in increasing order: %v", c.ID, c.Edges)
+				}
+				edgesByCell[c.ID][e.EdgeID] = true
+				if !bound.ContainsPoint(e.A) || !bound.ContainsPoint(e.B) {
+					t.Errorf("cell %v bound %v does not contain clipped edge %v", c.ID, bound, e)
+				}
+				edge := shape.Edge(e.EdgeID)
+				if d := cell.DistanceToEdge(edge.V0, edge.V1); d > s1.ChordAngleFromAngle(1e-14) {
+					t.Errorf("cell %v is %v from edge %d, want intersecting", c.ID, d.Angle(), e.EdgeID)
+				}
+			}
+		}
+
+		// Every point along each edge must be in a returned cell listing
+		// that edge.
+		for e := 0; e < shape.NumEdges(); e++ {
+			edge := shape.Edge(e)
+			for k := 0; k <= 100; k++ {
+				p := Interpolate(float64(k)/100, edge.V0, edge.V1)
+				id := cellIDFromPoint(p).Parent(level)
+				if !edgesByCell[id][e] {
+					t.Errorf("point %v on edge %d is in cell %v, which does not list the edge", p, e, id)
+				}
+			}
+		}
+	}
+}
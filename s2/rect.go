@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/rand"
 
 	"github.com/golang/geo/r1"
 	"github.com/golang/geo/r3"
@@ -709,5 +710,20 @@ func (r Rect) Centroid() Point {
 	return Point{r3.Vector{r0 * math.Cos(lng), r0 * math.Sin(lng), z}}
 }
 
+// SamplePoint returns a point chosen uniformly at random (with respect to area
+// on the sphere) from the rectangle, using the given source of randomness.
+// The rectangle must not be empty.
+func (r Rect) SamplePoint(rng *rand.Rand) Point {
+	// First choose a latitude uniformly with respect to area on the sphere.
+	sinLo := math.Sin(r.Lat.Lo)
+	sinHi := math.Sin(r.Lat.Hi)
+	lat := math.Asin(sinLo + rng.Float64()*(sinHi-sinLo))
+
+	// Now choose longitude uniformly within the given range.
+	lng := r.Lng.Lo + rng.Float64()*r.Lng.Length()
+
+	return PointFromLatLng(LatLng{s1.Angle(lat), s1.Angle(lng)}.Normalized())
+}
+
 // BUG: The major differences from the C++ version are:
 //  - Get*Distance, Vertex, InteriorContains(LatLng|Rect|Point)
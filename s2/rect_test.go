@@ -16,6 +16,7 @@ package s2
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/golang/geo/r1"
@@ -1206,3 +1207,33 @@ func TestRectCentroidFullRange(t *testing.T) {
 	// line of longitude.
 	testRectCentroidSplitting(t, Rect{r1.Interval{-math.Pi / 2, math.Pi / 2}, s1.Interval{-math.Pi, math.Pi}}, 10)
 }
+
+func TestRectSamplePoint(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tests := []Rect{
+		rectFromDegrees(-10, 20, 30, 40),
+		rectFromDegrees(60, 170, 89, -170), // inverted longitude interval
+		{r1.Interval{-math.Pi / 2, -1}, s1.FullInterval()},
+		FullRect(),
+	}
+	for _, r := range tests {
+		// Points must be inside the rect, and their mean must approach the
+		// area-weighted centroid.
+		const n = 20000
+		var sum r3.Vector
+		for i := 0; i < n; i++ {
+			p := r.SamplePoint(rng)
+			if !p.IsUnit() {
+				t.Errorf("%v.SamplePoint() = %v, want unit length", r, p)
+			}
+			if !r.ContainsPoint(p) {
+				t.Errorf("%v.ContainsPoint(%v) = false, want true", r, p)
+			}
+			sum = sum.Add(p.Vector)
+		}
+		want := r.Centroid().Mul(1 / r.Area())
+		if got := sum.Mul(1.0 / n); got.Sub(want).Norm() > 0.02 {
+			t.Errorf("mean of %v.SamplePoint() = %v, want ~%v", r, got, want)
+		}
+	}
+}
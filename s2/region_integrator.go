@@ -0,0 +1,115 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+)
+
+// RegionIntegrator numerically integrates a scalar function over a region
+// using adaptive quadrature on the cell hierarchy. This is useful for
+// computing quantities such as population or rainfall totals over regions
+// given a density function defined on the sphere.
+//
+// Typical usage:
+//
+//	ri := &s2.RegionIntegrator{MaxLevel: 12, Tolerance: 1e-6}
+//	total := ri.Integrate(polygon, func(p s2.Point) float64 { return density(p) })
+//
+// The result is the integral of f with respect to area on the unit sphere
+// (i.e. in steradians); multiply by the square of the sphere radius to
+// obtain the integral with respect to the area on a sphere of that radius.
+//
+// Cells that are entirely contained by the region are integrated by
+// comparing the midpoint rule over the cell with the midpoint rule over its
+// four children, and subdividing until the two estimates agree to within the
+// cell's share of Tolerance. Cells on the boundary of the region are always
+// subdivided down to MaxLevel, where they are counted if the region contains
+// the cell center. The accuracy near the boundary is therefore determined by
+// MaxLevel, and the number of function evaluations grows with the length of
+// the boundary.
+type RegionIntegrator struct {
+	MinLevel int // cells are always subdivided to at least this level.
+	MaxLevel int // the finest cell level used.
+
+	// Tolerance is the absolute error allowed over the whole sphere, which
+	// is apportioned to each interior cell in proportion to its area.
+	Tolerance float64
+}
+
+// NewRegionIntegrator returns a region integrator with the default settings.
+func NewRegionIntegrator() *RegionIntegrator {
+	return &RegionIntegrator{
+		MinLevel:  0,
+		MaxLevel:  10,
+		Tolerance: 1e-9,
+	}
+}
+
+// Integrate returns the integral of f over the given region.
+func (ri *RegionIntegrator) Integrate(region Region, f func(Point) float64) float64 {
+	sum := 0.0
+	for face := 0; face < 6; face++ {
+		cell := CellFromCellID(CellIDFromFace(face))
+		sum += ri.integrateCell(region, f, cell, ri.estimate(f, cell), false)
+	}
+	return sum
+}
+
+// estimate returns the midpoint rule estimate of the integral of f over the cell.
+func (ri *RegionIntegrator) estimate(f func(Point) float64, cell Cell) float64 {
+	return f(cell.Center()) * cell.ExactArea()
+}
+
+// integrateCell returns the integral of f over the intersection of the
+// region with the given cell, given the midpoint estimate for the cell. If
+// contained is true, the cell is known to be contained by the region.
+func (ri *RegionIntegrator) integrateCell(region Region, f func(Point) float64, cell Cell, estimate float64, contained bool) float64 {
+	if !contained {
+		if !region.IntersectsCell(cell) {
+			return 0
+		}
+		contained = region.ContainsCell(cell)
+	}
+
+	level := cell.Level()
+	if level >= ri.MaxLevel || cell.IsLeaf() {
+		if contained || region.ContainsPoint(cell.Center()) {
+			return estimate
+		}
+		return 0
+	}
+
+	children, _ := cell.Children()
+	var estimates [4]float64
+	refined := 0.0
+	for i, child := range children {
+		estimates[i] = ri.estimate(f, child)
+		refined += estimates[i]
+	}
+
+	if contained && level >= ri.MinLevel {
+		tolerance := ri.Tolerance * cell.ExactArea() / (4 * math.Pi)
+		if math.Abs(refined-estimate) <= tolerance {
+			return refined
+		}
+	}
+
+	sum := 0.0
+	for i, child := range children {
+		sum += ri.integrateCell(region, f, child, estimates[i], contained)
+	}
+	return sum
+}
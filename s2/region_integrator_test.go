@@ -0,0 +1,75 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestRegionIntegratorConstant(t *testing.T) {
+	one := func(Point) float64 { return 1 }
+
+	ri := NewRegionIntegrator()
+	if got, want := ri.Integrate(FullCap(), one), 4*math.Pi; !float64Near(got, want, 1e-13) {
+		t.Errorf("Integrate(FullCap(), 1) = %v, want %v", got, want)
+	}
+
+	// Cell unions are integrated exactly, since every cell is either
+	// contained or disjoint.
+	cu := CellUnion{CellIDFromFace(1).ChildBeginAtLevel(3), CellIDFromFace(4).ChildBeginAtLevel(5)}
+	want := CellFromCellID(cu[0]).ExactArea() + CellFromCellID(cu[1]).ExactArea()
+	if got := ri.Integrate(&cu, one); !float64Near(got, want, 1e-15) {
+		t.Errorf("Integrate(%v, 1) = %v, want %v", cu, got, want)
+	}
+
+	// For general regions the error is limited by MaxLevel.
+	c := CapFromCenterAngle(PointFromCoords(1, 2, 3), 10*s1.Degree)
+	if got, want := ri.Integrate(c, one), c.Area(); !float64Near(got, want, 1e-4) {
+		t.Errorf("Integrate(%v, 1) = %v, want %v", c, got, want)
+	}
+}
+
+func TestRegionIntegratorLinear(t *testing.T) {
+	z := func(p Point) float64 { return p.Z }
+	ri := &RegionIntegrator{MaxLevel: 8, Tolerance: 1e-6}
+
+	// The integral of z over the northern hemisphere is pi.
+	north := CapFromCenterAngle(PointFromCoords(0, 0, 1), 90*s1.Degree)
+	if got, want := ri.Integrate(north, z), math.Pi; !float64Near(got, want, 1e-3) {
+		t.Errorf("Integrate(northern hemisphere, z) = %v, want %v", got, want)
+	}
+
+	// By symmetry, the integral of z over the whole sphere is zero.
+	if got := ri.Integrate(FullCap(), z); !float64Near(got, 0, 1e-6) {
+		t.Errorf("Integrate(FullCap(), z) = %v, want 0", got)
+	}
+}
+
+func TestRegionIntegratorPolygon(t *testing.T) {
+	p := makePolygon("0:0, 0:10, 10:10, 10:0; 2:2, 2:4, 4:4, 4:2", true)
+	ri := &RegionIntegrator{MaxLevel: 12, Tolerance: 1e-12}
+	got := ri.Integrate(p, func(Point) float64 { return 2 })
+	if want := 2 * p.Area(); !float64Near(got, want, 1e-4) {
+		t.Errorf("Integrate(%v, 2) = %v, want %v", p, got, want)
+	}
+
+	// Empty regions have a zero integral.
+	if got := ri.Integrate(EmptyCap(), func(Point) float64 { return 1 }); got != 0 {
+		t.Errorf("Integrate(EmptyCap(), 1) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+// RegionLookup finds the polygons that contain a point, for a fixed set of
+// polygons keyed by region ID. It combines two CellLookup tables with the
+// polygons themselves:
+//
+//   - a point inside the interior covering of a polygon is contained by it
+//     without further checks;
+//   - a point inside the covering but not the interior covering of a
+//     polygon is a candidate, which is checked against the polygon;
+//   - any other point is not contained by the polygon.
+//
+// Only points near polygon boundaries need exact tests, so lookups take
+// little more time than a CellLookup while giving exact answers. Points on
+// polygon boundaries are handled as by Polygon.ContainsPoint.
+//
+// A RegionLookup is immutable once built and safe for concurrent use. The
+// polygons must not be modified while it is in use.
+type RegionLookup struct {
+	coverings *CellLookup
+	interiors *CellLookup
+	polygons  map[int32]*Polygon
+}
+
+// NewRegionLookup returns a RegionLookup for the given polygons, keyed by
+// region ID. The coverings are computed with the given coverer; if it is
+// nil, a default with MaxCells 16 is used. More cells make the coverings
+// tighter, so fewer points need exact tests, at the cost of more memory.
+func NewRegionLookup(polygons map[int32]*Polygon, coverer *RegionCoverer) *RegionLookup {
+	if coverer == nil {
+		coverer = &RegionCoverer{MaxLevel: MaxLevel, LevelMod: 1, MaxCells: 16}
+	}
+	coverings := make(map[int32]CellUnion, len(polygons))
+	interiors := make(map[int32]CellUnion, len(polygons))
+	l := &RegionLookup{polygons: make(map[int32]*Polygon, len(polygons))}
+	for id, p := range polygons {
+		coverings[id] = coverer.Covering(p)
+		interiors[id] = coverer.InteriorCovering(p)
+		l.polygons[id] = p
+	}
+	l.coverings = NewCellLookup(coverings)
+	l.interiors = NewCellLookup(interiors)
+	return l
+}
+
+// ContainingRegions returns the IDs of the polygons that contain the given
+// point, in increasing order.
+func (l *RegionLookup) ContainingRegions(p Point) []int32 {
+	id := cellIDFromPoint(p)
+	candidates := l.coverings.RegionsContainingCell(id)
+	interior := l.interiors.RegionsContainingCell(id)
+	// Both lists are sorted. Every interior region should also be a
+	// candidate, but a point on a cell boundary may be assigned to a leaf
+	// cell outside the covering, so the lists are merged.
+	var ids []int32
+	for _, c := range candidates {
+		for len(interior) > 0 && interior[0] < c {
+			ids = append(ids, interior[0])
+			interior = interior[1:]
+		}
+		if len(interior) > 0 && interior[0] == c {
+			ids = append(ids, c)
+			interior = interior[1:]
+		} else if l.polygons[c].ContainsPoint(p) {
+			ids = append(ids, c)
+		}
+	}
+	return append(ids, interior...)
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRegionLookup(t *testing.T) {
+	polygons := map[int32]*Polygon{
+		1: makePolygon("0:0, 0:10, 10:10, 10:0", true),
+		2: makePolygon("5:5, 5:15, 15:15, 15:5", true),
+		3: makePolygon("0:0, 0:20, 20:20, 20:0; 2:2, 18:2, 18:18, 2:18", true),
+		4: makePolygon("-5:-5, -5:-1, -1:-1, -1:-5", true),
+		5: makePolygon("", true),
+	}
+	for _, coverer := range []*RegionCoverer{nil, {MaxLevel: 10, LevelMod: 1, MaxCells: 4}} {
+		l := NewRegionLookup(polygons, coverer)
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 2000; i++ {
+			p := PointFromLatLng(LatLngFromDegrees(-10+35*r.Float64(), -10+35*r.Float64()))
+			var want []int32
+			for id := int32(1); id <= 5; id++ {
+				if polygons[id].ContainsPoint(p) {
+					want = append(want, id)
+				}
+			}
+			if got := l.ContainingRegions(p); !reflect.DeepEqual(got, want) {
+				t.Errorf("ContainingRegions(%v) = %v, want %v", p, got, want)
+			}
+		}
+
+		// Polygon vertices are contained under the semi-open model.
+		for _, v := range []string{"5:5", "10:10", "2:2"} {
+			p := parsePoint(v)
+			var want []int32
+			for id := int32(1); id <= 5; id++ {
+				if polygons[id].ContainsPoint(p) {
+					want = append(want, id)
+				}
+			}
+			if got := l.ContainingRegions(p); !reflect.DeepEqual(got, want) {
+				t.Errorf("ContainingRegions(%v) = %v, want %v", v, got, want)
+			}
+		}
+	}
+}
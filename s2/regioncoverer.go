@@ -16,6 +16,7 @@ package s2
 
 import (
 	"container/heap"
+	"context"
 	"sort"
 )
 
@@ -77,6 +78,10 @@ type RegionCoverer struct {
 	MaxLevel int // the maximum cell level to be used.
 	LevelMod int // the LevelMod to be used.
 	MaxCells int // the maximum desired number of cells in the approximation.
+
+	// Cache, if non-nil, holds previously computed coverings that are
+	// reused by Covering, InteriorCovering, CellUnion, and InteriorCellUnion.
+	Cache *CoveringCache
 }
 
 // NewRegionCoverer returns a region coverer with the appropriate defaults.
@@ -98,8 +103,31 @@ type coverer struct {
 	result           CellUnion
 	pq               priorityQueue
 	interiorCovering bool
+	cancel           cancelChecker
+	stats            CovererStats
+	progress         func(CovererStats)
+}
+
+// CovererStats reports statistics about the work done to compute a covering,
+// which can be used to log and tune the covering parameters.
+type CovererStats struct {
+	// CellsTested is the number of cells tested for intersection with or
+	// containment by the region. This is usually the dominant cost.
+	CellsTested int
+	// CandidatesCreated is the number of cells that intersected the region
+	// and were considered for the covering.
+	CandidatesCreated int
+	// CandidatesExpanded is the number of candidates that were subdivided.
+	CandidatesExpanded int
+	// ResultCells is the number of cells in the covering. It is only set in
+	// the final statistics.
+	ResultCells int
 }
 
+// covererProgressInterval is the number of candidates expanded between
+// calls to the progress function of a covering.
+const covererProgressInterval = 64
+
 type candidate struct {
 	cell        Cell
 	terminal    bool         // Cell should not be expanded further.
@@ -138,22 +166,35 @@ func (pq *priorityQueue) Reset() {
 	*pq = (*pq)[:0]
 }
 
+// intersectsCell reports whether the region intersects the given cell.
+func (c *coverer) intersectsCell(cell Cell) bool {
+	c.stats.CellsTested++
+	return c.region.IntersectsCell(cell)
+}
+
+// containsCell reports whether the region contains the given cell.
+func (c *coverer) containsCell(cell Cell) bool {
+	c.stats.CellsTested++
+	return c.region.ContainsCell(cell)
+}
+
 // newCandidate returns a new candidate with no children if the cell intersects the given region.
 // The candidate is marked as terminal if it should not be expanded further.
 func (c *coverer) newCandidate(cell Cell) *candidate {
-	if !c.region.IntersectsCell(cell) {
+	if !c.intersectsCell(cell) {
 		return nil
 	}
+	c.stats.CandidatesCreated++
 	cand := &candidate{cell: cell}
 	level := int(cell.level)
 	if level >= c.minLevel {
 		if c.interiorCovering {
-			if c.region.ContainsCell(cell) {
+			if c.containsCell(cell) {
 				cand.terminal = true
 			} else if level+c.levelMod > c.MaxLevel {
 				return nil
 			}
-		} else if level+c.levelMod > c.MaxLevel || c.region.ContainsCell(cell) {
+		} else if level+c.levelMod > c.MaxLevel || c.containsCell(cell) {
 			cand.terminal = true
 		}
 	}
@@ -169,7 +210,7 @@ func (c *coverer) expandChildren(cand *candidate, cell Cell, numLevels int) int
 	for ci := cell.id.ChildBegin(); ci != last; ci = ci.Next() {
 		childCell := CellFromCellID(ci)
 		if numLevels > 0 {
-			if c.region.IntersectsCell(childCell) {
+			if c.intersectsCell(childCell) {
 				numTerminals += c.expandChildren(cand, childCell, numLevels)
 			}
 			continue
@@ -205,6 +246,10 @@ func (c *coverer) addCandidate(cand *candidate) {
 		numLevels = 1
 	}
 
+	c.stats.CandidatesExpanded++
+	if c.progress != nil && c.stats.CandidatesExpanded%covererProgressInterval == 0 {
+		c.progress(c.stats)
+	}
 	numTerminals := c.expandChildren(cand, cand.cell, numLevels)
 	maxChildrenShift := uint(2 * c.levelMod)
 	if cand.numChildren == 0 {
@@ -296,6 +341,18 @@ func (c *coverer) coveringInternal(region Region) {
 
 	c.initialCandidates()
 	for c.pq.Len() > 0 && (!c.interiorCovering || len(c.result) < c.maxCells) {
+		if c.cancel.canceled() {
+			// The cells remaining in the queue together with the result still
+			// cover the region, so an exterior covering remains valid (though
+			// coarser than it would otherwise be). An interior covering is
+			// valid as it is.
+			if !c.interiorCovering {
+				for _, cand := range c.pq {
+					c.result = append(c.result, cand.cell.id)
+				}
+			}
+			break
+		}
 		cand := heap.Pop(&c.pq).(*candidate)
 
 		// For interior covering we keep subdividing no matter how many children
@@ -357,6 +414,113 @@ func (rc *RegionCoverer) InteriorCovering(region Region) CellUnion {
 	return intCovering
 }
 
+// CoveringWithStats is like Covering, but also returns statistics about the
+// work done. If progress is non-nil, it is called periodically with the
+// statistics so far while the covering is being computed.
+func (rc *RegionCoverer) CoveringWithStats(region Region, progress func(CovererStats)) (CellUnion, CovererStats) {
+	c := rc.newCoverer()
+	c.progress = progress
+	c.coveringInternal(region)
+	covering := c.result
+	covering.Normalize()
+	covering.Denormalize(c.minLevel, c.levelMod)
+	c.stats.ResultCells = len(covering)
+	return covering, c.stats
+}
+
+// CoveringQuality describes how closely a covering approximates a region,
+// which can be used to choose MaxCells and the level limits without trial
+// and error.
+type CoveringQuality struct {
+	// NumCells is the number of cells in the covering.
+	NumCells int
+	// MinLevel and MaxLevel are the smallest and largest levels of the
+	// cells in the covering, or zero if the covering is empty.
+	MinLevel, MaxLevel int
+	// CoveringArea is the area of the covering in steradians.
+	CoveringArea float64
+	// RegionArea is the area of the region in steradians, or zero if it
+	// cannot be computed for this type of region.
+	RegionArea float64
+	// AreaRatio is CoveringArea divided by RegionArea, which is at least 1
+	// for a covering and measures how much it over-approximates the region.
+	// It is zero if RegionArea is zero.
+	AreaRatio float64
+}
+
+// EvaluateCovering returns the quality of the given covering of the region.
+// The area of the region is known for Cap, Rect, Cell, CellUnion, Loop and
+// Polygon regions, and for any other region that has an Area method.
+func EvaluateCovering(region Region, covering CellUnion) CoveringQuality {
+	q := CoveringQuality{
+		NumCells:     len(covering),
+		CoveringArea: covering.ExactArea(),
+		RegionArea:   regionArea(region),
+	}
+	for i, id := range covering {
+		level := id.Level()
+		if i == 0 || level < q.MinLevel {
+			q.MinLevel = level
+		}
+		if level > q.MaxLevel {
+			q.MaxLevel = level
+		}
+	}
+	if q.RegionArea > 0 {
+		q.AreaRatio = q.CoveringArea / q.RegionArea
+	}
+	return q
+}
+
+// regionArea returns the area of the region, or zero if it is unknown.
+func regionArea(region Region) float64 {
+	switch r := region.(type) {
+	case Cell:
+		return r.ExactArea()
+	case *CellUnion:
+		return r.ExactArea()
+	case interface{ Area() float64 }:
+		return r.Area()
+	}
+	return 0
+}
+
+// CoveringWithQuality is like Covering, but also returns the quality of the
+// covering as computed by EvaluateCovering.
+func (rc *RegionCoverer) CoveringWithQuality(region Region) (CellUnion, CoveringQuality) {
+	covering := rc.Covering(region)
+	return covering, EvaluateCovering(region, covering)
+}
+
+// CoveringContext is like Covering, but stops refining the covering if the
+// given context is canceled. In that case it returns the context's error
+// along with a covering that is still guaranteed to cover the region, but
+// that may be much coarser than usual and may not respect MaxCells.
+func (rc *RegionCoverer) CoveringContext(ctx context.Context, region Region) (CellUnion, error) {
+	c := rc.newCoverer()
+	c.cancel.ctx = ctx
+	c.coveringInternal(region)
+	covering := c.result
+	covering.Normalize()
+	covering.Denormalize(c.minLevel, c.levelMod)
+	return covering, c.cancel.err
+}
+
+// InteriorCoveringContext is like InteriorCovering, but stops refining the
+// covering if the given context is canceled. In that case it returns the
+// context's error along with the cells found so far, which are contained
+// within the region but may cover much less of it than usual.
+func (rc *RegionCoverer) InteriorCoveringContext(ctx context.Context, region Region) (CellUnion, error) {
+	c := rc.newCoverer()
+	c.interiorCovering = true
+	c.cancel.ctx = ctx
+	c.coveringInternal(region)
+	covering := c.result
+	covering.Normalize()
+	covering.Denormalize(c.minLevel, c.levelMod)
+	return covering, c.cancel.err
+}
+
 // CellUnion returns a normalized CellUnion that covers the given region and
 // satisfies the restrictions except for minLevel and levelMod. These criteria
 // cannot be satisfied using a cell union because cell unions are
@@ -364,6 +528,9 @@ func (rc *RegionCoverer) InteriorCovering(region Region) CellUnion {
 // whenever possible. (Note that the list of cell ids passed to the CellUnion
 // constructor does in fact satisfy all the given restrictions.)
 func (rc *RegionCoverer) CellUnion(region Region) CellUnion {
+	if rc.Cache != nil {
+		return rc.cachedCellUnion(region, false)
+	}
 	c := rc.newCoverer()
 	c.coveringInternal(region)
 	cu := c.result
@@ -378,6 +545,9 @@ func (rc *RegionCoverer) CellUnion(region Region) CellUnion {
 // whenever possible. (Note that the list of cell ids passed to the CellUnion
 // constructor does in fact satisfy all the given restrictions.)
 func (rc *RegionCoverer) InteriorCellUnion(region Region) CellUnion {
+	if rc.Cache != nil {
+		return rc.cachedCellUnion(region, true)
+	}
 	c := rc.newCoverer()
 	c.interiorCovering = true
 	c.coveringInternal(region)
@@ -613,3 +783,87 @@ func FloodFillRegionCovering(region Region, start CellID) []CellID {
 
 	return output
 }
+
+// RegionCellIterator visits the cells at a fixed level that intersect a
+// region, one at a time and in increasing order of CellID, without
+// materializing the covering. It descends from the face cells, pruning cells
+// that do not intersect the region, so its memory use is proportional to
+// the level rather than to the number of cells visited. This makes it
+// suitable for coverings that are too large to hold in memory.
+//
+// The cells visited are those at the level that the region's IntersectsCell
+// reports as intersecting, which for a connected region are the same cells
+// that SimpleRegionCovering returns. Iterate over them with:
+//
+//	for it := NewRegionCellIterator(region, level); !it.Done(); it.Next() {
+//		... it.CellID() ...
+//	}
+type RegionCellIterator struct {
+	region Region
+	level  int
+
+	// stack holds the cells still to be examined, with the next one last.
+	stack []CellID
+	// When a cell is contained by the region, its descendants at the target
+	// level are visited without further tests, from rangeNext up to but not
+	// including rangeEnd.
+	rangeNext, rangeEnd CellID
+
+	id   CellID
+	done bool
+}
+
+// NewRegionCellIterator returns an iterator over the cells at the given
+// level that intersect the region, positioned at the first such cell.
+func NewRegionCellIterator(region Region, level int) *RegionCellIterator {
+	it := &RegionCellIterator{
+		region: region,
+		level:  level,
+		stack:  make([]CellID, 0, 3*level+NumFaces),
+	}
+	for face := NumFaces - 1; face >= 0; face-- {
+		it.stack = append(it.stack, CellIDFromFace(face))
+	}
+	it.Next()
+	return it
+}
+
+// CellID returns the current cell. It is only valid if Done is false.
+func (it *RegionCellIterator) CellID() CellID { return it.id }
+
+// Done reports whether all the cells have been visited.
+func (it *RegionCellIterator) Done() bool { return it.done }
+
+// Next advances the iterator to the next cell.
+func (it *RegionCellIterator) Next() {
+	if it.rangeNext != it.rangeEnd {
+		it.id = it.rangeNext
+		it.rangeNext = it.rangeNext.Next()
+		return
+	}
+	for len(it.stack) > 0 {
+		id := it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+
+		cell := CellFromCellID(id)
+		if !it.region.IntersectsCell(cell) {
+			continue
+		}
+		if id.Level() >= it.level {
+			it.id = id
+			return
+		}
+		if it.region.ContainsCell(cell) {
+			it.id = id.ChildBeginAtLevel(it.level)
+			it.rangeNext, it.rangeEnd = it.id.Next(), id.ChildEndAtLevel(it.level)
+			return
+		}
+		// Push the children in reverse order so that they are visited in
+		// increasing order.
+		children := id.Children()
+		for i := 3; i >= 0; i-- {
+			it.stack = append(it.stack, children[i])
+		}
+	}
+	it.done = true
+}
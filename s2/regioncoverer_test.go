@@ -19,6 +19,7 @@ import (
 	"math"
 	"math/rand"
 	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -355,7 +356,58 @@ func BenchmarkRegionCovererCoveringCellUnion(b *testing.B) {
 		})
 }
 
-// TODO(roberts): Add more benchmarking that changes the values in the coverer (min/max level, # cells).
+func BenchmarkRegionCovererCoveringMaxCells(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	regions := make([]Region, numCoveringBMRegions)
+	for i := range regions {
+		regions[i] = RegularLoop(randomPointFromRand(rng), kmToAngle(10.0), 256)
+	}
+
+	for _, maxCells := range []int{4, 8, 16, 64, 256, 1024} {
+		rc := &RegionCoverer{MinLevel: 0, MaxLevel: 30, LevelMod: 1, MaxCells: maxCells}
+		b.Run(fmt.Sprintf("MaxCells-%d", maxCells), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				rc.Covering(regions[i%len(regions)])
+			}
+		})
+	}
+}
+
+func BenchmarkRegionCovererInteriorCovering(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	regions := make([]Region, numCoveringBMRegions)
+	for i := range regions {
+		regions[i] = RegularLoop(randomPointFromRand(rng), kmToAngle(10.0), 256)
+	}
+
+	// As recommended for interior coverings, limit the maximum level.
+	for _, maxLevel := range []int{10, 15, 20} {
+		rc := &RegionCoverer{MinLevel: 0, MaxLevel: maxLevel, LevelMod: 1, MaxCells: 8}
+		b.Run(fmt.Sprintf("MaxLevel-%d", maxLevel), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				rc.InteriorCovering(regions[i%len(regions)])
+			}
+		})
+	}
+}
+
+func BenchmarkRegionCovererCoveringCached(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	regions := make([]Region, numCoveringBMRegions)
+	for i := range regions {
+		regions[i] = RegularLoop(randomPointFromRand(rng), kmToAngle(10.0), 256)
+	}
+
+	// All the regions fit in the cache, so after the first pass every
+	// covering is a cache hit.
+	rc := &RegionCoverer{MinLevel: 0, MaxLevel: 30, LevelMod: 1, MaxCells: 8, Cache: NewCoveringCache(numCoveringBMRegions)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rc.Covering(regions[i%len(regions)])
+	}
+}
+
+// TODO(roberts): Add more benchmarking that changes the min and max levels in the coverer.
 
 // benchmark Covering using the supplied func to generate a slice of random Regions of
 // the given type to choose from for the benchmark.
@@ -389,3 +441,108 @@ func benchmarkRegionCovererCovering(b *testing.B, genLabel func(n int) string, g
 //  func TestRegionCovererCanonicalizeCoveringDenormalizedCellUnion(t *testing.T) {
 //  func TestRegionCovererCanonicalizeCoveringMaxCellsMergesSmallest(t *testing.T) {
 //  func TestRegionCovererCanonicalizeCoveringMaxCellsMergesRepeatedly(t *testing.T) {
+
+func TestRegionCovererCoveringWithStats(t *testing.T) {
+	rc := &RegionCoverer{MaxLevel: 30, MaxCells: 100}
+	region := Region(CapFromCenterAngle(PointFromLatLng(LatLngFromDegrees(-30, 60)), 0.05))
+
+	var calls int
+	var last CovererStats
+	covering, stats := rc.CoveringWithStats(region, func(s CovererStats) {
+		calls++
+		if s.CandidatesExpanded < last.CandidatesExpanded || s.CellsTested < last.CellsTested {
+			t.Errorf("progress went backwards: %+v after %+v", s, last)
+		}
+		last = s
+	})
+
+	if want := rc.Covering(region); !covering.Equal(want) {
+		t.Errorf("CoveringWithStats() = %v, want %v", covering, want)
+	}
+	if stats.ResultCells != len(covering) {
+		t.Errorf("stats.ResultCells = %d, want %d", stats.ResultCells, len(covering))
+	}
+	if stats.CandidatesCreated == 0 || stats.CellsTested < stats.CandidatesCreated {
+		t.Errorf("stats = %+v, want CellsTested >= CandidatesCreated > 0", stats)
+	}
+	if want := stats.CandidatesExpanded / covererProgressInterval; calls != want {
+		t.Errorf("progress called %d times, want %d (stats %+v)", calls, want, stats)
+	}
+
+	// The progress function is optional.
+	if _, stats := rc.CoveringWithStats(region, nil); stats.ResultCells != len(covering) {
+		t.Errorf("CoveringWithStats(nil progress).ResultCells = %d, want %d", stats.ResultCells, len(covering))
+	}
+}
+
+func TestRegionCovererCoveringWithQuality(t *testing.T) {
+	region := Region(CapFromCenterAngle(PointFromLatLng(LatLngFromDegrees(-30, 60)), 0.05))
+	prevRatio := math.Inf(1)
+	for _, maxCells := range []int{4, 16, 64} {
+		rc := &RegionCoverer{MinLevel: 3, MaxLevel: 20, MaxCells: maxCells}
+		covering, q := rc.CoveringWithQuality(region)
+		if want := rc.Covering(region); !covering.Equal(want) {
+			t.Errorf("CoveringWithQuality() = %v, want %v", covering, want)
+		}
+		if q.NumCells != len(covering) || q.NumCells > maxCells {
+			t.Errorf("maxCells=%d: NumCells = %d, want %d", maxCells, q.NumCells, len(covering))
+		}
+		if q.MinLevel < rc.MinLevel || q.MaxLevel > rc.MaxLevel || q.MinLevel > q.MaxLevel {
+			t.Errorf("maxCells=%d: levels [%d, %d] outside [%d, %d]", maxCells, q.MinLevel, q.MaxLevel, rc.MinLevel, rc.MaxLevel)
+		}
+		if got, want := q.RegionArea, region.(Cap).Area(); got != want {
+			t.Errorf("maxCells=%d: RegionArea = %v, want %v", maxCells, got, want)
+		}
+		if q.AreaRatio < 1 || q.AreaRatio > prevRatio {
+			t.Errorf("maxCells=%d: AreaRatio = %v, want in [1, %v]", maxCells, q.AreaRatio, prevRatio)
+		}
+		prevRatio = q.AreaRatio
+	}
+
+	// Regions of unknown area report no ratio.
+	q := EvaluateCovering(RegionUnion{region}, CellUnion{CellIDFromFace(0)})
+	if q.RegionArea != 0 || q.AreaRatio != 0 || q.MinLevel != 0 || q.MaxLevel != 0 {
+		t.Errorf("EvaluateCovering(RegionUnion) = %+v, want no region area", q)
+	}
+	if q := EvaluateCovering(region, nil); q != (CoveringQuality{RegionArea: region.(Cap).Area()}) {
+		t.Errorf("EvaluateCovering(empty) = %+v", q)
+	}
+}
+
+func TestRegionCellIterator(t *testing.T) {
+	for _, test := range []struct {
+		region Region
+		level  int
+	}{
+		{CapFromCenterAngle(PointFromLatLng(LatLngFromDegrees(10, 20)), 0.05), 7},
+		// A cap spanning several faces.
+		{CapFromCenterAngle(PointFromCoords(1, 1, 1), 0.3), 6},
+		{RectFromLatLng(LatLngFromDegrees(-10, -10)).AddPoint(LatLngFromDegrees(5, 30)), 5},
+		{makePolygon("0:0, 0:3, 3:0", true), 6},
+		{FullCap(), 3},
+		{EmptyCap(), 3},
+	} {
+		var got []CellID
+		for it := NewRegionCellIterator(test.region, test.level); !it.Done(); it.Next() {
+			if id := it.CellID(); id.Level() != test.level {
+				t.Errorf("RegionCellIterator(%v, %d) visited %v at level %d", test.region, test.level, id, id.Level())
+			}
+			got = append(got, it.CellID())
+		}
+		if !sort.SliceIsSorted(got, func(i, j int) bool { return got[i] < got[j] }) {
+			t.Errorf("RegionCellIterator(%v, %d) visited cells out of order", test.region, test.level)
+		}
+
+		// Compare with a brute force search over every cell at the level.
+		var want []CellID
+		end := CellIDFromFace(5).ChildEndAtLevel(test.level)
+		for id := CellIDFromFace(0).ChildBeginAtLevel(test.level); id != end; id = id.Next() {
+			if test.region.IntersectsCell(CellFromCellID(id)) {
+				want = append(want, id)
+			}
+		}
+		if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Errorf("RegionCellIterator(%v, %d) visited %d cells, want %d", test.region, test.level, len(got), len(want))
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"sync/atomic"
+)
+
+// RobustnessEvent identifies a situation in which a computation had to take a
+// slower or more conservative path in order to produce a robust result. These
+// are normally rare, so a high rate of events points at input geometry that
+// is numerically delicate, such as many exactly collinear or nearly
+// antipodal points.
+type RobustnessEvent int
+
+const (
+	// ExactArithmeticFallback means that a predicate or construction could
+	// not be decided with floating-point arithmetic and fell back to much
+	// slower exact arithmetic.
+	ExactArithmeticFallback RobustnessEvent = iota
+
+	// SymbolicPerturbation means that exact arithmetic found a degenerate
+	// configuration, such as three exactly collinear points, which was
+	// resolved using symbolic perturbations.
+	SymbolicPerturbation
+
+	// FullRectBound means that a latitude-longitude bound was expanded to
+	// the full rectangle because of nearly antipodal points.
+	FullRectBound
+)
+
+func (e RobustnessEvent) String() string {
+	switch e {
+	case ExactArithmeticFallback:
+		return "ExactArithmeticFallback"
+	case SymbolicPerturbation:
+		return "SymbolicPerturbation"
+	case FullRectBound:
+		return "FullRectBound"
+	}
+	return "RobustnessEvent(unknown)"
+}
+
+// RobustnessHook is a function that is called with each RobustnessEvent and
+// the name of the operation in which it occurred, such as "CompareDistances".
+// It may be called concurrently from multiple goroutines, and should return
+// quickly, for example by incrementing a counter.
+type RobustnessHook func(event RobustnessEvent, operation string)
+
+// robustnessHook holds the current RobustnessHook, which may be nil.
+var robustnessHook atomic.Value
+
+// SetRobustnessHook sets the function that is called when a RobustnessEvent
+// occurs anywhere in this package, and returns the previous one. A nil hook,
+// which is the default, disables reporting. This is intended for monitoring
+// numerical robustness in production, e.g. by exporting counters or traces.
+func SetRobustnessHook(hook RobustnessHook) RobustnessHook {
+	prev, _ := robustnessHook.Swap(hook).(RobustnessHook)
+	return prev
+}
+
+// reportRobustness calls the current RobustnessHook, if any.
+func reportRobustness(event RobustnessEvent, operation string) {
+	if hook, _ := robustnessHook.Load().(RobustnessHook); hook != nil {
+		hook(event, operation)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"reflect"
+	"testing"
+)
+
+// robustnessEventCount is a RobustnessEvent and operation pair for counting.
+type robustnessEventCount struct {
+	event     RobustnessEvent
+	operation string
+}
+
+func TestRobustnessHook(t *testing.T) {
+	counts := make(map[robustnessEventCount]int)
+	prev := SetRobustnessHook(func(event RobustnessEvent, operation string) {
+		counts[robustnessEventCount{event, operation}]++
+	})
+	defer SetRobustnessHook(prev)
+
+	// Three distinct points on the equator are exactly collinear.
+	a := PointFromCoords(1, 0, 0)
+	b := PointFromCoords(1, 1, 0)
+	c := PointFromCoords(0, 1, 0)
+	RobustSign(a, b, c)
+
+	// Two points at exactly the same distance from x.
+	x := PointFromCoords(0, 0, 1)
+	CompareDistances(x, a, c)
+
+	// An edge between antipodal points can go in any direction.
+	bounder := NewRectBounder()
+	bounder.AddPoint(a)
+	bounder.AddPoint(Point{a.Mul(-1)})
+
+	want := map[robustnessEventCount]int{
+		{ExactArithmeticFallback, "Sign"}:             1,
+		{SymbolicPerturbation, "Sign"}:                1,
+		{ExactArithmeticFallback, "CompareDistances"}: 1,
+		{SymbolicPerturbation, "CompareDistances"}:    1,
+		{FullRectBound, "RectBounder.AddPoint"}:       1,
+	}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("robustness events = %v, want %v", counts, want)
+	}
+
+	// Predicates that can be decided with floating-point arithmetic report
+	// nothing, and neither does anything after the hook is removed.
+	counts = make(map[robustnessEventCount]int)
+	RobustSign(a, c, x)
+	if len(counts) != 0 {
+		t.Errorf("robustness events for a non-degenerate predicate = %v, want none", counts)
+	}
+	if got := SetRobustnessHook(nil); got == nil {
+		t.Errorf("SetRobustnessHook(nil) returned a nil previous hook")
+	}
+	RobustSign(a, b, c)
+	if len(counts) != 0 {
+		t.Errorf("robustness events without a hook = %v, want none", counts)
+	}
+}
+
+func TestRobustnessEventString(t *testing.T) {
+	tests := []struct {
+		event RobustnessEvent
+		want  string
+	}{
+		{ExactArithmeticFallback, "ExactArithmeticFallback"},
+		{SymbolicPerturbation, "SymbolicPerturbation"},
+		{FullRectBound, "FullRectBound"},
+		{RobustnessEvent(-1), "RobustnessEvent(unknown)"},
+	}
+	for _, test := range tests {
+		if got := test.event.String(); got != test.want {
+			t.Errorf("%d.String() = %q, want %q", int(test.event), got, test.want)
+		}
+	}
+}
@@ -212,8 +212,8 @@ func r1IntervalsApproxEqual(a, b r1.Interval, epsilon float64) bool {
 }
 
 var (
-	rectErrorLat = 10 * dblEpsilon
-	rectErrorLng = dblEpsilon
+	rectErrorLat = 10 * DblEpsilon
+	rectErrorLng = DblEpsilon
 )
 
 // r2PointsApproxEqual reports whether the two points are within the given epsilon.
@@ -304,7 +304,7 @@ func perturbATowardsB(a, b Point) Point {
 		// Return a point that is exactly proportional to A and that still
 		// satisfies IsUnitLength().
 		for {
-			b := Point{a.Mul(2 - a.Norm() + 5*(randomFloat64()-0.5)*dblEpsilon)}
+			b := Point{a.Mul(2 - a.Norm() + 5*(randomFloat64()-0.5)*DblEpsilon)}
 			if !b.ApproxEqual(a) && b.IsUnit() {
 				return b
 			}
@@ -314,9 +314,9 @@ func perturbATowardsB(a, b Point) Point {
 		// Return a point such that the distance squared to A will underflow.
 		return InterpolateAtDistance(1e-300, a, b)
 	}
-	// Otherwise return a point whose distance from A is near dblEpsilon such
+	// Otherwise return a point whose distance from A is near DblEpsilon such
 	// that the log of the pdf is uniformly distributed.
-	distance := dblEpsilon * 1e-5 * math.Pow(1e6, randomFloat64())
+	distance := DblEpsilon * 1e-5 * math.Pow(1e6, randomFloat64())
 	return InterpolateAtDistance(s1.Angle(distance), a, b)
 }
 
@@ -335,7 +335,7 @@ func perturbedCornerOrMidpoint(p, q Point) Point {
 	} else if oneIn(2) {
 		// For coordinates near 1 (say > 0.5), this perturbation yields values
 		// that are only a few representable values away from the initial value.
-		a = a.Add(randomPoint().Mul(4 * dblEpsilon))
+		a = a.Add(randomPoint().Mul(4 * DblEpsilon))
 	} else {
 		// A perturbation whose magnitude is in the range [1e-25, 1e-10].
 		a = a.Add(randomPoint().Mul(1e-10 * math.Pow(1e-15, randomFloat64())))
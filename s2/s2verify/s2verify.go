@@ -0,0 +1,131 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package s2verify checks invariants that s2 geometry is expected to satisfy.
+// These are the cross-checks used by the s2 tests, packaged so that programs
+// which produce geometry can validate their outputs, for example in
+// continuous integration. Each check returns nil if the invariant holds, and
+// otherwise an error describing the first violation found.
+//
+// The checks are relatively expensive, and are not meant to be run on every
+// geometry in production.
+package s2verify
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/geo/s2"
+)
+
+// areaTolerance is the maximum difference allowed between the area of a loop
+// and the area implied by its turning angle.
+const areaTolerance = 1e-9
+
+// AreaConsistentWithTurningAngle checks that the area of the loop is
+// consistent with its turning angle. According to the Gauss-Bonnet theorem,
+// the area of a loop is equal to 2*Pi minus its turning angle.
+func AreaConsistentWithTurningAngle(l *s2.Loop) error {
+	if area, gaussArea := l.Area(), 2*math.Pi-l.TurningAngle(); math.Abs(area-gaussArea) > areaTolerance {
+		return fmt.Errorf("s2verify: loop area %v differs from 2*Pi minus its turning angle %v", area, gaussArea)
+	}
+	return nil
+}
+
+// PolygonAreaConsistentWithTurningAngle checks that the area of each loop of
+// the polygon is consistent with its turning angle.
+func PolygonAreaConsistentWithTurningAngle(p *s2.Polygon) error {
+	for i, l := range p.Loops() {
+		if area, gaussArea := l.Area(), 2*math.Pi-l.TurningAngle(); math.Abs(area-gaussArea) > areaTolerance {
+			return fmt.Errorf("s2verify: loop %d area %v differs from 2*Pi minus its turning angle %v", i, area, gaussArea)
+		}
+	}
+	return nil
+}
+
+// ContainsConsistentWithCrossings checks that the containment of each of the
+// given points by a shape with an interior agrees with the parity of the
+// number of shape edges crossed by the segment from the shape's reference
+// point to the point. Containment is computed by a ContainsPointQuery with
+// the semi-open vertex model, and also by the ContainsPoint method of the
+// shape if it has one (as Loops and Polygons do).
+//
+// Shapes of dimension 0 or 1 contain no points in the semi-open model, so
+// for them the check is that no point is contained.
+func ContainsConsistentWithCrossings(shape s2.Shape, points []s2.Point) error {
+	index := s2.NewShapeIndex()
+	index.Add(shape)
+	query := s2.NewContainsPointQuery(index, s2.VertexModelSemiOpen)
+	containsPointer, hasContainsPoint := shape.(interface{ ContainsPoint(s2.Point) bool })
+
+	for _, p := range points {
+		want := crossingsContain(shape, p)
+		if got := query.Contains(p); got != want {
+			return fmt.Errorf("s2verify: ContainsPointQuery.Contains(%v) = %v, but the edge crossings give %v", p, got, want)
+		}
+		if hasContainsPoint {
+			if got := containsPointer.ContainsPoint(p); got != want {
+				return fmt.Errorf("s2verify: ContainsPoint(%v) = %v, but the edge crossings give %v", p, got, want)
+			}
+		}
+	}
+	return nil
+}
+
+// crossingsContain reports whether the shape contains p by counting the edges
+// crossed by the segment from the shape's reference point to p.
+func crossingsContain(shape s2.Shape, p s2.Point) bool {
+	if shape.Dimension() != 2 {
+		return false
+	}
+	ref := shape.ReferencePoint()
+	if ref.Point == p {
+		return ref.Contained
+	}
+	inside := ref.Contained
+	crosser := s2.NewEdgeCrosser(ref.Point, p)
+	for e := 0; e < shape.NumEdges(); e++ {
+		edge := shape.Edge(e)
+		if crosser.EdgeOrVertexCrossing(edge.V0, edge.V1) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// BoundsContainRegion checks that the bounds of the region contain each of
+// the given points that the region contains. The bounds checked are the
+// RectBound, the CapBound, and the cells of the CellUnionBound.
+func BoundsContainRegion(region s2.Region, points []s2.Point) error {
+	rect := region.RectBound()
+	c := region.CapBound()
+	cells := s2.CellUnion(region.CellUnionBound())
+	cells.Normalize()
+
+	for _, p := range points {
+		if !region.ContainsPoint(p) {
+			continue
+		}
+		if !rect.ContainsPoint(p) {
+			return fmt.Errorf("s2verify: RectBound %v does not contain the contained point %v", rect, p)
+		}
+		if !c.ContainsPoint(p) {
+			return fmt.Errorf("s2verify: CapBound %v does not contain the contained point %v", c, p)
+		}
+		if !cells.ContainsPoint(p) {
+			return fmt.Errorf("s2verify: CellUnionBound does not contain the contained point %v", p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2verify
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
+// testPoints returns the vertices of the given loops together with random
+// points, half of which are sampled near the first vertex of the first loop.
+func testPoints(loops ...*s2.Loop) []s2.Point {
+	rng := rand.New(rand.NewSource(1))
+	var points []s2.Point
+	for _, l := range loops {
+		points = append(points, l.Vertices()...)
+	}
+	near := s2.CapFromCenterAngle(loops[0].Vertex(0), 0.1*s1.Radian)
+	for i := 0; i < 200; i++ {
+		p := s2.PointFromCoords(rng.NormFloat64(), rng.NormFloat64(), rng.NormFloat64())
+		if i%2 == 0 {
+			p = s2.Point{Vector: near.Center().Add(p.Mul(0.1)).Normalize()}
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+func testLoops() []*s2.Loop {
+	center := s2.PointFromLatLng(s2.LatLngFromDegrees(40, -70))
+	return []*s2.Loop{
+		s2.EmptyLoop(),
+		s2.FullLoop(),
+		s2.RegularLoop(center, 10*s1.Degree, 20),
+		s2.LoopFromPoints([]s2.Point{
+			s2.PointFromLatLng(s2.LatLngFromDegrees(0, 0)),
+			s2.PointFromLatLng(s2.LatLngFromDegrees(0, 90)),
+			s2.PointFromLatLng(s2.LatLngFromDegrees(45, 45)),
+		}),
+	}
+}
+
+func TestValidGeometry(t *testing.T) {
+	for _, l := range testLoops() {
+		if err := AreaConsistentWithTurningAngle(l); err != nil {
+			t.Errorf("AreaConsistentWithTurningAngle(%v) = %v", l, err)
+		}
+		points := testPoints(l, testLoops()[2])
+		if err := ContainsConsistentWithCrossings(l, points); err != nil {
+			t.Errorf("ContainsConsistentWithCrossings(%v) = %v", l, err)
+		}
+		if err := BoundsContainRegion(l, points); err != nil {
+			t.Errorf("BoundsContainRegion(%v) = %v", l, err)
+		}
+	}
+
+	outer := s2.RegularLoop(s2.PointFromCoords(1, 1, 1), 10*s1.Degree, 12)
+	inner := s2.RegularLoop(s2.PointFromCoords(1, 1, 1), 5*s1.Degree, 7)
+	p := s2.PolygonFromLoops([]*s2.Loop{outer, inner})
+	if err := PolygonAreaConsistentWithTurningAngle(p); err != nil {
+		t.Errorf("PolygonAreaConsistentWithTurningAngle(%v) = %v", p, err)
+	}
+	points := testPoints(outer, inner)
+	if err := ContainsConsistentWithCrossings(p, points); err != nil {
+		t.Errorf("ContainsConsistentWithCrossings(%v) = %v", p, err)
+	}
+	if err := BoundsContainRegion(p, points); err != nil {
+		t.Errorf("BoundsContainRegion(%v) = %v", p, err)
+	}
+
+	polyline := s2.Polyline(outer.Vertices())
+	if err := ContainsConsistentWithCrossings(&polyline, points); err != nil {
+		t.Errorf("ContainsConsistentWithCrossings(polyline) = %v", err)
+	}
+	c := s2.CapFromCenterAngle(s2.PointFromCoords(1, 1, 1), 10*s1.Degree)
+	if err := BoundsContainRegion(c, points); err != nil {
+		t.Errorf("BoundsContainRegion(%v) = %v", c, err)
+	}
+}
+
+// complementLoop is a loop that claims to contain the points that it does not.
+type complementLoop struct {
+	*s2.Loop
+}
+
+func (l complementLoop) ContainsPoint(p s2.Point) bool { return !l.Loop.ContainsPoint(p) }
+
+// pointBoundedLoop is a loop whose bounds only contain one of its vertices.
+type pointBoundedLoop struct {
+	*s2.Loop
+}
+
+func (l pointBoundedLoop) CapBound() s2.Cap { return s2.CapFromPoint(l.Vertex(0)) }
+func (l pointBoundedLoop) RectBound() s2.Rect {
+	return s2.RectFromLatLng(s2.LatLngFromPoint(l.Vertex(0)))
+}
+
+func TestInvalidGeometry(t *testing.T) {
+	l := testLoops()[2]
+	points := testPoints(l)
+
+	if err := ContainsConsistentWithCrossings(complementLoop{l}, points); err == nil {
+		t.Errorf("ContainsConsistentWithCrossings with an incorrect ContainsPoint = nil, want an error")
+	}
+	if err := BoundsContainRegion(pointBoundedLoop{l}, points); err == nil {
+		t.Errorf("BoundsContainRegion with incorrect bounds = nil, want an error")
+	}
+
+	// A self-intersecting loop has a turning angle of zero, since the turns
+	// at the crossing cancel out, but its area is not 2*Pi.
+	bowtie := s2.LoopFromPoints([]s2.Point{
+		s2.PointFromLatLng(s2.LatLngFromDegrees(0, 0)),
+		s2.PointFromLatLng(s2.LatLngFromDegrees(10, 10)),
+		s2.PointFromLatLng(s2.LatLngFromDegrees(0, 10)),
+		s2.PointFromLatLng(s2.LatLngFromDegrees(10, 0)),
+	})
+	if err := AreaConsistentWithTurningAngle(bowtie); err == nil {
+		t.Errorf("AreaConsistentWithTurningAngle(%v) = nil, want an error", bowtie)
+	}
+}
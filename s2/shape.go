@@ -129,6 +129,11 @@ const (
 
 	// The minimum allowable tag for future user-defined Shape types.
 	typeTagMinUser typeTag = 8192
+
+	// Tags for Shape types that exist only in this library. They are
+	// allocated downwards from typeTagMinUser so that they do not collide
+	// with tags added to the C++ library in the future.
+	typeTagMultiPolyline typeTag = typeTagMinUser - 1
 )
 
 // Shape represents polygonal geometry in a flexible way. It is organized as a
@@ -41,13 +41,13 @@ const (
 	// cellPadding defines the total error when clipping an edge which comes
 	// from two sources:
 	// (1) Clipping the original spherical edge to a cube face (the face edge).
-	//     The maximum error in this step is faceClipErrorUVCoord.
+	//     The maximum error in this step is FaceClipErrorUVCoord.
 	// (2) Clipping the face edge to the u- or v-coordinate of a cell boundary.
-	//     The maximum error in this step is edgeClipErrorUVCoord.
+	//     The maximum error in this step is EdgeClipErrorUVCoord.
 	// Finally, since we encounter the same errors when clipping query edges, we
 	// double the total error so that we only need to pad edges during indexing
 	// and not at query time.
-	cellPadding = 2.0 * (faceClipErrorUVCoord + edgeClipErrorUVCoord)
+	cellPadding = 2.0 * (FaceClipErrorUVCoord + EdgeClipErrorUVCoord)
 
 	// cellSizeToLongEdgeRatio defines the cell size relative to the length of an
 	// edge at which it is first considered to be long. Long edges do not
@@ -208,7 +208,9 @@ type ShapeIndexIterator struct {
 }
 
 // NewShapeIndexIterator creates a new iterator for the given index. If a starting
-// position is specified, the iterator is positioned at the given spot.
+// position is specified, the iterator is positioned at the given spot. An
+// iterator created without a starting position does not build the index, so
+// Build should be called first if the index may have pending updates.
 func NewShapeIndexIterator(index *ShapeIndex, pos ...ShapeIndexIteratorPos) *ShapeIndexIterator {
 	s := &ShapeIndexIterator{
 		index: index,
@@ -289,7 +291,10 @@ func (s *ShapeIndexIterator) Prev() bool {
 
 // End positions the iterator at the end of the index.
 func (s *ShapeIndexIterator) End() {
-	s.position = len(s.index.cells)
+	if !s.index.IsFresh() {
+		s.index.maybeApplyUpdates()
+	}
+	s.position = s.index.numCells()
 	s.refresh()
 }
 
@@ -300,9 +305,9 @@ func (s *ShapeIndexIterator) Done() bool {
 
 // refresh updates the stored internal iterator values.
 func (s *ShapeIndexIterator) refresh() {
-	if s.position < len(s.index.cells) {
-		s.id = s.index.cells[s.position]
-		s.cell = s.index.cellMap[s.CellID()]
+	if s.position < s.index.numCells() {
+		s.id = s.index.cellID(s.position)
+		s.cell = s.index.indexCell(s.position)
 	} else {
 		s.id = SentinelCellID
 		s.cell = nil
@@ -312,8 +317,8 @@ func (s *ShapeIndexIterator) refresh() {
 // seek positions the iterator at the first cell whose ID >= target, or at the
 // end of the index if no such cell exists.
 func (s *ShapeIndexIterator) seek(target CellID) {
-	s.position = sort.Search(len(s.index.cells), func(i int) bool {
-		return s.index.cells[i] >= target
+	s.position = sort.Search(s.index.numCells(), func(i int) bool {
+		return s.index.cellID(i) >= target
 	})
 	s.refresh()
 }
@@ -533,9 +538,10 @@ func (t *tracker) restoreStateBefore(limitShapeID int32) {
 	t.savedIDs = nil
 }
 
-// lowerBound returns the shapeID of the first entry x where x >= shapeID.
-func (t *tracker) lowerBound(shapeID int32) int32 {
-	panic("not implemented")
+// lowerBound returns the position of the first entry x in the sorted set of
+// tracked shape IDs where x >= shapeID.
+func (t *tracker) lowerBound(shapeID int32) int {
+	return sort.Search(len(t.shapeIDs), func(i int) bool { return t.shapeIDs[i] >= shapeID })
 }
 
 // removedShape represents a set of edges from the given shape that is queued for removal.
@@ -568,6 +574,15 @@ const (
 // be indexed easily. You can find useful query methods in CrossingEdgeQuery
 // and ClosestEdgeQuery (Not yet implemented in Go).
 //
+// The index is built lazily, by the first query after shapes are added or
+// removed (or by Build). Any number of goroutines may query the index at
+// once, including before it has been built: the pending updates are applied
+// exactly once, and the other goroutines wait for them to finish. Methods
+// that modify the index (Add, Remove, Reset and Decode) must not be called
+// concurrently with any other method. Query types such as ContainsPointQuery
+// and ShapeIndexIterator are not safe for concurrent use, so each goroutine
+// should create its own.
+//
 // Example showing how to build an index of Polylines:
 //
 //	index := NewShapeIndex()
@@ -594,6 +609,16 @@ type ShapeIndex struct {
 	// Track the ordered list of cell IDs.
 	cells []CellID
 
+	// encoded holds the cells of an index created by NewEncodedShapeIndex,
+	// which are decoded on demand. If it is non-nil, cellMap and cells are
+	// unused until the index is updated.
+	encoded *encodedCells
+
+	// decodeErr is the first error found when decoding a cell of encoded,
+	// which is reported by DecodeErr.
+	decodeErrMu sync.Mutex
+	decodeErr   error
+
 	// The current status of the index; accessed atomically.
 	status int32
 
@@ -679,9 +704,39 @@ func (s *ShapeIndex) Reset() {
 	s.nextID = 0
 	s.cellMap = make(map[CellID]*ShapeIndexCell)
 	s.cells = nil
+	s.encoded = nil
+	s.decodeErrMu.Lock()
+	s.decodeErr = nil
+	s.decodeErrMu.Unlock()
+	s.pendingAdditionsPos = 0
+	s.pendingRemovals = nil
 	atomic.StoreInt32(&s.status, fresh)
 }
 
+// numCells returns the number of cells in the index.
+func (s *ShapeIndex) numCells() int {
+	if s.encoded != nil {
+		return s.encoded.numCells
+	}
+	return len(s.cells)
+}
+
+// cellID returns the ID of the i-th cell of the index.
+func (s *ShapeIndex) cellID(i int) CellID {
+	if s.encoded != nil {
+		return s.encoded.cellID(i)
+	}
+	return s.cells[i]
+}
+
+// indexCell returns the contents of the i-th cell of the index.
+func (s *ShapeIndex) indexCell(i int) *ShapeIndexCell {
+	if s.encoded != nil {
+		return s.encodedIndexCell(i)
+	}
+	return s.cellMap[s.cells[i]]
+}
+
 // NumEdges returns the number of edges in this index.
 func (s *ShapeIndex) NumEdges() int {
 	numEdges := 0
@@ -812,18 +867,28 @@ func (s *ShapeIndex) isShapeBeingRemoved(shapeID int32) bool {
 }
 
 // maybeApplyUpdates checks if the index pieces have changed, and if so, applies pending updates.
+// It is safe to call from multiple goroutines at once: the first one to find
+// the index stale applies the updates while holding the mutex, and the others
+// wait for it to finish.
 func (s *ShapeIndex) maybeApplyUpdates() {
-	// TODO(roberts): To avoid acquiring and releasing the mutex on every
-	// query, we should use atomic operations when testing whether the status
-	// is fresh and when updating the status to be fresh. This guarantees
-	// that any thread that sees a status of fresh will also see the
-	// corresponding index updates.
-	if atomic.LoadInt32(&s.status) != fresh {
-		s.mu.Lock()
-		s.applyUpdatesInternal()
-		atomic.StoreInt32(&s.status, fresh)
-		s.mu.Unlock()
+	// To avoid acquiring and releasing the mutex on every query, we use
+	// atomic operations when testing whether the status is fresh and when
+	// updating the status to be fresh. This guarantees that any goroutine
+	// that sees a status of fresh will also see the corresponding index
+	// updates.
+	if atomic.LoadInt32(&s.status) == fresh {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Another goroutine may have applied the updates while this one was
+	// waiting for the lock.
+	if atomic.LoadInt32(&s.status) == fresh {
+		return
 	}
+	atomic.StoreInt32(&s.status, updating)
+	s.applyUpdatesInternal()
+	atomic.StoreInt32(&s.status, fresh)
 }
 
 // applyUpdatesInternal does the actual work of updating the index by applying all
@@ -833,6 +898,12 @@ func (s *ShapeIndex) applyUpdatesInternal() {
 	// edge as the final index memory size. If this causes issues, add in
 	// batched updating to limit the amount of items per batch to a
 	// configurable memory footprint overhead.
+	// An index backed by an encoding must be fully decoded before it can
+	// be updated.
+	if s.encoded != nil {
+		s.decodeAllCells()
+	}
+
 	t := newTracker()
 
 	// allEdges maps a Face to a collection of faceEdges.
@@ -842,7 +913,8 @@ func (s *ShapeIndex) applyUpdatesInternal() {
 		s.removeShapeInternal(p, allEdges, t)
 	}
 
-	for id := s.pendingAdditionsPos; id < int32(len(s.shapes)); id++ {
+	// Shape IDs are not reused, so removed shapes leave gaps below nextID.
+	for id := s.pendingAdditionsPos; id < s.nextID; id++ {
 		s.addShapeInternal(id, allEdges, t)
 	}
 
@@ -850,8 +922,18 @@ func (s *ShapeIndex) applyUpdatesInternal() {
 		s.updateFaceEdges(face, allEdges[face], t)
 	}
 
+	if !s.isFirstUpdate() {
+		// Incremental updates delete absorbed cells from the map and create
+		// new cells out of order, so rebuild the sorted list of cell ids.
+		s.cells = s.cells[:0]
+		for id := range s.cellMap {
+			s.cells = append(s.cells, id)
+		}
+		sort.Slice(s.cells, func(i, j int) bool { return s.cells[i] < s.cells[j] })
+	}
+
 	s.pendingRemovals = s.pendingRemovals[:0]
-	s.pendingAdditionsPos = int32(len(s.shapes))
+	s.pendingAdditionsPos = s.nextID
 	// It is the caller's responsibility to update the index status.
 }
 
@@ -969,8 +1051,9 @@ func (s *ShapeIndex) shrinkToFit(pcell *PaddedCell, bound r2.Rect) CellID {
 
 	if !s.isFirstUpdate() && shrunkID != pcell.CellID() {
 		// Don't shrink any smaller than the existing index cells, since we need
-		// to combine the new edges with those cells.
-		iter := s.Iterator()
+		// to combine the new edges with those cells. The index is being
+		// updated, so the iterator must not try to apply the updates itself.
+		iter := NewShapeIndexIterator(s)
 		if iter.LocateCellID(shrunkID) == Indexed {
 			shrunkID = iter.CellID()
 		}
@@ -1023,15 +1106,16 @@ func (s *ShapeIndex) updateEdges(pcell *PaddedCell, edges []*clippedEdge, t *tra
 	if !disjointFromIndex {
 		// There may be existing index cells contained inside pcell. If we
 		// encounter such a cell, we need to combine the edges being updated with
-		// the existing cell contents by absorbing the cell.
-		iter := s.Iterator()
+		// the existing cell contents by absorbing the cell. As above, the
+		// iterator must not try to apply the updates in progress.
+		iter := NewShapeIndexIterator(s)
 		r := iter.LocateCellID(pcell.id)
 		if r == Disjoint {
 			disjointFromIndex = true
 		} else if r == Indexed {
 			// Absorb the index cell by transferring its contents to edges and
 			// deleting it. We also start tracking the interior of any new shapes.
-			s.absorbIndexCell(pcell, iter, edges, t)
+			edges = s.absorbIndexCell(pcell, iter, edges, t)
 			indexCellAbsorbed = true
 			disjointFromIndex = true
 		} else {
@@ -1236,9 +1320,13 @@ func (s *ShapeIndex) makeIndexCell(p *PaddedCell, edges []*clippedEdge, t *track
 		cell.shapes[i] = clipped
 	}
 
-	// Add this cell to the map.
+	// Add this cell to the map. During the first update cells are created in
+	// increasing order, so they can be appended directly to the list of cell
+	// ids; incremental updates rebuild the list once they are finished.
 	s.cellMap[p.id] = cell
-	s.cells = append(s.cells, p.id)
+	if s.isFirstUpdate() {
+		s.cells = append(s.cells, p.id)
+	}
 
 	// Shift the tracker focus point to the exit vertex of this cell.
 	if t.isActive && len(edges) != 0 {
@@ -1355,8 +1443,9 @@ func (s *ShapeIndex) clipVAxis(edge *clippedEdge, middle r1.Interval) (a, b *cli
 // absorbIndexCell absorbs an index cell by transferring its contents to edges
 // and/or "tracker", and then delete this cell from the index. If edges includes
 // any edges that are being removed, this method also updates their
-// InteriorTracker state to correspond to the exit vertex of this cell.
-func (s *ShapeIndex) absorbIndexCell(p *PaddedCell, iter *ShapeIndexIterator, edges []*clippedEdge, t *tracker) {
+// InteriorTracker state to correspond to the exit vertex of this cell, and
+// returns the updated set of edges.
+func (s *ShapeIndex) absorbIndexCell(p *PaddedCell, iter *ShapeIndexIterator, edges []*clippedEdge, t *tracker) []*clippedEdge {
 	// When we absorb a cell, we erase all the edges that are being removed.
 	// However when we are finished with this cell, we want to restore the state
 	// of those edges (since that is how we find all the index cells that need
@@ -1413,12 +1502,8 @@ func (s *ShapeIndex) absorbIndexCell(p *PaddedCell, iter *ShapeIndexIterator, ed
 		// cell is inside the shape, but we only know whether the center of the
 		// cell is inside the shape, so we need to test all the edges against the
 		// line segment from the cell center to the entry vertex.
-		edge := &faceEdge{
-			shapeID:     shapeID,
-			hasInterior: shape.Dimension() == 2,
-		}
-
-		if edge.hasInterior {
+		hasInterior := shape.Dimension() == 2
+		if hasInterior {
 			t.addShape(shapeID, clipped.containsCenter)
 			// There might not be any edges in this entire cell (i.e., it might be
 			// in the interior of all shapes), so we delay updating the tracker
@@ -1432,8 +1517,12 @@ func (s *ShapeIndex) absorbIndexCell(p *PaddedCell, iter *ShapeIndexIterator, ed
 		}
 		for i := 0; i < numClipped; i++ {
 			edgeID := clipped.edges[i]
-			edge.edgeID = edgeID
-			edge.edge = shape.Edge(edgeID)
+			edge := &faceEdge{
+				shapeID:     shapeID,
+				edgeID:      edgeID,
+				edge:        shape.Edge(edgeID),
+				hasInterior: hasInterior,
+			}
 			edge.MaxLevel = maxLevelForEdge(edge.edge)
 			if edge.hasInterior {
 				t.testEdge(shapeID, edge.edge)
@@ -1451,7 +1540,7 @@ func (s *ShapeIndex) absorbIndexCell(p *PaddedCell, iter *ShapeIndexIterator, ed
 	for _, faceEdge := range faceEdges {
 		clipped := &clippedEdge{
 			faceEdge: faceEdge,
-			bound:    clippedEdgeBound(faceEdge.a, faceEdge.b, p.bound),
+			bound:    ClippedEdgeBound(faceEdge.a, faceEdge.b, p.bound),
 		}
 		newEdges = append(newEdges, clipped)
 	}
@@ -1465,10 +1554,10 @@ func (s *ShapeIndex) absorbIndexCell(p *PaddedCell, iter *ShapeIndexIterator, ed
 		}
 	}
 
-	// Update the edge list and delete this cell from the index.
-	edges, newEdges = newEdges, edges
+	// Delete this cell from the index and return the new edge list. The
+	// sorted list of cell ids is rebuilt once all updates have been applied.
 	delete(s.cellMap, p.id)
-	// TODO(roberts): delete from s.Cells
+	return newEdges
 }
 
 // testAllEdges calls the trackers testEdge on all edges from shapes that have interiors.
@@ -23,9 +23,24 @@ type ShapeIndexRegion struct {
 	iter          *ShapeIndexIterator
 }
 
-// TODO(roberts): Uncomment once implementation is complete.
 // Enforce Region interface satisfaction similar to other types that implement Region.
-// var _ Region = (*ShapeIndexRegion)(nil)
+var _ Region = (*ShapeIndexRegion)(nil)
+
+// NewShapeRegion returns a Region for a single shape, so that shapes other
+// than the built-in Region types can be covered by RegionCoverer or used as
+// query regions. The shape is added to a new ShapeIndex of its own, and the
+// given vertex model determines whether the region contains the vertices of
+// the shape (see ContainsPointQuery). Polygon interiors are always part of
+// the region, while points and polylines contain at most their vertices.
+func NewShapeRegion(shape Shape, model VertexModel) *ShapeIndexRegion {
+	index := NewShapeIndex()
+	index.Add(shape)
+	return &ShapeIndexRegion{
+		index:         index,
+		containsQuery: NewContainsPointQuery(index, model),
+		iter:          index.Iterator(),
+	}
+}
 
 // CapBound returns a bounding spherical cap for this collection of geometry.
 // This is not guaranteed to be exact.
@@ -123,11 +138,184 @@ func (s *ShapeIndexRegion) coverRange(first, last CellID, cellIDs []CellID) []Ce
 	return append(cellIDs, first.Parent(level))
 }
 
-// TODO(roberts): remaining methods
-/*
-// ContainsCell(target Cell) bool {
-// IntersectsCell(target Cell) bool {
-// ContainsPoint(p Point) bool {
-// contains(id CellID, clipped clippedShape, p Point) bool {
-// anyEdgeIntersects(clipped clippedShape, target Cell) bool {
-*/
+// VisitIntersectingShapes calls the visitor for each shape in the index that
+// may intersect the given region, visiting each shape at most once. The
+// exact flag reports whether the shape is known to intersect the region
+// (assuming the region's IntersectsCell is exact); otherwise the shape is
+// only a candidate whose index cells intersect the region, and callers that
+// need an exact answer must test it themselves.
+//
+// Shapes that are found to intersect the region while traversing the index
+// are visited as soon as they are found, and the remaining ones are visited
+// at the end. If the
+// visitor returns false, the traversal stops and false is returned.
+func (s *ShapeIndex) VisitIntersectingShapes(region Region, visitor func(shape Shape, exact bool) bool) bool {
+	// exact records the shapes seen so far and whether each is known to
+	// intersect the region. Candidates are kept in order of discovery.
+	exact := make(map[int32]bool)
+	var candidates []int32
+
+	processCell := func(id CellID, cell *ShapeIndexCell) bool {
+		c := CellFromCellID(id)
+		if !region.IntersectsCell(c) {
+			return true
+		}
+		centerInside := region.ContainsPoint(c.Center())
+		for _, clipped := range cell.shapes {
+			found, seen := exact[clipped.shapeID]
+			if found {
+				continue
+			}
+			// The shape is known to intersect the region if the region
+			// contains a point of the shape: either the cell center, or
+			// any point of the cell when no edges cross it, or a vertex.
+			shape := s.Shape(clipped.shapeID)
+			inInterior := clipped.containsCenter && (centerInside || clipped.numEdges() == 0)
+			if !inInterior && !anyClippedVertexContained(shape, clipped, region) {
+				if !seen {
+					exact[clipped.shapeID] = false
+					candidates = append(candidates, clipped.shapeID)
+				}
+				continue
+			}
+			exact[clipped.shapeID] = true
+			if !visitor(shape, true) {
+				return false
+			}
+		}
+		return true
+	}
+
+	coverer := &RegionCoverer{MaxLevel: MaxLevel, MaxCells: 8}
+	iter := s.Iterator()
+	var last CellID
+	for _, id := range coverer.Covering(region) {
+		switch iter.LocateCellID(id) {
+		case Indexed:
+			// Consecutive covering cells may lie within the same index cell.
+			if iter.CellID() == last {
+				continue
+			}
+			last = iter.CellID()
+			if !processCell(iter.CellID(), iter.IndexCell()) {
+				return false
+			}
+		case Subdivided:
+			for ; !iter.Done() && iter.CellID() <= id.RangeMax(); iter.Next() {
+				last = iter.CellID()
+				if !processCell(iter.CellID(), iter.IndexCell()) {
+					return false
+				}
+			}
+		}
+	}
+
+	// Polygons whose boundary is outside the region may still contain it, so
+	// test whether they contain a point of the region.
+	if probe := region.CapBound().Center(); len(candidates) > 0 && region.ContainsPoint(probe) {
+		q := NewContainsPointQuery(s, VertexModelClosed)
+		q.VisitContainingShapes(probe, func(shape Shape) bool {
+			exact[s.idForShape(shape)] = true
+			return true
+		})
+	}
+	for _, id := range candidates {
+		if !visitor(s.Shape(id), exact[id]) {
+			return false
+		}
+	}
+	return true
+}
+
+// anyClippedVertexContained reports whether the region contains a vertex of
+// any of the edges of the clipped shape.
+func anyClippedVertexContained(shape Shape, clipped *clippedShape, region Region) bool {
+	for _, e := range clipped.edges {
+		edge := shape.Edge(e)
+		if region.ContainsPoint(edge.V0) || region.ContainsPoint(edge.V1) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsCell reports whether the given Cell is contained by the region.
+// Note that it is only possible for a cell to be contained by a polygon, and
+// false may be returned if the cell is very close to the polygon boundary.
+func (s *ShapeIndexRegion) ContainsCell(target Cell) bool {
+	if s.iter.LocateCellID(target.ID()) != Indexed {
+		return false
+	}
+
+	// The target is contained by (or equal to) an index cell, which is the
+	// only cell whose shapes can contain it.
+	cell := s.iter.IndexCell()
+	for _, clipped := range cell.shapes {
+		if s.iter.CellID() == target.ID() {
+			if clipped.numEdges() == 0 && clipped.containsCenter {
+				return true
+			}
+			continue
+		}
+		// It is faster to check for intersecting edges before testing
+		// containment of the target's center.
+		if s.index.Shape(clipped.shapeID).Dimension() == 2 &&
+			!s.anyEdgeIntersects(clipped, target) &&
+			s.containsQuery.shapeContains(clipped, s.iter.Center(), target.Center()) {
+			return true
+		}
+	}
+	return false
+}
+
+// IntersectsCell reports whether the region may intersect the given cell.
+// It returns true if any shape intersects the cell to within the ShapeIndex
+// error bound, so it may return true for cells that are very close to, but
+// not intersecting, the indexed geometry.
+func (s *ShapeIndexRegion) IntersectsCell(target Cell) bool {
+	switch s.iter.LocateCellID(target.ID()) {
+	case Disjoint:
+		return false
+	case Subdivided:
+		// The target contains index cells, which exist only where there are
+		// edges or the interior of a polygon.
+		return true
+	}
+
+	// The target is an index cell, or is contained by one. Index cells are
+	// created only if they have at least one edge or are entirely contained
+	// by a polygon, so the first case is an intersection.
+	if s.iter.CellID() == target.ID() {
+		return true
+	}
+	cell := s.iter.IndexCell()
+	for _, clipped := range cell.shapes {
+		if s.anyEdgeIntersects(clipped, target) ||
+			s.containsQuery.shapeContains(clipped, s.iter.Center(), target.Center()) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsPoint reports whether the region contains the given point, under
+// the vertex model of the region.
+func (s *ShapeIndexRegion) ContainsPoint(p Point) bool {
+	return s.containsQuery.Contains(p)
+}
+
+// anyEdgeIntersects reports whether any edge of the clipped shape intersects
+// the given cell, to within the error bound of clipping.
+func (s *ShapeIndexRegion) anyEdgeIntersects(clipped *clippedShape, target Cell) bool {
+	const maxError = FaceClipErrorUVCoord + IntersectsRectErrorUVDist
+	bound := target.BoundUV().ExpandedByMargin(maxError)
+	face := target.Face()
+	shape := s.index.Shape(clipped.shapeID)
+	for _, edgeID := range clipped.edges {
+		edge := shape.Edge(edgeID)
+		if p0, p1, ok := ClipToPaddedFace(edge.V0, edge.V1, face, maxError); ok && EdgeIntersectsRect(p0, p1, bound) {
+			return true
+		}
+	}
+	return false
+}
@@ -16,10 +16,12 @@ package s2
 
 import (
 	"testing"
+
+	"github.com/golang/geo/s1"
 )
 
 // set padding to at least twice the maximum error for reliable results.
-const shapeIndexCellPadding = 2 * (faceClipErrorUVCoord + intersectsRectErrorUVDist)
+const shapeIndexCellPadding = 2 * (FaceClipErrorUVCoord + IntersectsRectErrorUVDist)
 
 func padCell(id CellID, paddingUV float64) Shape {
 	face, i, j, _ := id.faceIJOrientation()
@@ -130,3 +132,170 @@ func TestShapeIndexRegionCellUnionBoundOneFace(t *testing.T) {
 // func TestShapeIndexRegionIntersectsExactCell(t *testing.T){ }
 // Add VisitIntersectingShapes tests
 // Benchmarks
+
+func TestShapeIndexRegionContainsCellMultipleShapes(t *testing.T) {
+	id := CellIDFromString("3/0123012301230123012301230123")
+
+	// Add a polygon that is slightly smaller than the cell being tested.
+	index := NewShapeIndex()
+	index.Add(padCell(id, -shapeIndexCellPadding))
+	if index.Region().ContainsCell(CellFromCellID(id)) {
+		t.Errorf("%v.ContainsCell(%v) = true, want false", index, id)
+	}
+
+	// Add a second polygon that is slightly larger.
+	index.Add(padCell(id, shapeIndexCellPadding))
+	region := index.Region()
+	if !region.ContainsCell(CellFromCellID(id)) {
+		t.Errorf("%v.ContainsCell(%v) = false, want true", index, id)
+	}
+
+	// Verify that all children of the cell are also contained.
+	for _, child := range id.Children() {
+		if !region.ContainsCell(CellFromCellID(child)) {
+			t.Errorf("%v.ContainsCell(%v) = false, want true", index, child)
+		}
+	}
+}
+
+func TestShapeIndexRegionIntersectsShrunkenCell(t *testing.T) {
+	target := CellIDFromString("3/0123012301230123012301230123")
+
+	// Add a polygon that is slightly smaller than the cell being tested.
+	index := NewShapeIndex()
+	index.Add(padCell(target, -shapeIndexCellPadding))
+	region := index.Region()
+
+	// Check that the index intersects the cell itself, but not any of the
+	// neighboring cells.
+	if !region.IntersectsCell(CellFromCellID(target)) {
+		t.Errorf("%v.IntersectsCell(%v) = false, want true", index, target)
+	}
+	for _, id := range target.AllNeighbors(target.Level()) {
+		if region.IntersectsCell(CellFromCellID(id)) {
+			t.Errorf("%v.IntersectsCell(%v) = true, want false", index, id)
+		}
+	}
+}
+
+func TestShapeIndexRegionIntersectsExactCell(t *testing.T) {
+	target := CellIDFromString("3/0123012301230123012301230123")
+
+	// Adds a polygon that exactly follows a cell boundary.
+	index := NewShapeIndex()
+	index.Add(padCell(target, 0.0))
+	region := index.Region()
+
+	// Check that the index intersects the cell and all of its neighbors.
+	for _, id := range append([]CellID{target}, target.AllNeighbors(target.Level())...) {
+		if !region.IntersectsCell(CellFromCellID(id)) {
+			t.Errorf("%v.IntersectsCell(%v) = false, want true", index, id)
+		}
+	}
+}
+
+func TestShapeRegion(t *testing.T) {
+	line := LaxPolylineFromPoints(parsePoints("0:0, 0:10, 10:10"))
+	open := NewShapeRegion(line, VertexModelOpen)
+	closed := NewShapeRegion(line, VertexModelClosed)
+	for _, p := range parsePoints("0:0, 0:10, 10:10") {
+		if open.ContainsPoint(p) {
+			t.Errorf("open polyline region contains vertex %v", p)
+		}
+		if !closed.ContainsPoint(p) {
+			t.Errorf("closed polyline region does not contain vertex %v", p)
+		}
+	}
+	if closed.ContainsPoint(parsePoint("0:5")) {
+		t.Errorf("closed polyline region contains an edge interior point")
+	}
+
+	// A covering of the region covers the whole shape but not far away points.
+	loop := LaxLoopFromPoints(parsePoints("0:0, 0:10, 10:10, 10:0"))
+	region := NewShapeRegion(loop, VertexModelSemiOpen)
+	if !region.ContainsPoint(parsePoint("5:5")) || region.ContainsPoint(parsePoint("15:5")) {
+		t.Errorf("loop region containment is wrong")
+	}
+	rc := &RegionCoverer{MaxLevel: 30, MaxCells: 20}
+	covering := rc.Covering(region)
+	for _, p := range parsePoints("0:0, 5:5, 10:10, 0.5:9.5, 9.9:0.1") {
+		if !covering.ContainsPoint(p) {
+			t.Errorf("covering of %v does not contain %v", region, p)
+		}
+	}
+	if covering.ContainsPoint(parsePoint("20:20")) {
+		t.Errorf("covering of %v contains a far away point", region)
+	}
+	interior := rc.InteriorCovering(region)
+	if len(interior) == 0 {
+		t.Errorf("interior covering of %v is empty", region)
+	}
+	for _, id := range interior {
+		if !region.ContainsPoint(CellFromCellID(id).Center()) {
+			t.Errorf("interior covering cell %v is not contained by the region", id)
+		}
+	}
+
+	if want := rectFromDegrees(0, 0, 10, 10); !region.RectBound().Contains(want) {
+		t.Errorf("%v.RectBound() = %v, want a bound containing %v", region, region.RectBound(), want)
+	}
+}
+
+func TestShapeIndexVisitIntersectingShapes(t *testing.T) {
+	index := NewShapeIndex()
+	triangle := index.Add(makePolygon("0:0, 0:10, 10:0", true))
+	line := index.Add(makePolyline("20:20, 20:21"))
+	points := index.Add(PointCloudFromPoints[int](parsePoints("30:30"), nil))
+	far := index.Add(makePolygon("-40:-40, -40:-39, -39:-40", true))
+
+	capAt := func(s string, radius s1.Angle) Region {
+		return CapFromCenterAngle(parsePoint(s), radius)
+	}
+	tests := []struct {
+		region Region
+		exact  []int32
+		absent []int32
+	}{
+		// A region inside the polygon interior.
+		{capAt("2:2", s1.Degree), []int32{triangle}, []int32{line, points, far}},
+		// A region containing a polyline vertex.
+		{RectFromLatLng(LatLngFromDegrees(20, 20)).AddPoint(LatLngFromDegrees(21, 19)), []int32{line}, []int32{triangle, points, far}},
+		// A region containing the point, given as a cell union.
+		{&CellUnion{cellIDFromPoint(parsePoint("30:30")).Parent(10)}, []int32{points}, []int32{triangle, line, far}},
+		// A region covering everything but the far polygon.
+		{capAt("15:15", 25*s1.Degree), []int32{triangle, line, points}, []int32{far}},
+	}
+	for _, test := range tests {
+		visited := make(map[int32]bool)
+		index.VisitIntersectingShapes(test.region, func(shape Shape, exact bool) bool {
+			id := index.idForShape(shape)
+			if _, ok := visited[id]; ok {
+				t.Errorf("VisitIntersectingShapes(%v) visited shape %d twice", test.region, id)
+			}
+			visited[id] = exact
+			return true
+		})
+		for _, id := range test.exact {
+			if exact, ok := visited[id]; !ok || !exact {
+				t.Errorf("VisitIntersectingShapes(%v): shape %d visited=%v exact=%v, want exact visit", test.region, id, ok, exact)
+			}
+		}
+		for _, id := range test.absent {
+			if visited[id] {
+				t.Errorf("VisitIntersectingShapes(%v) reported shape %d as intersecting", test.region, id)
+			}
+		}
+	}
+
+	// The traversal stops when the visitor returns false.
+	n := 0
+	if index.VisitIntersectingShapes(capAt("15:15", 25*s1.Degree), func(Shape, bool) bool {
+		n++
+		return false
+	}) {
+		t.Errorf("VisitIntersectingShapes returned true after the visitor stopped")
+	}
+	if n != 1 {
+		t.Errorf("visitor called %d times after returning false, want 1", n)
+	}
+}
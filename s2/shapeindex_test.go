@@ -15,6 +15,8 @@
 package s2
 
 import (
+	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/golang/geo/r3"
@@ -40,6 +42,22 @@ func TestShapeIndexBasics(t *testing.T) {
 	}
 }
 
+func TestShapeIndexResetAfterBuild(t *testing.T) {
+	index := NewShapeIndex()
+	loop := makeLoop("0:0, 0:1, 1:1, 1:0")
+	index.Add(loop)
+	index.Build()
+
+	// Shapes added after a Reset must be indexed even if the index had been
+	// built before.
+	index.Reset()
+	index.Add(loop)
+	q := NewContainsPointQuery(index, VertexModelSemiOpen)
+	if p := parsePoint("0.5:0.5"); !q.Contains(p) {
+		t.Errorf("index rebuilt after Reset does not contain %v", p)
+	}
+}
+
 func TestShapeEdgeComparisons(t *testing.T) {
 	tests := []struct {
 		a, b Edge
@@ -128,12 +146,12 @@ func validateEdge(t *testing.T, a, b Point, ci CellID, hasEdge bool) {
 	if !hasEdge {
 		sign = -1
 	}
-	padding += sign * intersectsRectErrorUVDist
+	padding += sign * IntersectsRectErrorUVDist
 	bound := ci.boundUV().ExpandedByMargin(padding)
 	aUV, bUV, ok := ClipToPaddedFace(a, b, ci.Face(), padding)
 
-	if got := ok && edgeIntersectsRect(aUV, bUV, bound); got != hasEdge {
-		t.Errorf("edgeIntersectsRect(%v, %v, %v) = %v && clip = %v, want %v", aUV, bUV, bound, edgeIntersectsRect(aUV, bUV, bound), ok, hasEdge)
+	if got := ok && EdgeIntersectsRect(aUV, bUV, bound); got != hasEdge {
+		t.Errorf("EdgeIntersectsRect(%v, %v, %v) = %v && clip = %v, want %v", aUV, bUV, bound, EdgeIntersectsRect(aUV, bUV, bound), ok, hasEdge)
 	}
 }
 
@@ -503,6 +521,23 @@ func TestShapeIndexLoopSpanningThreeFaces(t *testing.T) {
 	testIteratorMethods(t, index)
 }
 
+func TestShapeIndexIncrementalAdd(t *testing.T) {
+	// Adding shapes to an index that has already been built used to deadlock,
+	// because the update tried to apply itself again while it held the lock.
+	index := NewShapeIndex()
+	polygon := concentricLoopsPolygon(PointFromCoords(1, -1, -1), 3, 50)
+	for _, l := range polygon.loops {
+		index.Add(l)
+		index.Iterator()
+		quadraticValidate(t, index)
+	}
+	index.Add(makePolyline("0:0, 2:1, 0:2, 2:3"))
+	pv := PointVector(parsePoints("1:1, 40:40"))
+	index.Add(&pv)
+	quadraticValidate(t, index)
+	testIteratorMethods(t, index)
+}
+
 func TestShapeIndexNumEdgesUpTo(t *testing.T) {
 	index := makeShapeIndex("0:0 | 0:1 | 0:2 | 0:3 | 0:4 # 1:0, 1:1 | 1:2, 1:3 | 1:4, 1:5, 1:6 #")
 
@@ -568,3 +603,51 @@ func BenchmarkShapeIndexIteratorLocatePoint(b *testing.B) {
 		it.LocatePoint(randomPoint())
 	}
 }
+
+func TestShapeIndexConcurrentQueries(t *testing.T) {
+	center := randomPoint()
+	newIndex := func() *ShapeIndex {
+		index := NewShapeIndex()
+		for i := 0; i < 5; i++ {
+			index.Add(concentricLoopsPolygon(center, 3, 100))
+		}
+		return index
+	}
+	points := make([]Point, 100)
+	c := CapFromCenterAngle(center, 0.01)
+	for i := range points {
+		points[i] = samplePointFromCap(c)
+	}
+
+	want := make([][]int32, len(points))
+	query := NewContainsPointQuery(newIndex(), VertexModelSemiOpen)
+	for i, p := range points {
+		want[i] = query.containingShapeIDs(p)
+	}
+
+	// Several goroutines query an index that has not been built yet, so
+	// they all try to build it at once.
+	index := newIndex()
+	const numGoroutines = 8
+	got := make([][][]int32, numGoroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < numGoroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			q := NewContainsPointQuery(index, VertexModelSemiOpen)
+			for _, p := range points {
+				got[g] = append(got[g], q.containingShapeIDs(p))
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	for g := range got {
+		for i := range points {
+			if !reflect.DeepEqual(got[g][i], want[i]) {
+				t.Errorf("goroutine %d: containingShapeIDs(%v) = %v, want %v", g, points[i], got[g][i], want[i])
+			}
+		}
+	}
+}
@@ -22,6 +22,11 @@ type EdgeIterator struct {
 	shapeID  int32
 	numEdges int32
 	edgeID   int32
+
+	// pos is the chain position of the current edge, and chain is the
+	// chain containing it.
+	pos   ChainPosition
+	chain Chain
 }
 
 // NewEdgeIterator creates a new edge iterator for the given index.
@@ -45,6 +50,11 @@ func (e *EdgeIterator) EdgeID() int32 { return e.edgeID }
 // ShapeEdgeID returns the current (shapeID, edgeID).
 func (e *EdgeIterator) ShapeEdgeID() ShapeEdgeID { return ShapeEdgeID{e.shapeID, e.edgeID} }
 
+// ChainPosition returns the position of the current edge within its shape's
+// chains. This is the same as calling ChainPosition on the shape, but does
+// not need to search the chains.
+func (e *EdgeIterator) ChainPosition() ChainPosition { return e.pos }
+
 // Edge returns the current edge.
 func (e *EdgeIterator) Edge() Edge {
 	return e.index.Shape(e.shapeID).Edge(int(e.edgeID))
@@ -68,5 +78,79 @@ func (e *EdgeIterator) Next() {
 			e.numEdges = int32(shape.NumEdges())
 		}
 		e.edgeID = -1
+		e.pos, e.chain = ChainPosition{ChainID: -1}, Chain{}
+	}
+	if !e.Done() {
+		advanceChainPosition(e.index.Shape(e.shapeID), &e.pos, &e.chain)
+	}
+}
+
+// advanceChainPosition moves pos, the position of an edge within the given
+// chain of the shape, to the position of the next edge, skipping over any
+// empty chains. A pos with ChainID -1 and an empty chain start the iteration
+// at the first edge of the shape.
+func advanceChainPosition(shape Shape, pos *ChainPosition, chain *Chain) {
+	pos.Offset++
+	for pos.Offset >= chain.Length {
+		pos.ChainID++
+		*chain = shape.Chain(pos.ChainID)
+		pos.Offset = 0
 	}
 }
+
+// ChainEdgeIterator iterates over the edges of a single shape in edge ID
+// order, reporting the chain position of each edge along with its endpoints.
+// This lets algorithms that work with flat edge IDs reconstruct the chains
+// (such as polyline segments or polygon loops) of any shape, without special
+// cases for particular shape types.
+//
+// Example usage:
+//
+//	for it := NewChainEdgeIterator(shape); !it.Done(); it.Next() {
+//		if it.ChainPosition().Offset == 0 {
+//			// it.Edge() is the first edge of a new chain.
+//		}
+//	}
+type ChainEdgeIterator struct {
+	shape    Shape
+	numEdges int
+	edgeID   int
+	pos      ChainPosition
+	chain    Chain
+}
+
+// NewChainEdgeIterator returns an iterator positioned at the first edge of the
+// given shape.
+func NewChainEdgeIterator(shape Shape) *ChainEdgeIterator {
+	it := &ChainEdgeIterator{
+		shape:    shape,
+		numEdges: shape.NumEdges(),
+		edgeID:   -1,
+		pos:      ChainPosition{ChainID: -1},
+	}
+	it.Next()
+	return it
+}
+
+// Done reports if the iterator is positioned after the last edge of the shape.
+func (it *ChainEdgeIterator) Done() bool { return it.edgeID >= it.numEdges }
+
+// Next positions the iterator at the next edge of the shape.
+func (it *ChainEdgeIterator) Next() {
+	it.edgeID++
+	if !it.Done() {
+		advanceChainPosition(it.shape, &it.pos, &it.chain)
+	}
+}
+
+// EdgeID returns the ID of the current edge.
+func (it *ChainEdgeIterator) EdgeID() int { return it.edgeID }
+
+// ChainPosition returns the position of the current edge within its chain.
+func (it *ChainEdgeIterator) ChainPosition() ChainPosition { return it.pos }
+
+// Chain returns the chain containing the current edge.
+func (it *ChainEdgeIterator) Chain() Chain { return it.chain }
+
+// Edge returns the endpoints of the current edge.
+func (it *ChainEdgeIterator) Edge() Edge { return it.shape.Edge(it.edgeID) }
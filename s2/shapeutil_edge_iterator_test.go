@@ -43,6 +43,10 @@ func verifyEdgeIterator(t *testing.T, index *ShapeIndex) {
 		if got, want := iter.Edge(), expected[i]; got != want {
 			t.Errorf("edge[%d] = %v, want %v", i, got, want)
 		}
+		shape := index.Shape(iter.ShapeID())
+		if got, want := iter.ChainPosition(), shape.ChainPosition(int(iter.EdgeID())); got != want {
+			t.Errorf("edge[%d].ChainPosition() = %v, want %v", i, got, want)
+		}
 		i++
 	}
 }
@@ -122,3 +126,39 @@ func TestShapeutilEdgeIteratorAssignmentAndEquality(t *testing.T) {
 		t.Errorf("edgeIterators not equal but should be after both advanced same amount")
 	}
 }
+
+func TestShapeutilChainEdgeIterator(t *testing.T) {
+	pv := PointVector(parsePoints("0:0, 1:1, 2:2"))
+	for _, shape := range []Shape{
+		&pv,
+		makePolyline("0:0, 1:1, 2:2"),
+		makeLaxPolyline(""),
+		makePolygon("0:0, 0:10, 10:10, 10:0; 1:1, 1:5, 5:5, 5:1; 6:6, 6:8, 8:8, 8:6", true),
+		makeLaxPolygon("0:0, 0:3, 3:3; empty; 5:5, 5:6, 6:6"),
+		makeLaxPolygon("full"),
+		FullPolygon(),
+		PolygonFromLoops(nil),
+		MultiPolylineFromPoints([][]Point{parsePoints("0:0, 1:1"), parsePoints("2:2, 3:3, 4:4")}),
+	} {
+		i := 0
+		for it := NewChainEdgeIterator(shape); !it.Done(); it.Next() {
+			if got := it.EdgeID(); got != i {
+				t.Errorf("EdgeID() = %d, want %d", got, i)
+			}
+			pos := it.ChainPosition()
+			if want := shape.ChainPosition(i); pos != want {
+				t.Errorf("%T: edge %d: ChainPosition() = %v, want %v", shape, i, pos, want)
+			}
+			if got, want := it.Chain(), shape.Chain(pos.ChainID); got != want {
+				t.Errorf("%T: edge %d: Chain() = %v, want %v", shape, i, got, want)
+			}
+			if got, want := it.Edge(), shape.ChainEdge(pos.ChainID, pos.Offset); got != want {
+				t.Errorf("%T: edge %d: Edge() = %v, want %v", shape, i, got, want)
+			}
+			i++
+		}
+		if i != shape.NumEdges() {
+			t.Errorf("%T: iterated over %d edges, want %d", shape, i, shape.NumEdges())
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "fmt"
+
+// EdgePairVisitor is a function that is called with pairs of crossing edges.
+// The isInterior argument reports whether the crossing is at a point interior
+// to both edges. If the function returns false, the visiting stops.
+type EdgePairVisitor func(a, b ShapeEdge, isInterior bool) bool
+
+// VisitCrossingEdgePairs visits all pairs of crossing edges in the given
+// index, terminating early if the visitor returns false (in which case
+// VisitCrossingEdgePairs returns false as well). The crossType indicates
+// whether all crossings should be visited, or only interior ones.
+//
+// If crossType is CrossingTypeAll, this includes both edges that share a
+// vertex and edges that intersect at an interior point. With
+// CrossingTypeNonAdjacent, pairs of the form (AB, BC) that are adjacent in
+// the index are skipped, which is often enough for validation purposes and
+// saves a lot of work. Each pair of edges may be visited more than once if
+// the pair crosses in several index cells.
+func VisitCrossingEdgePairs(index *ShapeIndex, crossType CrossingType, visitor EdgePairVisitor) bool {
+	needAdjacent := crossType == CrossingTypeAll
+	for iter := index.Iterator(); !iter.Done(); iter.Next() {
+		edges := shapeEdgesInCell(index, iter.IndexCell())
+		if !visitCrossings(edges, crossType, needAdjacent, visitor) {
+			return false
+		}
+	}
+	return true
+}
+
+// shapeEdgesInCell returns all the edges that intersect the given index cell.
+func shapeEdgesInCell(index *ShapeIndex, cell *ShapeIndexCell) []ShapeEdge {
+	var edges []ShapeEdge
+	for _, clipped := range cell.shapes {
+		shape := index.Shape(clipped.shapeID)
+		for _, e := range clipped.edges {
+			edges = append(edges, ShapeEdge{
+				ID:   ShapeEdgeID{clipped.shapeID, int32(e)},
+				Edge: shape.Edge(e),
+			})
+		}
+	}
+	return edges
+}
+
+// visitCrossings visits all pairs of crossing edges of the given crossType
+// among the given edges.
+func visitCrossings(edges []ShapeEdge, crossType CrossingType, needAdjacent bool, visitor EdgePairVisitor) bool {
+	for i := 0; i+1 < len(edges); i++ {
+		a := edges[i]
+		j := i + 1
+		// A common situation is that an edge AB is followed by an edge BC. We
+		// only need to visit such crossings if needAdjacent is true (even if
+		// AB and BC belong to different edge chains).
+		if !needAdjacent && a.Edge.V1 == edges[j].Edge.V0 {
+			j++
+			if j >= len(edges) {
+				break
+			}
+		}
+		crosser := NewEdgeCrosser(a.Edge.V0, a.Edge.V1)
+		for ; j < len(edges); j++ {
+			b := edges[j]
+			if crosser.c != b.Edge.V0 {
+				crosser.RestartAt(b.Edge.V0)
+			}
+			sign := crosser.ChainCrossingSign(b.Edge.V1)
+			if sign == Cross || (sign == MaybeCross && crossType != CrossingTypeInterior) {
+				if !visitor(a, b, sign == Cross) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// findSelfIntersection returns an error describing the first self-intersection
+// found in the given index, which must contain a single shape consisting of
+// polygon loops. This includes edges that cross at interior points, loops with
+// duplicate vertices, loops that share edges, and loops that cross at a
+// shared vertex. It returns nil if there is no self-intersection.
+func findSelfIntersection(index *ShapeIndex) error {
+	shape := index.Shape(0)
+	if shape == nil {
+		return nil
+	}
+
+	// Visit all crossing pairs except possibly for ones of the form (AB, BC),
+	// since such pairs are very common and findCrossingError only needs pairs
+	// of the form (AB, AC).
+	var err error
+	VisitCrossingEdgePairs(index, CrossingTypeNonAdjacent, func(a, b ShapeEdge, isInterior bool) bool {
+		err = findCrossingError(shape, a, b, isInterior)
+		return err == nil
+	})
+	return err
+}
+
+// loopError formats a loop error message. If the loop belongs to a
+// multi-loop polygon, a prefix indicating which loop is affected is added.
+func loopError(isPolygon bool, chainID int, format string, args ...any) error {
+	if isPolygon {
+		return fmt.Errorf("loop %d: "+format, append([]any{chainID}, args...)...)
+	}
+	return fmt.Errorf(format, args...)
+}
+
+// findCrossingError returns an error if the two given crossing loop edges
+// (including crossings at a shared vertex) form an invalid configuration.
+func findCrossingError(shape Shape, a, b ShapeEdge, isInterior bool) error {
+	isPolygon := shape.NumChains() > 1
+	ap := shape.ChainPosition(int(a.ID.EdgeID))
+	bp := shape.ChainPosition(int(b.ID.EdgeID))
+	if isInterior {
+		if ap.ChainID != bp.ChainID {
+			return fmt.Errorf("loop %d edge %d crosses loop %d edge %d", ap.ChainID, ap.Offset, bp.ChainID, bp.Offset)
+		}
+		return loopError(isPolygon, ap.ChainID, "edge %d crosses edge %d", ap.Offset, bp.Offset)
+	}
+
+	// Loops are not allowed to have duplicate vertices, and separate loops
+	// are not allowed to share edges or cross at vertices. We only need to
+	// check a given vertex once, so we also require that the two edges have
+	// the same end vertex.
+	if a.Edge.V1 != b.Edge.V1 {
+		return nil
+	}
+	if ap.ChainID == bp.ChainID {
+		return loopError(isPolygon, ap.ChainID, "edge %d has duplicate vertex with edge %d", ap.Offset, bp.Offset)
+	}
+	aLen := shape.Chain(ap.ChainID).Length
+	bLen := shape.Chain(bp.ChainID).Length
+	aNext := ap.Offset + 1
+	if aNext == aLen {
+		aNext = 0
+	}
+	bNext := bp.Offset + 1
+	if bNext == bLen {
+		bNext = 0
+	}
+	a2 := shape.ChainEdge(ap.ChainID, aNext).V1
+	b2 := shape.ChainEdge(bp.ChainID, bNext).V1
+	if a.Edge.V0 == b.Edge.V0 || a.Edge.V0 == b2 {
+		// The second edge index is sometimes off by one, hence "near".
+		return fmt.Errorf("loop %d edge %d has duplicate near loop %d edge %d", ap.ChainID, ap.Offset, bp.ChainID, bp.Offset)
+	}
+
+	// Since ShapeIndex loops are oriented such that the polygon interior is
+	// always on the left, we need to handle the case where one wedge contains
+	// the complement of the other wedge. This is not specifically detected by
+	// WedgeRelation, so there are two cases to check for.
+	//
+	// Note that we don't need to maintain any state regarding loop crossings
+	// because duplicate edges are detected and rejected above.
+	if WedgeRelation(a.Edge.V0, a.Edge.V1, a2, b.Edge.V0, b2) == WedgeProperlyOverlaps &&
+		WedgeRelation(a.Edge.V0, a.Edge.V1, a2, b2, b.Edge.V0) == WedgeProperlyOverlaps {
+		return fmt.Errorf("loop %d edge %d crosses loop %d edge %d", ap.ChainID, ap.Offset, bp.ChainID, bp.Offset)
+	}
+	return nil
+}
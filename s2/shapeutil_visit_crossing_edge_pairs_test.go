@@ -0,0 +1,76 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVisitCrossingEdgePairs(t *testing.T) {
+	// Two polylines that cross at an interior point, and a third that shares
+	// a vertex with the first.
+	index := makeShapeIndex("# 0:0, 2:2 | 0:2, 2:0 | 2:2, 3:2 #")
+
+	tests := []struct {
+		crossType CrossingType
+		want      int
+	}{
+		{CrossingTypeInterior, 1},
+		{CrossingTypeAll, 2},
+	}
+	for _, test := range tests {
+		got := 0
+		VisitCrossingEdgePairs(index, test.crossType, func(a, b ShapeEdge, isInterior bool) bool {
+			if test.crossType == CrossingTypeInterior && !isInterior {
+				t.Errorf("VisitCrossingEdgePairs(%v) visited non-interior pair %v, %v", test.crossType, a.ID, b.ID)
+			}
+			got++
+			return true
+		})
+		if got != test.want {
+			t.Errorf("VisitCrossingEdgePairs(%v) visited %d pairs, want %d", test.crossType, got, test.want)
+		}
+	}
+
+	// Returning false from the visitor stops the iteration.
+	if VisitCrossingEdgePairs(index, CrossingTypeAll, func(a, b ShapeEdge, isInterior bool) bool { return false }) {
+		t.Errorf("VisitCrossingEdgePairs should return false when the visitor does")
+	}
+}
+
+func TestFindSelfIntersection(t *testing.T) {
+	tests := []struct {
+		polygon string
+		want    string
+	}{
+		{"0:0, 0:1, 1:1, 1:0", ""},
+		{"0:0, 0:2, 2:2, 2:0; 0.5:0.5, 0.5:1.5, 1.5:1.5", ""},
+		{"0:0, 1:1, 1:0, 0:1", "crosses edge"},
+		{"0:0, 1:1, 1:0, 0:0, 0:1", "duplicate vertex"},
+		{"0:0, 0:2, 2:2, 2:0; 1:1, 1:3, 3:3, 3:1", "loop 0 edge"},
+	}
+	for _, test := range tests {
+		index := NewShapeIndex()
+		index.Add(makePolygon(test.polygon, false))
+		err := findSelfIntersection(index)
+		switch {
+		case test.want == "" && err != nil:
+			t.Errorf("findSelfIntersection(%q) = %v, want nil", test.polygon, err)
+		case test.want != "" && (err == nil || !strings.Contains(err.Error(), test.want)):
+			t.Errorf("findSelfIntersection(%q) = %v, want error containing %q", test.polygon, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"sort"
+
+	"github.com/golang/geo/s1"
+)
+
+// SignedDistanceField is a coarse signed distance field for a polygon,
+// sampled at the centers of the cells of a covering at a fixed level. The
+// value for each cell is the distance from the cell center to the polygon
+// boundary, which is negative if the polygon contains the cell center.
+//
+// The field can be used for fast approximate queries (such as "how far
+// inside the region is this point") and for gradient-style computations
+// without consulting the polygon edges.
+type SignedDistanceField struct {
+	level     int
+	cellIDs   []CellID
+	distances []s1.Angle
+}
+
+// NewSignedDistanceField computes the signed distance field for the given
+// polygon over the given covering. Every cell of the covering is converted
+// to cells at the given level: coarser cells are replaced by their
+// descendants at the level, and finer cells by their ancestor at the level.
+//
+// The number of distance computations equals the number of cells at the
+// given level, so the level should be chosen with the covering area in mind.
+func NewSignedDistanceField(p *Polygon, covering CellUnion, level int) *SignedDistanceField {
+	level = clampInt(level, 0, MaxLevel)
+	f := &SignedDistanceField{level: level}
+
+	for _, id := range covering {
+		if id.Level() >= level {
+			parent := id.Parent(level)
+			if n := len(f.cellIDs); n == 0 || f.cellIDs[n-1] != parent {
+				f.cellIDs = append(f.cellIDs, parent)
+			}
+			continue
+		}
+		for ci := id.ChildBeginAtLevel(level); ci != id.ChildEndAtLevel(level); ci = ci.Next() {
+			f.cellIDs = append(f.cellIDs, ci)
+		}
+	}
+	sortCellIDs(f.cellIDs)
+	f.cellIDs = dedupCellIDs(f.cellIDs)
+
+	opts := NewClosestEdgeQueryOptions().MaxResults(1).IncludeInteriors(false)
+	query := NewClosestEdgeQuery(p.index, opts)
+	f.distances = make([]s1.Angle, len(f.cellIDs))
+	for i, id := range f.cellIDs {
+		center := id.Point()
+		dist := query.Distance(NewMinDistanceToPointTarget(center)).Angle()
+		if p.ContainsPoint(center) {
+			dist = -dist
+		}
+		f.distances[i] = dist
+	}
+	return f
+}
+
+// Level returns the cell level at which the field is sampled.
+func (f *SignedDistanceField) Level() int { return f.level }
+
+// Len returns the number of cells in the field.
+func (f *SignedDistanceField) Len() int { return len(f.cellIDs) }
+
+// Cell returns the i-th cell of the field in CellID order along with the
+// signed distance from its center to the polygon boundary.
+func (f *SignedDistanceField) Cell(i int) (CellID, s1.Angle) {
+	return f.cellIDs[i], f.distances[i]
+}
+
+// CellDistance returns the signed distance for the given cell, which must
+// be at the level of the field. It returns false if the cell is not part of
+// the field.
+func (f *SignedDistanceField) CellDistance(id CellID) (s1.Angle, bool) {
+	i := sort.Search(len(f.cellIDs), func(i int) bool { return f.cellIDs[i] >= id })
+	if i < len(f.cellIDs) && f.cellIDs[i] == id {
+		return f.distances[i], true
+	}
+	return 0, false
+}
+
+// Distance returns the signed distance of the cell containing the given
+// point. It returns false if the point is not covered by the field.
+func (f *SignedDistanceField) Distance(p Point) (s1.Angle, bool) {
+	return f.CellDistance(cellIDFromPoint(p).Parent(f.level))
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestSignedDistanceField(t *testing.T) {
+	p := makePolygon("0:0, 0:10, 10:10, 10:0", true)
+	rc := &RegionCoverer{MinLevel: 4, MaxLevel: 6, MaxCells: 20}
+	covering := rc.Covering(p)
+	level := 7
+	f := NewSignedDistanceField(p, covering, level)
+
+	if got := f.Level(); got != level {
+		t.Errorf("Level() = %d, want %d", got, level)
+	}
+	if f.Len() == 0 {
+		t.Fatalf("field has no cells")
+	}
+
+	prev := CellID(0)
+	for i := 0; i < f.Len(); i++ {
+		id, dist := f.Cell(i)
+		if id.Level() != level {
+			t.Errorf("cell %v has level %d, want %d", id, id.Level(), level)
+		}
+		if id <= prev {
+			t.Errorf("cells are not sorted and unique: %v after %v", id, prev)
+		}
+		prev = id
+
+		if !covering.ContainsCellID(id) {
+			t.Errorf("cell %v is not part of the covering", id)
+		}
+		center := id.Point()
+		if got, want := dist < 0, p.ContainsPoint(center); got != want {
+			t.Errorf("distance %v for cell %v has the wrong sign", dist, id)
+		}
+		// The boundary is at most as far as the closest polygon vertex.
+		minVertexDist := s1.InfAngle()
+		for _, v := range p.Loop(0).Vertices() {
+			if d := center.Distance(v); d < minVertexDist {
+				minVertexDist = d
+			}
+		}
+		if math.Abs(dist.Radians()) > minVertexDist.Radians()+1e-15 {
+			t.Errorf("|distance| %v for cell %v is larger than the distance to a vertex %v", dist, id, minVertexDist)
+		}
+	}
+
+	// A point near the middle of the polygon is about 5 degrees inside.
+	if got, ok := f.Distance(PointFromLatLng(LatLngFromDegrees(5, 5))); !ok || got.Degrees() > -4.5 {
+		t.Errorf("Distance(5:5) = %v, %v, want about -5 degrees", got, ok)
+	}
+	if _, ok := f.Distance(PointFromLatLng(LatLngFromDegrees(-40, 100))); ok {
+		t.Errorf("Distance(-40:100) = _, true, want false for a point outside the covering")
+	}
+	id, dist := f.Cell(0)
+	if got, ok := f.CellDistance(id); !ok || got != dist {
+		t.Errorf("CellDistance(%v) = %v, %v, want %v, true", id, got, ok, dist)
+	}
+}
+
+func TestSignedDistanceFieldFinerCovering(t *testing.T) {
+	p := makePolygon("0:0, 0:10, 10:10, 10:0", true)
+	id := cellIDFromPoint(PointFromLatLng(LatLngFromDegrees(5, 5)))
+	covering := CellUnion{id.Parent(12), id.Parent(15).Next().Parent(15)}
+	covering.Normalize()
+	f := NewSignedDistanceField(p, covering, 10)
+	if got := f.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1", got)
+	}
+
+	empty := NewSignedDistanceField(PolygonFromLoops(nil), covering, 10)
+	if _, dist := empty.Cell(0); !math.IsInf(dist.Radians(), 1) {
+		t.Errorf("distance to the empty polygon = %v, want +Inf", dist)
+	}
+}
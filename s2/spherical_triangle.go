@@ -0,0 +1,90 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// This file contains the relations between the sides and angles of a
+// spherical triangle, and its centers. The sides a, b, c are the arc
+// lengths opposite the vertices with angles A, B, C respectively.
+
+// TriangleAnglesFromSides returns the interior angles of the spherical
+// triangle with the given side lengths, each opposite the corresponding
+// side. The sides must satisfy the triangle inequality and sum to at most
+// 2π. It uses the half-angle formulas, which are accurate for small
+// triangles, unlike the spherical law of cosines.
+func TriangleAnglesFromSides(a, b, c s1.Angle) (s1.Angle, s1.Angle, s1.Angle) {
+	s := 0.5 * (a + b + c)
+	sin := func(x s1.Angle) float64 { return math.Sin(float64(x)) }
+	// tan(A/2) = sqrt(sin(s-b) sin(s-c) / (sin(s) sin(s-a))).
+	halfAngle := func(opposite, other1, other2 s1.Angle) s1.Angle {
+		num := math.Max(0, sin(s-other1)*sin(s-other2))
+		den := math.Max(0, sin(s)*sin(s-opposite))
+		return s1.Angle(2 * math.Atan2(math.Sqrt(num), math.Sqrt(den)))
+	}
+	return halfAngle(a, b, c), halfAngle(b, c, a), halfAngle(c, a, b)
+}
+
+// TriangleSidesFromAngles returns the side lengths of the spherical triangle
+// with the given interior angles, each opposite the corresponding angle.
+// The angles must sum to more than π, and each must be less than π. It is
+// the dual of TriangleAnglesFromSides, using the half-side formulas.
+func TriangleSidesFromAngles(angleA, angleB, angleC s1.Angle) (s1.Angle, s1.Angle, s1.Angle) {
+	S := 0.5 * (angleA + angleB + angleC)
+	cos := func(x s1.Angle) float64 { return math.Cos(float64(x)) }
+	// tan(a/2) = sqrt(-cos(S) cos(S-A) / (cos(S-B) cos(S-C))).
+	halfSide := func(opposite, other1, other2 s1.Angle) s1.Angle {
+		num := math.Max(0, -cos(S)*cos(S-opposite))
+		den := math.Max(0, cos(S-other1)*cos(S-other2))
+		return s1.Angle(2 * math.Atan2(math.Sqrt(num), math.Sqrt(den)))
+	}
+	return halfSide(angleA, angleB, angleC), halfSide(angleB, angleC, angleA), halfSide(angleC, angleA, angleB)
+}
+
+// TriangleAreaFromSides returns the area of the spherical triangle with the
+// given side lengths, which is also its spherical excess, computed with
+// l'Huilier's formula.
+func TriangleAreaFromSides(a, b, c s1.Angle) float64 {
+	return lhuilierArea(a, b, c)
+}
+
+// TriangleCircumcenter returns the center of the circle through the three
+// points, which is equidistant from all of them. Of the two antipodal
+// points that are equidistant, it returns the one on the same side of the
+// plane through the points as the triangle, so it is within 90 degrees of
+// each vertex. The points must be distinct and not on a common great circle.
+func TriangleCircumcenter(a, b, c Point) Point {
+	// The perpendicular bisector normals of AB and BC are both orthogonal to
+	// the circumcenter.
+	center := PerpendicularBisector(a, b).Cross(PerpendicularBisector(b, c).Vector)
+	if center.Dot(a.Vector) < 0 {
+		center = center.Mul(-1)
+	}
+	return Point{center.Normalize()}
+}
+
+// TriangleIncenter returns the center of the largest circle inside the
+// triangle, which is equidistant from its three edges. It is the sum of the
+// vertices weighted by the sines of the opposite sides.
+func TriangleIncenter(a, b, c Point) Point {
+	wa := math.Sin(float64(b.Distance(c)))
+	wb := math.Sin(float64(c.Distance(a)))
+	wc := math.Sin(float64(a.Distance(b)))
+	return Point{a.Mul(wa).Add(b.Mul(wb)).Add(c.Mul(wc)).Normalize()}
+}
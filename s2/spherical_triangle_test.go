@@ -0,0 +1,98 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestSphericalTriangleRelations(t *testing.T) {
+	// The octant triangle has sides and angles of 90 degrees.
+	if A, B, C := TriangleAnglesFromSides(math.Pi/2, math.Pi/2, math.Pi/2); !A.ApproxEqual(math.Pi/2) || !B.ApproxEqual(math.Pi/2) || !C.ApproxEqual(math.Pi/2) {
+		t.Errorf("TriangleAnglesFromSides(octant) = %v, %v, %v, want 90 degrees each", A, B, C)
+	}
+
+	for i := 0; i < 100; i++ {
+		// Well-shaped triangles of sizes from about 1e-6 to 1 radians, with
+		// vertices roughly 120 degrees apart around a random center.
+		center := randomPoint()
+		u := center.Ortho()
+		v := center.Cross(u)
+		r := math.Pow(10, -6*randomFloat64())
+		vertex := func(theta float64) Point {
+			w := u.Mul(math.Cos(theta)).Add(v.Mul(math.Sin(theta)))
+			return Point{center.Mul(math.Cos(r)).Add(w.Mul(math.Sin(r))).Normalize()}
+		}
+		theta := 2 * math.Pi * randomFloat64()
+		a := vertex(theta)
+		b := vertex(theta + 2*math.Pi/3 + 0.5*(randomFloat64()-0.5))
+		c := vertex(theta + 4*math.Pi/3 + 0.5*(randomFloat64()-0.5))
+		sa, sb, sc := b.Distance(c), c.Distance(a), a.Distance(b)
+		angleA, angleB, angleC := TriangleAnglesFromSides(sa, sb, sc)
+		for _, test := range []struct{ got, want s1.Angle }{
+			{angleA, Angle(c, a, b)},
+			{angleB, Angle(a, b, c)},
+			{angleC, Angle(b, c, a)},
+		} {
+			if math.Abs(float64(test.got-test.want)) > 1e-7 {
+				t.Errorf("TriangleAnglesFromSides(%v, %v, %v) gives angle %v, want %v", sa, sb, sc, test.got, test.want)
+			}
+		}
+
+		area := PointArea(a, b, c)
+		if got := TriangleAreaFromSides(sa, sb, sc); !float64Near(got, area, 1e-9*math.Max(area, 1e-10)+1e-15) {
+			t.Errorf("TriangleAreaFromSides(%v, %v, %v) = %v, want %v", sa, sb, sc, got, area)
+		}
+		if r > 1e-3 {
+			// Girard's theorem: the excess of the angles is the area.
+			if excess := float64(angleA+angleB+angleC) - math.Pi; !float64Near(excess, area, 1e-9) {
+				t.Errorf("angle excess = %v, want area %v", excess, area)
+			}
+			ra, rb, rc := TriangleSidesFromAngles(angleA, angleB, angleC)
+			if math.Abs(float64(ra-sa)) > 1e-6 || math.Abs(float64(rb-sb)) > 1e-6 || math.Abs(float64(rc-sc)) > 1e-6 {
+				t.Errorf("TriangleSidesFromAngles(%v, %v, %v) = %v, %v, %v, want %v, %v, %v", angleA, angleB, angleC, ra, rb, rc, sa, sb, sc)
+			}
+		}
+	}
+}
+
+func TestSphericalTriangleCenters(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		a, b, c := randomPoint(), randomPoint(), randomPoint()
+		if !Sign(a, b, c) {
+			a, b = b, a
+		}
+
+		cc := TriangleCircumcenter(a, b, c)
+		da, db, dc := cc.Distance(a), cc.Distance(b), cc.Distance(c)
+		if math.Abs(float64(da-db)) > 1e-9 || math.Abs(float64(da-dc)) > 1e-9 || da > math.Pi/2 {
+			t.Errorf("TriangleCircumcenter(%v, %v, %v) = %v at distances %v, %v, %v", a, b, c, cc, da, db, dc)
+		}
+
+		in := TriangleIncenter(a, b, c)
+		if !Sign(a, b, in) || !Sign(b, c, in) || !Sign(c, a, in) {
+			t.Errorf("TriangleIncenter(%v, %v, %v) = %v is outside the triangle", a, b, c, in)
+		}
+		ea := DistanceFromSegment(in, b, c)
+		eb := DistanceFromSegment(in, c, a)
+		ec := DistanceFromSegment(in, a, b)
+		if math.Abs(float64(ea-eb)) > 1e-9 || math.Abs(float64(ea-ec)) > 1e-9 {
+			t.Errorf("TriangleIncenter(%v, %v, %v) = %v at distances %v, %v, %v from the edges", a, b, c, in, ea, eb, ec)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "github.com/golang/geo/s1"
+
+// Tolerance is a policy for approximate comparisons of geometry. It is given
+// by the largest angle by which corresponding vertices may differ, so that a
+// pipeline can compare all of its values with one consistent tolerance.
+type Tolerance struct {
+	MaxError s1.Angle
+}
+
+// DefaultTolerance is the tolerance used by the ApproxEqual methods of the
+// various types.
+var DefaultTolerance = Tolerance{MaxError: s1.Angle(epsilon)}
+
+// Points reports whether the two points are within the tolerance.
+func (t Tolerance) Points(a, b Point) bool {
+	return a.approxEqual(b, t.MaxError)
+}
+
+// LatLngs reports whether the latitudes and the longitudes of the two
+// LatLngs each differ by at most the tolerance.
+func (t Tolerance) LatLngs(a, b LatLng) bool {
+	return a.ApproxEqualWithin(b, t.MaxError)
+}
+
+// Rects reports whether the latitude and longitude intervals of the two
+// rectangles have endpoints that each differ by at most the tolerance.
+func (t Tolerance) Rects(a, b Rect) bool {
+	return a.Lat.ApproxEqualWithin(b.Lat, float64(t.MaxError)) &&
+		a.Lng.ApproxEqualWithin(b.Lng, float64(t.MaxError))
+}
+
+// Polylines reports whether the two polylines have the same number of
+// vertices and corresponding vertices are within the tolerance.
+func (t Tolerance) Polylines(a, b *Polyline) bool {
+	return a.approxEqual(b, t.MaxError)
+}
+
+// Loops reports whether the two loops have the same number of vertices and
+// a cyclic rotation of the vertices of b is within the tolerance of the
+// vertices of a. The empty and full loops are only equal to themselves.
+func (t Tolerance) Loops(a, b *Loop) bool {
+	if len(a.vertices) != len(b.vertices) {
+		return false
+	}
+	// Special case to handle empty or full loops. Since they have the same
+	// number of vertices, if one loop is empty/full then so is the other.
+	if a.isEmptyOrFull() {
+		return a.IsEmpty() == b.IsEmpty()
+	}
+	for offset := range b.vertices {
+		if !t.Points(a.vertices[0], b.vertices[offset]) {
+			continue
+		}
+		success := true
+		for i := range a.vertices {
+			if !t.Points(a.vertices[i], b.Vertex(i+offset)) {
+				success = false
+				break
+			}
+		}
+		if success {
+			return true
+		}
+	}
+	return false
+}
+
+// Polygons reports whether the two polygons have the same number of loops,
+// and each loop of a matches a loop of b at the same depth under Loops.
+// The order of the loops does not matter.
+func (t Tolerance) Polygons(a, b *Polygon) bool {
+	if a.NumLoops() != b.NumLoops() {
+		return false
+	}
+	for _, la := range a.loops {
+		found := false
+		for _, lb := range b.loops {
+			if la.depth == lb.depth && t.Loops(la, lb) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
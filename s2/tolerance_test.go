@@ -0,0 +1,61 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestTolerance(t *testing.T) {
+	loose := Tolerance{MaxError: s1.Degree / 10}
+	tight := Tolerance{MaxError: s1.Degree / 1000}
+
+	tests := []struct {
+		name         string
+		loose, tight bool
+	}{
+		{"points", loose.Points(parsePoint("1:1"), parsePoint("1:1.01")),
+			tight.Points(parsePoint("1:1"), parsePoint("1:1.01"))},
+		{"latlngs", loose.LatLngs(LatLngFromDegrees(1, 1), LatLngFromDegrees(1.01, 0.99)),
+			tight.LatLngs(LatLngFromDegrees(1, 1), LatLngFromDegrees(1.01, 0.99))},
+		{"rects", loose.Rects(makeRect("0:0, 2:2"), makeRect("0.01:0, 2:2.01")),
+			tight.Rects(makeRect("0:0, 2:2"), makeRect("0.01:0, 2:2.01"))},
+		{"polylines", loose.Polylines(makePolyline("0:0, 1:1, 2:0"), makePolyline("0:0.01, 1:1, 2:0")),
+			tight.Polylines(makePolyline("0:0, 1:1, 2:0"), makePolyline("0:0.01, 1:1, 2:0"))},
+		// The second loop starts at a different vertex.
+		{"loops", loose.Loops(makeLoop("0:0, 0:2, 2:0"), makeLoop("0:2.01, 2:0, 0:0")),
+			tight.Loops(makeLoop("0:0, 0:2, 2:0"), makeLoop("0:2.01, 2:0, 0:0"))},
+		// The loops are given in a different order.
+		{"polygons", loose.Polygons(makePolygon("0:0, 0:5, 5:0; 1:1, 1:2, 2:1", true), makePolygon("1:1, 1:2.01, 2:1; 0:0, 0:5, 5:0", true)),
+			tight.Polygons(makePolygon("0:0, 0:5, 5:0; 1:1, 1:2, 2:1", true), makePolygon("1:1, 1:2.01, 2:1; 0:0, 0:5, 5:0", true))},
+	}
+	for _, test := range tests {
+		if !test.loose || test.tight {
+			t.Errorf("%s: within loose tolerance = %v, within tight tolerance = %v, want true and false", test.name, test.loose, test.tight)
+		}
+	}
+
+	if !DefaultTolerance.Loops(EmptyLoop(), EmptyLoop()) || DefaultTolerance.Loops(EmptyLoop(), FullLoop()) {
+		t.Errorf("empty and full loops should only match themselves")
+	}
+	if loose.Loops(makeLoop("0:0, 0:2, 2:0"), makeLoop("0:0, 0:2, 2:0, 1:0")) {
+		t.Errorf("loops with different numbers of vertices should not match")
+	}
+	if a := makeRect("0:0, 2:2"); DefaultTolerance.Rects(a, a) != a.ApproxEqual(a) {
+		t.Errorf("DefaultTolerance.Rects disagrees with Rect.ApproxEqual")
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+// Shape interface enforcement
+var (
+	_ Shape = (*TriangleMesh)(nil)
+)
+
+// TriangleMesh is a Shape representing a set of spherical triangles with
+// disjoint interiors. The vertices are shared between triangles, and each
+// triangle is stored as three indices into the vertex slice in
+// counterclockwise order.
+//
+// As a Shape, each triangle is represented as a chain of three edges. Edges
+// shared by adjacent triangles appear twice in opposite directions, which
+// is allowed for polygonal shapes (see Shape).
+//
+// A TriangleMesh is typically obtained from Polygon.Triangulate, and is
+// useful for area-weighted sampling, rendering, and numerical integration
+// over regions.
+type TriangleMesh struct {
+	Vertices  []Point
+	Triangles [][3]int
+}
+
+// NumTriangles returns the number of triangles in the mesh.
+func (m *TriangleMesh) NumTriangles() int { return len(m.Triangles) }
+
+// Triangle returns the vertices of the i-th triangle in counterclockwise order.
+func (m *TriangleMesh) Triangle(i int) [3]Point {
+	t := m.Triangles[i]
+	return [3]Point{m.Vertices[t[0]], m.Vertices[t[1]], m.Vertices[t[2]]}
+}
+
+// Area returns the total area of the triangles in the mesh.
+func (m *TriangleMesh) Area() float64 {
+	area := 0.0
+	for i := range m.Triangles {
+		t := m.Triangle(i)
+		area += PointArea(t[0], t[1], t[2])
+	}
+	return area
+}
+
+// Centroid returns the true centroid of the mesh multiplied by its area
+// (see Loop.Centroid for details). The result is not unit length.
+func (m *TriangleMesh) Centroid() Point {
+	var centroid Point
+	for i := range m.Triangles {
+		t := m.Triangle(i)
+		centroid = Point{centroid.Add(TrueCentroid(t[0], t[1], t[2]).Vector)}
+	}
+	return centroid
+}
+
+func (m *TriangleMesh) NumEdges() int { return 3 * len(m.Triangles) }
+
+func (m *TriangleMesh) Edge(e int) Edge {
+	return m.ChainEdge(e/3, e%3)
+}
+
+func (m *TriangleMesh) ReferencePoint() ReferencePoint { return referencePointForShape(m) }
+func (m *TriangleMesh) NumChains() int                 { return len(m.Triangles) }
+func (m *TriangleMesh) Chain(i int) Chain              { return Chain{3 * i, 3} }
+
+func (m *TriangleMesh) ChainEdge(i, j int) Edge {
+	t := m.Triangles[i]
+	return Edge{m.Vertices[t[j]], m.Vertices[t[(j+1)%3]]}
+}
+
+func (m *TriangleMesh) ChainPosition(e int) ChainPosition { return ChainPosition{e / 3, e % 3} }
+func (m *TriangleMesh) Dimension() int                    { return 2 }
+func (m *TriangleMesh) IsEmpty() bool                     { return defaultShapeIsEmpty(m) }
+func (m *TriangleMesh) IsFull() bool                      { return defaultShapeIsFull(m) }
+func (m *TriangleMesh) typeTag() typeTag                  { return typeTagNone }
+func (m *TriangleMesh) privateInterface()                 {}
@@ -0,0 +1,70 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+)
+
+func TestTriangleMeshShape(t *testing.T) {
+	mesh := &TriangleMesh{
+		Vertices: parsePoints("0:0, 0:1, 1:1, 1:0"),
+		Triangles: [][3]int{
+			{0, 1, 2},
+			{0, 2, 3},
+		},
+	}
+
+	if got, want := mesh.NumEdges(), 6; got != want {
+		t.Errorf("NumEdges() = %d, want %d", got, want)
+	}
+	if got, want := mesh.NumChains(), 2; got != want {
+		t.Errorf("NumChains() = %d, want %d", got, want)
+	}
+	if got, want := mesh.Chain(1), (Chain{3, 3}); got != want {
+		t.Errorf("Chain(1) = %v, want %v", got, want)
+	}
+	if got, want := mesh.ChainPosition(4), (ChainPosition{1, 1}); got != want {
+		t.Errorf("ChainPosition(4) = %v, want %v", got, want)
+	}
+	if got, want := mesh.Edge(4), (Edge{mesh.Vertices[2], mesh.Vertices[3]}); got != want {
+		t.Errorf("Edge(4) = %v, want %v", got, want)
+	}
+	if mesh.IsEmpty() || mesh.IsFull() {
+		t.Errorf("mesh should be neither empty nor full")
+	}
+	if got := mesh.Dimension(); got != 2 {
+		t.Errorf("Dimension() = %d, want 2", got)
+	}
+
+	index := NewShapeIndex()
+	index.Add(mesh)
+	query := NewContainsPointQuery(index, VertexModelSemiOpen)
+	for _, test := range []struct {
+		point string
+		want  bool
+	}{
+		{"0.5:0.5", true},
+		{"0.2:0.7", true},
+		{"0.7:0.2", true},
+		{"2:2", false},
+		{"-0.5:0.5", false},
+	} {
+		p := parsePoint(test.point)
+		if got := query.Contains(p); got != test.want {
+			t.Errorf("index containing the mesh Contains(%s) = %v, want %v", test.point, got, test.want)
+		}
+	}
+}
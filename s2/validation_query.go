@@ -0,0 +1,267 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import "fmt"
+
+// ValidationOptions holds the options that define the semantics used by a
+// ValidationQuery. NewValidQuery and NewLegacyValidQuery provide the two
+// standard sets of semantics.
+type ValidationOptions struct {
+	allowDegenerateEdges   bool
+	allowReverseDuplicates bool
+	allowDuplicateVertices bool
+	minLoopVertices        int
+}
+
+// NewValidationOptions returns the options for the S2 valid semantics
+// described at NewValidQuery.
+func NewValidationOptions() *ValidationOptions {
+	return &ValidationOptions{
+		allowDegenerateEdges:   true,
+		allowReverseDuplicates: true,
+		allowDuplicateVertices: true,
+		minLoopVertices:        1,
+	}
+}
+
+// AllowDegenerateEdges specifies whether polygon edges of the form AA are
+// allowed. Such edges can be used to represent degenerate loops.
+func (o *ValidationOptions) AllowDegenerateEdges(x bool) *ValidationOptions {
+	o.allowDegenerateEdges = x
+	return o
+}
+
+// AllowReverseDuplicates specifies whether a polygon may contain both the
+// edge AB and its reverse BA, which can be used to represent degenerate
+// shells and holes.
+func (o *ValidationOptions) AllowReverseDuplicates(x bool) *ValidationOptions {
+	o.allowReverseDuplicates = x
+	return o
+}
+
+// AllowDuplicateVertices specifies whether a polygon loop may visit the same
+// vertex more than once (without crossing itself there).
+func (o *ValidationOptions) AllowDuplicateVertices(x bool) *ValidationOptions {
+	o.allowDuplicateVertices = x
+	return o
+}
+
+// MinLoopVertices sets the minimum number of vertices of each polygon loop.
+// The loop with no vertices that represents the full polygon is always
+// allowed.
+func (o *ValidationOptions) MinLoopVertices(n int) *ValidationOptions {
+	o.minLoopVertices = n
+	return o
+}
+
+// ValidationQuery checks that the shapes in a ShapeIndex are valid, which
+// works for any Shape type, including LaxPolygon and LaxPolyline. Unlike
+// Loop.Validate and Polygon.Validate, the rules that are checked are
+// configurable through ValidationOptions.
+//
+// The following rules are always checked:
+//
+//   - All vertices are unit length.
+//   - No edge connects two antipodal points.
+//   - Consecutive edges of each chain are connected, and polygon chains are
+//     closed.
+//   - The edges of a polygon do not cross each other, and do not contain the
+//     same edge twice in the same direction.
+//   - Where the loops of a polygon touch at a vertex, they do not cross, so
+//     that the interior is consistently on the left of all the edges.
+//
+// Polylines may intersect themselves, and separate shapes are allowed to
+// overlap.
+type ValidationQuery struct {
+	opts *ValidationOptions
+}
+
+// NewValidationQuery returns a ValidationQuery with the given options. If
+// opts is nil, the options of NewValidQuery are used.
+func NewValidationQuery(opts *ValidationOptions) *ValidationQuery {
+	if opts == nil {
+		opts = NewValidationOptions()
+	}
+	return &ValidationQuery{opts: opts}
+}
+
+// NewValidQuery returns a ValidationQuery with the S2 valid semantics, which
+// allow the degeneracies that lax shapes can represent: degenerate edges,
+// pairs of reversed edges, and loops that touch themselves at a vertex.
+func NewValidQuery() *ValidationQuery {
+	return NewValidationQuery(NewValidationOptions())
+}
+
+// NewLegacyValidQuery returns a ValidationQuery with the semantics of
+// Polygon.Validate: in addition to the standard rules, polygon loops must
+// have at least 3 vertices, must not have degenerate edges or duplicate
+// vertices, and must not share edges with each other in either direction.
+// Loops may still touch each other at a vertex.
+func NewLegacyValidQuery() *ValidationQuery {
+	return NewValidationQuery(NewValidationOptions().
+		AllowDegenerateEdges(false).
+		AllowReverseDuplicates(false).
+		AllowDuplicateVertices(false).
+		MinLoopVertices(3))
+}
+
+// Validate returns an error describing the first invalid feature found in the
+// given index, or nil if all of its shapes are valid.
+func (q *ValidationQuery) Validate(index *ShapeIndex) error {
+	for id := int32(0); id < index.nextID; id++ {
+		shape := index.Shape(id)
+		if shape == nil {
+			continue
+		}
+		if err := q.validateShape(shape); err != nil {
+			return fmt.Errorf("shape %d: %v", id, err)
+		}
+	}
+
+	var err error
+	VisitCrossingEdgePairs(index, CrossingTypeNonAdjacent, func(a, b ShapeEdge, isInterior bool) bool {
+		if a.ID.ShapeID != b.ID.ShapeID {
+			return true
+		}
+		shape := index.Shape(a.ID.ShapeID)
+		if shape.Dimension() != 2 {
+			return true
+		}
+		if err = q.checkCrossing(shape, a, b, isInterior); err != nil {
+			err = fmt.Errorf("shape %d: %v", a.ID.ShapeID, err)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// ValidateShape returns an error describing the first invalid feature found
+// in the given shape, or nil if it is valid.
+func (q *ValidationQuery) ValidateShape(shape Shape) error {
+	index := NewShapeIndex()
+	index.Add(shape)
+	return q.Validate(index)
+}
+
+// validateShape checks the rules that do not require the index.
+func (q *ValidationQuery) validateShape(shape Shape) error {
+	dim := shape.Dimension()
+	for c := 0; c < shape.NumChains(); c++ {
+		n := shape.Chain(c).Length
+		if dim == 2 && n > 0 && n < q.opts.minLoopVertices {
+			return fmt.Errorf("chain %d has %d vertices, want at least %d", c, n, q.opts.minLoopVertices)
+		}
+		for i := 0; i < n; i++ {
+			e := shape.ChainEdge(c, i)
+			// Note that IsUnit is false for NaN and infinite coordinates.
+			for _, v := range []Point{e.V0, e.V1} {
+				if !v.IsUnit() {
+					return fmt.Errorf("chain %d edge %d has a vertex that is not unit length", c, i)
+				}
+			}
+			if e.V0 == (Point{e.V1.Mul(-1)}) {
+				return fmt.Errorf("chain %d edge %d has antipodal vertices", c, i)
+			}
+			if dim == 2 && !q.opts.allowDegenerateEdges && e.V0 == e.V1 {
+				return fmt.Errorf("chain %d edge %d is degenerate (duplicate vertex)", c, i)
+			}
+			if dim == 0 {
+				continue
+			}
+			next := i + 1
+			if next == n {
+				if dim == 1 {
+					continue
+				}
+				next = 0
+			}
+			if e.V1 != shape.ChainEdge(c, next).V0 {
+				return fmt.Errorf("chain %d edge %d is not connected to edge %d", c, i, next)
+			}
+		}
+	}
+	return nil
+}
+
+// checkCrossing returns an error if the two given crossing edges of a
+// polygon (including crossings at a shared vertex) form an invalid
+// configuration. It is similar to findCrossingError, except that it allows
+// the degeneracies permitted by the options.
+func (q *ValidationQuery) checkCrossing(shape Shape, a, b ShapeEdge, isInterior bool) error {
+	ap := shape.ChainPosition(int(a.ID.EdgeID))
+	bp := shape.ChainPosition(int(b.ID.EdgeID))
+	if isInterior {
+		return fmt.Errorf("chain %d edge %d crosses chain %d edge %d", ap.ChainID, ap.Offset, bp.ChainID, bp.Offset)
+	}
+
+	// Degenerate edges touch every edge incident to their vertex.
+	if a.Edge.V0 == a.Edge.V1 || b.Edge.V0 == b.Edge.V1 {
+		return nil
+	}
+	if a.Edge == b.Edge {
+		return fmt.Errorf("chain %d edge %d duplicates chain %d edge %d", ap.ChainID, ap.Offset, bp.ChainID, bp.Offset)
+	}
+	if a.Edge.V0 == b.Edge.V1 && a.Edge.V1 == b.Edge.V0 {
+		if q.opts.allowReverseDuplicates {
+			return nil
+		}
+		return fmt.Errorf("chain %d edge %d is the reverse of chain %d edge %d", ap.ChainID, ap.Offset, bp.ChainID, bp.Offset)
+	}
+
+	// We only need to check a given vertex once, so we require that the two
+	// edges have the same end vertex.
+	if a.Edge.V1 != b.Edge.V1 {
+		return nil
+	}
+	if ap.ChainID == bp.ChainID && !q.opts.allowDuplicateVertices {
+		return fmt.Errorf("chain %d edge %d has duplicate vertex with edge %d", ap.ChainID, ap.Offset, bp.Offset)
+	}
+	a2 := nextChainVertex(shape, ap)
+	b2 := nextChainVertex(shape, bp)
+	if a2 == a.Edge.V1 || b2 == b.Edge.V1 || a.Edge.V0 == a2 || b.Edge.V0 == b2 {
+		// The following edge is degenerate or reverses this one, in which
+		// case the wedges are not well defined.
+		return nil
+	}
+	if a.Edge.V0 == b2 || b.Edge.V0 == a2 {
+		// The edges form a reversed pair with the following edge of the
+		// other chain.
+		if q.opts.allowReverseDuplicates {
+			return nil
+		}
+		return fmt.Errorf("chain %d edge %d is the reverse of an edge adjacent to chain %d edge %d", ap.ChainID, ap.Offset, bp.ChainID, bp.Offset)
+	}
+
+	// Since polygon edges are oriented such that the interior is always on the
+	// left, the two wedges at the shared vertex must not overlap. One wedge may
+	// contain the complement of the other, which is not detected by
+	// WedgeRelation, so there are two cases to check for.
+	if WedgeRelation(a.Edge.V0, a.Edge.V1, a2, b.Edge.V0, b2) == WedgeProperlyOverlaps &&
+		WedgeRelation(a.Edge.V0, a.Edge.V1, a2, b2, b.Edge.V0) == WedgeProperlyOverlaps {
+		return fmt.Errorf("chain %d edge %d crosses chain %d edge %d", ap.ChainID, ap.Offset, bp.ChainID, bp.Offset)
+	}
+	return nil
+}
+
+// nextChainVertex returns the end vertex of the edge that follows the given
+// position in its (closed) chain.
+func nextChainVertex(shape Shape, pos ChainPosition) Point {
+	next := pos.Offset + 1
+	if next == shape.Chain(pos.ChainID).Length {
+		next = 0
+	}
+	return shape.ChainEdge(pos.ChainID, next).V1
+}
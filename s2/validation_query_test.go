@@ -0,0 +1,107 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidationQuery(t *testing.T) {
+	tests := []struct {
+		desc  string
+		index string
+		// valid and legacy are substrings of the errors expected from
+		// NewValidQuery and NewLegacyValidQuery, or "" if the index is valid.
+		valid  string
+		legacy string
+	}{
+		{"empty index", "# #", "", ""},
+		{"full polygon", "# # full", "", ""},
+		{"square", "# # 0:0, 0:3, 3:3, 3:0", "", ""},
+		{"square with hole", "# # 0:0, 0:3, 3:3, 3:0; 1:1, 2:1, 2:2, 1:2", "", ""},
+		{"self-intersecting polyline", "# 0:0, 1:1, 1:0, 0:1 #", "", ""},
+		{"overlapping shapes", "# # 0:0, 0:3, 3:3, 3:0 | 1:1, 1:4, 4:4, 4:1", "", ""},
+		{"shells touching at a vertex", "# # 0:0, 0:1, 1:1, 1:0; 1:1, 1:2, 2:2, 2:1", "", ""},
+		{"crossing edges", "# # 0:0, 1:1, 1:0, 0:1", "crosses", "crosses"},
+		{"degenerate edge", "# # 0:0, 0:3, 3:3, 3:3, 3:0", "", "degenerate"},
+		{"point loop", "# # 0:0, 0:3, 3:3, 3:0; 5:5", "", "at least 3"},
+		{"degenerate hole", "# # 0:0, 0:3, 3:3, 3:0; 1:1, 2:2", "", "at least 3"},
+		{"duplicate loop", "# # 0:0, 0:3, 3:3, 3:0; 0:0, 0:3, 3:3, 3:0", "duplicates", "duplicates"},
+		{"shells sharing an edge", "# # 0:0, 0:1, 1:1, 1:0; 0:1, 0:2, 1:2, 1:1", "", "reverse"},
+		{"loops crossing at vertices", "# # 0:0, 0:2, 2:2, 2:0; 0:0, 1:1, 2:2, 3:3, -1:3, -1:-1", "crosses", "crosses"},
+		{"loop with duplicate vertex", "# # 0:0, 0:3, 3:3, 3:0, 0:0, 1:2, 2:1", "crosses", "duplicate vertex"},
+	}
+	for _, test := range tests {
+		index := makeShapeIndex(test.index)
+		for _, q := range []struct {
+			name  string
+			query *ValidationQuery
+			want  string
+		}{
+			{"NewValidQuery", NewValidQuery(), test.valid},
+			{"NewLegacyValidQuery", NewLegacyValidQuery(), test.legacy},
+		} {
+			err := q.query.Validate(index)
+			switch {
+			case q.want == "" && err != nil:
+				t.Errorf("%s: %s().Validate(%q) = %v, want nil", test.desc, q.name, test.index, err)
+			case q.want != "" && (err == nil || !strings.Contains(err.Error(), q.want)):
+				t.Errorf("%s: %s().Validate(%q) = %v, want error containing %q", test.desc, q.name, test.index, err, q.want)
+			}
+		}
+	}
+}
+
+// polygonEdgeVectorShape is an edgeVectorShape that claims to be a polygon,
+// so that each of its edges is an unclosed polygon chain.
+type polygonEdgeVectorShape struct{ edgeVectorShape }
+
+func (s *polygonEdgeVectorShape) Dimension() int { return 2 }
+
+func TestValidationQueryValidateShape(t *testing.T) {
+	a, b := parsePoint("1:1"), parsePoint("2:2")
+	tests := []struct {
+		desc  string
+		shape Shape
+		want  string
+	}{
+		{"valid lax polygon", makeLaxPolygon("0:0, 0:3, 3:3, 3:0"), ""},
+		{"vertex not unit length", LaxPolygonFromPoints([][]Point{{a, {b.Mul(2)}, parsePoint("1:3")}}), "unit length"},
+		{"antipodal vertices", LaxPolylineFromPoints([]Point{a, {a.Mul(-1)}}), "antipodal"},
+		{"edges in separate chains", &edgeVectorShape{edges: []Edge{{a, b}, {b, a}}}, ""},
+		{"unclosed polygon chain", &polygonEdgeVectorShape{edgeVectorShape{edges: []Edge{{a, b}}}}, "not connected"},
+	}
+	for _, test := range tests {
+		err := NewValidQuery().ValidateShape(test.shape)
+		switch {
+		case test.want == "" && err != nil:
+			t.Errorf("%s: ValidateShape = %v, want nil", test.desc, err)
+		case test.want != "" && (err == nil || !strings.Contains(err.Error(), test.want)):
+			t.Errorf("%s: ValidateShape = %v, want error containing %q", test.desc, err, test.want)
+		}
+	}
+
+	// The options can be combined to get semantics in between the two
+	// standard sets.
+	shape := makeLaxPolygon("0:0, 0:3, 3:3, 3:3, 3:0")
+	if err := NewValidationQuery(nil).ValidateShape(shape); err != nil {
+		t.Errorf("NewValidationQuery(nil).ValidateShape(%v) = %v, want nil", shape, err)
+	}
+	q := NewValidationQuery(NewValidationOptions().AllowDegenerateEdges(false))
+	if err := q.ValidateShape(shape); err == nil {
+		t.Errorf("ValidateShape(%v) with degenerate edges disallowed = nil, want error", shape)
+	}
+}
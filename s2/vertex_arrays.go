@@ -0,0 +1,269 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"sort"
+
+	"github.com/golang/geo/r3"
+)
+
+// VertexArrays stores a sequence of points as three separate arrays of
+// coordinates (a "structure of arrays"), rather than as a slice of Points.
+// Loops over a single coordinate then access contiguous memory, which suits
+// bulk computations over many vertices: they use the cache better and can
+// be vectorized by the compiler.
+//
+// All three slices must have the same length.
+type VertexArrays struct {
+	X, Y, Z []float64
+}
+
+// VertexArraysFromPoints returns the coordinates of the given points.
+func VertexArraysFromPoints(points []Point) VertexArrays {
+	v := VertexArrays{
+		X: make([]float64, len(points)),
+		Y: make([]float64, len(points)),
+		Z: make([]float64, len(points)),
+	}
+	for i, p := range points {
+		v.X[i], v.Y[i], v.Z[i] = p.X, p.Y, p.Z
+	}
+	return v
+}
+
+// Len returns the number of points.
+func (v VertexArrays) Len() int { return len(v.X) }
+
+// Point returns the i-th point.
+func (v VertexArrays) Point(i int) Point {
+	return Point{r3.Vector{X: v.X[i], Y: v.Y[i], Z: v.Z[i]}}
+}
+
+// Points returns the points as a slice of Points.
+func (v VertexArrays) Points() []Point {
+	points := make([]Point, v.Len())
+	for i := range points {
+		points[i] = v.Point(i)
+	}
+	return points
+}
+
+// Dots appends the dot product of d with each point to dst and returns the
+// extended slice. The sign of the dot product tells which side of the plane
+// with normal d each point is on; for example, with d = a.PointCross(b) it
+// is positive for points to the left of the edge ab. Unlike Sign, this is not
+// robust for points that are nearly on the plane.
+func (v VertexArrays) Dots(dst []float64, d r3.Vector) []float64 {
+	n := len(dst)
+	dst = append(dst, make([]float64, v.Len())...)
+	out := dst[n:]
+	// Reslicing to a common length lets the compiler drop bounds checks.
+	x, y, z := v.X, v.Y[:len(v.X)], v.Z[:len(v.X)]
+	for i := range x {
+		out[i] = d.X*x[i] + d.Y*y[i] + d.Z*z[i]
+	}
+	return dst
+}
+
+// ClosestVertex returns the index of the point closest to p, or -1 if there
+// are no points.
+func (v VertexArrays) ClosestVertex(p Point) int {
+	best, bestDot := -1, -2.0
+	x, y, z := v.X, v.Y[:len(v.X)], v.Z[:len(v.X)]
+	for i := range x {
+		if d := p.X*x[i] + p.Y*y[i] + p.Z*z[i]; d > bestDot {
+			best, bestDot = i, d
+		}
+	}
+	return best
+}
+
+// SoAPolyline is a polyline whose vertices are stored in VertexArrays. It
+// implements Shape in the same way as Polyline.
+type SoAPolyline struct {
+	VertexArrays
+}
+
+// SoAPolylineFromPolyline returns an SoAPolyline with the vertices of the
+// given polyline.
+func SoAPolylineFromPolyline(p *Polyline) *SoAPolyline {
+	return &SoAPolyline{VertexArraysFromPoints(*p)}
+}
+
+// Polyline returns the polyline as a Polyline.
+func (p *SoAPolyline) Polyline() *Polyline {
+	line := Polyline(p.Points())
+	return &line
+}
+
+// NumEdges returns the number of edges in this shape.
+func (p *SoAPolyline) NumEdges() int { return maxInt(0, p.Len()-1) }
+
+// Edge returns endpoints for the given edge index.
+func (p *SoAPolyline) Edge(i int) Edge { return Edge{p.Point(i), p.Point(i + 1)} }
+
+// ReferencePoint returns the default reference point with negative
+// containment because polylines are not closed.
+func (p *SoAPolyline) ReferencePoint() ReferencePoint { return OriginReferencePoint(false) }
+
+// NumChains reports the number of contiguous edge chains in this polyline.
+func (p *SoAPolyline) NumChains() int { return minInt(1, p.NumEdges()) }
+
+// Chain returns the i-th edge Chain in the Shape.
+func (p *SoAPolyline) Chain(chainID int) Chain { return Chain{0, p.NumEdges()} }
+
+// ChainEdge returns the j-th edge of the i-th edge Chain.
+func (p *SoAPolyline) ChainEdge(chainID, offset int) Edge { return p.Edge(offset) }
+
+// ChainPosition returns a pair (i, j) such that edgeID is the j-th edge
+// of the i-th edge Chain.
+func (p *SoAPolyline) ChainPosition(edgeID int) ChainPosition { return ChainPosition{0, edgeID} }
+
+// Dimension returns the dimension of the geometry represented by this polyline.
+func (p *SoAPolyline) Dimension() int { return 1 }
+
+// IsEmpty reports whether this shape contains no points.
+func (p *SoAPolyline) IsEmpty() bool { return defaultShapeIsEmpty(p) }
+
+// IsFull reports whether this shape contains all points on the sphere.
+func (p *SoAPolyline) IsFull() bool { return defaultShapeIsFull(p) }
+
+func (p *SoAPolyline) typeTag() typeTag { return typeTagNone }
+
+func (p *SoAPolyline) privateInterface() {}
+
+// SoAPolygon is a polygon whose vertices are stored in VertexArrays. The
+// vertices of each loop are stored as the Shape chain of the loop, so holes
+// are stored in reverse order and the full loop has no vertices. It
+// implements Shape in the same way as Polygon.
+type SoAPolygon struct {
+	VertexArrays
+	polygonChains
+}
+
+// SoAPolygonFromPolygon returns an SoAPolygon with the loops of the given
+// polygon.
+func SoAPolygonFromPolygon(p *Polygon) *SoAPolygon {
+	chains, vertices := newPolygonChains(p)
+	return &SoAPolygon{VertexArraysFromPoints(vertices), chains}
+}
+
+// Polygon returns the polygon as a Polygon.
+func (p *SoAPolygon) Polygon() *Polygon { return p.polygon(p.Point) }
+
+// NumEdges returns the number of edges in the polygon.
+func (p *SoAPolygon) NumEdges() int { return p.Len() }
+
+// Edge returns endpoints for the given edge index.
+func (p *SoAPolygon) Edge(e int) Edge {
+	pos := p.ChainPosition(e)
+	return p.ChainEdge(pos.ChainID, pos.Offset)
+}
+
+// ChainEdge returns the j-th edge of the i-th edge Chain (loop).
+func (p *SoAPolygon) ChainEdge(i, j int) Edge { return p.chainEdge(i, j, p.Point) }
+
+// IsEmpty reports whether this polygon has no loops.
+func (p *SoAPolygon) IsEmpty() bool { return defaultShapeIsEmpty(p) }
+
+// IsFull reports whether this is the full polygon.
+func (p *SoAPolygon) IsFull() bool { return defaultShapeIsFull(p) }
+
+func (p *SoAPolygon) typeTag() typeTag { return typeTagNone }
+
+func (p *SoAPolygon) privateInterface() {}
+
+// polygonChains holds the loop structure of a polygon whose vertices are
+// stored as the Shape chains of its loops, for polygon types that store
+// their vertices in other ways than Loop. It implements the methods of
+// Shape that do not need the vertices.
+type polygonChains struct {
+	chains         []Chain
+	depths         []int
+	containsOrigin bool
+}
+
+// newPolygonChains returns the loop structure of the given polygon and the
+// vertices of its chains.
+func newPolygonChains(p *Polygon) (polygonChains, []Point) {
+	c := polygonChains{
+		chains:         make([]Chain, p.NumLoops()),
+		depths:         make([]int, p.NumLoops()),
+		containsOrigin: p.ReferencePoint().Contained,
+	}
+	var vertices []Point
+	for i, l := range p.Loops() {
+		n := p.Chain(i).Length
+		c.chains[i] = Chain{len(vertices), n}
+		c.depths[i] = l.depth
+		for j := 0; j < n; j++ {
+			vertices = append(vertices, p.ChainEdge(i, j).V0)
+		}
+	}
+	return c, vertices
+}
+
+// polygon returns the Polygon with the given vertices.
+func (c *polygonChains) polygon(vertex func(i int) Point) *Polygon {
+	if len(c.chains) == 0 {
+		return &Polygon{}
+	}
+	loops := make([]*Loop, len(c.chains))
+	for i, chain := range c.chains {
+		vertices := make([]Point, chain.Length)
+		for j := range vertices {
+			vertices[j] = vertex(chain.Start + j)
+		}
+		loops[i] = loopFromChain(vertices, c.depths[i]&1 != 0)
+	}
+	return PolygonFromLoops(loops)
+}
+
+// chainEdge returns the j-th edge of the i-th chain with the given vertices.
+func (c *polygonChains) chainEdge(i, j int, vertex func(i int) Point) Edge {
+	chain := c.chains[i]
+	k := j + 1
+	if k == chain.Length {
+		k = 0
+	}
+	return Edge{vertex(chain.Start + j), vertex(chain.Start + k)}
+}
+
+// NumLoops returns the number of loops in the polygon.
+func (c *polygonChains) NumLoops() int { return len(c.chains) }
+
+// ReferencePoint returns the reference point for this polygon.
+func (c *polygonChains) ReferencePoint() ReferencePoint {
+	return OriginReferencePoint(c.containsOrigin)
+}
+
+// NumChains reports the number of loops in the polygon.
+func (c *polygonChains) NumChains() int { return len(c.chains) }
+
+// Chain returns the i-th edge Chain (loop) in the Shape.
+func (c *polygonChains) Chain(chainID int) Chain { return c.chains[chainID] }
+
+// ChainPosition returns a pair (i, j) such that edgeID is the j-th edge
+// of the i-th edge Chain.
+func (c *polygonChains) ChainPosition(edgeID int) ChainPosition {
+	i := sort.Search(len(c.chains), func(i int) bool {
+		return c.chains[i].Start+c.chains[i].Length > edgeID
+	})
+	return ChainPosition{i, edgeID - c.chains[i].Start}
+}
+
+// Dimension returns the dimension of the geometry represented by this polygon.
+func (c *polygonChains) Dimension() int { return 2 }
@@ -0,0 +1,125 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"reflect"
+	"testing"
+)
+
+// checkShapesEqual reports an error if the edges, chains or reference
+// points of the two shapes differ.
+func checkShapesEqual(t *testing.T, desc string, got, want Shape) {
+	t.Helper()
+	if got.Dimension() != want.Dimension() || got.IsEmpty() != want.IsEmpty() || got.IsFull() != want.IsFull() {
+		t.Errorf("%s: Dimension(), IsEmpty(), IsFull() = %v, %v, %v, want %v, %v, %v", desc,
+			got.Dimension(), got.IsEmpty(), got.IsFull(), want.Dimension(), want.IsEmpty(), want.IsFull())
+	}
+	if got.ReferencePoint() != want.ReferencePoint() {
+		t.Errorf("%s: ReferencePoint() = %v, want %v", desc, got.ReferencePoint(), want.ReferencePoint())
+	}
+	if got.NumEdges() != want.NumEdges() || got.NumChains() != want.NumChains() {
+		t.Errorf("%s: NumEdges(), NumChains() = %d, %d, want %d, %d", desc,
+			got.NumEdges(), got.NumChains(), want.NumEdges(), want.NumChains())
+		return
+	}
+	for e := 0; e < want.NumEdges(); e++ {
+		if g, w := got.Edge(e), want.Edge(e); g != w {
+			t.Errorf("%s: Edge(%d) = %v, want %v", desc, e, g, w)
+		}
+		if g, w := got.ChainPosition(e), want.ChainPosition(e); g != w {
+			t.Errorf("%s: ChainPosition(%d) = %v, want %v", desc, e, g, w)
+		}
+	}
+	for i := 0; i < want.NumChains(); i++ {
+		if g, w := got.Chain(i), want.Chain(i); g != w {
+			t.Errorf("%s: Chain(%d) = %v, want %v", desc, i, g, w)
+			continue
+		}
+		for j := 0; j < want.Chain(i).Length; j++ {
+			if g, w := got.ChainEdge(i, j), want.ChainEdge(i, j); g != w {
+				t.Errorf("%s: ChainEdge(%d, %d) = %v, want %v", desc, i, j, g, w)
+			}
+		}
+	}
+}
+
+func TestVertexArrays(t *testing.T) {
+	points := parsePoints("0:0, 0:90, 90:0, 45:45, -30:120")
+	v := VertexArraysFromPoints(points)
+	if got := v.Len(); got != len(points) {
+		t.Errorf("Len() = %d, want %d", got, len(points))
+	}
+	if got := v.Points(); !reflect.DeepEqual(got, points) {
+		t.Errorf("Points() = %v, want %v", got, points)
+	}
+
+	d := points[0].PointCross(points[1]).Vector
+	dots := v.Dots([]float64{7}, d)
+	if len(dots) != len(points)+1 || dots[0] != 7 {
+		t.Fatalf("Dots() = %v, want %d values after 7", dots, len(points))
+	}
+	for i, p := range points {
+		if got, want := dots[i+1], d.Dot(p.Vector); got != want {
+			t.Errorf("Dots()[%d] = %v, want %v", i, got, want)
+		}
+	}
+
+	for _, s := range []string{"1:1", "80:10", "40:50", "-30:100", "0:-170"} {
+		p := parsePoint(s)
+		want := 0
+		for i := range points {
+			if p.Distance(points[i]) < p.Distance(points[want]) {
+				want = i
+			}
+		}
+		if got := v.ClosestVertex(p); got != want {
+			t.Errorf("ClosestVertex(%s) = %d, want %d", s, got, want)
+		}
+	}
+	if got := (VertexArrays{}).ClosestVertex(points[0]); got != -1 {
+		t.Errorf("ClosestVertex() of no points = %d, want -1", got)
+	}
+}
+
+func TestSoAPolyline(t *testing.T) {
+	for _, s := range []string{"", "0:0", "0:0, 1:1", "0:0, 0:10, 10:10, 10:0"} {
+		want := makePolyline(s)
+		p := SoAPolylineFromPolyline(want)
+		checkShapesEqual(t, s, p, want)
+		if got := p.Polyline(); len(*got) != len(*want) || (len(*want) > 0 && !got.Equal(want)) {
+			t.Errorf("SoAPolylineFromPolyline(%s).Polyline() = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestSoAPolygon(t *testing.T) {
+	polygons := []*Polygon{
+		makePolygon("", true),
+		FullPolygon(),
+		makePolygon("0:0, 0:10, 10:10, 10:0", true),
+		makePolygon("0:0, 0:10, 10:10, 10:0; 20:20, 20:25, 25:25, 25:20; 2:2, 8:2, 8:8, 2:8", true),
+	}
+	for _, want := range polygons {
+		p := SoAPolygonFromPolygon(want)
+		checkShapesEqual(t, "SoAPolygon", p, want)
+		if p.NumLoops() != want.NumLoops() {
+			t.Errorf("NumLoops() = %d, want %d", p.NumLoops(), want.NumLoops())
+		}
+		if got := p.Polygon(); !DefaultTolerance.Polygons(got, want) {
+			t.Errorf("SoAPolygonFromPolygon(%v).Polygon() = %v, want %v", want, got, want)
+		}
+	}
+}
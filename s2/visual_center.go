@@ -0,0 +1,105 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// visualCenterCell is a candidate cell in the search for a visual center.
+type visualCenterCell struct {
+	cell   Cell
+	center Point
+	// dist is the signed distance from the center of the cell to the
+	// boundary of the polygon, which is positive inside the polygon.
+	dist s1.Angle
+	// bound is an upper bound on the signed distance for every point of the
+	// cell.
+	bound s1.Angle
+}
+
+// VisualCenter returns the point inside the polygon that is farthest from
+// its boundary, also known as the pole of inaccessibility, along with its
+// distance to the boundary. This is a good place to put a label or a marker
+// for an irregular region, where the centroid may lie outside the polygon
+// or very close to its boundary.
+//
+// The result is within precision of the best possible distance. The search
+// refines cells whose distance bounds could still improve on the best point
+// found so far, in the manner of the polylabel algorithm, so smaller
+// precisions take longer. The polygon must be neither empty nor full; for
+// those the zero Point and a distance of zero are returned.
+func (p *Polygon) VisualCenter(precision s1.Angle) (Point, s1.Angle) {
+	if p.IsEmpty() || p.IsFull() {
+		return Point{}, 0
+	}
+	precision = s1.Angle(math.Max(float64(precision), float64(s1.Angle(epsilon))))
+
+	query := NewClosestEdgeQuery(p.index, NewClosestEdgeQueryOptions().IncludeInteriors(false))
+	signedDistance := func(x Point) s1.Angle {
+		d := query.Distance(NewMinDistanceToPointTarget(x)).Angle()
+		if !p.ContainsPoint(x) {
+			return -d
+		}
+		return d
+	}
+	newCandidate := func(c Cell) visualCenterCell {
+		center := c.Center()
+		var radius s1.Angle
+		for k := 0; k < 4; k++ {
+			radius = maxAngle(radius, center.Distance(c.Vertex(k)))
+		}
+		dist := signedDistance(center)
+		return visualCenterCell{c, center, dist, dist + radius}
+	}
+
+	// Start with the centroid, which is often a good guess for convex
+	// shapes, and a coarse covering of the polygon.
+	var best Point
+	bestDist := s1.Angle(math.Inf(-1))
+	if c := p.Centroid(); c.Norm() > 0 {
+		best = Point{c.Normalize()}
+		bestDist = signedDistance(best)
+	}
+	queue := newBinaryHeap(func(a, b visualCenterCell) bool { return a.bound > b.bound })
+	coverer := &RegionCoverer{MaxLevel: MaxLevel, LevelMod: 1, MaxCells: 8}
+	for _, id := range coverer.Covering(p) {
+		queue.push(newCandidate(CellFromCellID(id)))
+	}
+
+	for queue.len() > 0 {
+		c := queue.pop()
+		if c.dist > bestDist {
+			best, bestDist = c.center, c.dist
+		}
+		if c.bound-bestDist <= precision {
+			// No remaining cell can improve on the best point by more than
+			// the precision, since the queue is ordered by bound.
+			break
+		}
+		children, ok := c.cell.Children()
+		if !ok {
+			continue
+		}
+		for _, child := range children {
+			if cand := newCandidate(child); cand.bound-bestDist > precision {
+				queue.push(cand)
+			}
+		}
+	}
+	return best, bestDist
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s2
+
+import (
+	"testing"
+
+	"github.com/golang/geo/s1"
+)
+
+func TestPolygonVisualCenter(t *testing.T) {
+	const precision = s1.Degree / 100
+	tests := []struct {
+		polygon *Polygon
+		want    Point
+		dist    s1.Angle
+	}{
+		// A square, whose visual center is its center.
+		{makePolygon("-1:-1, -1:1, 1:1, 1:-1", true), parsePoint("0:0"), 1 * s1.Degree},
+		// An L shape, whose largest inscribed circle sits in the corner and
+		// touches the two outer edges and the reflex vertex at 4:4, so its
+		// radius is about 4/(1+1/√2) degrees.
+		{makePolygon("0:0, 0:10, 4:10, 4:4, 10:4, 10:0", true), parsePoint("2.34:2.34"), 2.34 * s1.Degree},
+	}
+	for _, test := range tests {
+		got, dist := test.polygon.VisualCenter(precision)
+		if !test.polygon.ContainsPoint(got) {
+			t.Errorf("VisualCenter of %v = %v, which is outside the polygon", test.polygon, got)
+		}
+		if dist < test.dist-2*precision || dist > test.dist+2*precision {
+			t.Errorf("VisualCenter of %v has distance %v, want %v", test.polygon, dist.Degrees(), test.dist.Degrees())
+		}
+		if got.Distance(test.want) > 2*s1.Degree {
+			t.Errorf("VisualCenter of %v = %v, want near %v", test.polygon, got, test.want)
+		}
+	}
+
+	// A polygon with a hole in the middle pushes the center away from the
+	// centroid, which is inside the hole.
+	p := makePolygon("-10:-10, -10:10, 10:10, 10:-10; -6:-6, -6:6, 6:6, 6:-6", true)
+	got, dist := p.VisualCenter(precision)
+	if !p.ContainsPoint(got) || dist < 1.9*s1.Degree {
+		t.Errorf("VisualCenter of a square with a hole = %v at distance %v, want a point inside at distance about 2", got, dist.Degrees())
+	}
+
+	if _, dist := (&Polygon{}).VisualCenter(precision); dist != 0 {
+		t.Errorf("VisualCenter of the empty polygon has distance %v, want 0", dist)
+	}
+}